@@ -1,7 +1,10 @@
 package account
 
 import (
+	"fmt"
+	"path"
 	"sync"
+	"time"
 
 	"github.com/aergoio/aergo/account/key"
 
@@ -34,7 +37,10 @@ func NewAccountService(cfg *cfg.Config) *AccountService {
 }
 
 func (as *AccountService) BeforeStart() {
-	as.ks = key.NewStore(as.cfg.DataDir)
+	var err error
+	if as.ks, err = newStore(as.cfg); err != nil {
+		as.Logger.Fatal().Err(err).Msg("could not open keystore")
+	}
 
 	as.accounts = []*types.Account{}
 	addresses, err := as.ks.GetAddresses()
@@ -46,6 +52,32 @@ func (as *AccountService) BeforeStart() {
 	}
 }
 
+// newStore opens the keystore with the key storage in the configuration
+func newStore(conf *cfg.Config) (*key.Store, error) {
+	accountCfg := conf.Account
+	if accountCfg == nil {
+		accountCfg = &cfg.AccountConfig{KeyStore: "db"}
+	}
+	var storage key.KeyStore
+	switch accountCfg.KeyStore {
+	case "db", "":
+		storage = key.NewDBKeyStore(conf.DataDir)
+	case "file":
+		var err error
+		storage, err = key.NewFileKeyStore(path.Join(conf.DataDir, "keystore"), key.StandardScryptN, key.StandardScryptP)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid key storage: %s", accountCfg.KeyStore)
+	}
+	ks := key.NewStoreWith(storage, time.Duration(accountCfg.UnlockTimeout)*time.Second)
+	if accountCfg.ExternalSigner != "" {
+		ks.SetExternalSigner(key.NewExternalSigner(accountCfg.ExternalSigner, key.DefaultSignerTimeout))
+	}
+	return ks, nil
+}
+
 func (as *AccountService) AfterStart() {}
 
 func (as *AccountService) BeforeStop() {
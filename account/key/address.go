@@ -11,8 +11,6 @@ import (
 
 type Address = []byte
 
-var addresses = []byte("ADDRESSES")
-
 func GenerateAddress(pubkey *ecdsa.PublicKey) []byte {
 	if pubkey == nil {
 		return nil
@@ -30,20 +28,40 @@ func GenerateAddress(pubkey *ecdsa.PublicKey) []byte {
 	return addr.Bytes() // 33 bytes
 }
 
+// SaveAddress checks addr. The address is already saved with its key by the
+// key storage.
 func (ks *Store) SaveAddress(addr Address) error {
 	if len(addr) != types.AddressLength {
 		return errors.New("invalid address length")
 	}
-	addrs := append(ks.storage.Get(addresses), addr...)
-	ks.storage.Set(addresses, addrs)
 	return nil
 }
 
+// GetAddresses returns the addresses of the keys in the key storage and the
+// accounts of the external signer. If the signer fails, the addresses in the
+// storage are returned with the error.
 func (ks *Store) GetAddresses() ([]Address, error) {
-	b := ks.storage.Get(addresses)
-	var ret []Address
-	for i := 0; i < len(b); i += types.AddressLength {
-		ret = append(ret, b[i:i+types.AddressLength])
+	addresses, err := ks.storage.Addresses()
+	if err != nil || ks.signer == nil {
+		return addresses, err
+	}
+	external, err := ks.signer.Accounts()
+	if err != nil {
+		return addresses, err
+	}
+	for _, addr := range external {
+		if !containsAddress(addresses, addr) {
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses, nil
+}
+
+func containsAddress(addresses []Address, addr Address) bool {
+	for _, v := range addresses {
+		if bytes.Equal(v, addr) {
+			return true
+		}
 	}
-	return ret, nil
+	return false
 }
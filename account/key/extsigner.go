@@ -0,0 +1,126 @@
+package key
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// DefaultSignerTimeout is the time limit of a request to the external signer
+const DefaultSignerTimeout = 10 * time.Second
+
+// ExternalSigner forwards the txs to a signer process listening on a local
+// unix socket, so that the keys don't have to be in the node process.
+//
+// A request and its response are JSON objects, each in a line. The request has
+// the account in base58, the tx body in protobuf without the sign, and the
+// hash to be signed:
+//
+//	{"method":"signTx","account":"...","body":"<base64>","hash":"<base58>"}
+//
+// The response has the signature of the hash, or the reason of rejection:
+//
+//	{"sign":"<base64>"} or {"error":"..."}
+//
+// The accounts of the signer are listed by the request, whose response has
+// the accounts in base58:
+//
+//	{"method":"accounts"} -> {"accounts":["...", ...]}
+type ExternalSigner struct {
+	path    string
+	timeout time.Duration
+}
+
+type signRequest struct {
+	Method  string `json:"method"`
+	Account string `json:"account,omitempty"`
+	Body    []byte `json:"body,omitempty"`
+	Hash    string `json:"hash,omitempty"`
+}
+
+type signResponse struct {
+	Sign     []byte   `json:"sign"`
+	Accounts []string `json:"accounts"`
+	Error    string   `json:"error"`
+}
+
+// NewExternalSigner makes a signer forwarding to the unix socket of path
+func NewExternalSigner(path string, timeout time.Duration) *ExternalSigner {
+	if timeout == 0 {
+		timeout = DefaultSignerTimeout
+	}
+	return &ExternalSigner{path: path, timeout: timeout}
+}
+
+// SignTx requests the signature of tx to the external signer, and checks it.
+func (s *ExternalSigner) SignTx(tx *types.Tx) error {
+	unsigned := proto.Clone(tx.Body).(*types.TxBody)
+	unsigned.Sign = nil
+	body, err := proto.Marshal(unsigned)
+	if err != nil {
+		return err
+	}
+	hash := CalculateHashWithoutSign(unsigned)
+
+	rsp, err := s.call(&signRequest{
+		Method:  "signTx",
+		Account: types.EncodeAddress(tx.Body.Account),
+		Body:    body,
+		Hash:    enc.ToString(hash),
+	})
+	if err != nil {
+		return err
+	}
+
+	tx.Body.Sign = rsp.Sign
+	if err := VerifyTx(tx); err != nil {
+		tx.Body.Sign = nil
+		return err
+	}
+	tx.Hash = tx.CalculateTxHash()
+	return nil
+}
+
+// Accounts returns the addresses of the accounts whose txs the signer signs.
+func (s *ExternalSigner) Accounts() ([]Address, error) {
+	rsp, err := s.call(&signRequest{Method: "accounts"})
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]Address, 0, len(rsp.Accounts))
+	for _, account := range rsp.Accounts {
+		addr, err := types.DecodeAddress(account)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, addr)
+	}
+	return addresses, nil
+}
+
+// call sends req to the signer, and returns its response.
+func (s *ExternalSigner) call(req *signRequest) (*signResponse, error) {
+	conn, err := net.DialTimeout("unix", s.path, s.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(s.timeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, err
+	}
+	rsp := &signResponse{}
+	if err := json.NewDecoder(conn).Decode(rsp); err != nil {
+		return nil, err
+	}
+	if rsp.Error != "" {
+		return nil, errors.New("external signer: " + rsp.Error)
+	}
+	return rsp, nil
+}
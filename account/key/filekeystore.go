@@ -0,0 +1,261 @@
+package key
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreVersion = 1
+	keystoreSuffix  = ".json"
+	keystoreCipher  = "aes-256-gcm"
+	keystoreKDF     = "scrypt"
+
+	// StandardScryptN and StandardScryptP are the scrypt parameters of the
+	// keystore files, which take about 1 second on a modern CPU.
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// LightScryptN and LightScryptP are the scrypt parameters for a fast
+	// keystore, which is less secure.
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+
+	// the bounds of the scrypt parameters of a keystore file to decrypt, not
+	// to exhaust the memory and the CPU by a crafted file
+	maxScryptN = 1 << 20
+	maxScryptR = 8
+	maxScryptP = 16
+)
+
+var (
+	ErrKeystoreFormat = errors.New("invalid keystore format")
+	ErrKeyExist       = errors.New("already exist")
+)
+
+type keystoreJSON struct {
+	Version int            `json:"version"`
+	Address string         `json:"address"`
+	Crypto  keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	Cipher     string       `json:"cipher"`
+	CipherText string       `json:"ciphertext"`
+	Nonce      string       `json:"nonce"`
+	KDF        string       `json:"kdf"`
+	KDFParams  scryptParams `json:"kdfparams"`
+}
+
+type scryptParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// valid checks that the parameters are in the bounds. N must be a power of 2
+// greater than 1, and the key length must be the one of AES-256.
+func (p *scryptParams) valid() bool {
+	return p.N > 1 && p.N <= maxScryptN && p.N&(p.N-1) == 0 &&
+		p.R >= 1 && p.R <= maxScryptR &&
+		p.P >= 1 && p.P <= maxScryptP &&
+		p.DKLen == scryptDKLen
+}
+
+// EncryptKeystore encrypts key with pass into the keystore JSON format. The
+// encryption key is derived from pass by scrypt with scryptN and scryptP.
+func EncryptKeystore(key *btcec.PrivateKey, pass string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	derived, err := scrypt.Key([]byte(pass), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	aesgcm, err := newGCM(derived)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	address := GenerateAddress(key.PubKey().ToECDSA())
+	ks := &keystoreJSON{
+		Version: keystoreVersion,
+		Address: types.EncodeAddress(address),
+		Crypto: keystoreCrypto{
+			Cipher:     keystoreCipher,
+			CipherText: hex.EncodeToString(aesgcm.Seal(nil, nonce, key.Serialize(), address)),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        keystoreKDF,
+			KDFParams: scryptParams{
+				N:     scryptN,
+				R:     scryptR,
+				P:     scryptP,
+				DKLen: scryptDKLen,
+				Salt:  hex.EncodeToString(salt),
+			},
+		},
+	}
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// DecryptKeystore decrypts the key in the keystore JSON format with pass.
+func DecryptKeystore(data []byte, pass string) (*btcec.PrivateKey, error) {
+	ks := &keystoreJSON{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, ErrKeystoreFormat
+	}
+	if ks.Version != keystoreVersion || ks.Crypto.Cipher != keystoreCipher || ks.Crypto.KDF != keystoreKDF {
+		return nil, ErrKeystoreFormat
+	}
+	address, err := types.DecodeAddress(ks.Address)
+	if err != nil {
+		return nil, ErrKeystoreFormat
+	}
+	params := ks.Crypto.KDFParams
+	if !params.valid() {
+		return nil, ErrKeystoreFormat
+	}
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, ErrKeystoreFormat
+	}
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return nil, ErrKeystoreFormat
+	}
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, ErrKeystoreFormat
+	}
+	derived, err := scrypt.Key([]byte(pass), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	aesgcm, err := newGCM(derived)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aesgcm.NonceSize() {
+		return nil, ErrKeystoreFormat
+	}
+	plain, err := aesgcm.Open(nil, nonce, cipherText, address)
+	if err != nil {
+		return nil, types.ErrWrongAddressOrPassWord
+	}
+	privkey, _ := btcec.PrivKeyFromBytes(btcec.S256(), plain)
+	return privkey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// fileKeyStore keeps each key in a keystore file of the directory
+type fileKeyStore struct {
+	dir     string
+	scryptN int
+	scryptP int
+}
+
+// NewFileKeyStore makes a key storage, which keeps the keystore files in dir
+func NewFileKeyStore(dir string, scryptN, scryptP int) (KeyStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &fileKeyStore{dir: dir, scryptN: scryptN, scryptP: scryptP}, nil
+}
+
+func (ks *fileKeyStore) keyFile(addr Address) string {
+	return filepath.Join(ks.dir, types.EncodeAddress(addr)+keystoreSuffix)
+}
+
+func (ks *fileKeyStore) AddKey(key *aergokey, pass string) (Address, error) {
+	address := GenerateAddress(key.PubKey().ToECDSA())
+	file := ks.keyFile(address)
+	if _, err := os.Stat(file); err == nil {
+		return nil, ErrKeyExist
+	}
+	data, err := EncryptKeystore(key, pass, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return nil, err
+	}
+	// write the temporary file first not to leave a broken keystore file
+	tmp, err := ioutil.TempFile(ks.dir, ".keystore")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return address, nil
+}
+
+func (ks *fileKeyStore) GetKey(addr Address, pass string) (*aergokey, error) {
+	data, err := ioutil.ReadFile(ks.keyFile(addr))
+	if err != nil {
+		return nil, types.ErrWrongAddressOrPassWord
+	}
+	return DecryptKeystore(data, pass)
+}
+
+func (ks *fileKeyStore) Addresses() ([]Address, error) {
+	files, err := ioutil.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), keystoreSuffix) {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var ret []Address
+	for _, name := range names {
+		addr, err := types.DecodeAddress(strings.TrimSuffix(name, keystoreSuffix))
+		if err != nil {
+			// not a keystore file
+			continue
+		}
+		ret = append(ret, addr)
+	}
+	return ret, nil
+}
+
+func (ks *fileKeyStore) Close() {
+}
@@ -0,0 +1,93 @@
+package key
+
+import (
+	"bytes"
+	"path"
+	"sync"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// KeyStore is a storage of the private keys, which are encrypted with their
+// passphrases.
+type KeyStore interface {
+	// AddKey encrypts key with pass, and stores it with its address.
+	AddKey(key *aergokey, pass string) (Address, error)
+	// GetKey returns the key of addr decrypted with pass.
+	GetKey(addr Address, pass string) (*aergokey, error)
+	// Addresses returns the addresses of the stored keys.
+	Addresses() ([]Address, error)
+	Close()
+}
+
+var addresses = []byte("ADDRESSES")
+
+// dbKeyStore keeps the keys in a LevelDB
+type dbKeyStore struct {
+	sync.Mutex
+	storage db.DB
+}
+
+// NewDBKeyStore opens the account db in storePath
+func NewDBKeyStore(storePath string) KeyStore {
+	const dbName = "account"
+	dbPath := path.Join(storePath, dbName)
+
+	return &dbKeyStore{storage: db.NewDB(db.LevelImpl, dbPath)}
+}
+
+func (ks *dbKeyStore) AddKey(key *aergokey, pass string) (Address, error) {
+	//gen new address
+	address := GenerateAddress(&key.PublicKey)
+	//save pass/address/key
+	encryptkey := hashBytes(address, []byte(pass))
+	encrypted, err := encrypt(address, encryptkey, key.Serialize())
+	if err != nil {
+		return nil, err
+	}
+	ks.storage.Set(hashBytes(address, encryptkey), encrypted)
+	ks.saveAddress(address)
+	return address, nil
+}
+
+func (ks *dbKeyStore) GetKey(address Address, pass string) (*aergokey, error) {
+	encryptkey := hashBytes(address, []byte(pass))
+	key := ks.storage.Get(hashBytes(address, encryptkey))
+	if cap(key) == 0 {
+		return nil, types.ErrWrongAddressOrPassWord
+	}
+	plain, err := decrypt(address, encryptkey, key)
+	if err != nil {
+		return nil, err
+	}
+	privkey, _ := btcec.PrivKeyFromBytes(btcec.S256(), plain)
+	return privkey, nil
+}
+
+func (ks *dbKeyStore) saveAddress(addr Address) {
+	ks.Lock()
+	defer ks.Unlock()
+
+	addrs := ks.storage.Get(addresses)
+	for i := 0; i < len(addrs); i += types.AddressLength {
+		if bytes.Equal(addrs[i:i+types.AddressLength], addr) {
+			return
+		}
+	}
+	ks.storage.Set(addresses, append(addrs, addr...))
+}
+
+func (ks *dbKeyStore) Addresses() ([]Address, error) {
+	b := ks.storage.Get(addresses)
+	var ret []Address
+	for i := 0; i < len(b); i += types.AddressLength {
+		ret = append(ret, b[i:i+types.AddressLength])
+	}
+	return ret, nil
+}
+
+func (ks *dbKeyStore) Close() {
+	ks.storage.Close()
+}
@@ -11,11 +11,10 @@ import (
 
 //Sign return sign with key in the store
 func (ks *Store) Sign(addr Address, pass string, hash []byte) ([]byte, error) {
-	k, err := ks.getKey(addr, pass)
-	if k == nil {
+	key, err := ks.getKey(addr, pass)
+	if key == nil {
 		return nil, err
 	}
-	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), k)
 	sign, err := key.Sign(hash)
 	if err != nil {
 		return nil, err
@@ -34,14 +33,19 @@ func SignTx(tx *types.Tx, key *aergokey) error {
 	return nil
 }
 
-//SignTx return transaction which signed with unlocked key
+//SignTx return transaction which signed with unlocked key or external signer
 func (ks *Store) SignTx(tx *types.Tx) error {
 	addr := tx.Body.Account
+	ks.mu.RLock()
 	key, exist := ks.unlocked[types.EncodeAddress(addr)]
-	if !exist {
-		return types.ErrShouldUnlockAccount
+	ks.mu.RUnlock()
+	if exist {
+		return SignTx(tx, key)
 	}
-	return SignTx(tx, key)
+	if ks.signer != nil {
+		return ks.signer.SignTx(tx)
+	}
+	return types.ErrShouldUnlockAccount
 }
 
 //VerifyTx return result to varify sign
@@ -79,5 +83,10 @@ func CalculateHashWithoutSign(txBody *types.TxBody) []byte {
 	binary.Write(h, binary.LittleEndian, txBody.Limit)
 	binary.Write(h, binary.LittleEndian, txBody.Price)
 	binary.Write(h, binary.LittleEndian, txBody.Type)
+	// prefixed with its length like in the hash of the tx
+	if len(txBody.ChainIdHash) > 0 {
+		binary.Write(h, binary.LittleEndian, uint32(len(txBody.ChainIdHash)))
+		h.Write(txBody.ChainIdHash)
+	}
 	return h.Sum(nil)
 }
@@ -1,15 +1,14 @@
 package key
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"errors"
-	"path"
+	"sync"
+	"time"
 
 	sha256 "github.com/minio/sha256-simd"
 
-	"github.com/aergoio/aergo-lib/db"
 	"github.com/aergoio/aergo/types"
 	"github.com/btcsuite/btcd/btcec"
 )
@@ -18,22 +17,45 @@ type aergokey = btcec.PrivateKey
 
 // Store stucture of keystore
 type Store struct {
-	unlocked map[string]*aergokey
-	storage  db.DB
+	mu            sync.RWMutex
+	unlocked      map[string]*aergokey
+	relockTimers  map[string]*time.Timer
+	unlockTimeout time.Duration
+	storage       KeyStore
+	signer        *ExternalSigner
 }
 
 // NewStore make new instance of keystore
 func NewStore(storePath string) *Store {
-	const dbName = "account"
-	dbPath := path.Join(storePath, dbName)
+	return NewStoreWith(NewDBKeyStore(storePath), 0)
+}
 
+// NewStoreWith makes a keystore with the key storage. If unlockTimeout is not
+// 0, an unlocked account is locked again after it.
+func NewStoreWith(storage KeyStore, unlockTimeout time.Duration) *Store {
 	return &Store{
-		unlocked: map[string]*aergokey{},
-		storage:  db.NewDB(db.LevelImpl, dbPath),
+		unlocked:      map[string]*aergokey{},
+		relockTimers:  map[string]*time.Timer{},
+		unlockTimeout: unlockTimeout,
+		storage:       storage,
 	}
 }
+
+// SetExternalSigner sets the signer for the txs of the accounts which are not
+// unlocked in the keystore.
+func (ks *Store) SetExternalSigner(signer *ExternalSigner) {
+	ks.signer = signer
+}
+
 func (ks *Store) CloseStore() {
+	ks.mu.Lock()
+	for _, timer := range ks.relockTimers {
+		timer.Stop()
+	}
 	ks.unlocked = nil
+	ks.relockTimers = nil
+	ks.mu.Unlock()
+
 	ks.storage.Close()
 }
 
@@ -49,26 +71,17 @@ func (ks *Store) CreateKey(pass string) (Address, error) {
 
 //ImportKey is to import encrypted key
 func (ks *Store) ImportKey(imported []byte, oldpass string, newpass string) (Address, error) {
-	hash := hashBytes([]byte(oldpass), nil)
-	rehash := hashBytes([]byte(oldpass), hash)
-	key, err := decrypt(hash, rehash, imported)
+	privkey, err := DecryptWIF(imported, oldpass)
 	if err != nil {
 		return nil, err
 	}
-	privkey, pubkey := btcec.PrivKeyFromBytes(btcec.S256(), key)
-	address := GenerateAddress(pubkey.ToECDSA())
-	addresses, err := ks.GetAddresses()
+	address := GenerateAddress(privkey.PubKey().ToECDSA())
+	addresses, err := ks.storage.Addresses()
 	if err != nil {
 		return nil, err
 	}
-	for _, v := range addresses {
-		if bytes.Equal(address, v) {
-			return nil, errors.New("already exist")
-		}
-	}
-	err = ks.SaveAddress(address)
-	if err != nil {
-		return nil, err
+	if containsAddress(addresses, address) {
+		return nil, ErrKeyExist
 	}
 	return ks.addKey(privkey, newpass)
 }
@@ -79,9 +92,7 @@ func (ks *Store) ExportKey(addr Address, pass string) ([]byte, error) {
 	if key == nil {
 		return nil, err
 	}
-	hash := hashBytes([]byte(pass), nil)
-	rehash := hashBytes([]byte(pass), hash)
-	return encrypt(hash, rehash, key)
+	return EncryptWIF(key, pass)
 }
 
 //Unlock is to unlock account for signing
@@ -90,10 +101,35 @@ func (ks *Store) Unlock(addr Address, pass string) (Address, error) {
 	if key == nil {
 		return nil, err
 	}
-	ks.unlocked[types.EncodeAddress(addr)], _ = btcec.PrivKeyFromBytes(btcec.S256(), key)
+	b58addr := types.EncodeAddress(addr)
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.unlocked[b58addr] = key
+	if timer, exist := ks.relockTimers[b58addr]; exist {
+		timer.Stop()
+		delete(ks.relockTimers, b58addr)
+	}
+	if ks.unlockTimeout > 0 {
+		ks.relockTimers[b58addr] = time.AfterFunc(ks.unlockTimeout, func() {
+			ks.relock(b58addr, key)
+		})
+	}
 	return addr, nil
 }
 
+// relock locks the account unlocked with key, unless it is unlocked again.
+func (ks *Store) relock(b58addr string, key *aergokey) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if ks.unlocked[b58addr] == key {
+		delete(ks.unlocked, b58addr)
+		delete(ks.relockTimers, b58addr)
+	}
+}
+
 //Lock is to lock account prevent signing
 func (ks *Store) Lock(addr Address, pass string) (Address, error) {
 	key, err := ks.getKey(addr, pass)
@@ -101,31 +137,43 @@ func (ks *Store) Lock(addr Address, pass string) (Address, error) {
 		return nil, err
 	}
 	b58addr := types.EncodeAddress(addr)
-	ks.unlocked[b58addr] = nil
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if timer, exist := ks.relockTimers[b58addr]; exist {
+		timer.Stop()
+		delete(ks.relockTimers, b58addr)
+	}
 	delete(ks.unlocked, b58addr)
 	return addr, nil
 }
 
-func (ks *Store) getKey(address []byte, pass string) ([]byte, error) {
-	encryptkey := hashBytes(address, []byte(pass))
-	key := ks.storage.Get(hashBytes(address, encryptkey))
-	if cap(key) == 0 {
-		return nil, types.ErrWrongAddressOrPassWord
-	}
-	return decrypt(address, encryptkey, key)
+func (ks *Store) getKey(address []byte, pass string) (*aergokey, error) {
+	return ks.storage.GetKey(address, pass)
 }
 
 func (ks *Store) addKey(key *btcec.PrivateKey, pass string) (Address, error) {
-	//gen new address
-	address := GenerateAddress(&key.PublicKey)
-	//save pass/address/key
-	encryptkey := hashBytes(address, []byte(pass))
-	encrypted, err := encrypt(address, encryptkey, key.Serialize())
+	return ks.storage.AddKey(key, pass)
+}
+
+// EncryptWIF encrypts key with pass in the format of the exported key.
+func EncryptWIF(key *btcec.PrivateKey, pass string) ([]byte, error) {
+	hash := hashBytes([]byte(pass), nil)
+	rehash := hashBytes([]byte(pass), hash)
+	return encrypt(hash, rehash, key.Serialize())
+}
+
+// DecryptWIF decrypts the exported key with pass.
+func DecryptWIF(wif []byte, pass string) (*btcec.PrivateKey, error) {
+	hash := hashBytes([]byte(pass), nil)
+	rehash := hashBytes([]byte(pass), hash)
+	key, err := decrypt(hash, rehash, wif)
 	if err != nil {
 		return nil, err
 	}
-	ks.storage.Set(hashBytes(address, encryptkey), encrypted)
-	return address, nil
+	privkey, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	return privkey, nil
 }
 
 func hashBytes(b1 []byte, b2 []byte) []byte {
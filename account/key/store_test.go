@@ -1,11 +1,18 @@
 package key
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
 )
 
 var (
@@ -127,3 +134,130 @@ func TestSign(t *testing.T) {
 		}
 	}
 }
+
+func TestUnlockTimeout(t *testing.T) {
+	testDir, _ = ioutil.TempDir("", "test")
+	ks = NewStoreWith(NewDBKeyStore(testDir), 100*time.Millisecond)
+	defer deinitTest()
+
+	addr, err := ks.CreateKey("pass")
+	if err != nil {
+		t.Fatalf("could not create key : %s", err.Error())
+	}
+	if _, err := ks.Unlock(addr, "pass"); err != nil {
+		t.Fatalf("could not unlock address: %s", err.Error())
+	}
+	tx := &types.Tx{Body: &types.TxBody{Account: addr}}
+	if err := ks.SignTx(tx); err != nil {
+		t.Errorf("could not sign : %s", err.Error())
+	}
+	time.Sleep(300 * time.Millisecond)
+	if err := ks.SignTx(tx); err != types.ErrShouldUnlockAccount {
+		t.Errorf("account not relocked : %v", err)
+	}
+}
+
+func TestFileKeyStore(t *testing.T) {
+	testDir, _ = ioutil.TempDir("", "test")
+	storage, err := NewFileKeyStore(testDir, LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatalf("could not open keystore : %s", err.Error())
+	}
+	ks = NewStoreWith(storage, 0)
+	defer deinitTest()
+
+	addr, err := ks.CreateKey("pass")
+	if err != nil {
+		t.Fatalf("could not create key : %s", err.Error())
+	}
+	addrs, err := ks.GetAddresses()
+	if err != nil || len(addrs) != 1 || !bytes.Equal(addrs[0], addr) {
+		t.Errorf("invalid addresses : %v", addrs)
+	}
+	if _, err := ks.Unlock(addr, "wrong"); err != types.ErrWrongAddressOrPassWord {
+		t.Errorf("unlocked with wrong password : %v", err)
+	}
+	if _, err := ks.Unlock(addr, "pass"); err != nil {
+		t.Errorf("could not unlock address: %s", err.Error())
+	}
+
+	// export to the keystore format, and import it
+	key, err := ks.getKey(addr, "pass")
+	if err != nil {
+		t.Fatalf("could not get key : %s", err.Error())
+	}
+	data, err := EncryptKeystore(key, "other", LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatalf("could not encrypt key : %s", err.Error())
+	}
+	imported, err := DecryptKeystore(data, "other")
+	if err != nil {
+		t.Fatalf("could not decrypt keystore : %s", err.Error())
+	}
+	if !bytes.Equal(imported.Serialize(), key.Serialize()) {
+		t.Errorf("decrypted key not matched")
+	}
+	if _, err := ks.addKey(imported, "other"); err != ErrKeyExist {
+		t.Errorf("same key added : %v", err)
+	}
+
+	// the scrypt parameters out of the bounds
+	for _, params := range []string{`"n":2097152`, `"r":9`, `"p":17`, `"n":3`, `"dklen":64`} {
+		ksj := map[string]interface{}{}
+		json.Unmarshal(data, &ksj)
+		kdf := ksj["crypto"].(map[string]interface{})["kdfparams"].(map[string]interface{})
+		json.Unmarshal([]byte("{"+params+"}"), &kdf)
+		crafted, _ := json.Marshal(ksj)
+		if _, err := DecryptKeystore(crafted, "other"); err != ErrKeystoreFormat {
+			t.Errorf("decrypted with %s : %v", params, err)
+		}
+	}
+}
+
+func TestExternalSigner(t *testing.T) {
+	testDir, _ = ioutil.TempDir("", "test")
+	signerKey, _ := btcec.NewPrivateKey(btcec.S256())
+	sockPath := filepath.Join(testDir, "signer.sock")
+	l, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("could not listen : %s", err.Error())
+	}
+	defer l.Close()
+	signerAddr := GenerateAddress(signerKey.PubKey().ToECDSA())
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			req := &signRequest{}
+			json.NewDecoder(conn).Decode(req)
+			switch req.Method {
+			case "accounts":
+				json.NewEncoder(conn).Encode(&signResponse{Accounts: []string{types.EncodeAddress(signerAddr)}})
+			case "signTx":
+				hash, _ := enc.ToBytes(req.Hash)
+				sign, _ := signerKey.Sign(hash)
+				json.NewEncoder(conn).Encode(&signResponse{Sign: sign.Serialize()})
+			}
+			conn.Close()
+		}
+	}()
+
+	ks = NewStore(testDir)
+	ks.SetExternalSigner(NewExternalSigner(sockPath, time.Second))
+	defer deinitTest()
+
+	addrs, err := ks.GetAddresses()
+	if err != nil || len(addrs) != 1 || !bytes.Equal(addrs[0], signerAddr) {
+		t.Errorf("external signer account not listed : %v, %v", addrs, err)
+	}
+
+	tx := &types.Tx{Body: &types.TxBody{Account: signerAddr, Nonce: 1}}
+	if err := ks.SignTx(tx); err != nil {
+		t.Fatalf("could not sign by external signer : %s", err.Error())
+	}
+	if err := VerifyTx(tx); err != nil {
+		t.Errorf("invalid sign : %s", err.Error())
+	}
+}
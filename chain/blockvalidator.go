@@ -10,6 +10,7 @@ import (
 	"errors"
 
 	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 )
@@ -17,24 +18,48 @@ import (
 type BlockValidator struct {
 	signVerifier *SignVerifier
 	sdb          *state.ChainStateDB
+	chainIdHash  []byte
 }
 
 var (
 	ErrorBlockVerifySign      = errors.New("Block verify failed, because Tx sign is invalid")
 	ErrorBlockVerifyTxRoot    = errors.New("Block verify failed, because Tx root hash is invaild")
 	ErrorBlockVerifyStateRoot = errors.New("Block verify failed, because state root hash is not equal")
+	ErrorBlockVerifyChainId   = errors.New("Block verify failed, because Tx is bound to another chain")
+
+	ErrorBlockVerifyReceiptsRoot = errors.New("Block verify failed, because receipts root hash is not equal")
+	ErrorBlockVerifyEventsBloom  = errors.New("Block verify failed, because events bloom is not equal")
+	ErrorBlockVerifyStateExist   = errors.New("Block verify failed, because state of the block already exists")
 )
 
-func NewBlockValidator(sdb *state.ChainStateDB) *BlockValidator {
+func NewBlockValidator(sdb *state.ChainStateDB, chainIdHash []byte) *BlockValidator {
 	bv := BlockValidator{
 		signVerifier: NewSignVerifier(DefaultVerifierCnt),
 		sdb:          sdb,
+		chainIdHash:  chainIdHash,
 	}
 
 	logger.Debug().Msg("started signverifier")
 	return &bv
 }
 
+// BlockMisbehavior returns the misbehavior of the peer which sent a block
+// failed to be added with err, or false if err isn't caused by the block itself.
+// A block whose state already exists may be sent by an honest peer, so it isn't
+// a misbehavior.
+func BlockMisbehavior(err error) (message.Misbehavior, bool) {
+	switch err {
+	case ErrorBlockVerifySign:
+		return message.MisbehaveInvalidTxSign, true
+	case ErrorBlockVerifyStateRoot:
+		return message.MisbehaveStateMismatch, true
+	case ErrorBlockVerifyTxRoot, ErrorBlockVerifyChainId,
+		ErrorBlockVerifyReceiptsRoot, ErrorBlockVerifyEventsBloom:
+		return message.MisbehaveInvalidBlock, true
+	}
+	return 0, false
+}
+
 func (bv *BlockValidator) Stop() {
 	bv.signVerifier.Stop()
 }
@@ -58,7 +83,7 @@ func (bv *BlockValidator) ValidateHeader(header *types.BlockHeader) error {
 	//	ChainVersion
 	//	StateRootHash
 	if bv.sdb.IsExistState(header.GetBlocksRootHash()) {
-		return ErrorBlockVerifyStateRoot
+		return ErrorBlockVerifyStateExist
 	}
 
 	return nil
@@ -85,6 +110,17 @@ func (bv *BlockValidator) ValidateBody(block *types.Block) error {
 		return nil
 	}
 
+	// the txs are bound to the chain from the activation height
+	if Params.ChainIdAt(block.BlockNo()) {
+		for _, tx := range txs {
+			if err := tx.ValidateChainIdHash(bv.chainIdHash); err != nil {
+				logger.Error().Str("block", block.ID()).Str("tx", enc.ToString(tx.GetHash())).
+					Msg("chain id hash of tx validation failed")
+				return ErrorBlockVerifyChainId
+			}
+		}
+	}
+
 	failed, _ := bv.signVerifier.VerifyTxs(&types.TxList{Txs: txs})
 
 	if failed {
@@ -103,7 +139,7 @@ func (bv *BlockValidator) ValidatePost(sdbRoot []byte, receipts types.Receipts,
 			Str("hdrroot", enc.ToString(hdrRoot)).
 			Str("sdbroot", enc.ToString(sdbRoot)).
 			Msg("block root hash validation failed")
-		return ErrorBlockVerifySign
+		return ErrorBlockVerifyStateRoot
 	}
 
 	logger.Debug().Str("block", block.ID()).
@@ -118,12 +154,17 @@ func (bv *BlockValidator) ValidatePost(sdbRoot []byte, receipts types.Receipts,
 			Str("hdrroot", enc.ToString(hdrRoot)).
 			Str("receipts_root", enc.ToString(receiptsRoot)).
 			Msg("receipts root hash validation failed")
-		return ErrorBlockVerifySign
+		return ErrorBlockVerifyReceiptsRoot
 	}
 	logger.Debug().Str("block", block.ID()).
 		Str("hdrroot", enc.ToString(hdrRoot)).
 		Str("receipts_root", enc.ToString(receiptsRoot)).
 		Msg("receipt root hash validation succeed")
 
+	if !bytes.Equal(block.GetHeader().GetEventsBloom(), receipts.Bloom()) {
+		logger.Error().Str("block", block.ID()).Msg("events bloom validation failed")
+		return ErrorBlockVerifyEventsBloom
+	}
+
 	return nil
 }
@@ -27,6 +27,8 @@ func (cs *ChainService) getAnchorsNew() (ChainAnchor, types.BlockNo, error) {
 	logger.Debug().Msg("get anchors")
 
 	blkNo := cs.getBestBlockNo()
+	// the blocks below the snapshot are not stored
+	baseNo := cs.cdb.snapshotNo
 	var lastNo types.BlockNo
 LOOP:
 	for i := 0; i < cnt; i++ {
@@ -45,8 +47,10 @@ LOOP:
 		switch {
 		case blkNo == 0:
 			break LOOP
-		case blkNo < Skip:
+		case blkNo == baseNo:
 			blkNo = 0
+		case blkNo < baseNo+Skip:
+			blkNo = baseNo
 		default:
 			blkNo -= Skip
 		}
@@ -0,0 +1,262 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// A chain archive is a portable file of the blocks, which is used to back up
+// the chain and to bootstrap a node without peers. It begins with the magic
+// bytes, and is followed by records. Each record has the length of its
+// payload (4 bytes, big endian), the payload and the CRC-32 of the payload
+// (4 bytes, big endian). The payload of the first record is a
+// ChainArchiveHeader, and those of the others are ChainArchiveBlocks.
+const (
+	archiveVersion   = 1
+	archiveMaxRecord = 1 << 30
+)
+
+var (
+	archiveMagic = []byte("AERGOARC")
+
+	ErrArchiveMagic    = errors.New("not a chain archive")
+	ErrArchiveVersion  = errors.New("unsupported chain archive version")
+	ErrArchiveChecksum = errors.New("chain archive record corrupted: checksum mismatch")
+	ErrArchiveRecord   = errors.New("chain archive record too large")
+	ErrArchiveGenesis  = errors.New("genesis block of chain archive mismatch")
+)
+
+// ArchiveWriter writes the blocks to a chain archive.
+type ArchiveWriter struct {
+	w *bufio.Writer
+}
+
+// NewArchiveWriter writes the magic bytes and header to w, and returns a
+// writer for the blocks.
+func NewArchiveWriter(w io.Writer, header *types.ChainArchiveHeader) (*ArchiveWriter, error) {
+	aw := &ArchiveWriter{w: bufio.NewWriter(w)}
+	if _, err := aw.w.Write(archiveMagic); err != nil {
+		return nil, err
+	}
+	header.Version = archiveVersion
+	if err := aw.writeRecord(header); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+// WriteBlock appends a block and its receipts. receipts may be nil.
+func (aw *ArchiveWriter) WriteBlock(block *types.Block, receipts []*types.Receipt) error {
+	return aw.writeRecord(&types.ChainArchiveBlock{Block: block, Receipts: receipts})
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (aw *ArchiveWriter) Flush() error {
+	return aw.w.Flush()
+}
+
+func (aw *ArchiveWriter) writeRecord(pb proto.Message) error {
+	payload, err := proto.Marshal(pb)
+	if err != nil {
+		return err
+	}
+	if len(payload) > archiveMaxRecord {
+		return ErrArchiveRecord
+	}
+	var n [4]byte
+	binary.BigEndian.PutUint32(n[:], uint32(len(payload)))
+	if _, err := aw.w.Write(n[:]); err != nil {
+		return err
+	}
+	if _, err := aw.w.Write(payload); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(n[:], crc32.ChecksumIEEE(payload))
+	_, err = aw.w.Write(n[:])
+	return err
+}
+
+// ArchiveReader reads the blocks from a chain archive.
+type ArchiveReader struct {
+	Header *types.ChainArchiveHeader
+
+	r *bufio.Reader
+}
+
+// NewArchiveReader checks the magic bytes of r, and reads its header.
+func NewArchiveReader(r io.Reader) (*ArchiveReader, error) {
+	ar := &ArchiveReader{r: bufio.NewReader(r), Header: &types.ChainArchiveHeader{}}
+
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(ar.r, magic); err != nil || !bytes.Equal(magic, archiveMagic) {
+		return nil, ErrArchiveMagic
+	}
+	if err := ar.readRecord(ar.Header); err != nil {
+		return nil, err
+	}
+	if ar.Header.Version != archiveVersion {
+		return nil, ErrArchiveVersion
+	}
+	return ar, nil
+}
+
+// ReadBlock returns the next block of the archive. It returns io.EOF at the end
+// of the archive, and io.ErrUnexpectedEOF if the archive is truncated.
+func (ar *ArchiveReader) ReadBlock() (*types.ChainArchiveBlock, error) {
+	rec := &types.ChainArchiveBlock{}
+	if err := ar.readRecord(rec); err != nil {
+		return nil, err
+	}
+	if rec.Block == nil || rec.Block.Header == nil {
+		return nil, fmt.Errorf("chain archive record has no block")
+	}
+	return rec, nil
+}
+
+func (ar *ArchiveReader) readRecord(pb proto.Message) error {
+	var n [4]byte
+	if _, err := io.ReadFull(ar.r, n[:]); err != nil {
+		return err
+	}
+	size := binary.BigEndian.Uint32(n[:])
+	if size > archiveMaxRecord {
+		return ErrArchiveRecord
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(ar.r, payload); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	if _, err := io.ReadFull(ar.r, n[:]); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	if binary.BigEndian.Uint32(n[:]) != crc32.ChecksumIEEE(payload) {
+		return ErrArchiveChecksum
+	}
+	return proto.Unmarshal(payload, pb)
+}
+
+// ExportChain writes the blocks from no from to no to of the main chain to w.
+// If to is 0, the blocks are exported up to the best block. If withReceipts
+// is true, the receipts of the blocks are also exported. It returns the number
+// of the last block written.
+func (core *Core) ExportChain(w io.Writer, from, to types.BlockNo, withReceipts bool) (types.BlockNo, error) {
+	if from == 0 {
+		// the genesis block is created by the init command
+		from = 1
+	}
+	best := core.cdb.getBestBlockNo()
+	if to == 0 || to > best {
+		to = best
+	}
+	if from > to {
+		return 0, fmt.Errorf("invalid block range to export: %d-%d", from, to)
+	}
+	genesisHash, err := core.cdb.getHashByNo(0)
+	if err != nil {
+		return 0, err
+	}
+
+	aw, err := NewArchiveWriter(w, &types.ChainArchiveHeader{
+		GenesisHash: genesisHash,
+		StartNo:     from,
+		EndNo:       to,
+		Receipts:    withReceipts,
+	})
+	if err != nil {
+		return 0, err
+	}
+	last, err := core.exportBlocks(aw, from, to, withReceipts)
+	// keep the blocks written before an error, which can be imported
+	if flushErr := aw.Flush(); err == nil {
+		err = flushErr
+	}
+	return last, err
+}
+
+func (core *Core) exportBlocks(aw *ArchiveWriter, from, to types.BlockNo, withReceipts bool) (types.BlockNo, error) {
+	for no := from; no <= to; no++ {
+		block, err := core.cdb.GetBlockByNo(no)
+		if err != nil {
+			return no - 1, err
+		}
+		var receipts types.Receipts
+		if withReceipts && len(block.GetBody().GetTxs()) > 0 {
+			if receipts, err = core.cdb.getReceipts(block.BlockHash(), no); err != nil {
+				return no - 1, err
+			}
+		}
+		if err := aw.WriteBlock(block, receipts); err != nil {
+			return no - 1, err
+		}
+	}
+	return to, nil
+}
+
+// ImportChain adds the blocks of the chain archive r through the same
+// validation and execution as the blocks from the peers. The blocks already
+// in the main chain are skipped, so an interrupted import is resumed by
+// running it again. The receipts in the archive are not used, since they are
+// made by the execution. It calls progress, if not nil, after each block
+// added, and stops if progress returns false. It returns the number of the
+// blocks added.
+func (cs *ChainService) ImportChain(r io.Reader, progress func(block *types.Block) bool) (int, error) {
+	ar, err := NewArchiveReader(r)
+	if err != nil {
+		return 0, err
+	}
+	genesisHash, err := cs.cdb.getHashByNo(0)
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(ar.Header.GenesisHash, genesisHash) {
+		return 0, ErrArchiveGenesis
+	}
+
+	added := 0
+	for {
+		rec, err := ar.ReadBlock()
+		if err == io.EOF {
+			return added, nil
+		} else if err != nil {
+			return added, err
+		}
+		block := rec.Block
+		// recalculate the hash, which is not trusted
+		block.Hash = nil
+		no := block.BlockNo()
+
+		best := cs.cdb.getBestBlockNo()
+		if no <= best {
+			hash, err := cs.cdb.getHashByNo(no)
+			if err == nil && bytes.Equal(hash, block.BlockHash()) {
+				// already imported
+				continue
+			}
+			return added, fmt.Errorf("block %d of chain archive conflicts with the chain", no)
+		}
+		if no > best+1 {
+			return added, fmt.Errorf("block %d of chain archive is not connected to the best block %d", no, best)
+		}
+
+		if err := cs.addBlock(block, nil, ""); err != nil {
+			return added, err
+		}
+		added++
+		if progress != nil && !progress(block) {
+			return added, nil
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package chain
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainArchive(t *testing.T) {
+	var buf bytes.Buffer
+
+	aw, err := NewArchiveWriter(&buf, &types.ChainArchiveHeader{GenesisHash: []byte("genesis"), StartNo: 1, EndNo: 3})
+	assert.NoError(t, err)
+
+	prev := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	for no := types.BlockNo(1); no <= 3; no++ {
+		block := types.NewBlock(prev, nil, nil, nil, nil, int64(no))
+		receipt := types.NewReceipt(make([]byte, 33), "SUCCESS", "")
+		assert.NoError(t, aw.WriteBlock(block, []*types.Receipt{receipt}))
+		prev = block
+	}
+	assert.NoError(t, aw.Flush())
+	data := buf.Bytes()
+
+	ar, err := NewArchiveReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("genesis"), ar.Header.GenesisHash)
+	assert.Equal(t, uint64(3), ar.Header.EndNo)
+	for no := types.BlockNo(1); no <= 3; no++ {
+		rec, err := ar.ReadBlock()
+		assert.NoError(t, err)
+		assert.Equal(t, no, rec.Block.BlockNo())
+		assert.Equal(t, 1, len(rec.Receipts))
+	}
+	_, err = ar.ReadBlock()
+	assert.Equal(t, io.EOF, err)
+
+	// truncated
+	ar, err = NewArchiveReader(bytes.NewReader(data[:len(data)-2]))
+	assert.NoError(t, err)
+	ar.ReadBlock()
+	ar.ReadBlock()
+	_, err = ar.ReadBlock()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	// corrupted
+	corrupted := append([]byte(nil), data...)
+	corrupted[len(corrupted)-10] ^= 0xff
+	ar, err = NewArchiveReader(bytes.NewReader(corrupted))
+	assert.NoError(t, err)
+	ar.ReadBlock()
+	ar.ReadBlock()
+	_, err = ar.ReadBlock()
+	assert.Equal(t, ErrArchiveChecksum, err)
+
+	_, err = NewArchiveReader(bytes.NewReader([]byte("not an archive")))
+	assert.Equal(t, ErrArchiveMagic, err)
+}
@@ -35,6 +35,7 @@ var (
 	ErrorLoadBestBlock = errors.New("failed to load latest block from DB")
 
 	latestKey      = []byte(chainDBName + ".latest")
+	snapshotKey    = []byte(chainDBName + ".snapshot")
 	receiptsPrefix = []byte("r")
 )
 
@@ -61,6 +62,9 @@ type ChainDB struct {
 
 	latest    types.BlockNo
 	bestBlock atomic.Value // *types.Block
+	// snapshotNo is the number of the block installed by the snapshot sync.
+	// The blocks between the genesis block and it are not stored.
+	snapshotNo types.BlockNo
 	//	blocks []*types.Block
 	store db.DB
 }
@@ -160,6 +164,10 @@ func (cdb *ChainDB) loadChainData() error {
 	}
 	cdb.setLatest(latestBlock)
 
+	if snapshotBytes := cdb.store.Get(snapshotKey); len(snapshotBytes) != 0 {
+		cdb.snapshotNo = types.BlockNoFromBytes(snapshotBytes)
+	}
+
 	// skips := true
 	// for i, _ := range cdb.blocks {
 	// 	if i > 3 && i+3 <= cdb.latest {
@@ -380,6 +388,27 @@ func (cdb *ChainDB) addBlock(dbtx *db.Transaction, block *types.Block) error {
 	return nil
 }
 
+// installSnapshot connects the block of a snapshot to the genesis block as the
+// best block.
+func (cdb *ChainDB) installSnapshot(block *types.Block) error {
+	dbTx := cdb.store.NewTx()
+	defer dbTx.Discard()
+
+	if err := cdb.addBlock(&dbTx, block); err != nil {
+		return err
+	}
+	if err := cdb.addTxsOfBlock(&dbTx, block.GetBody().GetTxs(), block.BlockHash()); err != nil {
+		return err
+	}
+	dbTx.Set(snapshotKey, types.BlockNoToBytes(block.BlockNo()))
+	cdb.connectToChain(&dbTx, block)
+
+	dbTx.Commit()
+	cdb.snapshotNo = block.BlockNo()
+
+	return nil
+}
+
 func (cdb *ChainDB) getBestBlockNo() types.BlockNo {
 	return cdb.latest
 }
@@ -394,6 +423,11 @@ func (cdb *ChainDB) GetBlockByNo(blockNo types.BlockNo) (*types.Block, error) {
 	return cdb.getBlock(blockHash)
 }
 
+// GetBlock returns the block of blockHash.
+func (cdb *ChainDB) GetBlock(blockHash []byte) (*types.Block, error) {
+	return cdb.getBlock(blockHash)
+}
+
 func (cdb *ChainDB) getBlock(blockHash []byte) (*types.Block, error) {
 	if blockHash == nil {
 		return nil, fmt.Errorf("block hash invalid(nil)")
@@ -441,7 +475,7 @@ func (cdb *ChainDB) getTx(txHash []byte) (*types.Tx, *types.TxIdx, error) {
 	return tx, txIdx, nil
 }
 
-func (cdb *ChainDB) getReceipt(blockHash []byte, blockNo types.BlockNo, idx int32) (*types.Receipt, error) {
+func (cdb *ChainDB) getReceipts(blockHash []byte, blockNo types.BlockNo) (types.Receipts, error) {
 	data := cdb.store.Get(receiptsKey(blockHash, blockNo))
 	if len(data) == 0 {
 		return nil, errors.New("cannot find a receipt")
@@ -452,6 +486,15 @@ func (cdb *ChainDB) getReceipt(blockHash []byte, blockNo types.BlockNo, idx int3
 	gob := gob.NewDecoder(&b)
 	gob.Decode(&receipts)
 
+	return receipts, nil
+}
+
+func (cdb *ChainDB) getReceipt(blockHash []byte, blockNo types.BlockNo, idx int32) (*types.Receipt, error) {
+	receipts, err := cdb.getReceipts(blockHash, blockNo)
+	if err != nil {
+		return nil, err
+	}
+
 	if idx < 0 || idx > int32(len(receipts)) {
 		return nil, fmt.Errorf("cannot find a receipt: invalid index (%d)", idx)
 	}
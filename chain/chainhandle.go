@@ -59,6 +59,10 @@ func (cs *ChainService) GetBestBlock() (*types.Block, error) {
 	return cs.cdb.GetBestBlock()
 }
 
+func (cs *ChainService) GetGenesisInfo() *types.Genesis {
+	return cs.cdb.GetGenesisInfo()
+}
+
 func (cs *ChainService) getBlockByNo(blockNo types.BlockNo) (*types.Block, error) {
 	return cs.cdb.GetBlockByNo(blockNo)
 }
@@ -107,6 +111,53 @@ func (cs *ChainService) getReceipt(txHash []byte) (*types.Receipt, error) {
 	return cs.cdb.getReceipt(block.BlockHash(), block.GetHeader().BlockNo, i.Idx)
 }
 
+// listEvents returns the events matching filter in the main chain. The blocks
+// are skipped by the events bloom in their headers.
+func (cs *ChainService) listEvents(filter *types.FilterInfo) ([]*types.Event, error) {
+	if len(filter.ContractAddress) == 0 {
+		return nil, errors.New("contract address is required")
+	}
+	from, to := filter.Blockfrom, filter.Blockto
+	if bestNo := cs.getBestBlockNo(); to == 0 || to > bestNo {
+		to = bestNo
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range (%d-%d)", from, to)
+	}
+	if to-from >= MaxEventBlockRange {
+		return nil, fmt.Errorf("too large block range (max %d blocks)", MaxEventBlockRange)
+	}
+
+	var events []*types.Event
+	for blockNo := from; blockNo <= to; blockNo++ {
+		block, err := cs.getBlockByNo(blockNo)
+		if err != nil {
+			return nil, err
+		}
+		if !types.BloomMatchEvent(block.GetHeader().GetEventsBloom(), filter.ContractAddress, filter.EventName) {
+			continue
+		}
+		receipts, err := cs.cdb.getReceipts(block.BlockHash(), blockNo)
+		if err != nil {
+			return nil, err
+		}
+		txs := block.GetBody().GetTxs()
+		for idx, r := range receipts {
+			for _, ev := range r.Events {
+				if !ev.MatchFilter(filter) {
+					continue
+				}
+				ev.TxHash = txs[idx].GetHash()
+				ev.BlockHash = block.BlockHash()
+				ev.BlockNo = blockNo
+				ev.TxIndex = int32(idx)
+				events = append(events, ev)
+			}
+		}
+	}
+	return events, nil
+}
+
 type chainProcessor struct {
 	*ChainService
 	block     *types.Block // starting block
@@ -186,6 +237,12 @@ func (cp *chainProcessor) prepare() error {
 		if blk, err = cp.resolveOrphan(blk); err != nil {
 			return err
 		}
+		// The BP of an orphan block is validated once its branch is known.
+		if blk != nil {
+			if err = cp.IsBlockValid(blk, cp.lastBlock); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -226,6 +283,7 @@ func (cp *chainProcessor) execute() error {
 			return err
 		}
 		cp.notifyBlock(block)
+		cp.notifyPeerAllowlist(block)
 		blockNo := block.BlockNo()
 		if logger.IsDebugEnabled() {
 			logger.Debug().
@@ -422,6 +480,12 @@ func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.B
 
 // NewTxExecutor returns a new TxExecFn.
 func NewTxExecutor(blockNo types.BlockNo, ts int64, preLoadService int) TxExecFn {
+	return newTxExecutorTrace(blockNo, ts, preLoadService, nil)
+}
+
+// newTxExecutorTrace returns a new TxExecFn recording the contract calls of
+// the txs with tracer.
+func newTxExecutorTrace(blockNo types.BlockNo, ts int64, preLoadService int, tracer *contract.Tracer) TxExecFn {
 	return func(bState *state.BlockState, tx *types.Tx) error {
 		if bState == nil {
 			logger.Error().Msg("bstate is nil in txexec")
@@ -429,7 +493,7 @@ func NewTxExecutor(blockNo types.BlockNo, ts int64, preLoadService int) TxExecFn
 		}
 		snapshot := bState.Snapshot()
 
-		err := executeTx(bState, tx, blockNo, ts, preLoadService)
+		err := executeTxTrace(bState, tx, blockNo, ts, preLoadService, tracer)
 		if err != nil {
 			logger.Error().Err(err).Str("hash", enc.ToString(tx.GetHash())).Msg("tx failed")
 			bState.Rollback(snapshot)
@@ -520,10 +584,19 @@ func (cs *ChainService) executeBlock(bstate *state.BlockState, block *types.Bloc
 
 	cs.Update(block)
 
+	cs.collectStateGarbage(block)
+
 	return nil
 }
 
 func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, preLoadService int) error {
+	return executeTxTrace(bs, tx, blockNo, ts, preLoadService, nil)
+}
+
+// executeTxTrace executes tx like executeTx and records the execution of the
+// contract call with tracer.
+func executeTxTrace(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, preLoadService int,
+	tracer *contract.Tracer) error {
 	err := tx.Validate()
 	if err != nil {
 		return err
@@ -535,7 +608,8 @@ func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, pre
 		return err
 	}
 
-	err = tx.ValidateWithSenderState(sender.State())
+	gasMetered := Params.GasAt(blockNo)
+	err = tx.ValidateWithSenderState(sender.State(), gasMetered)
 	if err != nil {
 		return err
 	}
@@ -554,10 +628,20 @@ func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, pre
 	var txFee uint64
 	var rv string
 	switch txBody.Type {
-	case types.TxType_NORMAL:
-		txFee = CoinbaseFee
-		sender.SubBalance(txFee)
-		rv, err = contract.Execute(bs, tx, blockNo, ts, sender, receiver, preLoadService)
+	case types.TxType_NORMAL, types.TxType_REDEPLOY:
+		if err = tx.ValidateGasPrice(Params.MinGasPriceAt(blockNo)); err != nil {
+			return err
+		}
+		if !gasMetered {
+			// the fixed fee is charged before the execution
+			txFee = types.DefaultCoinbaseFee
+			sender.SubBalance(txFee)
+		}
+		var usedGas uint64
+		rv, usedGas, err = contract.ExecuteTrace(bs, tx, blockNo, ts, sender, receiver, preLoadService, tracer)
+		if gasMetered {
+			txFee = usedGas * txBody.Price
+		}
 	case types.TxType_GOVERNANCE:
 		err = executeGovernanceTx(&bs.StateDB, txBody, sender, receiver, blockNo)
 		if err != nil {
@@ -575,12 +659,15 @@ func executeTx(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64, pre
 				return sErr
 			}
 			bs.BpReward += txFee
-			bs.AddReceipt(types.NewReceipt(receiver.ID(), err.Error(), ""))
+			bs.AddReceipt(types.NewReceipt(receiver.ID(), contract.ReceiptStatus(err), ""))
 			return nil
 		}
 		return err
 	}
 
+	if gasMetered {
+		sender.SubBalance(txFee)
+	}
 	sender.SetNonce(txBody.Nonce)
 	err = sender.PutState()
 	if err != nil {
@@ -86,6 +86,9 @@ func (core *Core) initGenesis(genesis *types.Genesis) (*types.Block, error) {
 			if genesis == nil {
 				genesis = types.GetDefaultGenesis()
 			}
+			if genesis.Params == nil {
+				genesis.Params = types.DefaultChainParams()
+			}
 
 			err := InitGenesisBPs(core.sdb.GetStateDB(), genesis)
 			if err != nil {
@@ -146,6 +149,8 @@ type ChainService struct {
 	op  *OrphanPool
 
 	validator *BlockValidator
+	stateGC   *stateGC
+	replayer  *actor.PID
 }
 
 // NewChainService creates an instance of ChainService.
@@ -163,7 +168,6 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 
 	if err = Init(cfg.Blockchain.MaxBlockSize,
 		cfg.Blockchain.CoinbaseAccount,
-		types.DefaultCoinbaseFee,
 		cfg.Consensus.EnableBp,
 		cfg.Blockchain.MaxAnchorCount,
 		cfg.Blockchain.UseFastSyncer); err != nil {
@@ -171,7 +175,11 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 		panic("invalid config: blockchain")
 	}
 
-	cs.validator = NewBlockValidator(cs.sdb)
+	if cs.stateGC, err = newStateGC(cs.sdb, cfg.Blockchain); err != nil {
+		logger.Error().Err(err).Msg("failed to init state storage mode")
+		panic("invalid config: blockchain")
+	}
+
 	cs.BaseComponent = component.NewBaseComponent(message.ChainSvc, cs, logger)
 
 	// init genesis block
@@ -179,6 +187,12 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 		logger.Fatal().Err(err).Msg("failed to create a genesis block")
 	}
 
+	Params = cs.GetGenesisInfo().ChainParams()
+	contract.SetChainParams(Params)
+	system.SetChainParams(Params)
+	// the txs of a block must be bound to the chain of the genesis block
+	cs.validator = NewBlockValidator(cs.sdb, cs.GetGenesisInfo().ChainIdHash())
+
 	return cs
 }
 
@@ -202,8 +216,9 @@ func (cs *ChainService) SetChainConsensus(cc consensus.ChainConsensus) {
 func (cs *ChainService) BeforeStart() {
 }
 
-// AfterStart ... do nothing
+// AfterStart starts the replayer of the txs
 func (cs *ChainService) AfterStart() {
+	cs.replayer = actor.Spawn(actor.FromInstance(newReplayer(cs)))
 }
 
 // ChainSync synchronize with peer
@@ -221,6 +236,9 @@ func (cs *ChainService) ChainSync(peerID peer.ID, remoteBestHash []byte) {
 
 // BeforeStop close chain database and stop BlockValidator
 func (cs *ChainService) BeforeStop() {
+	if cs.replayer != nil {
+		cs.replayer.GracefulStop()
+	}
 	cs.Close()
 
 	cs.validator.Stop()
@@ -291,6 +309,9 @@ func (cs *ChainService) Receive(context actor.Context) {
 			if err != nil && err != ErrBlockOrphan {
 				logger.Error().Err(err).Str("hash", msg.Block.ID()).Msg("failed add block")
 			}
+			if kind, ok := BlockMisbehavior(err); ok && msg.PeerID != "" {
+				cs.TellTo(message.P2PSvc, &message.PeerMisbehavior{PeerID: msg.PeerID, Kind: kind})
+			}
 		}
 
 		rsp := message.AddBlockRsp{
@@ -382,6 +403,12 @@ func (cs *ChainService) Receive(context actor.Context) {
 				Err: err,
 			})
 		}
+	case *message.ListEvents:
+		events, err := cs.listEvents(msg.Filter)
+		context.Respond(message.ListEventsRsp{
+			Events: events,
+			Err:    err,
+		})
 	case *message.GetQuery:
 		runtime.LockOSThread()
 		defer runtime.UnlockOSThread()
@@ -391,9 +418,11 @@ func (cs *ChainService) Receive(context actor.Context) {
 			context.Respond(message.GetQueryRsp{Result: nil, Err: err})
 		} else {
 			bs := state.NewBlockState(cs.sdb.OpenNewStateDB(cs.sdb.GetRoot()))
-			ret, err := contract.Query(msg.Contract, bs, ctrState, msg.Queryinfo)
+			ret, err := contract.Query(msg.Contract, bs, ctrState, msg.Queryinfo, cs.getBestBlockNo())
 			context.Respond(message.GetQueryRsp{Result: ret, Err: err})
 		}
+	case *message.TraceTx, *message.SimulateTx:
+		cs.replayer.Request(msg, context.Sender())
 	case *message.GetStateQuery:
 		var varProof *types.ContractVarProof
 		var contractProof *types.StateProof
@@ -442,6 +471,52 @@ func (cs *ChainService) Receive(context actor.Context) {
 			Staking: staking,
 			Err:     err,
 		})
+	case *message.GetPeerAllowlist:
+		peerIDs, err := cs.getPeerAllowlist()
+		context.Respond(&message.GetPeerAllowlistRsp{
+			PeerIDs: peerIDs,
+			Err:     err,
+		})
+	case *message.ExportStateChunk:
+		entries, next, err := cs.sdb.GetStateChunk(msg.Root, msg.Start, msg.Accounts, msg.MaxEntries, msg.MaxSize)
+		if err != nil {
+			logger.Error().Str("root", enc.ToString(msg.Root)).Err(err).Msg("failed to get state chunk")
+		}
+		context.Respond(message.ExportStateChunkRsp{
+			Entries: entries,
+			Next:    next,
+			Err:     err,
+		})
+	case *message.ExportSQLChunk:
+		context.Respond(*cs.exportSQLChunk(msg))
+	case *message.ImportStateChunk:
+		root, err := cs.sdb.ImportStateChunk(msg.Root, msg.Entries)
+		context.Respond(message.ImportStateChunkRsp{
+			Root: root,
+			Err:  err,
+		})
+	case *message.ImportSQLChunk:
+		context.Respond(message.ImportSQLChunkRsp{
+			Err: cs.importSQLChunk(msg),
+		})
+	case *message.GetSnapshotNo:
+		context.Respond(message.GetSnapshotNoRsp{
+			BlockNo: cs.snapshotNo(msg.BlockNo),
+		})
+	case *message.GetAncestorVerifier:
+		rsp := message.GetAncestorVerifierRsp{Err: ErrSnapshotVerify}
+		if av, ok := cs.ChainConsensus.(ancestorVerifier); ok {
+			rsp.Verifier, rsp.Err = av.NewAncestorVerifier()
+		}
+		context.Respond(rsp)
+	case *message.InstallSnapshot:
+		err := cs.installSnapshot(msg.Block, msg.Descendants)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to install snapshot")
+		}
+		context.Respond(message.InstallSnapshotRsp{
+			Err: err,
+		})
 
 	case actor.SystemMessage,
 		actor.AutoReceiveMessage,
@@ -505,3 +580,42 @@ func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
 	}
 	return staking, nil
 }
+
+func (cs *ChainService) getPeerAllowlist() ([]peer.ID, error) {
+	scs, err := cs.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
+	if err != nil {
+		return nil, err
+	}
+	allowlist, err := system.GetPeerAllowlist(scs)
+	if err != nil {
+		return nil, err
+	}
+	peerIDs := make([]peer.ID, len(allowlist))
+	for i, id := range allowlist {
+		peerIDs[i] = peer.ID(id)
+	}
+	return peerIDs, nil
+}
+
+// notifyPeerAllowlist lets p2p service reload the on-chain peer allowlist if
+// any of blocks has a tx changing it
+func (cs *ChainService) notifyPeerAllowlist(blocks ...*types.Block) {
+	changed := false
+	for _, block := range blocks {
+		for _, tx := range block.GetBody().GetTxs() {
+			if system.IsAllowlistTx(tx.GetBody()) {
+				changed = true
+				break
+			}
+		}
+	}
+	if !changed {
+		return
+	}
+	peerIDs, err := cs.getPeerAllowlist()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get peer allowlist")
+		return
+	}
+	cs.TellTo(message.P2PSvc, &message.PeerAllowlistChanged{PeerIDs: peerIDs})
+}
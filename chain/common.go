@@ -15,17 +15,22 @@ var (
 	// MaxBlockSize is the maximum size of a block.
 	MaxBlockSize    uint32
 	CoinbaseAccount []byte
-	CoinbaseFee     uint64
 	MaxAnchorCount  int
 	UseFastSyncer   bool
+
+	// Params is the consensus parameters of the chain, set from its genesis.
+	Params = types.LegacyChainParams()
 )
 
+// MaxEventBlockRange is the maximum number of blocks searched for events at once.
+const MaxEventBlockRange = 10000
+
 var (
 	ErrInvalidCoinbaseAccount = errors.New("invalid coinbase account in config")
 )
 
 // Init initializes the blockchain-related parameters.
-func Init(maxBlockSize uint32, coinbaseAccountStr string, coinbaseFee uint64, isBp bool, maxAnchorCount int, useFastSyncer bool) error {
+func Init(maxBlockSize uint32, coinbaseAccountStr string, isBp bool, maxAnchorCount int, useFastSyncer bool) error {
 	var err error
 
 	MaxBlockSize = maxBlockSize
@@ -43,7 +48,6 @@ func Init(maxBlockSize uint32, coinbaseAccountStr string, coinbaseFee uint64, is
 		}
 	}
 
-	CoinbaseFee = coinbaseFee
 	MaxAnchorCount = maxAnchorCount
 	UseFastSyncer = useFastSyncer
 	return nil
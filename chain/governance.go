@@ -60,7 +60,7 @@ func InitGenesisBPs(states *state.StateDB, genesis *types.Genesis) error {
 	for _, v := range genesis.BPs {
 		voteResult[v] = uint64(0)
 	}
-	if err = system.InitVoteResult(scs, &voteResult); err != nil {
+	if err = system.InitVoteResult(scs, &voteResult, genesis.ChainParams()); err != nil {
 		return err
 	}
 	if err = states.StageContractState(scs); err != nil {
@@ -111,6 +111,7 @@ func (cs *ChainService) reorg(topBlock *types.Block) error {
 	if err := reorg.swapChain(); err != nil {
 		return err
 	}
+	cs.notifyPeerAllowlist(append(reorg.oldBlocks, reorg.newBlocks...)...)
 
 	logger.Info().Msg("reorg end")
 
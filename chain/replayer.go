@@ -0,0 +1,41 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"runtime"
+
+	"github.com/aergoio/aergo-actor/actor"
+	"github.com/aergoio/aergo/message"
+)
+
+// replayer re-executes the txs of the chain off the actor of the chain
+// service, so a long trace or simulation doesn't delay the blocks being connected. The txs
+// are replayed one by one, since the contract states of a replayed tx are
+// registered by its hash.
+type replayer struct {
+	cs *ChainService
+}
+
+func newReplayer(cs *ChainService) *replayer {
+	return &replayer{cs: cs}
+}
+
+// Receive actor message
+func (r *replayer) Receive(context actor.Context) {
+	switch msg := context.Message().(type) {
+	case *message.TraceTx:
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		trace, err := r.cs.traceTx(msg.TxHash)
+		context.Respond(message.TraceTxRsp{Trace: trace, Err: err})
+	case *message.SimulateTx:
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		simulation, err := r.cs.simulateTx(msg.Tx)
+		context.Respond(message.SimulateTxRsp{Simulation: simulation, Err: err})
+	}
+}
@@ -0,0 +1,222 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// simulateTx executes tx on a throwaway block state over the state of the best
+// block and returns the would-be receipt, the gas and the fee of tx and the
+// changes of the accounts and the contract storage made by it. All the changes
+// are discarded.
+//
+// tx may be unsigned. A zero nonce is replaced by the next nonce of the sender
+// and a missing chain ID hash by the one of the chain, and the hash of tx is
+// always calculated again. Like a traced tx, the
+// simulated one doesn't change the sql databases of the contracts. It runs on
+// the replayer, and the best state is kept from garbage collection during the
+// simulation.
+func (cs *ChainService) simulateTx(tx *types.Tx) (*types.TxSimulation, error) {
+	if tx.GetBody() == nil || len(tx.GetBody().GetAccount()) == 0 {
+		return nil, types.ErrTxFormatInvalid
+	}
+	best, err := cs.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	root := best.GetHeader().GetBlocksRootHash()
+	release, ok := cs.sdb.AcquireState(root)
+	if !ok {
+		return nil, ErrStatePruned
+	}
+	defer release()
+	base := cs.sdb.OpenNewStateDB(root)
+	bs := state.NewBlockState(cs.sdb.OpenNewStateDB(root))
+
+	tx = tx.Clone()
+	if tx.Body.Nonce == 0 {
+		sender, err := base.GetAccountState(types.ToAccountID(tx.Body.Account))
+		if err != nil {
+			return nil, err
+		}
+		tx.Body.Nonce = sender.GetNonce() + 1
+	}
+	if len(tx.Body.ChainIdHash) == 0 {
+		tx.Body.ChainIdHash = cs.GetGenesisInfo().ChainIdHash()
+	}
+	tx.Hash = tx.CalculateTxHash()
+
+	tracer := contract.NewTracer()
+	exec := newTxExecutorTrace(best.BlockNo()+1, time.Now().UnixNano(), contract.Replay, tracer)
+	if err := exec(bs, tx); err != nil {
+		return nil, err
+	}
+	receipts := bs.Receipts()
+	if len(receipts) == 0 {
+		return nil, ErrTraceNoReceipt
+	}
+
+	call := tracer.Trace()
+	accounts, err := accountDiffs(base, &bs.StateDB, touchedAccounts(tx, call))
+	if err != nil {
+		return nil, err
+	}
+	storage, err := storageDiffs(base, &bs.StateDB, call)
+	if err != nil {
+		return nil, err
+	}
+	return &types.TxSimulation{
+		TxHash:   tx.GetHash(),
+		Receipt:  receipts[len(receipts)-1],
+		GasUsed:  tracer.GasUsed(),
+		Fee:      bs.BpReward,
+		Accounts: accounts,
+		Storage:  storage,
+		Call:     call,
+	}, nil
+}
+
+// touchedAccounts returns the addresses of the sender and the receiver of tx
+// and of the accounts called by or sent aergo by the contracts in call
+func touchedAccounts(tx *types.Tx, call *types.CallFrame) [][]byte {
+	var addrs [][]byte
+	seen := make(map[string]bool)
+	add := func(addr []byte) {
+		if len(addr) == 0 || seen[string(addr)] {
+			return
+		}
+		seen[string(addr)] = true
+		addrs = append(addrs, addr)
+	}
+	addEncoded := func(encoded string) {
+		if addr, err := types.DecodeAddress(encoded); err == nil {
+			add(addr)
+		}
+	}
+
+	body := tx.GetBody()
+	add(body.GetAccount())
+	if len(body.GetRecipient()) == 0 {
+		add(contract.CreateContractID(body.GetAccount(), body.GetNonce()))
+	} else {
+		add(body.GetRecipient())
+	}
+
+	var walk func(frame *types.CallFrame)
+	walk = func(frame *types.CallFrame) {
+		if frame == nil {
+			return
+		}
+		addEncoded(frame.Caller)
+		addEncoded(frame.Callee)
+		for _, step := range frame.Steps {
+			switch {
+			case step.Call != nil:
+				walk(step.Call)
+			case step.Op == contract.StepSend, step.Op == contract.StepDestroy:
+				addEncoded(step.Key)
+			}
+		}
+	}
+	walk(call)
+	return addrs
+}
+
+// accountDiffs returns the states of the accounts of addrs changed from base to
+// after
+func accountDiffs(base, after *state.StateDB, addrs [][]byte) ([]*types.AccountDiff, error) {
+	var diffs []*types.AccountDiff
+	for _, addr := range addrs {
+		id := types.ToAccountID(addr)
+		prev, err := base.GetAccountState(id)
+		if err != nil {
+			return nil, err
+		}
+		next, err := after.GetAccountState(id)
+		if err != nil {
+			return nil, err
+		}
+		if proto.Equal(prev, next) {
+			continue
+		}
+		diffs = append(diffs, &types.AccountDiff{Address: addr, Before: prev, After: next})
+	}
+	return diffs, nil
+}
+
+// storageDiffs returns the contract variables written in call and changed from
+// base to after. A delegated call writes the storage of its caller.
+func storageDiffs(base, after *state.StateDB, call *types.CallFrame) ([]*types.StorageDiff, error) {
+	var diffs []*types.StorageDiff
+	type variable struct{ owner, key string }
+	seen := make(map[variable]bool)
+
+	var walk func(frame *types.CallFrame) error
+	walk = func(frame *types.CallFrame) error {
+		if frame == nil || frame.Kind == contract.FrameQuery {
+			return nil
+		}
+		owner := frame.Callee
+		if frame.Kind == contract.FrameDelegateCall {
+			owner = frame.Caller
+		}
+		for _, step := range frame.Steps {
+			if step.Call != nil {
+				if err := walk(step.Call); err != nil {
+					return err
+				}
+				continue
+			}
+			v := variable{owner, step.Key}
+			if step.Op != contract.StepStateSet || seen[v] {
+				continue
+			}
+			seen[v] = true
+
+			addr, err := types.DecodeAddress(owner)
+			if err != nil {
+				return err
+			}
+			prev, err := contractData(base, addr, step.Key)
+			if err != nil {
+				return err
+			}
+			next, err := contractData(after, addr, step.Key)
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(prev, next) {
+				continue
+			}
+			diffs = append(diffs, &types.StorageDiff{
+				Address: addr,
+				Key:     step.Key,
+				Before:  string(prev),
+				After:   string(next),
+			})
+		}
+		return nil
+	}
+	if err := walk(call); err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
+
+func contractData(sdb *state.StateDB, addr []byte, key string) ([]byte, error) {
+	contractState, err := sdb.OpenContractStateAccount(types.ToAccountID(addr))
+	if err != nil {
+		return nil, err
+	}
+	return contractState.GetData([]byte(key))
+}
@@ -0,0 +1,163 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	ErrSnapshotNotNew = errors.New("snapshot can be installed on a new chain only")
+	ErrSnapshotNoSQL  = errors.New("sql database not found")
+	ErrSnapshotSQL    = errors.New("sql database of a contract not in the snapshot")
+	ErrSnapshotEpoch  = errors.New("snapshot must be at an epoch boundary block")
+	ErrSnapshotVerify = errors.New("snapshot can't be verified by the consensus")
+)
+
+// snapshotVerifier is implemented by the consensus which can confirm that the
+// block of a snapshot is irreversible by the headers of the following blocks.
+type snapshotVerifier interface {
+	VerifySnapshot(block *types.Block, descendants []*types.BlockHeader) error
+}
+
+// ancestorVerifier is implemented by the consensus which can verify the headers
+// below the block of a snapshot from the genesis block.
+type ancestorVerifier interface {
+	NewAncestorVerifier() (types.AncestorVerifier, error)
+}
+
+// snapshotNo returns the number of the block at or below no whose snapshot can
+// be installed. The state of an epoch boundary block is required to validate
+// the blocks of the epoch, so a snapshot is taken at the boundary.
+func (cs *ChainService) snapshotNo(no types.BlockNo) types.BlockNo {
+	if er, ok := cs.ChainConsensus.(epochReader); ok {
+		return er.EpochBoundary(no + 1)
+	}
+	return no
+}
+
+// exportSQLChunk reads a part of the sql database file of a contract for the
+// snapshot sync of a remote peer.
+func (cs *ChainService) exportSQLChunk(msg *message.ExportSQLChunk) *message.ExportSQLChunkRsp {
+	names, err := contract.DatabaseNames()
+	if err != nil {
+		return &message.ExportSQLChunkRsp{Err: err}
+	}
+	name := msg.Name
+	if name == "" {
+		if len(names) == 0 {
+			// no sql database
+			return &message.ExportSQLChunkRsp{}
+		}
+		name = names[0]
+	}
+	i := sort.SearchStrings(names, name)
+	if i == len(names) || names[i] != name {
+		return &message.ExportSQLChunkRsp{Err: ErrSnapshotNoSQL}
+	}
+	data, size, err := contract.ReadDatabaseFile(name, int64(msg.Offset), msg.MaxSize)
+	if err != nil {
+		return &message.ExportSQLChunkRsp{Err: err}
+	}
+	rsp := &message.ExportSQLChunkRsp{
+		Name: name,
+		Data: data,
+		Size: uint64(size),
+	}
+	if i+1 < len(names) {
+		rsp.Next = names[i+1]
+	}
+	return rsp
+}
+
+// importSQLChunk writes a part of the sql database file of a contract
+// downloaded by the snapshot sync. The contract must have used the sql
+// database in the state of the snapshot.
+func (cs *ChainService) importSQLChunk(msg *message.ImportSQLChunk) error {
+	addr, err := types.DecodeAddress(msg.Name)
+	if err != nil {
+		return err
+	}
+	st, err := cs.sdb.OpenNewStateDB(msg.StateRoot).GetState(types.ToAccountID(addr))
+	if err != nil {
+		return err
+	}
+	if st == nil || st.GetSqlRecoveryPoint() == 0 {
+		return ErrSnapshotSQL
+	}
+	// The file may have the commits after the snapshot. They are truncated
+	// to the recovery point of the state, when the database is opened.
+	return contract.WriteDatabaseFile(msg.Name, int64(msg.Offset), msg.Data)
+}
+
+// verifySQL checks that the sql databases imported have the commits up to the
+// recovery points of the contracts in the state of root.
+func (cs *ChainService) verifySQL(root []byte) error {
+	names, err := contract.DatabaseNames()
+	if err != nil {
+		return err
+	}
+	sdb := cs.sdb.OpenNewStateDB(root)
+	for _, name := range names {
+		addr, err := types.DecodeAddress(name)
+		if err != nil {
+			return err
+		}
+		st, err := sdb.GetState(types.ToAccountID(addr))
+		if err != nil {
+			return err
+		}
+		if st == nil || st.GetSqlRecoveryPoint() == 0 {
+			return ErrSnapshotSQL
+		}
+		if err := contract.VerifyDatabase(name, st.GetSqlRecoveryPoint()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installSnapshot sets the block of a snapshot, whose states are imported, as
+// the best block. The block must be confirmed by the descendants, and the sql
+// databases must match the state. The sync continues from the block.
+func (cs *ChainService) installSnapshot(block *types.Block, descendants []*types.BlockHeader) error {
+	if cs.getBestBlockNo() != 0 {
+		return ErrSnapshotNotNew
+	}
+	if cs.snapshotNo(block.BlockNo()) != block.BlockNo() {
+		return ErrSnapshotEpoch
+	}
+	if err := cs.validator.ValidateBlock(block); err != nil {
+		return err
+	}
+	sv, ok := cs.ChainConsensus.(snapshotVerifier)
+	if !ok {
+		return ErrSnapshotVerify
+	}
+	if err := sv.VerifySnapshot(block, descendants); err != nil {
+		return err
+	}
+	if err := cs.verifySQL(block.GetHeader().GetBlocksRootHash()); err != nil {
+		return err
+	}
+	if err := cs.sdb.SetSnapshotRoot(block.GetHeader().GetBlocksRootHash()); err != nil {
+		return err
+	}
+	if err := cs.cdb.installSnapshot(block); err != nil {
+		return err
+	}
+	cs.Update(block)
+
+	logger.Info().Uint64("no", block.BlockNo()).Str("hash", enc.ToString(block.BlockHash())).
+		Str("stateroot", enc.ToString(block.GetHeader().GetBlocksRootHash())).Msg("snapshot installed")
+	return nil
+}
@@ -0,0 +1,121 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"errors"
+
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+const (
+	// StateModeArchive keeps the states of all the blocks.
+	StateModeArchive = "archive"
+	// StateModePruned keeps the states of the recent blocks only.
+	StateModePruned = "pruned"
+)
+
+var (
+	ErrInvalidStateMode = errors.New("invalid state mode in config")
+	ErrInvalidStateGC   = errors.New("state retention and gc interval must be positive in pruned mode")
+	ErrStatePruned      = errors.New("state of the block is pruned")
+)
+
+// libReader is implemented by the consensus having the last irreversible
+// block. The states above LIB are retained since they may be reorganized.
+type libReader interface {
+	LIBNo() types.BlockNo
+}
+
+// epochReader is implemented by the consensus electing the block producers
+// from the state of an epoch boundary block. The state is retained while the
+// blocks of the epoch can be validated.
+type epochReader interface {
+	EpochBoundary(blockNo types.BlockNo) types.BlockNo
+}
+
+type stateGC struct {
+	retention types.BlockNo
+	interval  types.BlockNo
+}
+
+// newStateGC returns a stateGC for the pruned state mode and enables the
+// garbage collection of sdb. It returns nil for the archive mode.
+func newStateGC(sdb *state.ChainStateDB, conf *cfg.BlockchainConfig) (*stateGC, error) {
+	switch conf.StateMode {
+	case StateModeArchive, "":
+		return nil, nil
+	case StateModePruned:
+		if conf.StateRetention == 0 || conf.StateGCInterval == 0 {
+			return nil, ErrInvalidStateGC
+		}
+		sdb.EnableGC()
+		logger.Info().Uint64("retention", conf.StateRetention).
+			Uint64("interval", conf.StateGCInterval).Msg("state pruning enabled")
+		return &stateGC{
+			retention: conf.StateRetention,
+			interval:  conf.StateGCInterval,
+		}, nil
+	default:
+		return nil, ErrInvalidStateMode
+	}
+}
+
+// collectStateGarbage starts the garbage collection of the state db, if the
+// pruned mode is enabled and block is at the interval. The states to retain
+// are fixed before it returns, and the garbage is deleted in the background.
+func (cs *ChainService) collectStateGarbage(block *types.Block) {
+	gc := cs.stateGC
+	if gc == nil || block.BlockNo() == 0 || block.BlockNo()%gc.interval != 0 {
+		return
+	}
+
+	collect, err := cs.sdb.BeginGC(func() ([][]byte, error) {
+		return cs.retainedStateRoots(block)
+	})
+	if err != nil {
+		logger.Error().Err(err).Uint64("no", block.BlockNo()).Msg("failed to start garbage collection of state db")
+		return
+	}
+	go func() {
+		if _, err := collect(); err != nil {
+			logger.Error().Err(err).Msg("failed to collect garbage of state db")
+		}
+	}()
+}
+
+// retainedStateRoots returns the state roots of best and its ancestors in the
+// retention. If the retention reaches beyond LIB, it is extended to LIB, and
+// then to the epoch boundary block electing the block producers of it.
+func (cs *ChainService) retainedStateRoots(best *types.Block) ([][]byte, error) {
+	var from types.BlockNo
+	if best.BlockNo() > cs.stateGC.retention {
+		from = best.BlockNo() - cs.stateGC.retention
+	}
+	if lr, ok := cs.ChainConsensus.(libReader); ok {
+		if libNo := lr.LIBNo(); libNo < from {
+			from = libNo
+		}
+	}
+	if er, ok := cs.ChainConsensus.(epochReader); ok {
+		from = er.EpochBoundary(from + 1)
+	}
+
+	roots := make([][]byte, 0, best.BlockNo()-from+1)
+	for block := best; ; {
+		roots = append(roots, block.GetHeader().GetBlocksRootHash())
+		if block.BlockNo() <= from {
+			break
+		}
+		var err error
+		if block, err = cs.cdb.getBlock(block.GetHeader().GetPrevBlockHash()); err != nil {
+			return nil, err
+		}
+	}
+	return roots, nil
+}
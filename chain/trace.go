@@ -0,0 +1,81 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"errors"
+
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	ErrTraceNoReceipt = errors.New("traced tx has no receipt")
+	ErrTraceSqlWrite  = errors.New("cannot trace a tx after the sql writes of the previous txs in its block")
+)
+
+// traceTx re-executes the tx of txHash on the state of the parent of its block
+// and returns the trace of the execution. The txs before it in the block are
+// replayed first, and all the changes are discarded.
+//
+// The sql databases of the contracts have no history, so a replayed tx reads
+// them at the recovery points of the contracts in the parent state, and its
+// sql writes are recorded but not executed. Since the tx would read the
+// databases without the writes of the previous txs, ErrTraceSqlWrite is
+// returned if any of them changes a database. If the state of the parent block
+// is pruned, ErrStatePruned is returned.
+//
+// It runs on a replayer, not on the actor of the chain service. The parent
+// state is kept from garbage collection during the trace.
+func (cs *ChainService) traceTx(txHash []byte) (*types.TxTrace, error) {
+	tx, txIdx, err := cs.getTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	block, err := cs.getBlock(txIdx.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := cs.getBlock(block.GetHeader().GetPrevBlockHash())
+	if err != nil {
+		return nil, err
+	}
+
+	root := parent.GetHeader().GetBlocksRootHash()
+	release, ok := cs.sdb.AcquireState(root)
+	if !ok {
+		return nil, ErrStatePruned
+	}
+	defer release()
+	bs := state.NewBlockState(cs.sdb.OpenNewStateDB(root))
+	blockNo := block.BlockNo()
+	ts := block.GetHeader().GetTimestamp()
+	prevTracer := contract.NewTracer()
+	for _, prev := range block.GetBody().GetTxs()[:txIdx.Idx] {
+		if err := executeTxTrace(bs, prev, blockNo, ts, contract.Replay, prevTracer); err != nil {
+			return nil, err
+		}
+		if prevTracer.SqlWritten() {
+			return nil, ErrTraceSqlWrite
+		}
+	}
+
+	tracer := contract.NewTracer()
+	if err := executeTxTrace(bs, tx, blockNo, ts, contract.Replay, tracer); err != nil {
+		return nil, err
+	}
+	receipts := bs.Receipts()
+	if len(receipts) == 0 {
+		return nil, ErrTraceNoReceipt
+	}
+	return &types.TxTrace{
+		TxHash:  tx.GetHash(),
+		BlockNo: blockNo,
+		Status:  receipts[len(receipts)-1].Status,
+		Call:    tracer.Trace(),
+	}, nil
+}
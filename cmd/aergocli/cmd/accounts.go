@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"syscall"
 
@@ -36,7 +37,7 @@ func init() {
 	lockCmd.Flags().StringVar(&pw, "password", "", "Password")
 
 	importCmd.Flags().StringVar(&importFormat, "if", "", "Base58 import format string")
-	importCmd.MarkFlagRequired("if")
+	importCmd.Flags().StringVar(&keystore, "keystore", "", "Path to keystore file to import")
 	importCmd.Flags().StringVar(&pw, "password", "", "Password when exporting")
 	importCmd.Flags().StringVar(&to, "newpassword", "", "Password to be reset")
 	importCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
@@ -45,6 +46,7 @@ func init() {
 	exportCmd.MarkFlagRequired("address")
 	exportCmd.Flags().StringVar(&pw, "password", "", "Password")
 	exportCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	exportCmd.Flags().BoolVar(&useKeystore, "keystore", false, "Export in keystore file format")
 
 	voteCmd.Flags().StringVar(&address, "address", "", "Account address of voter")
 	voteCmd.MarkFlagRequired("address")
@@ -187,12 +189,12 @@ var importCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
 		var address []byte
-		importBuf, err := types.DecodePrivKey(importFormat)
-		if err != nil {
-			cmd.Printf("Failed to decode input: %s\n", err.Error())
+		var importBuf []byte
+		if importFormat == "" && keystore == "" {
+			cmd.Println("Error: required flag(s) \"if\" or \"keystore\" not set")
 			return
 		}
-		wif := &types.ImportFormat{Wif: &types.SingleBytes{Value: importBuf}}
+		wif := &types.ImportFormat{}
 		if pw != "" {
 			wif.Oldpass = pw
 		} else {
@@ -202,6 +204,20 @@ var importCmd = &cobra.Command{
 				return
 			}
 		}
+		if keystore != "" {
+			importBuf, err = readKeystore(keystore, wif.Oldpass)
+			if err != nil {
+				cmd.Printf("Failed to read keystore: %s\n", err.Error())
+				return
+			}
+		} else {
+			importBuf, err = types.DecodePrivKey(importFormat)
+			if err != nil {
+				cmd.Printf("Failed to decode input: %s\n", err.Error())
+				return
+			}
+		}
+		wif.Wif = &types.SingleBytes{Value: importBuf}
 
 		if to != "" {
 			wif.Newpass = to
@@ -258,10 +274,43 @@ var exportCmd = &cobra.Command{
 			}
 			result = wif
 		}
+		if useKeystore {
+			out, err := writeKeystore(result, param.Passphrase)
+			if err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+				return
+			}
+			cmd.Println(string(out))
+			return
+		}
 		cmd.Println(types.EncodePrivKey(result))
 	},
 }
 
+// readKeystore decrypts the keystore file, and encrypts the key again in the
+// import format
+func readKeystore(path string, pass string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	privkey, err := key.DecryptKeystore(data, pass)
+	if err != nil {
+		return nil, err
+	}
+	return key.EncryptWIF(privkey, pass)
+}
+
+// writeKeystore decrypts the exported key, and encrypts it again in the
+// keystore file format
+func writeKeystore(wif []byte, pass string) ([]byte, error) {
+	privkey, err := key.DecryptWIF(wif, pass)
+	if err != nil {
+		return nil, err
+	}
+	return key.EncryptKeystore(privkey, pass, key.StandardScryptN, key.StandardScryptP)
+}
+
 func parsePersonalParam(cmd *cobra.Command) (*types.Personal, error) {
 	var err error
 	param := &types.Personal{Account: &types.Account{}}
@@ -0,0 +1,71 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/mr-tron/base58/base58"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types"
+	"github.com/spf13/cobra"
+)
+
+var addrbookCmd = &cobra.Command{
+	Use:   "addrbook",
+	Short: "Get the address book of known peers",
+	Run:   execGetAddrBook,
+}
+
+var pruneAddrbookCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove a peer or the peers not seen for a while from the address book",
+	Run:   execPruneAddrBook,
+}
+
+var pabPeerID string
+var pabAge time.Duration
+
+func init() {
+	rootCmd.AddCommand(addrbookCmd)
+	addrbookCmd.AddCommand(pruneAddrbookCmd)
+
+	pruneAddrbookCmd.Flags().StringVar(&pabPeerID, "peer", "", "Peer id to remove")
+	pruneAddrbookCmd.Flags().DurationVar(&pabAge, "age", 0, "Remove the peers not seen for this duration (e.g. 72h)")
+}
+
+func execGetAddrBook(cmd *cobra.Command, args []string) {
+	msg, err := client.GetAddrBook(context.Background(), &types.Empty{})
+	if err != nil {
+		cmd.Printf("Failed to get address book from server: %s\n", err.Error())
+		return
+	}
+	cmd.Println(util.AddrBookToString(msg))
+}
+
+func execPruneAddrBook(cmd *cobra.Command, args []string) {
+	req := &types.AddrBookPruneRequest{MaxAge: int64(pabAge / time.Second)}
+	if pabPeerID != "" {
+		peerID, err := base58.Decode(pabPeerID)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		req.PeerID = peerID
+	} else if req.MaxAge <= 0 {
+		cmd.Printf("Error: required flag(s) \"peer\" or \"age\" not set\n")
+		return
+	}
+
+	msg, err := client.PruneAddrBook(context.Background(), req)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println(util.AddrBookToString(msg))
+}
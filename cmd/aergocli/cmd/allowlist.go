@@ -0,0 +1,116 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+var allowlistCmd = &cobra.Command{
+	Use:   "allowlist",
+	Short: "Get the effective peer allowlist of the permissioned network",
+	Run:   execGetAllowlist,
+}
+
+var allowPeerCmd = &cobra.Command{
+	Use:   "allow",
+	Short: "Approve adding peers to the on-chain peer allowlist, which is applied once the quorum of the admins approve it",
+	Run: func(cmd *cobra.Command, args []string) {
+		execChangeAllowlist(cmd, 'a')
+	},
+}
+
+var disallowPeerCmd = &cobra.Command{
+	Use:   "disallow",
+	Short: "Approve removing peers from the on-chain peer allowlist, which is applied once the quorum of the admins approve it",
+	Run: func(cmd *cobra.Command, args []string) {
+		execChangeAllowlist(cmd, 'd')
+	},
+}
+
+var allowlistPeers string
+
+func init() {
+	rootCmd.AddCommand(allowlistCmd)
+	allowlistCmd.AddCommand(allowPeerCmd, disallowPeerCmd)
+	for _, c := range []*cobra.Command{allowPeerCmd, disallowPeerCmd} {
+		c.Flags().StringVar(&address, "address", "", "Account address of allowlist admin")
+		c.MarkFlagRequired("address")
+		c.Flags().StringVar(&allowlistPeers, "peers", "", "Json array which has base58 peer ids")
+		c.MarkFlagRequired("peers")
+	}
+}
+
+func execGetAllowlist(cmd *cobra.Command, args []string) {
+	msg, err := client.GetPeerAllowlist(context.Background(), &types.Empty{})
+	if err != nil {
+		cmd.Printf("Failed to get peer allowlist from server: %s\n", err.Error())
+		return
+	}
+	cmd.Println(util.PeerAllowlistToString(msg))
+}
+
+func execChangeAllowlist(cmd *cobra.Command, systemCmd byte) {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	var peerIDs []string
+	if err := json.Unmarshal([]byte(allowlistPeers), &peerIDs); err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	payload := make([]byte, (len(peerIDs)*PeerIDLength)+1)
+	payload[0] = systemCmd
+	for i, v := range peerIDs {
+		peerID, err := base58.Decode(v)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		if _, err = peer.IDFromBytes(peerID); err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		copy(payload[1+(i*PeerIDLength):], peerID)
+	}
+
+	state, err := client.GetState(context.Background(), &types.SingleBytes{Value: account})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(aergosystem),
+			Payload:   payload,
+			Type:      types.TxType_GOVERNANCE,
+			Nonce:     state.GetNonce() + 1,
+		},
+	}
+	tx, err = client.SignTX(context.Background(), tx)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	msg, err := client.CommitTX(context.Background(), &types.TxList{Txs: []*types.Tx{tx}})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	for _, r := range msg.Results {
+		cmd.Println("allowlist hash :", base58.Encode(r.Hash), r.Error, r.Detail)
+	}
+}
@@ -16,10 +16,13 @@ import (
 )
 
 var (
-	client *util.ConnClient
-	data   string
-	nonce  uint64
-	toJson bool
+	client    *util.ConnClient
+	data      string
+	nonce     uint64
+	toJson    bool
+	blockFrom uint64
+	blockTo   uint64
+	redeploy  string
 )
 
 func init() {
@@ -37,6 +40,7 @@ func init() {
 	}
 	deployCmd.PersistentFlags().StringVar(&data, "payload", "", "result of compiling a contract")
 	deployCmd.PersistentFlags().Uint64Var(&amount, "amount", 0, "setting amount")
+	deployCmd.PersistentFlags().StringVar(&redeploy, "redeploy", "", "upgrade the code of the contract, keeping its state")
 
 	callCmd := &cobra.Command{
 		Use:   "call [flags] sender contract funcname '[argument...]'",
@@ -57,6 +61,15 @@ func init() {
 	stateQueryCmd.Flags().StringVar(&stateroot, "root", "", "Query the state at a specified state root")
 	stateQueryCmd.Flags().BoolVar(&compressed, "compressed", false, "Get a compressed proof for the state")
 
+	eventCmd := &cobra.Command{
+		Use:   "event [flags] contract [eventname]",
+		Short: "List events of the contract",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   runListEventsCmd,
+	}
+	eventCmd.Flags().Uint64Var(&blockFrom, "from", 0, "first block number to search")
+	eventCmd.Flags().Uint64Var(&blockTo, "to", 0, "last block number to search (default: best block)")
+
 	contractCmd.AddCommand(
 		deployCmd,
 		callCmd,
@@ -73,6 +86,7 @@ func init() {
 			Run:   runQueryCmd,
 		},
 		stateQueryCmd,
+		eventCmd,
 	)
 	rootCmd.AddCommand(contractCmd)
 }
@@ -152,6 +166,14 @@ func runDeployCmd(cmd *cobra.Command, args []string) {
 			Amount:  amount,
 		},
 	}
+	if redeploy != "" {
+		contract, err := types.DecodeAddress(redeploy)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tx.Body.Type = types.TxType_REDEPLOY
+		tx.Body.Recipient = contract
+	}
 
 	sign, err := client.SignTX(context.Background(), tx)
 	if err != nil || sign == nil {
@@ -185,10 +207,22 @@ func runCallCmd(cmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
+	var fn *types.Function
+	if !toJson {
+		abi, err := client.GetABI(context.Background(), &types.SingleBytes{Value: contract})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fn = abi.FindFunction(args[2])
+		if fn == nil {
+			log.Fatal(args[2], " function not found in contract :", args[1])
+		}
+	}
+
 	var ci types.CallInfo
 	ci.Name = args[2]
 	if len(args) > 3 {
-		err = json.Unmarshal([]byte(args[3]), &ci.Args)
+		ci.Args, err = util.EncodeCallArgs(fn, args[3])
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -198,23 +232,6 @@ func runCallCmd(cmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
-	if !toJson {
-		abi, err := client.GetABI(context.Background(), &types.SingleBytes{Value: contract})
-		if err != nil {
-			log.Fatal(err)
-		}
-		var found bool
-		for _, fn := range abi.Functions {
-			if fn.GetName() == args[2] {
-				found = true
-				break
-			}
-		}
-		if !found {
-			log.Fatal(args[2], " function not found in contract :", args[1])
-		}
-	}
-
 	tx := &types.Tx{
 		Body: &types.TxBody{
 			Nonce:     nonce,
@@ -256,16 +273,41 @@ func runGetABICmd(cmd *cobra.Command, args []string) {
 	cmd.Println(util.JSON(abi))
 }
 
+func runListEventsCmd(cmd *cobra.Command, args []string) {
+	contract, err := types.DecodeAddress(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	filter := &types.FilterInfo{
+		ContractAddress: contract,
+		Blockfrom:       blockFrom,
+		Blockto:         blockTo,
+	}
+	if len(args) > 1 {
+		filter.EventName = args[1]
+	}
+	events, err := client.ListEvents(context.Background(), filter)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.Println(util.JSON(events))
+}
+
 func runQueryCmd(cmd *cobra.Command, args []string) {
 	contract, err := types.DecodeAddress(args[0])
 	if err != nil {
 		log.Fatal(err)
 	}
-	var ci types.CallInfo
+	// the contract without the ABI is queried without the types
+	var fn *types.Function
+	if abi, err := client.GetABI(context.Background(), &types.SingleBytes{Value: contract}); err == nil {
+		fn = abi.FindFunction(args[1])
+	}
 
+	var ci types.CallInfo
 	ci.Name = args[1]
 	if len(args) > 2 {
-		err = json.Unmarshal([]byte(args[2]), &ci.Args)
+		ci.Args, err = util.EncodeCallArgs(fn, args[2])
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -44,6 +44,8 @@ var (
 
 	remote       bool
 	importFormat string
+	keystore     string
+	useKeystore  bool
 
 	rootConfig CliConfig
 
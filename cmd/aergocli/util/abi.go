@@ -0,0 +1,87 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/aergoio/aergo/types"
+)
+
+// EncodeCallArgs decodes the JSON arguments of a call to fn, and converts them
+// to the types in the ABI of fn. A big number is kept in a decimal string not
+// to lose its precision. fn can be nil for the call without the ABI.
+func EncodeCallArgs(fn *types.Function, jsonArgs string) ([]interface{}, error) {
+	var args []interface{}
+	d := json.NewDecoder(strings.NewReader(jsonArgs))
+	d.UseNumber()
+	if err := d.Decode(&args); err != nil {
+		return nil, err
+	}
+	for i, arg := range args {
+		var typ string
+		if fn != nil && i < len(fn.Arguments) {
+			typ = fn.Arguments[i].Type
+		}
+		v, err := encodeArg(typ, arg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: argument %d: %s", fn.GetName(), i+1, err)
+		}
+		args[i] = v
+	}
+	if fn != nil {
+		if err := fn.CheckArgs(args); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+func encodeArg(typ string, v interface{}) (interface{}, error) {
+	switch typ {
+	case types.AbiBignum:
+		if n, ok := v.(json.Number); ok {
+			r, ok := new(big.Rat).SetString(n.String())
+			if !ok || !r.IsInt() {
+				return nil, fmt.Errorf("%s is not an integer", n)
+			}
+			return r.Num().String(), nil
+		}
+	case types.AbiInteger:
+		if s, ok := v.(string); ok {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an integer", s)
+			}
+			return float64(i), nil
+		}
+	}
+	return fromJSONNumber(v)
+}
+
+// fromJSONNumber converts the numbers in v to float64 as json.Unmarshal does
+func fromJSONNumber(v interface{}) (interface{}, error) {
+	switch arg := v.(type) {
+	case json.Number:
+		return arg.Float64()
+	case []interface{}:
+		for i, e := range arg {
+			n, err := fromJSONNumber(e)
+			if err != nil {
+				return nil, err
+			}
+			arg[i] = n
+		}
+	case map[string]interface{}:
+		for k, e := range arg {
+			n, err := fromJSONNumber(e)
+			if err != nil {
+				return nil, err
+			}
+			arg[k] = n
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,37 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeCallArgs(t *testing.T) {
+	fn := &types.Function{
+		Name: "transfer",
+		Arguments: []*types.FnArgument{
+			{Name: "to", Type: types.AbiAddress},
+			{Name: "amount", Type: types.AbiBignum},
+			{Name: "nonce", Type: types.AbiInteger},
+			{Name: "memo"},
+		},
+	}
+	to := "AsiFCzSukVNUGufJSzSNLA1nKx39NxKcVBEWvW3riyfixcBjN1Qd"
+
+	args, err := EncodeCallArgs(fn, `["`+to+`", 1000000000000000000001, "7", {"a": [1, 2]}]`)
+	assert.NoError(t, err, "should be success")
+	assert.Equal(t, []interface{}{to, "1000000000000000000001", float64(7),
+		map[string]interface{}{"a": []interface{}{float64(1), float64(2)}}}, args)
+
+	_, err = EncodeCallArgs(fn, `["`+to+`", 1.5, 1]`)
+	assert.Error(t, err, "bignum should be an integer")
+	_, err = EncodeCallArgs(fn, `["to", 1, 1]`)
+	assert.Error(t, err, "invalid address")
+	_, err = EncodeCallArgs(fn, `["`+to+`", 1]`)
+	assert.Error(t, err, "missing argument")
+
+	args, err = EncodeCallArgs(nil, `["a", 1]`)
+	assert.NoError(t, err, "should be success without the ABI")
+	assert.Equal(t, []interface{}{"a", float64(1)}, args)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/aergoio/aergo/types"
 	"github.com/anaskhan96/base58check"
@@ -19,15 +20,16 @@ type InOutTx struct {
 }
 
 type InOutTxBody struct {
-	Nonce     uint64
-	Account   string
-	Recipient string
-	Amount    uint64
-	Payload   string
-	Limit     uint64
-	Price     uint64
-	Type      types.TxType
-	Sign      string
+	Nonce       uint64
+	Account     string
+	Recipient   string
+	Amount      uint64
+	Payload     string
+	Limit       uint64
+	Price       uint64
+	Type        types.TxType
+	Sign        string
+	ChainIdHash string
 }
 
 type InOutTxIdx struct {
@@ -73,9 +75,20 @@ type InOutPeerAddress struct {
 }
 
 type InOutPeer struct {
-	Address   InOutPeerAddress
-	BestBlock InOutBlockIdx
-	State     string
+	Address     InOutPeerAddress
+	BestBlock   InOutBlockIdx
+	State       string
+	Score       int32
+	BannedUntil string `json:",omitempty"`
+}
+
+type InOutAddrBookEntry struct {
+	Address     InOutPeerAddress
+	Source      string
+	LastSeen    string `json:",omitempty"`
+	LastAttempt string `json:",omitempty"`
+	Successes   uint32
+	Failures    uint32
 }
 
 func FillTxBody(source *InOutTxBody, target *types.TxBody) error {
@@ -112,6 +125,12 @@ func FillTxBody(source *InOutTxBody, target *types.TxBody) error {
 		}
 	}
 	target.Type = source.Type
+	if source.ChainIdHash != "" {
+		target.ChainIdHash, err = base58.Decode(source.ChainIdHash)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -181,6 +200,7 @@ func ConvTx(tx *types.Tx) *InOutTx {
 	out.Body.Price = tx.Body.Price
 	out.Body.Sign = base58.Encode(tx.Body.Sign)
 	out.Body.Type = tx.Body.Type
+	out.Body.ChainIdHash = base58.Encode(tx.Body.ChainIdHash)
 	return out
 }
 
@@ -220,6 +240,10 @@ func ConvPeer(p *types.Peer) *InOutPeer {
 	out.BestBlock.BlockNo = p.GetBestblock().GetBlockNo()
 	out.BestBlock.BlockHash = base58.Encode(p.GetBestblock().GetBlockHash())
 	out.State = types.PeerState(p.State).String()
+	out.Score = p.GetScore()
+	if p.GetBannedUntil() > 0 {
+		out.BannedUntil = time.Unix(p.GetBannedUntil(), 0).Format(time.RFC3339)
+	}
 	return out
 }
 
@@ -257,6 +281,46 @@ func PeerListToString(p *types.PeerList) string {
 	return toString(peers)
 }
 
+func ConvAddrBookEntry(e *types.AddrBookEntry) *InOutAddrBookEntry {
+	out := &InOutAddrBookEntry{}
+	out.Address.Address = net.IP(e.GetAddress().GetAddress()).String()
+	out.Address.Port = strconv.Itoa(int(e.GetAddress().GetPort()))
+	out.Address.PeerId = base58.Encode(e.GetAddress().GetPeerID())
+	out.Source = e.GetSource()
+	if e.GetLastSeen() > 0 {
+		out.LastSeen = time.Unix(e.GetLastSeen(), 0).Format(time.RFC3339)
+	}
+	if e.GetLastAttempt() > 0 {
+		out.LastAttempt = time.Unix(e.GetLastAttempt(), 0).Format(time.RFC3339)
+	}
+	out.Successes = e.GetSuccesses()
+	out.Failures = e.GetFailures()
+	return out
+}
+
+func AddrBookToString(b *types.AddrBook) string {
+	entries := []*InOutAddrBookEntry{}
+	for _, e := range b.GetEntries() {
+		entries = append(entries, ConvAddrBookEntry(e))
+	}
+	return toString(entries)
+}
+
+func PeerAllowlistToString(l *types.PeerAllowlist) string {
+	out := struct {
+		Permissioned bool
+		ConfigPeers  []string
+		ChainPeers   []string
+	}{Permissioned: l.GetPermissioned(), ConfigPeers: []string{}, ChainPeers: []string{}}
+	for _, id := range l.GetConfigPeers() {
+		out.ConfigPeers = append(out.ConfigPeers, base58.Encode(id))
+	}
+	for _, id := range l.GetChainPeers() {
+		out.ChainPeers = append(out.ChainPeers, base58.Encode(id))
+	}
+	return toString(out)
+}
+
 func toString(out interface{}) string {
 	jsonout, err := json.MarshalIndent(out, "", " ")
 	if err != nil {
@@ -5,8 +5,58 @@
 #include <lauxlib.h>
 #include <luajit.h>
 #include "state_module.h"
+#include "sandbox.h"
+#include "abi_types.h"
 #include "_cgo_export.h"
 
+static const char *jitUtil = "__luac_jit_util__";
+
+/*
+ * The checker finds the forbidden globals read or written by the bytecode of
+ * the chunk and its nested functions. The opcodes of GGET and GSET are taken
+ * from the probe chunks, not to depend on the LuaJIT version.
+ */
+static const char *forbiddenChecker =
+	"local chunk, jutil, probeGet, probeSet, forbidden = ...\n"
+	"local function op(ins) return ins % 256 end\n"
+	"local function operand(ins) return math.floor(ins / 65536) end\n"
+	"local function probe(f)\n"
+	"  local pc = 1\n"
+	"  while true do\n"
+	"    local ins, m = jutil.funcbc(f, pc)\n"
+	"    if ins == nil then return nil end\n"
+	"    -- no B operand, and D is a string constant\n"
+	"    if math.floor(m / 8) % 16 == 0 and math.floor(m / 128) % 16 == 10 and\n"
+	"       jutil.funck(f, -operand(ins) - 1) == '__luac_probe__' then\n"
+	"      return op(ins)\n"
+	"    end\n"
+	"    pc = pc + 1\n"
+	"  end\n"
+	"end\n"
+	"local GGET, GSET = probe(probeGet), probe(probeSet)\n"
+	"local function check(f)\n"
+	"  local pc = 1\n"
+	"  while true do\n"
+	"    local ins = jutil.funcbc(f, pc)\n"
+	"    if ins == nil then break end\n"
+	"    if op(ins) == GGET or op(ins) == GSET then\n"
+	"      local name = jutil.funck(f, -operand(ins) - 1)\n"
+	"      if forbidden[name] then\n"
+	"        error(string.format('line %d: forbidden global \\'%s\\'', jutil.funcinfo(f, pc).currentline, name), 0)\n"
+	"      end\n"
+	"    end\n"
+	"    pc = pc + 1\n"
+	"  end\n"
+	"  local i = -1\n"
+	"  while true do\n"
+	"    local k = jutil.funck(f, i)\n"
+	"    if k == nil then break end\n"
+	"    if type(k) == 'proto' then check(k) end\n"
+	"    i = i - 1\n"
+	"  end\n"
+	"end\n"
+	"check(chunk)\n";
+
 lua_State *luac_vm_newstate()
 {
 	lua_State *L = luaL_newstate();
@@ -14,10 +64,54 @@ lua_State *luac_vm_newstate()
 	    return NULL;
 	}
 	luaL_openlibs(L);
+
+	/* keep jit.util for the checker before the sandbox removes require */
+	lua_getglobal(L, "require");
+	lua_pushstring(L, "jit.util");
+	if (lua_pcall(L, 1, 1, 0) != 0) {
+		lua_pop(L, 1);
+		lua_pushnil(L);
+	}
+	lua_setfield(L, LUA_REGISTRYINDEX, jitUtil);
+
 	luac_open_state(L);
+	abi_types_open(L);
+	sandbox_open(L);
 	return L;
 }
 
+/* check the loaded chunk on the top of the stack */
+static const char *check_forbidden(lua_State *L)
+{
+	int i;
+
+	lua_getfield(L, LUA_REGISTRYINDEX, jitUtil);
+	if (lua_isnil(L, -1)) {
+		/* no bytecode inspection; the forbidden globals are nil at runtime */
+		lua_pop(L, 1);
+		return NULL;
+	}
+	if (luaL_loadstring(L, forbiddenChecker) != 0) {
+		return lua_tostring(L, -1);
+	}
+	lua_insert(L, -2);
+	lua_pushvalue(L, -3);
+	lua_insert(L, -2);
+	if (luaL_loadstring(L, "local _ = __luac_probe__") != 0 ||
+		luaL_loadstring(L, "__luac_probe__ = 0") != 0) {
+		return lua_tostring(L, -1);
+	}
+	lua_newtable(L);
+	for (i = 0; sandbox_forbidden_globals[i] != NULL; i++) {
+		lua_pushboolean(L, 1);
+		lua_setfield(L, -2, sandbox_forbidden_globals[i]);
+	}
+	if (lua_pcall(L, 5, 0, 0) != 0) {
+		return lua_tostring(L, -1);
+	}
+	return NULL;
+}
+
 void luac_vm_close(lua_State *L)
 {
 	if (L != NULL)
@@ -29,13 +123,17 @@ static int kpt_lua_Writer(struct lua_State *L, const void *p, size_t sz, void *u
 	return (fwrite(p, sz, 1, (FILE *)u) != 1) && (sz != 0);
 }
 
-const char *vm_compile(lua_State *L, const char *code, const char *byte, const char *abi)
+const char *vm_compile(lua_State *L, const char *code, const char *byte)
 {
 	FILE *f = NULL;
+	const char *err;
 
 	if (luaL_loadfile(L, code) != 0) {
 		return lua_tostring(L, -1);
 	}
+	if ((err = check_forbidden(L)) != NULL) {
+		return err;
+	}
 	f = fopen(byte, "wb");
 	if (f == NULL) {
 		return "cannot open a bytecode file";
@@ -46,28 +144,37 @@ const char *vm_compile(lua_State *L, const char *code, const char *byte, const c
 	}
 	fclose(f);
 
-	if (abi != NULL && strlen(abi) > 0) {
-		const char *r;
-		if (lua_pcall(L, 0, 0, 0) != 0) {
-		   return lua_tostring(L, -1);
-		}
-		lua_getfield(L, LUA_GLOBALSINDEX, "abi");
-		lua_getfield(L, -1, "generate");
-		if (lua_pcall(L, 0, 1, 0) != 0) {
-		    return lua_tostring(L, -1);
-		}
-		if (!lua_isstring(L, -1)) {
-		    return "cannot create a abi file";
-		}
-		r = lua_tostring(L, -1);
-		f = fopen(abi, "wb");
-		if (f == NULL) {
-		    return "cannot open a abi file";
-		}
-		fwrite(r, 1, strlen(r), f);
-		fclose(f);
+	return NULL;
+}
+
+/*
+ * Run the loaded chunk, and get the ABI generated by abi.generate and the
+ * type annotations of abi.register in JSON. They are left on the stack.
+ */
+const char *vm_generate_abi(lua_State *L, const char **abi, const char **types)
+{
+	if (lua_pcall(L, 0, 0, 0) != 0) {
+		return lua_tostring(L, -1);
+	}
+	lua_getfield(L, LUA_GLOBALSINDEX, "abi");
+	lua_getfield(L, -1, "generate");
+	if (lua_pcall(L, 0, 1, 0) != 0) {
+		return lua_tostring(L, -1);
+	}
+	if (!lua_isstring(L, -1)) {
+		return "empty ABI string";
 	}
+	*abi = lua_tostring(L, -1);
 
+	lua_getfield(L, LUA_REGISTRYINDEX, ABI_TYPES_GENERATE);
+	if (lua_isnil(L, -1)) {
+		*types = "{}";
+		return NULL;
+	}
+	if (lua_pcall(L, 0, 1, 0) != 0) {
+		return lua_tostring(L, -1);
+	}
+	*types = lua_tostring(L, -1);
 	return NULL;
 }
 
@@ -82,7 +189,7 @@ const char *vm_loadfile(lua_State *L, const char *code)
 	if (luaL_loadfile(L, code) != 0) {
 		return lua_tostring(L, -1);
 	}
-	return NULL;
+	return check_forbidden(L);
 }
 
 const char *vm_loadstring(lua_State *L, const char *code)
@@ -90,7 +197,7 @@ const char *vm_loadstring(lua_State *L, const char *code)
 	if (luaL_loadstring(L, code) != 0) {
 		return lua_tostring(L, -1);
 	}
-	return NULL;
+	return check_forbidden(L);
 }
 
 const char *vm_stringdump(lua_State *L)
@@ -109,19 +216,6 @@ const char *vm_stringdump(lua_State *L)
 	addByteN((char *)lua_tostring(L, -1), lua_strlen(L, -1));
 	lua_pop(L, 1);
 
-	if (lua_pcall(L, 0, 0, 0) != 0) {
-	   return lua_tostring(L, -1);
-	}
-	lua_getfield(L, LUA_GLOBALSINDEX, "abi");
-	lua_getfield(L, -1, "generate");
-	if (lua_pcall(L, 0, 1, 0) != 0) {
-		return lua_tostring(L, -1);
-	}
-	if (!lua_isstring(L, -1)) {
-		return "empty ABI string";
-	}
-	addByteN((char *)lua_tostring(L, -1), lua_strlen(L, -1));
-
 	return NULL;
 }
 
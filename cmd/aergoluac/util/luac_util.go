@@ -1,7 +1,7 @@
 package util
 
 /*
-#cgo CFLAGS: -I${SRCDIR}/../../../libtool/include/luajit-2.0
+#cgo CFLAGS: -I${SRCDIR}/../../../libtool/include/luajit-2.0 -I${SRCDIR}/../../../contract
 #cgo LDFLAGS: ${SRCDIR}/../../../libtool/lib/libluajit-5.1.a -lm
 
 #include <stdlib.h>
@@ -12,6 +12,7 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -43,8 +44,8 @@ func Compile(code string) ([]byte, error) {
 	if errMsg := C.vm_loadstring(L, cstr); errMsg != nil {
 		return nil, errors.New(C.GoString(errMsg))
 	}
-	if errMsg := C.vm_stringdump(L); errMsg != nil {
-		return nil, errors.New(C.GoString(errMsg))
+	if err := dump(L); err != nil {
+		return nil, err
 	}
 	return b.Bytes(), nil
 }
@@ -52,17 +53,22 @@ func Compile(code string) ([]byte, error) {
 func CompileFromFile(srcFileName, outFileName, abiFileName string) error {
 	cSrcFileName := C.CString(srcFileName)
 	cOutFileName := C.CString(outFileName)
-	cAbiFileName := C.CString(abiFileName)
 	L := C.luac_vm_newstate()
 	defer C.free(unsafe.Pointer(cSrcFileName))
 	defer C.free(unsafe.Pointer(cOutFileName))
-	defer C.free(unsafe.Pointer(cAbiFileName))
 	defer C.luac_vm_close(L)
 
-	if errMsg := C.vm_compile(L, cSrcFileName, cOutFileName, cAbiFileName); errMsg != nil {
+	if errMsg := C.vm_compile(L, cSrcFileName, cOutFileName); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
-	return nil
+	if len(abiFileName) == 0 {
+		return nil
+	}
+	abi, err := generateABI(L)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(abiFileName, abi, 0644)
 }
 
 func DumpFromFile(srcFileName string) error {
@@ -74,8 +80,8 @@ func DumpFromFile(srcFileName string) error {
 	if errMsg := C.vm_loadfile(L, cSrcFileName); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
-	if errMsg := C.vm_stringdump(L); errMsg != nil {
-		return errors.New(C.GoString(errMsg))
+	if err := dump(L); err != nil {
+		return err
 	}
 
 	fmt.Println(util.EncodeCode(b.Bytes()))
@@ -112,13 +118,73 @@ func DumpFromStdin() error {
 	if errMsg := C.vm_loadstring(L, srcCode); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
+	if err := dump(L); err != nil {
+		return err
+	}
+	fmt.Println(util.EncodeCode(b.Bytes()))
+	return nil
+}
+
+// dump writes the bytecode and the ABI of the loaded chunk to the buffer
+func dump(L *C.lua_State) error {
 	if errMsg := C.vm_stringdump(L); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
-	fmt.Println(util.EncodeCode(b.Bytes()))
+	abi, err := generateABI(L)
+	if err != nil {
+		return err
+	}
+	b.Write(abi)
 	return nil
 }
 
+type abiTypes struct {
+	Arguments []string `json:"arguments"`
+	Returns   []string `json:"returns"`
+	View      bool     `json:"view"`
+	Payable   bool     `json:"payable"`
+	Reentrant bool     `json:"reentrant"`
+}
+
+// generateABI runs the loaded chunk, and returns its ABI with the types and
+// the flags annotated by abi.register. The ABI is marked with
+// types.AbiFlagsVersion, since the functions without a flag are not views nor
+// payable.
+func generateABI(L *C.lua_State) ([]byte, error) {
+	var cAbi, cTypes *C.char
+	if errMsg := C.vm_generate_abi(L, &cAbi, &cTypes); errMsg != nil {
+		return nil, errors.New(C.GoString(errMsg))
+	}
+	abiJSON := []byte(C.GoString(cAbi))
+
+	var annotations map[string]abiTypes
+	if err := json.Unmarshal([]byte(C.GoString(cTypes)), &annotations); err != nil {
+		return nil, err
+	}
+	abi := new(types.ABI)
+	if err := json.Unmarshal(abiJSON, abi); err != nil {
+		return nil, err
+	}
+	abi.Version = types.AbiFlagsVersion
+	for name, t := range annotations {
+		fn := abi.FindFunction(name)
+		if fn == nil {
+			return nil, fmt.Errorf("%s: the annotated function is not registered", name)
+		}
+		if len(t.Arguments) > len(fn.Arguments) {
+			return nil, fmt.Errorf("%s: %d argument types for %d arguments", name, len(t.Arguments), len(fn.Arguments))
+		}
+		for i, typ := range t.Arguments {
+			fn.Arguments[i].Type = typ
+		}
+		fn.Returns = t.Returns
+		fn.View = t.View
+		fn.Payable = t.Payable
+		fn.Reentrant = t.Reentrant
+	}
+	return json.Marshal(abi)
+}
+
 //export addLen
 func addLen(length C.int) {
 	var l [4]byte
@@ -127,7 +127,7 @@ func rootRun(cmd *cobra.Command, args []string) {
 
 	chainSvc := chain.NewChainService(cfg)
 
-	mpoolSvc := mempool.NewMemPoolService(cfg, chainSvc.SDB())
+	mpoolSvc := mempool.NewMemPoolService(cfg, chainSvc.SDB(), chainSvc.GetGenesisInfo())
 	rpcSvc := rpc.NewRPC(cfg, chainSvc)
 	syncSvc := syncer.NewSyncer(cfg, chainSvc, nil)
 	p2pSvc := p2p.NewP2P(cfg, chainSvc)
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/consensus/impl"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/types"
+	"github.com/spf13/cobra"
+)
+
+// errArchiveArgs is returned by the archive commands run with invalid
+// arguments, to exit with a non-zero status.
+var errArchiveArgs = errors.New("invalid arguments")
+
+var (
+	exportFrom     uint64
+	exportTo       uint64
+	exportReceipts bool
+)
+
+func init() {
+	exportChain.Flags().Uint64Var(&exportFrom, "from", 1, "first block number to export")
+	exportChain.Flags().Uint64Var(&exportTo, "to", 0, "last block number to export (0: best block)")
+	exportChain.Flags().BoolVar(&exportReceipts, "receipts", false, "export the receipts of the blocks")
+	rootCmd.AddCommand(exportChain)
+	rootCmd.AddCommand(importChain)
+}
+
+var exportChain = &cobra.Command{
+	Use:   "export",
+	Short: "Export blocks of the chain to a chain archive file",
+	// the errors are printed by the command, and exit with a non-zero status
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Usage: aergosvr export {archive file} [--from {block no}] [--to {block no}] [--receipts]")
+			return errArchiveArgs
+		}
+		path := args[0]
+
+		core, err := chain.NewCore(cfg.DbType, cfg.DataDir, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to open a blockchain core (error:%s)\n", err)
+			return err
+		}
+		defer core.Close()
+
+		file, err := os.Create(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to create %s (error:%s)\n", path, err)
+			return err
+		}
+		defer file.Close()
+
+		last, err := core.ExportChain(file, exportFrom, exportTo, exportReceipts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to export blocks after %d (error:%s)\n", last, err)
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "blocks up to %d are exported to (%s)\n", last, path)
+		return nil
+	},
+}
+
+var importChain = &cobra.Command{
+	Use:   "import",
+	Short: "Import blocks from a chain archive file, executing them",
+	// the errors are printed by the command, and exit with a non-zero status
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "Usage: aergosvr import {archive file}")
+			return errArchiveArgs
+		}
+		path := args[0]
+
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to open %s \n", path)
+			return err
+		}
+		defer file.Close()
+
+		chainSvc := chain.NewChainService(cfg)
+		defer chainSvc.BeforeStop()
+
+		// The consensus validates the blocks only. It is not started.
+		if _, err := impl.New(cfg, chainSvc, component.NewComponentHub()); err != nil {
+			fmt.Fprintf(os.Stderr, "fail to init consensus (error:%s)\n", err)
+			return err
+		}
+
+		// stop after the block being executed on interrupt. The import is
+		// resumed by running it again.
+		sigChannel := make(chan os.Signal, 1)
+		signal.Notify(sigChannel, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+		defer signal.Stop(sigChannel)
+
+		added, err := chainSvc.ImportChain(file, func(block *types.Block) bool {
+			if block.BlockNo()%1000 == 0 {
+				fmt.Fprintf(os.Stderr, "block %d is imported\n", block.BlockNo())
+			}
+			select {
+			case <-sigChannel:
+				fmt.Fprintf(os.Stderr, "import is interrupted after block %d\n", block.BlockNo())
+				return false
+			default:
+				return true
+			}
+		})
+		best, _ := chainSvc.GetBestBlock()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to import blocks after %d (error:%s)\n", best.GetHeader().GetBlockNo(), err)
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%d blocks are imported, best block is %d\n", added, best.GetHeader().GetBlockNo())
+		return nil
+	},
+}
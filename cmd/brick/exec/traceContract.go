@@ -0,0 +1,93 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/contract"
+)
+
+func init() {
+	registerExec(&traceContract{})
+}
+
+type traceContract struct{}
+
+func (c *traceContract) Command() string {
+	return "trace"
+}
+
+func (c *traceContract) Syntax() string {
+	return fmt.Sprintf("%s %s %s %s %s", context.AccountSymbol,
+		context.AmountSymbol, context.ContractSymbol,
+		context.FunctionSymbol, context.ContractArgsSymbol)
+}
+
+func (c *traceContract) Usage() string {
+	return fmt.Sprintf("trace <sender_name> <amount> <contract_name> <func_name> `[call_json_str]`")
+}
+
+func (c *traceContract) Describe() string {
+	return "call to execute a smart contract and print the trace of the execution"
+}
+
+func (c *traceContract) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, _, _, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *traceContract) parse(args string) (string, uint64, string, string, string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) < 4 {
+		return "", 0, "", "", "", fmt.Errorf("need at least 4 arguments. usage: %s", c.Usage())
+	}
+
+	amount, err := strconv.ParseUint(splitArgs[1].Text, 10, 64)
+	if err != nil {
+		return "", 0, "", "", "", fmt.Errorf("fail to parse number %s: %s", splitArgs[1].Text, err.Error())
+	}
+
+	callCode := "[]"
+	if len(splitArgs) == 5 {
+		callCode = splitArgs[4].Text
+	}
+
+	return splitArgs[0].Text, //accountName
+		amount, //amount
+		splitArgs[2].Text, //contractName
+		splitArgs[3].Text, //funcName
+		callCode, //callCode
+		nil
+}
+
+func (c *traceContract) Run(args string) (string, error) {
+
+	accountName, amount, contractName, funcName, callCode, _ := c.parse(args)
+
+	formattedQuery := fmt.Sprintf("{\"name\":\"%s\",\"args\":%s}", funcName, callCode)
+
+	tracer := contract.NewTracer()
+	err := context.Get().ConnectBlock(
+		contract.NewLuaTxCall(accountName, contractName, amount, formattedQuery).Trace(tracer),
+	)
+
+	trace, _ := json.MarshalIndent(tracer.Trace(), "", "  ")
+	if err != nil {
+		// the trace of a failed call shows where it is failed
+		if tracer.Trace() != nil {
+			logger.Info().Str("cmd", c.Command()).Msg(string(trace))
+		}
+		return "", err
+	}
+
+	return string(trace), nil
+}
@@ -46,6 +46,7 @@ func (ctx *ServerContext) GetDefaultConfig() interface{} {
 		Mempool:    ctx.GetDefaultMempoolConfig(),
 		Consensus:  ctx.GetDefaultConsensusConfig(),
 		Monitor:	ctx.GetDefaultMonitorConfig(),
+		Account:    ctx.GetDefaultAccountConfig(),
 	}
 }
 
@@ -88,23 +89,44 @@ func (ctx *ServerContext) GetDefaultP2PConfig() *P2PConfig {
 		NPAddPeers:      nil,
 		NPMaxPeers:      100,
 		NPPeerPool:      100,
+
+		NPDisconnectScore: 50,
+		NPBanScore:        100,
+		NPBanDuration:     3600,
+		NPBanLocalIP:      false,
+
+		NPPermissioned: false,
+		NPAllowPeers:   nil,
+
+		NPCompactBlock: false,
 	}
 }
 
 func (ctx *ServerContext) GetDefaultBlockchainConfig() *BlockchainConfig {
 	return &BlockchainConfig{
-		MaxBlockSize:    types.DefaultMaxBlockSize,
-		CoinbaseAccount: "",
-		MaxAnchorCount:  20,
-		UseFastSyncer:   false,
+		MaxBlockSize:     types.DefaultMaxBlockSize,
+		CoinbaseAccount:  "",
+		MaxAnchorCount:   20,
+		UseFastSyncer:    false,
+		StateMode:        "archive",
+		StateRetention:   1024,
+		StateGCInterval:  10000,
+		SnapshotSync:     false,
+		SnapshotDistance: 512,
 	}
 }
 
 func (ctx *ServerContext) GetDefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
-		ShowMetrics:    false,
-		VerifierNumber: runtime.NumCPU(),
-		DumpFilePath:   ctx.ExpandPathEnv("$HOME/mempool.dump"),
+		ShowMetrics:       false,
+		VerifierNumber:    runtime.NumCPU(),
+		DumpFilePath:      ctx.ExpandPathEnv("$HOME/mempool.dump"),
+		MaxPoolSize:       100000,
+		MaxPoolBytes:      128 * 1024 * 1024,
+		MaxAccountTxs:     1000,
+		MaxAccountOrphans: 64,
+		OrphanTTL:         3 * 60 * 60,
+		ReplaceBump:       10,
 	}
 }
 
@@ -124,3 +146,11 @@ func (ctx *ServerContext) GetDefaultMonitorConfig() *MonitorConfig {
 	}
 
 }
+
+func (ctx *ServerContext) GetDefaultAccountConfig() *AccountConfig {
+	return &AccountConfig{
+		KeyStore:       "db",
+		UnlockTimeout:  0,
+		ExternalSigner: "",
+	}
+}
@@ -21,6 +21,7 @@ type Config struct {
 	Mempool    *MempoolConfig    `mapstructure:"mempool"`
 	Consensus  *ConsensusConfig  `mapstructure:"consensus"`
 	Monitor    *MonitorConfig	 `mapstructure:"monitor"`
+	Account    *AccountConfig    `mapstructure:"account"`
 }
 
 // BaseConfig defines base configurations for aergo server
@@ -65,21 +66,42 @@ type P2PConfig struct {
 	NPAddPeers      []string `mapstructure:"npaddpeers" description:"Add peers to connect with at startup"`
 	NPMaxPeers      int      `mapstructure:"npmaxpeers" description:"Maximum number of remote peers to keep"`
 	NPPeerPool      int      `mapstructure:"nppeerpool" description:"Max peer pool size"`
+
+	NPDisconnectScore int  `mapstructure:"npdisconnectscore" description:"Misbehavior score at which a remote peer is disconnected (0 means never)"`
+	NPBanScore        int  `mapstructure:"npbanscore" description:"Misbehavior score at which a remote peer is disconnected and banned by its peer id and ip address (0 means never)"`
+	NPBanDuration     int  `mapstructure:"npbanduration" description:"Duration in seconds for which a misbehaving peer is banned"`
+	NPBanLocalIP      bool `mapstructure:"npbanlocalip" description:"Ban the loopback and private ip addresses of misbehaving peers too"`
+
+	NPPermissioned bool     `mapstructure:"nppermissioned" description:"Connect only with the peers in the allowlist of the config and of the chain"`
+	NPAllowPeers   []string `mapstructure:"npallowpeers" description:"Peer ids allowed to connect in permissioned mode, in addition to the on-chain allowlist"`
+
+	NPCompactBlock bool `mapstructure:"npcompactblock" description:"Relay new blocks as compact blocks to the peers supporting them, which rebuild the blocks with the txs in their mempools"`
 }
 
 // BlockchainConfig defines configurations for blockchain service
 type BlockchainConfig struct {
-	MaxBlockSize    uint32 `mapstructure:"maxblocksize"  description:"maximum block size in bytes"`
-	CoinbaseAccount string `mapstructure:"coinbaseaccount" description:"wallet address for coinbase"`
-	MaxAnchorCount  int    `mapstructure:"maxanchorcount" description:"maximun anchor count for sync"`
-	UseFastSyncer   bool   `mapstructure:"usefastsyncer" description:"Enable FastSyncer"`
+	MaxBlockSize     uint32 `mapstructure:"maxblocksize"  description:"maximum block size in bytes"`
+	CoinbaseAccount  string `mapstructure:"coinbaseaccount" description:"wallet address for coinbase"`
+	MaxAnchorCount   int    `mapstructure:"maxanchorcount" description:"maximun anchor count for sync"`
+	UseFastSyncer    bool   `mapstructure:"usefastsyncer" description:"Enable FastSyncer"`
+	StateMode        string `mapstructure:"statemode" description:"state storage mode (archive: keep the states of all blocks, pruned: keep the states of recent blocks only)"`
+	StateRetention   uint64 `mapstructure:"stateretention" description:"number of recent blocks whose states are kept in pruned mode"`
+	StateGCInterval  uint64 `mapstructure:"stategcinterval" description:"interval in blocks between garbage collections of the states in pruned mode"`
+	SnapshotSync     bool   `mapstructure:"snapshotsync" description:"download the state snapshot of a recent block from peers when the chain is empty"`
+	SnapshotDistance uint64 `mapstructure:"snapshotdistance" description:"number of blocks between the snapshot block and the best block of the peer"`
 }
 
 // MempoolConfig defines configurations for mempool service
 type MempoolConfig struct {
-	ShowMetrics    bool   `mapstructure:"showmetrics" description:"show mempool metric periodically"`
-	VerifierNumber int    `mapstructure:"verifiers" description:"number of concurrent verifier"`
-	DumpFilePath   string `mapstructure:"dumpfilepath" description:"file path for recording mempool at process termintation"`
+	ShowMetrics       bool   `mapstructure:"showmetrics" description:"show mempool metric periodically"`
+	VerifierNumber    int    `mapstructure:"verifiers" description:"number of concurrent verifier"`
+	DumpFilePath      string `mapstructure:"dumpfilepath" description:"file path for recording mempool at process termintation"`
+	MaxPoolSize       int    `mapstructure:"maxpoolsize" description:"maximum number of txs in mempool (0: unlimited)"`
+	MaxPoolBytes      int    `mapstructure:"maxpoolbytes" description:"maximum total size of txs in mempool (0: unlimited)"`
+	MaxAccountTxs     int    `mapstructure:"maxaccounttxs" description:"maximum number of txs per account in mempool (0: unlimited)"`
+	MaxAccountOrphans int    `mapstructure:"maxaccountorphans" description:"maximum number of orphan txs per account in mempool (0: unlimited)"`
+	OrphanTTL         int64  `mapstructure:"orphanttl" description:"time (sec) after which orphan txs are evicted from mempool (0: no expiry)"`
+	ReplaceBump       uint64 `mapstructure:"replacebump" description:"minimum price increase (%) to replace a tx of the same nonce"`
 }
 
 // ConsensusConfig defines configurations for consensus service
@@ -96,6 +118,13 @@ type MonitorConfig struct {
 	ServerEndpoint string  `mapstructure:"endpoint" description:"Endpoint to send"`
 }
 
+// AccountConfig defines configurations for personal account service
+type AccountConfig struct {
+	KeyStore       string `mapstructure:"keystore" description:"key storage (db: keys in the account db, file: a keystore file per key)"`
+	UnlockTimeout  uint   `mapstructure:"unlocktimeout" description:"seconds after which an unlocked account is locked again (0: never)"`
+	ExternalSigner string `mapstructure:"externalsigner" description:"path of the unix socket of an external signer, which signs the txs of the accounts not unlocked in the node"`
+}
+
 /*
 How to write this template
 =======================================
@@ -148,6 +177,18 @@ npaddpeers = [{{range .P2P.NPAddPeers}}
 ]
 npmaxpeers = "{{.P2P.NPMaxPeers}}"
 nppeerpool = "{{.P2P.NPPeerPool}}"
+# Misbehaving peers sending invalid messages, blocks or txs are disconnected and banned when their score reaches the limits
+npdisconnectscore = {{.P2P.NPDisconnectScore}}
+npbanscore = {{.P2P.NPBanScore}}
+npbanduration = {{.P2P.NPBanDuration}}
+npbanlocalip = {{.P2P.NPBanLocalIP}}
+# In permissioned mode, only the peers listed in npallowpeers or allowed by the system txs can connect
+nppermissioned = {{.P2P.NPPermissioned}}
+npallowpeers = [{{range .P2P.NPAllowPeers}}
+"{{.}}", {{end}}
+]
+# Compact blocks carry the short ids of txs instead of txs. They are sent only to the peers which told their support in handshake, and the others get block notices
+npcompactblock = {{.P2P.NPCompactBlock}}
 
 [blockchain]
 # blockchain configurations
@@ -155,11 +196,22 @@ maxblocksize = {{.Blockchain.MaxBlockSize}}
 coinbaseaccount = "{{.Blockchain.CoinbaseAccount}}"
 maxanchorcount = "{{.Blockchain.MaxAnchorCount}}"
 usefastsyncer = "{{.Blockchain.UseFastSyncer}}"
+statemode = "{{.Blockchain.StateMode}}"
+stateretention = {{.Blockchain.StateRetention}}
+stategcinterval = {{.Blockchain.StateGCInterval}}
+snapshotsync = {{.Blockchain.SnapshotSync}}
+snapshotdistance = {{.Blockchain.SnapshotDistance}}
 
 [mempool]
 showmetrics = {{.Mempool.ShowMetrics}}
 verifiers = {{.Mempool.VerifierNumber}}
 dumpfilepath = "{{.Mempool.DumpFilePath}}"
+maxpoolsize = {{.Mempool.MaxPoolSize}}
+maxpoolbytes = {{.Mempool.MaxPoolBytes}}
+maxaccounttxs = {{.Mempool.MaxAccountTxs}}
+maxaccountorphans = {{.Mempool.MaxAccountOrphans}}
+orphanttl = {{.Mempool.OrphanTTL}}
+replacebump = {{.Mempool.ReplaceBump}}
 
 [consensus]
 enablebp = {{.Consensus.EnableBp}}
@@ -173,4 +225,9 @@ bpids = [{{range .Consensus.BpIds}}
 [monitor]
 protocol = "{{.Monitor.ServerProtocol}}"
 endpoint = "{{.Monitor.ServerEndpoint}}"
+
+[account]
+keystore = "{{.Account.KeyStore}}"
+unlocktimeout = {{.Account.UnlockTimeout}}
+externalsigner = "{{.Account.ExternalSigner}}"
 `
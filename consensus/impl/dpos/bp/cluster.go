@@ -7,6 +7,7 @@ package bp
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/libp2p/go-libp2p-peer"
 )
@@ -22,6 +23,7 @@ func (e errBpSize) Error() string {
 
 // Cluster represents a cluster of block producers.
 type Cluster struct {
+	sync.RWMutex
 	size   uint16
 	member map[uint16]*blockProducer
 	index  map[peer.ID]uint16
@@ -35,27 +37,48 @@ type blockProducer struct {
 // NewCluster returns a new bp.Cluster.
 func NewCluster(ids []string, blockProducers uint16) (*Cluster, error) {
 	c := &Cluster{
-		size:   blockProducers,
-		member: make(map[uint16]*blockProducer),
-		index:  make(map[peer.ID]uint16),
+		size: blockProducers,
 	}
 
+	if err := c.init(ids); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Update replaces the members of c by ids. The index of each BP follows the
+// order of ids. c is unchanged if ids are invalid.
+func (c *Cluster) Update(ids []string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.init(ids)
+}
+
+func (c *Cluster) init(ids []string) error {
+	member := make(map[uint16]*blockProducer)
+	index := make(map[peer.ID]uint16)
+
 	for i, id := range ids {
 		bpID, err := peer.IDB58Decode(id)
 		if err != nil {
-			return nil, fmt.Errorf("invalid node ID[%d]: %s", i, err.Error())
+			return fmt.Errorf("invalid node ID[%d]: %s", i, err.Error())
 		}
 
-		index := uint16(i)
-		c.member[index] = newBlockProducer(bpID)
-		c.index[bpID] = index
+		idx := uint16(i)
+		member[idx] = newBlockProducer(bpID)
+		index[bpID] = idx
 	}
 
-	if len(c.index) != int(blockProducers) {
-		return nil, errBpSize{required: blockProducers, given: uint16(len(ids))}
+	if len(index) != int(c.size) {
+		return errBpSize{required: c.size, given: uint16(len(ids))}
 	}
 
-	return c, nil
+	c.member = member
+	c.index = index
+
+	return nil
 }
 
 func newBlockProducer(id peer.ID) *blockProducer {
@@ -64,6 +87,9 @@ func newBlockProducer(id peer.ID) *blockProducer {
 
 // BpIndex2ID returns the ID correspinding to idx.
 func (c *Cluster) BpIndex2ID(idx uint16) (peer.ID, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
 	if bp, exist := c.member[idx]; exist {
 		return bp.id, exist
 	}
@@ -72,12 +98,30 @@ func (c *Cluster) BpIndex2ID(idx uint16) (peer.ID, bool) {
 
 // BpID2Index returns the index corresponding to id.
 func (c *Cluster) BpID2Index(id peer.ID) (uint16, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
 	idx, exist := c.index[id]
 	return idx, exist
 }
 
 // Has reports whether c includes id or not
 func (c *Cluster) Has(id peer.ID) bool {
+	c.RLock()
+	defer c.RUnlock()
+
 	_, exist := c.index[id]
 	return exist
 }
+
+// BPs returns the IDs of the members ordered by their index.
+func (c *Cluster) BPs() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	ids := make([]string, len(c.member))
+	for idx, bp := range c.member {
+		ids[idx] = peer.IDB58Encode(bp.id)
+	}
+	return ids
+}
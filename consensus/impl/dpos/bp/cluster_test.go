@@ -39,25 +39,52 @@ func TestNewClusterInvalid(t *testing.T) {
 	}
 }
 
-func TestNewCluster(t *testing.T) {
-	genID := func() string {
-		_, pubKey, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
-		assert.Nil(t, err)
-		b, err := peer.IDFromPublicKey(pubKey)
-		assert.Nil(t, err)
-		return b.Pretty()
-	}
+func genID(t *testing.T) string {
+	_, pubKey, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.Nil(t, err)
+	b, err := peer.IDFromPublicKey(pubKey)
+	assert.Nil(t, err)
+	return b.Pretty()
+}
 
-	genIds := func() []string {
-		ids := make([]string, BlockProducers)
-		for i := 0; i < BlockProducers; i++ {
-			ids[i] = genID()
-			fmt.Println(ids[i])
-		}
-		return ids
+func genIds(t *testing.T) []string {
+	ids := make([]string, BlockProducers)
+	for i := 0; i < BlockProducers; i++ {
+		ids[i] = genID(t)
+		fmt.Println(ids[i])
 	}
+	return ids
+}
 
-	bpc, err := NewCluster(genIds(), BlockProducers)
+func TestNewCluster(t *testing.T) {
+	bpc, err := NewCluster(genIds(t), BlockProducers)
 	assert.Nil(t, err)
 	assert.NotNil(t, bpc, "Cluster alloc failed")
 }
+
+func TestClusterUpdate(t *testing.T) {
+	ids := genIds(t)
+	bpc, err := NewCluster(ids, BlockProducers)
+	assert.Nil(t, err)
+	assert.Equal(t, ids, bpc.BPs())
+
+	// reversed order & one member replaced
+	newIds := make([]string, BlockProducers)
+	for i := range ids {
+		newIds[i] = ids[BlockProducers-1-i]
+	}
+	newIds[0] = genID(t)
+	assert.Nil(t, bpc.Update(newIds))
+	assert.Equal(t, newIds, bpc.BPs())
+
+	removed, _ := peer.IDB58Decode(ids[BlockProducers-1])
+	assert.False(t, bpc.Has(removed))
+	moved, _ := peer.IDB58Decode(ids[0])
+	idx, exist := bpc.BpID2Index(moved)
+	assert.True(t, exist)
+	assert.Equal(t, uint16(BlockProducers-1), idx)
+
+	// an invalid update doesn't change the cluster
+	assert.NotNil(t, bpc.Update(newIds[1:]))
+	assert.Equal(t, newIds, bpc.BPs())
+}
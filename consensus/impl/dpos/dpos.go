@@ -7,6 +7,7 @@ package dpos
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/aergoio/aergo-lib/log"
@@ -14,7 +15,6 @@ import (
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
 	"github.com/aergoio/aergo/consensus/impl/dpos/slot"
-	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/p2p"
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/state"
@@ -37,10 +37,18 @@ var (
 type DPoS struct {
 	*Status
 	*component.ComponentHub
-	bpc  *bp.Cluster
-	bf   *BlockFactory
-	quit chan interface{}
-	ca   types.ChainAccessor
+	bpc        *bp.Cluster
+	bf         *BlockFactory
+	quit       chan interface{}
+	ca         types.ChainAccessor
+	cdb        consensus.ChainDbReader
+	params     *types.ChainParams
+	initialBPs []string
+	initialBPC *bp.Cluster // BPs producing the blocks before the election
+
+	clusterLock sync.Mutex
+	bpEpochID   string // ID of the epoch boundary block where bpc is elected
+	epochs      epochClusters
 }
 
 // Status shows DPoS consensus's current status
@@ -51,9 +59,11 @@ type bpInfo struct {
 
 // New returns a new DPos object
 func New(cfg *config.Config, cdb consensus.ChainDbReader, hub *component.ComponentHub) (consensus.Consensus, error) {
+	params := types.LegacyChainParams()
 	genesis := cdb.GetGenesisInfo()
 	if genesis != nil {
 		logger.Debug().Str("genesis", spew.Sdump(genesis)).Msg("genesis info loaded")
+		params = genesis.ChainParams()
 		bpCount := len(genesis.BPs)
 		// Prefer BPs from the GenesisInfo. Overwrite.
 		if bpCount > 0 {
@@ -69,15 +79,23 @@ func New(cfg *config.Config, cdb consensus.ChainDbReader, hub *component.Compone
 	if err != nil {
 		return nil, err
 	}
+	initialBPC, err := bp.NewCluster(cfg.Consensus.BpIds, blockProducers)
+	if err != nil {
+		return nil, err
+	}
 
 	quitC := make(chan interface{})
 
 	return &DPoS{
-		Status:       NewStatus(defaultConsensusCount, cdb),
+		Status:       NewStatus(bpc, defaultConsensusCount, cdb),
 		ComponentHub: hub,
 		bpc:          bpc,
 		bf:           NewBlockFactory(hub, quitC),
 		quit:         quitC,
+		cdb:          cdb,
+		params:       params,
+		initialBPs:   cfg.Consensus.BpIds,
+		initialBPC:   initialBPC,
 	}, nil
 }
 
@@ -122,6 +140,18 @@ func (dpos *DPoS) SetChainAccessor(chainAccessor types.ChainAccessor) {
 // called only once during the boot sequence.
 func (dpos *DPoS) SetStateDB(sdb *state.ChainStateDB) {
 	dpos.bf.sdb = sdb
+
+	// Restore the BP cluster elected for the current best block.
+	if best, err := dpos.cdb.GetBestBlock(); err == nil {
+		dpos.updateCluster(best)
+	}
+}
+
+// Update updates the LIB and then the BP cluster if block crosses an epoch
+// boundary.
+func (dpos *DPoS) Update(block *types.Block) {
+	dpos.Status.Update(block)
+	dpos.updateCluster(block)
 }
 
 // IsTransactionValid checks the DPoS consensus level validity of a transaction
@@ -140,22 +170,31 @@ func (dpos *DPoS) bpid() peer.ID {
 	return p2p.NodeID()
 }
 
-// IsBlockValid checks the DPoS consensus level validity of a block
+// IsBlockValid checks the DPoS consensus level validity of a block. The BP of
+// the block must be a member of the cluster elected for the epoch of the block.
 func (dpos *DPoS) IsBlockValid(block *types.Block, bestBlock *types.Block) error {
 	id, err := block.BPID()
 	if err != nil {
 		return &consensus.ErrorConsensus{Msg: "bad public key in block", Err: err}
 	}
 
-	ns := block.GetHeader().GetTimestamp()
-	idx, ok := dpos.bpc.BpID2Index(id)
-	s := slot.NewFromUnixNano(ns)
-	// Check whether the BP ID is one of the current BP members and its
-	// corresponding BP index is consistent with the block timestamp.
-	if !ok || !s.IsFor(idx) {
-		return &consensus.ErrorConsensus{
-			Msg: fmt.Sprintf("BP %v (idx: %v) is not permitted for the time slot %v (%v)",
-				block.BPID2Str(), idx, time.Unix(0, ns), s.NextBpIndex()),
+	bpc, err := dpos.epochCluster(block)
+	if err != nil && err != errEpochBranchUnknown {
+		return &consensus.ErrorConsensus{Msg: "failed to elect BPs of the epoch", Err: err}
+	}
+
+	// The BP of an orphan block is checked when its branch is connected.
+	if bpc != nil {
+		ns := block.GetHeader().GetTimestamp()
+		idx, ok := bpc.BpID2Index(id)
+		s := slot.NewFromUnixNano(ns)
+		// Check whether the BP ID is one of the current BP members and its
+		// corresponding BP index is consistent with the block timestamp.
+		if !ok || !s.IsFor(idx) {
+			return &consensus.ErrorConsensus{
+				Msg: fmt.Sprintf("BP %v (idx: %v) is not permitted for the time slot %v (%v)",
+					block.BPID2Str(), idx, time.Unix(0, ns), s.NextBpIndex()),
+			}
 		}
 	}
 
@@ -167,19 +206,17 @@ func (dpos *DPoS) IsBlockValid(block *types.Block, bestBlock *types.Block) error
 	return nil
 }
 
-func (dpos *DPoS) bpIdx() uint16 {
-	idx, exist := dpos.bpc.BpID2Index(dpos.bpid())
-	if !exist {
-		logger.Fatal().Str("id", enc.ToString([]byte(dpos.bpid()))).Msg("BP has no correct BP membership")
-	}
-
-	return idx
+// bpIdx returns the index of this node in the current BP cluster. The second
+// result is false if this node is not elected.
+func (dpos *DPoS) bpIdx() (uint16, bool) {
+	return dpos.bpc.BpID2Index(dpos.bpid())
 }
 
 func (dpos *DPoS) getBpInfo(now time.Time, slotQueued *slot.Slot) *bpInfo {
 	s := slot.Time(now)
 
-	if !s.IsFor(dpos.bpIdx()) {
+	idx, elected := dpos.bpIdx()
+	if !elected || !s.IsFor(idx) {
 		return nil
 	}
 
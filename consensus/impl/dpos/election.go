@@ -0,0 +1,393 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package dpos
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/consensus/impl/dpos/slot"
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/types"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// epochRounds is the number of BP rounds in an epoch. The BP cluster is
+// re-elected from the vote result at every epoch boundary block.
+const epochRounds = 10
+
+var (
+	errEpochStatePruned        = errors.New("state of the epoch boundary block is pruned")
+	errEpochBranchUnknown      = errors.New("branch of the block is unknown")
+	errEpochStateNotReady      = errors.New("state of the chain is not ready")
+	errSnapshotNotBoundary     = errors.New("snapshot block is not an epoch boundary block")
+	errSnapshotDescendant      = errors.New("invalid block following snapshot")
+	errSnapshotNotIrreversible = errors.New("snapshot block is not confirmed by enough BPs")
+	errSnapshotAncestor        = errors.New("invalid block below snapshot")
+	errSnapshotVotes           = errors.New("vote result of epoch boundary block below snapshot not given")
+)
+
+// maxEpochClusters is the number of the BP clusters of the other epochs (or
+// branches) kept to validate blocks.
+const maxEpochClusters = 8
+
+// epochClusters caches the BP clusters by the ID of their epoch boundary block.
+type epochClusters struct {
+	sync.Mutex
+	clusters map[string]*bp.Cluster
+	ids      []string
+}
+
+func (ec *epochClusters) get(boundaryID string) *bp.Cluster {
+	ec.Lock()
+	defer ec.Unlock()
+	return ec.clusters[boundaryID]
+}
+
+func (ec *epochClusters) add(boundaryID string, c *bp.Cluster) {
+	ec.Lock()
+	defer ec.Unlock()
+	if ec.clusters == nil {
+		ec.clusters = make(map[string]*bp.Cluster)
+	}
+	if _, exist := ec.clusters[boundaryID]; exist {
+		return
+	}
+	if len(ec.ids) == maxEpochClusters {
+		delete(ec.clusters, ec.ids[0])
+		ec.ids = ec.ids[1:]
+	}
+	ec.clusters[boundaryID] = c
+	ec.ids = append(ec.ids, boundaryID)
+}
+
+func epochBlockCount() types.BlockNo {
+	return types.BlockNo(blockProducers) * epochRounds
+}
+
+// epochBoundary returns the number of the epoch boundary block at or before
+// blockNo. The BPs for the blocks after the boundary block are elected from
+// the state of the boundary block.
+func epochBoundary(blockNo types.BlockNo) types.BlockNo {
+	return blockNo - blockNo%epochBlockCount()
+}
+
+// electsBPs reports whether no is an epoch boundary block whose state elects
+// the BPs of a block in the following epoch.
+func electsBPs(params *types.ChainParams, no types.BlockNo) bool {
+	return epochBoundary(no) == no && params.ElectionAt(no+epochBlockCount())
+}
+
+// EpochBoundary returns the number of the epoch boundary block whose state
+// elects the BPs of the block of blockNo. The state must be retained as long
+// as the block can be validated.
+func (dpos *DPoS) EpochBoundary(blockNo types.BlockNo) types.BlockNo {
+	if blockNo == 0 {
+		return 0
+	}
+	return epochBoundary(blockNo - 1)
+}
+
+// epochCluster returns the BP cluster elected for the epoch of block on its
+// own branch, or the cluster of the initial BPs before the election is
+// activated. If the branch is unknown, as for an orphan block,
+// errEpochBranchUnknown is returned.
+func (dpos *DPoS) epochCluster(block *types.Block) (*bp.Cluster, error) {
+	if block.BlockNo() == 0 || !dpos.params.ElectionAt(block.BlockNo()) {
+		return dpos.initialBPC, nil
+	}
+	if dpos.bf.sdb == nil {
+		return nil, errEpochStateNotReady
+	}
+	boundary, err := dpos.ancestorOf(block, dpos.EpochBoundary(block.BlockNo()))
+	if err != nil {
+		return nil, err
+	}
+	if boundary == nil {
+		return nil, errEpochBranchUnknown
+	}
+	if c := dpos.epochs.get(boundary.ID()); c != nil {
+		return c, nil
+	}
+
+	ids, err := dpos.electBPs(boundary.GetHeader().GetBlocksRootHash())
+	if err != nil {
+		return nil, err
+	}
+	c, err := bp.NewCluster(ids, blockProducers)
+	if err != nil {
+		return nil, err
+	}
+	dpos.epochs.add(boundary.ID(), c)
+	return c, nil
+}
+
+// VerifySnapshot checks that the block of a snapshot is irreversible. The
+// state of the block, which is an epoch boundary block verified with its
+// ancestors, elects the BPs of the following blocks once the election is
+// activated. The descendants in the epoch must be linked to the block
+// and produced by those BPs, and the block must be confirmed by as many
+// distinct BPs as the LIB requires.
+func (dpos *DPoS) VerifySnapshot(block *types.Block, descendants []*types.BlockHeader) error {
+	if epochBoundary(block.BlockNo()) != block.BlockNo() {
+		return errSnapshotNotBoundary
+	}
+	bpc := dpos.initialBPC
+	if electsBPs(dpos.params, block.BlockNo()) {
+		ids, err := dpos.electBPs(block.GetHeader().GetBlocksRootHash())
+		if err != nil {
+			return err
+		}
+		if bpc, err = bp.NewCluster(ids, blockProducers); err != nil {
+			return err
+		}
+	}
+
+	confirms := make(map[uint16]bool)
+	prev := block
+	for _, header := range descendants {
+		cur := &types.Block{Header: header}
+		if cur.BlockNo() > block.BlockNo()+epochBlockCount() {
+			break
+		}
+		if cur.BlockNo() != prev.BlockNo()+1 || !bytes.Equal(cur.GetHeader().GetPrevBlockHash(), prev.BlockHash()) {
+			return errSnapshotDescendant
+		}
+		c := bpc
+		if !dpos.params.ElectionAt(cur.BlockNo()) {
+			c = dpos.initialBPC
+		}
+		idx, ok := producerIndex(cur, c)
+		if !ok {
+			return errSnapshotDescendant
+		}
+		confirms[idx] = true
+		prev = cur
+	}
+	if uint64(len(confirms)) < consensusBlockCount() {
+		return errSnapshotNotIrreversible
+	}
+	return nil
+}
+
+// producerIndex returns the index of the BP producing block in bpc. It reports
+// false unless block is signed by the BP at the slot of its timestamp.
+func producerIndex(block *types.Block, bpc *bp.Cluster) (uint16, bool) {
+	id, err := block.BPID()
+	if err != nil {
+		return 0, false
+	}
+	idx, ok := bpc.BpID2Index(id)
+	if !ok || !slot.NewFromUnixNano(block.GetHeader().GetTimestamp()).IsFor(idx) {
+		return 0, false
+	}
+	if valid, err := block.VerifySign(); !valid || err != nil {
+		return 0, false
+	}
+	return idx, true
+}
+
+// ancestorVerifier verifies the headers below a snapshot from the genesis
+// block. The BPs of each epoch are elected from the vote result of the
+// previous epoch boundary block already verified, so the chain of the BPs is
+// anchored at the initial BPs of the genesis block.
+type ancestorVerifier struct {
+	params     *types.ChainParams
+	initialBPs []string
+	initialBPC *bp.Cluster
+	elected    *bp.Cluster
+	prev       *types.Block
+}
+
+// NewAncestorVerifier returns a verifier of the headers following the genesis
+// block, whose states are not executed by the snapshot sync.
+func (dpos *DPoS) NewAncestorVerifier() (types.AncestorVerifier, error) {
+	genesis, err := dpos.cdb.GetBlockByNo(0)
+	if err != nil {
+		return nil, err
+	}
+	av := &ancestorVerifier{
+		params:     dpos.params,
+		initialBPs: dpos.initialBPs,
+		initialBPC: dpos.initialBPC,
+		elected:    dpos.initialBPC,
+		prev:       genesis,
+	}
+	if electsBPs(dpos.params, 0) {
+		if dpos.bf.sdb == nil {
+			return nil, errEpochStateNotReady
+		}
+		ids, err := dpos.electBPs(genesis.GetHeader().GetBlocksRootHash())
+		if err != nil {
+			return nil, err
+		}
+		if av.elected, err = bp.NewCluster(ids, blockProducers); err != nil {
+			return nil, err
+		}
+	}
+	return av, nil
+}
+
+// Elects reports whether the vote result in the state of header must be given
+// to verify the following headers.
+func (av *ancestorVerifier) Elects(header *types.BlockHeader) bool {
+	return electsBPs(av.params, header.GetBlockNo())
+}
+
+// Verify checks that header follows the last header verified and is produced
+// by the BP of its epoch. If Elects reports true for header, votes must be the
+// vote result in its state, which elects the BPs of the next epoch.
+func (av *ancestorVerifier) Verify(header *types.BlockHeader, votes *types.VoteList) error {
+	cur := &types.Block{Header: header}
+	if cur.BlockNo() != av.prev.BlockNo()+1 || !bytes.Equal(header.GetPrevBlockHash(), av.prev.BlockHash()) {
+		return errSnapshotAncestor
+	}
+	bpc := av.elected
+	if !av.params.ElectionAt(cur.BlockNo()) {
+		bpc = av.initialBPC
+	}
+	if _, ok := producerIndex(cur, bpc); !ok {
+		return errSnapshotAncestor
+	}
+	if av.Elects(header) {
+		if votes == nil {
+			return errSnapshotVotes
+		}
+		elected, err := bp.NewCluster(electBPs(votes, av.initialBPs, int(blockProducers)), blockProducers)
+		if err != nil {
+			return err
+		}
+		av.elected = elected
+	}
+	av.prev = cur
+	return nil
+}
+
+// ancestorOf returns the ancestor of block whose number is no. It follows the
+// previous hashes until the branch joins the main chain. It returns nil if an
+// ancestor is not found.
+func (dpos *DPoS) ancestorOf(block *types.Block, no types.BlockNo) (*types.Block, error) {
+	for cur := block; ; {
+		if cur.BlockNo() == no {
+			return cur, nil
+		}
+		if cur.BlockNo() < no {
+			return nil, nil
+		}
+		if main, err := dpos.cdb.GetBlockByNo(cur.BlockNo()); err == nil && bytes.Equal(main.BlockHash(), cur.BlockHash()) {
+			return dpos.cdb.GetBlockByNo(no)
+		}
+		prev, err := dpos.cdb.GetBlock(cur.GetHeader().GetPrevBlockHash())
+		if err != nil {
+			// the previous block is not received yet
+			return nil, nil
+		}
+		cur = prev
+	}
+}
+
+// updateCluster updates the BP cluster to the one elected at the epoch boundary
+// block of block, which is the new best block. Before the election is
+// activated, the cluster is that of the initial BPs.
+func (dpos *DPoS) updateCluster(block *types.Block) {
+	if dpos.bf.sdb == nil {
+		return
+	}
+	dpos.clusterLock.Lock()
+	defer dpos.clusterLock.Unlock()
+
+	if !dpos.params.ElectionAt(block.BlockNo() + 1) {
+		// back from a branch reorganized below the activation height
+		if dpos.bpEpochID != "" {
+			if err := dpos.bpc.Update(dpos.initialBPs); err != nil {
+				logger.Error().Err(err).Msg("failed to restore the initial BP cluster")
+				return
+			}
+			dpos.bpEpochID = ""
+		}
+		return
+	}
+
+	boundary := block
+	if no := epochBoundary(block.BlockNo()); no != block.BlockNo() {
+		var err error
+		if boundary, err = dpos.cdb.GetBlockByNo(no); err != nil {
+			logger.Error().Err(err).Uint64("no", no).Msg("failed to get the epoch boundary block")
+			return
+		}
+	}
+	if boundary.ID() == dpos.bpEpochID {
+		return
+	}
+
+	ids, err := dpos.electBPs(boundary.GetHeader().GetBlocksRootHash())
+	if err != nil {
+		logger.Error().Err(err).Str("boundary", boundary.ID()).Msg("failed to elect BPs")
+		return
+	}
+	if err := dpos.bpc.Update(ids); err != nil {
+		logger.Error().Err(err).Str("boundary", boundary.ID()).Msg("failed to update BP cluster")
+		return
+	}
+	dpos.bpEpochID = boundary.ID()
+
+	logger.Info().Str("boundary", boundary.ID()).Uint64("no", boundary.BlockNo()).
+		Strs("BPs", ids).Msg("BP cluster updated")
+}
+
+// electBPs returns the BPs elected from the vote result at the state root.
+func (dpos *DPoS) electBPs(root []byte) ([]string, error) {
+	if !dpos.bf.sdb.HasState(root) {
+		return nil, errEpochStatePruned
+	}
+	scs, err := dpos.bf.sdb.OpenNewStateDB(root).OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
+	if err != nil {
+		return nil, err
+	}
+	votes, err := system.GetVoteResult(scs, 0)
+	if err != nil {
+		return nil, err
+	}
+	return electBPs(votes, dpos.initialBPs, int(blockProducers)), nil
+}
+
+// electBPs returns the top n candidates of votes. If the candidates having
+// votes are less than n, the rest is filled with the initial BPs in order.
+func electBPs(votes *types.VoteList, initial []string, n int) []string {
+	ids := make([]string, 0, n)
+	elected := make(map[string]bool)
+	for _, v := range votes.GetVotes() {
+		if len(ids) == n {
+			break
+		}
+		if v.GetAmount() == 0 {
+			continue
+		}
+		bpID, err := peer.IDFromBytes(v.GetCandidate())
+		if err != nil {
+			continue
+		}
+		id := peer.IDB58Encode(bpID)
+		if elected[id] {
+			continue
+		}
+		ids = append(ids, id)
+		elected[id] = true
+	}
+	for _, id := range initial {
+		if len(ids) == n {
+			break
+		}
+		if elected[id] {
+			continue
+		}
+		ids = append(ids, id)
+		elected[id] = true
+	}
+	return ids
+}
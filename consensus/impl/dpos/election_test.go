@@ -0,0 +1,158 @@
+package dpos
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/consensus/impl/dpos/slot"
+	"github.com/aergoio/aergo/types"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func genPeerID(t *testing.T) peer.ID {
+	_, pubKey, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.Nil(t, err)
+	id, err := peer.IDFromPublicKey(pubKey)
+	assert.Nil(t, err)
+	return id
+}
+
+func TestElectBPs(t *testing.T) {
+	const n = 3
+
+	initial := make([]string, n)
+	for i := range initial {
+		initial[i] = genPeerID(t).Pretty()
+	}
+	candidate := genPeerID(t)
+
+	// no votes
+	assert.Equal(t, initial, electBPs(&types.VoteList{}, initial, n))
+
+	votes := &types.VoteList{
+		Votes: []*types.Vote{
+			{Candidate: []byte(candidate), Amount: 100},
+			{Candidate: []byte("invalid candidate"), Amount: 50},
+			{Candidate: []byte(mustDecodeID(t, initial[2])), Amount: 10},
+			{Candidate: []byte(genPeerID(t)), Amount: 0},
+		},
+	}
+
+	elected := electBPs(votes, initial, n)
+	assert.Equal(t, []string{candidate.Pretty(), initial[2], initial[0]}, elected)
+}
+
+func TestEpochBoundary(t *testing.T) {
+	blockProducers = 3
+	defer func() { blockProducers = 0 }()
+
+	epoch := epochBlockCount()
+	assert.Equal(t, types.BlockNo(3*epochRounds), epoch)
+	assert.Equal(t, types.BlockNo(0), epochBoundary(0))
+	assert.Equal(t, types.BlockNo(0), epochBoundary(epoch-1))
+	assert.Equal(t, epoch, epochBoundary(epoch))
+	assert.Equal(t, epoch, epochBoundary(2*epoch-1))
+}
+
+func mustDecodeID(t *testing.T, s string) peer.ID {
+	id, err := peer.IDB58Decode(s)
+	assert.Nil(t, err)
+	return id
+}
+
+func TestEpochClusters(t *testing.T) {
+	blockProducers = 1
+	defer func() { blockProducers = 0 }()
+
+	dpos := &DPoS{}
+	assert.Equal(t, types.BlockNo(0), dpos.EpochBoundary(0))
+	assert.Equal(t, types.BlockNo(0), dpos.EpochBoundary(epochRounds))
+	assert.Equal(t, types.BlockNo(epochRounds), dpos.EpochBoundary(epochRounds+1))
+
+	c, err := bp.NewCluster([]string{genPeerID(t).Pretty()}, 1)
+	assert.Nil(t, err)
+	for i := 0; i <= maxEpochClusters; i++ {
+		dpos.epochs.add(fmt.Sprint(i), c)
+	}
+	// the oldest cluster is evicted
+	assert.Nil(t, dpos.epochs.get("0"))
+	assert.Equal(t, c, dpos.epochs.get(fmt.Sprint(maxEpochClusters)))
+}
+
+func TestEpochClusterBeforeElection(t *testing.T) {
+	blockProducers = 1
+	defer func() { blockProducers = 0 }()
+
+	c, err := bp.NewCluster([]string{genPeerID(t).Pretty()}, 1)
+	assert.Nil(t, err)
+	params := types.DefaultChainParams()
+	params.ElectionHeight = 100
+	dpos := &DPoS{params: params, initialBPC: c, bf: &BlockFactory{}}
+
+	block := &types.Block{Header: &types.BlockHeader{BlockNo: 99}}
+	bpc, err := dpos.epochCluster(block)
+	assert.Nil(t, err)
+	assert.Equal(t, c, bpc)
+
+	// no fallback to the current cluster after the election is activated
+	block.Header.BlockNo = 100
+	_, err = dpos.epochCluster(block)
+	assert.Equal(t, errEpochStateNotReady, err)
+}
+
+func TestAncestorVerifier(t *testing.T) {
+	blockProducers = 1
+	slot.Init(1, 1)
+	defer func() { blockProducers = 0 }()
+
+	genKey := func() (crypto.PrivKey, peer.ID) {
+		privKey, pubKey, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+		assert.Nil(t, err)
+		id, err := peer.IDFromPublicKey(pubKey)
+		assert.Nil(t, err)
+		return privKey, id
+	}
+	initialKey, initialID := genKey()
+	electedKey, electedID := genKey()
+	newBlock := func(prev *types.Block, key crypto.PrivKey) *types.Block {
+		block := types.NewBlock(prev, nil, nil, nil, nil, time.Now().UnixNano())
+		assert.Nil(t, block.Sign(key))
+		return block
+	}
+
+	c, err := bp.NewCluster([]string{initialID.Pretty()}, 1)
+	assert.Nil(t, err)
+	genesis := types.NewBlock(nil, nil, nil, nil, nil, 0)
+	av := &ancestorVerifier{
+		params:     types.DefaultChainParams(),
+		initialBPs: []string{initialID.Pretty()},
+		initialBPC: c,
+		elected:    c,
+		prev:       genesis,
+	}
+
+	// the first epoch is produced by the initial BP
+	prev := genesis
+	for no := types.BlockNo(1); no < epochBlockCount(); no++ {
+		block := newBlock(prev, initialKey)
+		assert.False(t, av.Elects(block.GetHeader()))
+		assert.Nil(t, av.Verify(block.GetHeader(), nil))
+		prev = block
+	}
+	assert.Equal(t, errSnapshotAncestor, av.Verify(newBlock(genesis, initialKey).GetHeader(), nil))
+
+	// the epoch boundary block must be given with its vote result
+	boundary := newBlock(prev, initialKey)
+	assert.True(t, av.Elects(boundary.GetHeader()))
+	assert.Equal(t, errSnapshotVotes, av.Verify(boundary.GetHeader(), nil))
+	votes := &types.VoteList{Votes: []*types.Vote{{Candidate: []byte(electedID), Amount: 1}}}
+	assert.Nil(t, av.Verify(boundary.GetHeader(), votes))
+
+	// the next epoch is produced by the elected BP only
+	assert.Equal(t, errSnapshotAncestor, av.Verify(newBlock(boundary, initialKey).GetHeader(), nil))
+	assert.Nil(t, av.Verify(newBlock(boundary, electedKey).GetHeader(), nil))
+}
@@ -6,10 +6,13 @@ import (
 	"sort"
 
 	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
 	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/p2p"
 	"github.com/aergoio/aergo/types"
 	"github.com/davecgh/go-spew/spew"
+	peer "github.com/libp2p/go-libp2p-peer"
 )
 
 var libStatusKey = []byte("dpos.LibStatus")
@@ -100,11 +103,13 @@ func (ls *libStatus) addConfirmInfo(block *types.Block) {
 		Msg("new confirm info added")
 }
 
-func (ls *libStatus) update() *blockInfo {
+// update updates the pre-LIB map and returns the new LIB if any. Only the
+// pre-LIBs proposed by the members of bpc are counted; nil bpc means all.
+func (ls *libStatus) update(bpc *bp.Cluster) *blockInfo {
 	if bpID, pl := ls.getPreLIB(); pl != nil {
 		ls.updatePreLIB(bpID, pl)
 
-		return ls.calcLIB()
+		return ls.calcLIB(bpc)
 	}
 	return nil
 }
@@ -278,14 +283,14 @@ func cInfo(e *list.Element) *confirmInfo {
 	return e.Value.(*confirmInfo)
 }
 
-func (ls *libStatus) calcLIB() *blockInfo {
+func (ls *libStatus) calcLIB(bpc *bp.Cluster) *blockInfo {
 	if len(ls.Prpsd) == 0 {
 		return nil
 	}
 
 	libInfos := make([]*plInfo, 0, len(ls.Prpsd))
-	for _, l := range ls.Prpsd {
-		if l != nil {
+	for bpID, l := range ls.Prpsd {
+		if l != nil && isActiveBP(bpc, bpID) {
 			libInfos = append(libInfos, l)
 		}
 	}
@@ -304,6 +309,19 @@ func (ls *libStatus) calcLIB() *blockInfo {
 	return lib.Plib
 }
 
+// isActiveBP reports whether bpID (the encoded string of a BP ID) is a member
+// of bpc.
+func isActiveBP(bpc *bp.Cluster, bpID string) bool {
+	if bpc == nil {
+		return true
+	}
+	id, err := enc.ToBytes(bpID)
+	if err != nil {
+		return false
+	}
+	return bpc.Has(peer.ID(id))
+}
+
 type blockInfo struct {
 	BlockHash    string
 	BlockNo      uint64
@@ -393,7 +411,7 @@ func loadPlibStatus(begBlockNo, endBlockNo types.BlockNo) *libStatus {
 			return nil
 		}
 		pls.addConfirmInfo(block)
-		pls.update()
+		pls.update(nil)
 	}
 
 	return pls
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aergoio/aergo-lib/db"
 	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
 	"github.com/aergoio/aergo/types"
 )
 
@@ -15,13 +16,16 @@ type Status struct {
 	sync.RWMutex
 	bestBlock *types.Block
 	libState  *libStatus
+	bpc       *bp.Cluster
 	done      bool
 }
 
-// NewStatus returns a newly allocated Status.
-func NewStatus(confirmsRequired uint16, cdb consensus.ChainDbReader) *Status {
+// NewStatus returns a newly allocated Status. The LIB is calculated from the
+// confirmations by the members of bpc.
+func NewStatus(bpc *bp.Cluster, confirmsRequired uint16, cdb consensus.ChainDbReader) *Status {
 	s := &Status{
 		libState: newLibStatus(confirmsRequired),
+		bpc:      bpc,
 	}
 	s.init(cdb)
 
@@ -69,7 +73,7 @@ func (s *Status) Update(block *types.Block) {
 			Msg("update LIB status")
 
 		// Block connected
-		if lib := s.libState.update(); lib != nil {
+		if lib := s.libState.update(s.bpc); lib != nil {
 			s.updateLIB(lib)
 		}
 	} else {
@@ -105,6 +109,17 @@ func (s *Status) Save(tx db.Transaction) error {
 	return s.libState.save(tx)
 }
 
+// LIBNo returns the block number of the last irreversible block.
+func (s *Status) LIBNo() types.BlockNo {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.libState.Lib == nil {
+		return 0
+	}
+	return s.libState.Lib.BlockNo
+}
+
 // NeedReorganization reports whether reorganization is needed or not.
 func (s *Status) NeedReorganization(rootNo types.BlockNo) bool {
 	s.RLock()
@@ -0,0 +1,119 @@
+#ifndef _ABI_TYPES_H
+#define _ABI_TYPES_H
+
+/*
+ * The type annotations and the flags of abi.register. A table after a
+ * function gives the types of its arguments and, in the returns field, of its
+ * return values:
+ *
+ *   abi.register(transfer, {"address", "bignum", returns = "bool"}, balanceOf)
+ *
+ * abi.register_view registers the functions which don't change the state, so
+ * only they can be queried. abi.payable registers the functions which accept
+ * an amount. abi.reentrant registers the functions which can be called while
+ * the contract is already on the call stack of the tx.
+ *
+ * The annotations are removed before the functions are registered, so that
+ * the node (vm_newstate) loads the same contracts as the compiler
+ * (luac_vm_newstate), which generates them into the ABI. The two packages are
+ * linked together, so the functions are static.
+ */
+
+#include <lualib.h>
+#include <lauxlib.h>
+
+/* the registry key of the function generating the annotations in JSON */
+#define ABI_TYPES_GENERATE "__abi_types_generate__"
+
+static const char *abi_types_wrapper =
+	"local abi, G = ...\n"
+	"local register, pairs, select, type, error, tostring, unpack = abi.register, pairs, select, type, error, tostring, unpack\n"
+	"local format, concat, sort = string.format, table.concat, table.sort\n"
+	"local valid = { string = true, integer = true, bignum = true, address = true,\n"
+	"                bytes = true, map = true, array = true, bool = true }\n"
+	"local annotations = {}\n"
+	"local function annotation(fn)\n"
+	"  local a = annotations[fn]\n"
+	"  if a == nil then\n"
+	"    a = { args = {}, returns = {} }\n"
+	"    annotations[fn] = a\n"
+	"  end\n"
+	"  return a\n"
+	"end\n"
+	"local function checkTypes(list, what)\n"
+	"  for i = 1, #list do\n"
+	"    if not valid[list[i]] then\n"
+	"      error(format(\"abi.register: invalid %s type '%s'\", what, tostring(list[i])), 4)\n"
+	"    end\n"
+	"  end\n"
+	"end\n"
+	"-- the functions in the arguments of abi.register after their annotations are taken\n"
+	"local function collect(flag, ...)\n"
+	"  local fns, last = {}, nil\n"
+	"  for i = 1, select('#', ...) do\n"
+	"    local v = select(i, ...)\n"
+	"    if type(v) == 'table' then\n"
+	"      if last == nil then\n"
+	"        error('abi.register: a type annotation must follow a function', 3)\n"
+	"      end\n"
+	"      local returns = v.returns\n"
+	"      if type(returns) == 'string' then returns = { returns } end\n"
+	"      if returns ~= nil and type(returns) ~= 'table' then\n"
+	"        error('abi.register: returns must be a type or a list of types', 3)\n"
+	"      end\n"
+	"      checkTypes(v, 'argument')\n"
+	"      checkTypes(returns or {}, 'return')\n"
+	"      local a = annotation(last)\n"
+	"      a.args, a.returns = v, returns or {}\n"
+	"      last = nil\n"
+	"    else\n"
+	"      if flag ~= nil then annotation(v)[flag] = true end\n"
+	"      fns[#fns + 1] = v\n"
+	"      last = v\n"
+	"    end\n"
+	"  end\n"
+	"  return unpack(fns)\n"
+	"end\n"
+	"abi.register = function(...) return register(collect(nil, ...)) end\n"
+	"abi.register_view = function(...) return register(collect('view', ...)) end\n"
+	"abi.payable = function(...) return register(collect('payable', ...)) end\n"
+	"abi.reentrant = function(...) return register(collect('reentrant', ...)) end\n"
+	"local function list(t)\n"
+	"  local out = {}\n"
+	"  for i = 1, #t do out[i] = format('%q', t[i]) end\n"
+	"  return '[' .. concat(out, ',') .. ']'\n"
+	"end\n"
+	"return function()\n"
+	"  local names = {}\n"
+	"  for name, v in pairs(G) do\n"
+	"    if type(name) == 'string' and annotations[v] then names[#names + 1] = name end\n"
+	"  end\n"
+	"  sort(names)\n"
+	"  local out = {}\n"
+	"  for i, name in ipairs(names) do\n"
+	"    local a = annotations[G[name]]\n"
+	"    out[i] = format('%q:{\"arguments\":%s,\"returns\":%s,\"view\":%s,\"payable\":%s,\"reentrant\":%s}',\n"
+	"                    name, list(a.args), list(a.returns), tostring(a.view == true), tostring(a.payable == true),\n"
+	"                    tostring(a.reentrant == true))\n"
+	"  end\n"
+	"  return '{' .. concat(out, ',') .. '}'\n"
+	"end\n";
+
+static void abi_types_open(lua_State *L)
+{
+	lua_getfield(L, LUA_GLOBALSINDEX, "abi");
+	if (!lua_istable(L, -1)) {
+		lua_pop(L, 1);
+		return;
+	}
+	if (luaL_loadstring(L, abi_types_wrapper) != 0) {
+		lua_pop(L, 2);
+		return;
+	}
+	lua_insert(L, -2);
+	lua_pushvalue(L, LUA_GLOBALSINDEX);
+	lua_call(L, 2, 1);
+	lua_setfield(L, LUA_REGISTRYINDEX, ABI_TYPES_GENERATE);
+}
+
+#endif /* _ABI_TYPES_H */
@@ -0,0 +1,107 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package contract
+
+/*
+#include <stdlib.h>
+#include "vm.h"
+#include "bignum_module.h"
+*/
+import "C"
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+// bignumMaxBits limits the size of a bignum, which is big enough for the
+// amounts of the tokens.
+const bignumMaxBits = 1024
+
+var (
+	errBignumOverflow = errors.New("bignum: overflow")
+	errBignumDivZero  = errors.New("bignum: division by zero")
+)
+
+func parseBignum(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, errors.New("bignum: invalid number '" + s + "'")
+	}
+	if n.BitLen() > bignumMaxBits {
+		return nil, errBignumOverflow
+	}
+	return n, nil
+}
+
+func bignumOp(op C.int, x, y *big.Int) (*big.Int, error) {
+	r := new(big.Int)
+	switch op {
+	case C.BIGNUM_NUMBER:
+		r.Set(x)
+	case C.BIGNUM_ADD:
+		r.Add(x, y)
+	case C.BIGNUM_SUB:
+		r.Sub(x, y)
+	case C.BIGNUM_MUL:
+		r.Mul(x, y)
+	case C.BIGNUM_DIV:
+		if y.Sign() == 0 {
+			return nil, errBignumDivZero
+		}
+		r.Quo(x, y)
+	case C.BIGNUM_MOD:
+		if y.Sign() == 0 {
+			return nil, errBignumDivZero
+		}
+		r.Rem(x, y)
+	case C.BIGNUM_POW:
+		if y.Sign() < 0 {
+			return nil, errors.New("bignum: negative exponent")
+		}
+		// |x| >= 2 makes at least (bits of x - 1) * y bits
+		if x.BitLen() > 1 && (!y.IsInt64() || int64(x.BitLen()-1)*y.Int64() >= bignumMaxBits) {
+			return nil, errBignumOverflow
+		}
+		r.Exp(x, y, nil)
+	case C.BIGNUM_NEG:
+		r.Neg(x)
+	default:
+		return nil, errors.New("bignum: unknown operation")
+	}
+	if r.BitLen() > bignumMaxBits {
+		return nil, errBignumOverflow
+	}
+	return r, nil
+}
+
+//export LuaBignumOp
+func LuaBignumOp(L *LState, op C.int, a *C.char, b *C.char) C.int {
+	x, err := parseBignum(C.GoString(a))
+	if err != nil {
+		luaPushStr(L, err.Error())
+		return -1
+	}
+	var y *big.Int
+	if b != nil {
+		y, err = parseBignum(C.GoString(b))
+		if err != nil {
+			luaPushStr(L, err.Error())
+			return -1
+		}
+	}
+	if op == C.BIGNUM_CMP {
+		luaPushStr(L, strconv.Itoa(x.Cmp(y)))
+		return 0
+	}
+	r, err := bignumOp(op, x, y)
+	if err != nil {
+		luaPushStr(L, err.Error())
+		return -1
+	}
+	luaPushStr(L, r.String())
+	return 0
+}
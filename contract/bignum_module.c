@@ -0,0 +1,298 @@
+#include <stdio.h>
+#include <string.h>
+#include <stdlib.h>
+#include <errno.h>
+#include <math.h>
+#include "vm.h"
+#include "bignum_module.h"
+#include "_cgo_export.h"
+
+/*
+ * A bignum is an arbitrary-precision integer, which is a userdata keeping the
+ * value in a canonical decimal string. The arithmetic is done by math/big, and
+ * the division and the modulo are truncated toward zero. The operands can be
+ * bignums, integral numbers or decimal strings.
+ *
+ * The comparison operators of Lua work only between bignums, so
+ * bignum.compare is used with a number.
+ */
+
+static const char *mt_bignum = "bignum";
+
+int lua_bignum_is(lua_State *L, int idx)
+{
+	int ret;
+
+	if (lua_type(L, idx) != LUA_TUSERDATA || !lua_getmetatable(L, idx))
+		return 0;
+	luaL_getmetatable(L, mt_bignum);
+	ret = lua_rawequal(L, -1, -2);
+	lua_pop(L, 2);
+	return ret;
+}
+
+const char *lua_bignum_tostr(lua_State *L, int idx)
+{
+	return (const char *)lua_touserdata(L, idx);
+}
+
+/* push a bignum of the decimal string str, or return -1 if it is invalid */
+int lua_bignum_push(lua_State *L, const char *str)
+{
+	const char *p = str;
+	int neg = 0;
+	size_t len;
+	char *ud;
+
+	if (*p == '-') {
+		neg = 1;
+		p++;
+	}
+	if (*p == '\0')
+		return -1;
+	len = strspn(p, "0123456789");
+	if (p[len] != '\0')
+		return -1;
+	/* canonical form without the leading zeros and the negative zero */
+	while (*p == '0' && len > 1) {
+		p++;
+		len--;
+	}
+	if (*p == '0')
+		neg = 0;
+
+	ud = (char *)lua_newuserdata(L, neg + len + 1);
+	if (neg)
+		ud[0] = '-';
+	memcpy(ud + neg, p, len + 1);
+	luaL_getmetatable(L, mt_bignum);
+	lua_setmetatable(L, -2);
+	return 0;
+}
+
+/* the decimal string of the operand idx */
+static const char *bignum_arg(lua_State *L, int idx, char *buf, size_t sz)
+{
+	lua_Number d;
+
+	switch (lua_type(L, idx)) {
+	case LUA_TUSERDATA:
+		if (lua_bignum_is(L, idx))
+			return lua_bignum_tostr(L, idx);
+		break;
+	case LUA_TNUMBER:
+		d = lua_tonumber(L, idx);
+		if (isnan(d) || isinf(d) || d != floor(d))
+			luaL_error(L, "bignum: not an integer");
+		snprintf(buf, sz, "%.0f", d);
+		return buf;
+	case LUA_TSTRING:
+		return lua_tostring(L, idx);
+	}
+	luaL_typerror(L, idx, "bignum");
+	return NULL;
+}
+
+/* push the result of op with the operands in 1 and 2 */
+static int bignum_op(lua_State *L, int op, int nargs)
+{
+	char abuf[400], bbuf[400];
+	const char *a, *b = NULL;
+
+	vm_use_gas(L, GAS_BIGNUM);
+	a = bignum_arg(L, 1, abuf, sizeof(abuf));
+	if (nargs > 1)
+		b = bignum_arg(L, 2, bbuf, sizeof(bbuf));
+	if (LuaBignumOp(L, op, (char *)a, (char *)b) < 0) {
+		lua_error(L);
+	}
+	if (op == BIGNUM_CMP) {
+		lua_pushinteger(L, lua_tointeger(L, -1));
+		return 1;
+	}
+	if (lua_bignum_push(L, lua_tostring(L, -1)) != 0) {
+		luaL_error(L, "bignum: invalid result");
+	}
+	return 1;
+}
+
+static int bignum_number(lua_State *L)
+{
+	luaL_checkany(L, 1);
+	if (lua_bignum_is(L, 1)) {
+		lua_settop(L, 1);
+		return 1;
+	}
+	return bignum_op(L, BIGNUM_NUMBER, 1);
+}
+
+static int bignum_add(lua_State *L)
+{
+	return bignum_op(L, BIGNUM_ADD, 2);
+}
+
+static int bignum_sub(lua_State *L)
+{
+	return bignum_op(L, BIGNUM_SUB, 2);
+}
+
+static int bignum_mul(lua_State *L)
+{
+	return bignum_op(L, BIGNUM_MUL, 2);
+}
+
+static int bignum_div(lua_State *L)
+{
+	return bignum_op(L, BIGNUM_DIV, 2);
+}
+
+static int bignum_mod(lua_State *L)
+{
+	return bignum_op(L, BIGNUM_MOD, 2);
+}
+
+static int bignum_pow(lua_State *L)
+{
+	return bignum_op(L, BIGNUM_POW, 2);
+}
+
+static int bignum_neg(lua_State *L)
+{
+	return bignum_op(L, BIGNUM_NEG, 1);
+}
+
+/* bignum.compare(a, b) returns -1, 0 or 1 as a is less than, equal to or greater than b */
+static int bignum_compare(lua_State *L)
+{
+	return bignum_op(L, BIGNUM_CMP, 2);
+}
+
+static int bignum_cmp(lua_State *L)
+{
+	bignum_op(L, BIGNUM_CMP, 2);
+	return (int)lua_tointeger(L, -1);
+}
+
+static int bignum_eq(lua_State *L)
+{
+	lua_pushboolean(L, bignum_cmp(L) == 0);
+	return 1;
+}
+
+static int bignum_lt(lua_State *L)
+{
+	lua_pushboolean(L, bignum_cmp(L) < 0);
+	return 1;
+}
+
+static int bignum_le(lua_State *L)
+{
+	lua_pushboolean(L, bignum_cmp(L) <= 0);
+	return 1;
+}
+
+static int bignum_isbignum(lua_State *L)
+{
+	lua_pushboolean(L, lua_bignum_is(L, 1));
+	return 1;
+}
+
+static int bignum_iszero(lua_State *L)
+{
+	luaL_checkudata(L, 1, mt_bignum);
+	lua_pushboolean(L, strcmp(lua_bignum_tostr(L, 1), "0") == 0);
+	return 1;
+}
+
+static int bignum_isneg(lua_State *L)
+{
+	luaL_checkudata(L, 1, mt_bignum);
+	lua_pushboolean(L, lua_bignum_tostr(L, 1)[0] == '-');
+	return 1;
+}
+
+static int bignum_tostring(lua_State *L)
+{
+	luaL_checkudata(L, 1, mt_bignum);
+	lua_pushstring(L, lua_bignum_tostr(L, 1));
+	return 1;
+}
+
+/* bignum.tonumber(b) converts b to a number, which can lose the precision */
+static int bignum_tonumber(lua_State *L)
+{
+	luaL_checkudata(L, 1, mt_bignum);
+	lua_pushnumber(L, strtod(lua_bignum_tostr(L, 1), NULL));
+	return 1;
+}
+
+/*
+ * The amount of contract.send or contract.call.value, which is a bignum or a
+ * number. A bignum is not limited to the precision of the numbers.
+ */
+unsigned long long lua_bignum_checkamount(lua_State *L, int idx)
+{
+	const char *str;
+	char *end;
+	unsigned long long amount;
+	lua_Integer n;
+
+	if (!lua_bignum_is(L, idx)) {
+		n = luaL_checkinteger(L, idx);
+		if (n < 0)
+			luaL_error(L, "invalid number");
+		return (unsigned long long)n;
+	}
+	str = lua_bignum_tostr(L, idx);
+	if (str[0] == '-')
+		luaL_error(L, "invalid number");
+	errno = 0;
+	amount = strtoull(str, &end, 10);
+	if (errno == ERANGE || *end != '\0')
+		luaL_error(L, "amount is too big");
+	return amount;
+}
+
+static const luaL_Reg bignum_lib[] = {
+	{"number", bignum_number},
+	{"isbignum", bignum_isbignum},
+	{"add", bignum_add},
+	{"sub", bignum_sub},
+	{"mul", bignum_mul},
+	{"div", bignum_div},
+	{"mod", bignum_mod},
+	{"pow", bignum_pow},
+	{"neg", bignum_neg},
+	{"compare", bignum_compare},
+	{"iszero", bignum_iszero},
+	{"isneg", bignum_isneg},
+	{"tostring", bignum_tostring},
+	{"tonumber", bignum_tonumber},
+	{NULL, NULL}
+};
+
+static const luaL_Reg bignum_meta[] = {
+	{"__add", bignum_add},
+	{"__sub", bignum_sub},
+	{"__mul", bignum_mul},
+	{"__div", bignum_div},
+	{"__mod", bignum_mod},
+	{"__pow", bignum_pow},
+	{"__unm", bignum_neg},
+	{"__eq", bignum_eq},
+	{"__lt", bignum_lt},
+	{"__le", bignum_le},
+	{"__tostring", bignum_tostring},
+	{NULL, NULL}
+};
+
+int luaopen_bignum(lua_State *L)
+{
+	luaL_newmetatable(L, mt_bignum);
+	luaL_register(L, NULL, bignum_meta);
+	luaL_register(L, "bignum", bignum_lib);
+	/* the methods of a bignum, b:tostring() */
+	lua_setfield(L, -2, "__index");
+	lua_pop(L, 1);
+	return 1;
+}
@@ -0,0 +1,25 @@
+#ifndef _BIGNUM_MODULE_H
+#define _BIGNUM_MODULE_H
+
+#include "lua.h"
+
+#define BIGNUM_NUMBER 0
+#define BIGNUM_ADD    1
+#define BIGNUM_SUB    2
+#define BIGNUM_MUL    3
+#define BIGNUM_DIV    4
+#define BIGNUM_MOD    5
+#define BIGNUM_POW    6
+#define BIGNUM_NEG    7
+#define BIGNUM_CMP    8
+
+/* the JSON key of a bignum, {"_bignum":"<decimal>"} */
+#define BIGNUM_JSON_KEY "_bignum"
+
+extern int luaopen_bignum(lua_State *L);
+int lua_bignum_is(lua_State *L, int idx);
+const char *lua_bignum_tostr(lua_State *L, int idx);
+int lua_bignum_push(lua_State *L, const char *str);
+unsigned long long lua_bignum_checkamount(lua_State *L, int idx);
+
+#endif /* _BIGNUM_MODULE_H */
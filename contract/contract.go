@@ -2,6 +2,7 @@ package contract
 
 import "C"
 import (
+	"bytes"
 	"errors"
 	"strconv"
 
@@ -12,9 +13,10 @@ import (
 )
 
 type loadedReply struct {
-	tx  *types.Tx
-	ex  *Executor
-	err error
+	tx       *types.Tx
+	ex       *Executor
+	err      error
+	codeHash []byte
 }
 
 type preLoadReq struct {
@@ -34,6 +36,10 @@ var preLoadInfos [2]preLoadInfo
 const BlockFactory = 0
 const ChainService = 1
 
+// Replay re-executes a tx of the chain or simulates a tx. The sql databases are
+// read at the recovery points of the contracts and never changed.
+const Replay = 2
+
 func init() {
 	loadReqCh = make(chan *preLoadReq, 10)
 	preLoadInfos[BlockFactory].replyCh = make(chan *loadedReply, 4)
@@ -46,36 +52,76 @@ func SetPreloadTx(tx *types.Tx, service int) {
 	preLoadInfos[service].requestedTx = tx
 }
 
+// Execute runs a NORMAL tx and returns the result of the contract call with
+// the gas used by the tx, which is zero before gas is metered by the chain
+// parameters.
 func Execute(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64,
-	sender, receiver *state.V, preLoadService int) (string, error) {
+	sender, receiver *state.V, preLoadService int) (string, uint64, error) {
+	return ExecuteTrace(bs, tx, blockNo, ts, sender, receiver, preLoadService, nil)
+}
+
+// ExecuteTrace runs a NORMAL tx like Execute and records the execution of the
+// contract call with t. A traced tx is never preloaded.
+func ExecuteTrace(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64,
+	sender, receiver *state.V, preLoadService int, t *Tracer) (string, uint64, error) {
 
 	txBody := tx.GetBody()
 
+	gas := newTxGasMeter(tx.GasLimit(), blockNo)
+	if t != nil {
+		t.gas = gas
+	}
+	if err := gas.use(types.TxBaseGas); err != nil {
+		return "", gas.used, VmError(err)
+	}
+
 	// Transfer balance
 	if sender.AccountID() != receiver.AccountID() {
 		if sender.Balance() < txBody.Amount {
-			return "", types.ErrInsufficientBalance
+			return "", gas.used, types.ErrInsufficientBalance
 		}
 		sender.SubBalance(txBody.Amount)
 		receiver.AddBalance(txBody.Amount)
 	}
 
 	if txBody.Payload == nil {
-		return "", nil
+		return "", gas.used, nil
 	}
 
 	if !receiver.IsNew() && len(receiver.State().CodeHash) == 0 {
-		return "", errors.New("account is not a contract")
+		return "", gas.used, errors.New("account is not a contract")
 	}
 
 	contractState, err := bs.OpenContractState(receiver.AccountID(), receiver.State())
 	if err != nil {
-		return "", err
+		return "", gas.used, err
+	}
+
+	if txBody.Type == types.TxType_REDEPLOY {
+		if txBody.Amount > 0 {
+			return "", gas.used, VmError(ErrUpgradeNotPayable)
+		}
+		err = Upgrade(contractState, txBody.Payload, receiver.ID(), types.EncodeAddress(txBody.GetAccount()))
+		if err != nil {
+			return "", gas.used, VmError(err)
+		}
+		err = bs.StageContractState(contractState)
+		if err != nil {
+			return "", gas.used, err
+		}
+		return "", gas.used, nil
+	}
+
+	if txBody.Amount > 0 && !receiver.IsCreate() {
+		if err := CheckPayable(contractState, receiver.ID(), txBody.Payload, txBody.Amount, blockNo); err != nil {
+			return "", gas.used, VmError(err)
+		}
 	}
 
 	var rv string
 	var ex *Executor
-	if !receiver.IsCreate() && preLoadInfos[preLoadService].requestedTx == tx {
+	if t == nil && preLoadService != Replay && !receiver.IsCreate() &&
+		preLoadInfos[preLoadService].requestedTx == tx {
 		replyCh := preLoadInfos[preLoadService].replyCh
 		for {
 			preload := <-replyCh
@@ -83,21 +129,24 @@ func Execute(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64,
 				preload.ex.close(true)
 				continue
 			}
-			ex = preload.ex
-			err = preload.err
+			// The code may be replaced or removed by the previous txs after
+			// it is preloaded. Then the tx is executed without the preload.
+			if preload.err == nil && bytes.Equal(preload.codeHash, contractState.State.GetCodeHash()) {
+				ex = preload.ex
+			} else {
+				preload.ex.close(true)
+			}
 			break
 		}
-		if err != nil {
-			return "", err
-		}
 	}
 	if ex != nil {
-		rv, err = PreCall(ex, bs, sender.State(), contractState, blockNo, ts, receiver.RP())
+		rv, err = PreCall(ex, bs, sender.State(), contractState, blockNo, ts, receiver.RP(), gas)
 	} else {
 		bcCtx := NewContext(bs, sender.State(), contractState, types.EncodeAddress(txBody.GetAccount()),
 			enc.ToString(tx.GetHash()), blockNo, ts, "", 0,
 			types.EncodeAddress(receiver.ID()), 0, nil, receiver.RP(),
-			preLoadService, txBody.GetAmount())
+			preLoadService, txBody.GetAmount(), gas)
+		bcCtx.setTracer(t)
 
 		if receiver.IsCreate() {
 			rv, err = Create(contractState, txBody.Payload, receiver.ID(), bcCtx)
@@ -107,19 +156,19 @@ func Execute(bs *state.BlockState, tx *types.Tx, blockNo uint64, ts int64,
 	}
 	if err != nil {
 		if err == types.ErrInsufficientBalance || err == types.ErrVmStart {
-			return "", err
+			return "", gas.used, err
 		} else if _, ok := err.(DbSystemError); ok {
-			return "", err
+			return "", gas.used, err
 		}
-		return "", VmError(err)
+		return "", gas.used, VmError(err)
 	}
 
 	err = bs.StageContractState(contractState)
 	if err != nil {
-		return "", err
+		return "", gas.used, err
 	}
 
-	return rv, nil
+	return rv, gas.used, nil
 }
 
 func PreLoadRequest(bs *state.BlockState, tx *types.Tx, preLoadService int) {
@@ -148,21 +197,21 @@ func preLoadWorker() {
 		}
 		receiver, err := bs.GetAccountStateV(recipient)
 		if err != nil {
-			replyCh <- &loadedReply{tx, nil, err}
+			replyCh <- &loadedReply{tx, nil, err, nil}
 			continue
 		}
 		/* When deploy and call in same block and not deployed yet*/
 		if receiver.IsNew() {
-			replyCh <- &loadedReply{tx, nil, nil}
+			replyCh <- &loadedReply{tx, nil, nil, nil}
 			continue
 		}
 		if len(receiver.State().CodeHash) == 0 {
-			replyCh <- &loadedReply{tx, nil, errors.New("account is not a contract")}
+			replyCh <- &loadedReply{tx, nil, errors.New("account is not a contract"), nil}
 			continue
 		}
 		contractState, err := bs.OpenContractState(receiver.AccountID(), receiver.State())
 		if err != nil {
-			replyCh <- &loadedReply{tx, nil, err}
+			replyCh <- &loadedReply{tx, nil, err, nil}
 			continue
 		}
 		txHash := enc.ToString(tx.GetHash())
@@ -180,11 +229,12 @@ func preLoadWorker() {
 			node:       C.CString(""),
 		}
 
+		codeHash := append([]byte(nil), receiver.State().CodeHash...)
 		ex, err := PreloadEx(contractState, txBody.Payload, receiver.ID(), bcCtx)
 		if err != nil {
 			bcCtx.Del()
 		}
-		replyCh <- &loadedReply{tx, ex, err}
+		replyCh <- &loadedReply{tx, ex, err, codeHash}
 	}
 }
 
@@ -195,3 +245,15 @@ func CreateContractID(account []byte, nonce uint64) []byte {
 	recipientHash := h.Sum(nil)                   // byte array with length 32
 	return append([]byte{0x0C}, recipientHash...) // prepend 0x0C to make it same length as account addresses
 }
+
+// CreateContractID2 returns the address of the contract deployed by deployer
+// with salt and code. It doesn't depend on the nonce, so the address can be
+// known before the deployment.
+func CreateContractID2(deployer, salt, codeHash []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0xFF})
+	h.Write(deployer)
+	h.Write(salt)
+	h.Write(codeHash)
+	return append([]byte{0x0C}, h.Sum(nil)...)
+}
@@ -2,6 +2,7 @@
 #include <stdlib.h>
 #include "vm.h"
 #include "util.h"
+#include "bignum_module.h"
 #include "_cgo_export.h"
 
 extern const bc_ctx_t *getLuaExecContext(lua_State *L);
@@ -12,6 +13,8 @@ static const char *delegatecall_str = "delegatecall";
 static const char *amount_str = "amount";
 static const char *fee_str = "fee";
 
+#define EVENT_NAME_MAX_LEN 64
+
 static void set_call_obj(lua_State *L, const char* obj_name)
 {
 	lua_getglobal(L, contract_str);
@@ -26,19 +29,15 @@ static void reset_amount_info (lua_State *L)
 	lua_setfield(L, 1, fee_str);
 }
 
+/* the amount is a number or a bignum, which is kept as it is */
 static int call_value(lua_State *L)
 {
-	lua_Integer value;
-
 	set_call_obj(L, call_str);
 	if (lua_isnil(L, 1)) {
 		return 1;
 	}
-	value = luaL_checkinteger(L, 1);
-	if (value < 0) {
-		luaL_error(L, "invalid number");
-	}
-	lua_pushinteger(L, value);
+	lua_bignum_checkamount(L, 1);
+	lua_pushvalue(L, 1);
 	lua_setfield(L, -2, amount_str);
 	return 1;
 }
@@ -67,7 +66,8 @@ static int moduleCall(lua_State *L)
 	char *json_args;
 	int ret;
 	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
-	lua_Integer amount, gas;
+	unsigned long long amount;
+	lua_Integer gas;
 
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
@@ -77,7 +77,7 @@ static int moduleCall(lua_State *L)
 	if (lua_isnil(L, -1))
 		amount= 0;
 	else
-		amount = luaL_checkinteger(L, -1);
+		amount = lua_bignum_checkamount(L, -1);
 
 	lua_getfield(L, 1, fee_str);
 	if (lua_isnil(L, -1))
@@ -88,6 +88,7 @@ static int moduleCall(lua_State *L)
 		luaL_error(L, "set not permitted in query");
 
 	lua_pop(L, 2);
+	vm_use_gas(L, GAS_CALL);
 	contract = (char *)luaL_checkstring(L, 2);
 	fname = (char *)luaL_checkstring(L, 3);
 	json_args = lua_util_get_json_from_stack (L, 4, lua_gettop(L), false);
@@ -140,6 +141,7 @@ static int moduleDelegateCall(lua_State *L)
 		gas = luaL_checkinteger(L, -1);
 
 	lua_pop(L, 1);
+	vm_use_gas(L, GAS_CALL);
 	contract = (char *)luaL_checkstring(L, 2);
 	fname = (char *)luaL_checkstring(L, 3);
 	json_args = lua_util_get_json_from_stack (L, 4, lua_gettop(L), false);
@@ -161,7 +163,7 @@ static int moduleSend(lua_State *L)
 	char *contract;
 	int ret;
 	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
-	lua_Integer amount;
+	unsigned long long amount;
 
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
@@ -169,8 +171,9 @@ static int moduleSend(lua_State *L)
 	if (exec->isQuery)
 		luaL_error(L, "set not permitted in query");
 
+	vm_use_gas(L, GAS_SEND);
 	contract = (char *)luaL_checkstring(L, 1);
-	amount = luaL_checkinteger(L, 2);
+	amount = lua_bignum_checkamount(L, 2);
 	if ((ret = LuaSendAmount(L, exec, contract, amount)) < 0) {
 		lua_error(L);
 	}
@@ -178,6 +181,64 @@ static int moduleSend(lua_State *L)
 	return 0;
 }
 
+/*
+ * contract.deploy(template, salt, ...) deploys the code of the template
+ * contract, calling the constructor with the rest of the arguments. The
+ * address is decided by the deployer, salt and the code.
+ */
+static int moduleDeploy(lua_State *L)
+{
+	char *template;
+	const char *salt;
+	size_t salt_len;
+	char *json_args;
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+
+	if (exec == NULL) {
+		luaL_error(L, "cannot find execution context");
+	}
+	if (exec->isQuery)
+		luaL_error(L, "deploy not permitted in query");
+
+	vm_use_gas(L, GAS_DEPLOY);
+	template = (char *)luaL_checkstring(L, 1);
+	salt = luaL_checklstring(L, 2, &salt_len);
+	json_args = lua_util_get_json_from_stack (L, 3, lua_gettop(L), false);
+	if (json_args == NULL) {
+		lua_error(L);
+	}
+	if (LuaDeployContract(L, exec, template, (char *)salt, salt_len, json_args) < 0) {
+		free(json_args);
+		lua_error(L);
+	}
+	free(json_args);
+	return 1;
+}
+
+/*
+ * contract.destroy([beneficiary]) removes the contract at the end of the tx.
+ * The balance is sent to the beneficiary, or the creator of the contract.
+ */
+static int moduleDestroy(lua_State *L)
+{
+	char *beneficiary = NULL;
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+
+	if (exec == NULL) {
+		luaL_error(L, "cannot find execution context");
+	}
+	if (exec->isQuery)
+		luaL_error(L, "destroy not permitted in query");
+
+	vm_use_gas(L, GAS_DESTROY);
+	if (lua_gettop(L) > 0 && !lua_isnil(L, 1))
+		beneficiary = (char *)luaL_checkstring(L, 1);
+	if (LuaDestroyContract(L, exec, beneficiary) < 0) {
+		lua_error(L);
+	}
+	return 0;
+}
+
 static int moduleBalance(lua_State *L)
 {
 	char *contract;
@@ -189,6 +250,7 @@ static int moduleBalance(lua_State *L)
 		luaL_error(L, "cannot find execution context");
 	}
 
+	vm_use_gas(L, GAS_BALANCE);
     if (lua_gettop(L) == 0 || lua_isnil(L, 1))
         contract = NULL;
     else
@@ -201,6 +263,39 @@ static int moduleBalance(lua_State *L)
 	return 1;
 }
 
+static int moduleEvent(lua_State *L)
+{
+	char *event_name;
+	char *json_args;
+	size_t nbytes;
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+
+	if (exec == NULL) {
+		luaL_error(L, "cannot find execution context");
+	}
+	if (exec->isQuery) {
+		luaL_error(L, "event not permitted in query");
+	}
+
+	event_name = (char *)luaL_checkstring(L, 1);
+	if (strlen(event_name) > EVENT_NAME_MAX_LEN) {
+		luaL_error(L, "event name is too long (max %d)", EVENT_NAME_MAX_LEN);
+	}
+	json_args = lua_util_get_json_from_stack (L, 2, lua_gettop(L), false);
+	if (json_args == NULL) {
+		lua_error(L);
+	}
+	if (LuaEvent(L, exec, event_name, json_args) < 0) {
+		free(json_args);
+		lua_error(L);
+	}
+	nbytes = strlen(event_name) + strlen(json_args);
+	free(json_args);
+	vm_use_gas(L, GAS_EVENT + GAS_EVENT_BYTE * nbytes);
+
+	return 0;
+}
+
 static int modulePcall(lua_State *L)
 {
 	int argc = lua_gettop(L) - 1;
@@ -224,6 +319,10 @@ static int modulePcall(lua_State *L)
 			if (LuaClearRecovery(L, exec->stateKey, start_seq, true) < 0)
 				lua_error(L);
 		}
+		/* running out of gas or exceeding a limit of the tx aborts the
+		 * whole call, it cannot be caught */
+		if (vm_is_out_of_gas(L) || vm_limit_exceeded(L))
+			lua_error(L);
 		return 2;
 	}
 	lua_pushboolean(L, true);
@@ -260,6 +359,9 @@ static const luaL_Reg contract_lib[] = {
 	{"balance", moduleBalance},
 	{"send", moduleSend},
 	{"pcall", modulePcall},
+	{"event", moduleEvent},
+	{"deploy", moduleDeploy},
+	{"destroy", moduleDestroy},
 	{NULL, NULL}
 };
 
@@ -0,0 +1,191 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package contract
+
+/*
+#include <stdlib.h>
+#include "vm.h"
+#include "crypto_module.h"
+*/
+import "C"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strings"
+	"unsafe"
+
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+)
+
+// cryptoArg decodes a binary argument of the crypto module. It is a string of
+// the bytes, or a hex string with or without the 0x prefix if isHex is not 0.
+func cryptoArg(arg *C.char, argLen C.size_t, isHex C.int) ([]byte, error) {
+	s := C.GoStringN(arg, C.int(argLen))
+	if isHex == 0 {
+		return []byte(s), nil
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, errors.New("invalid hex string")
+	}
+	return b, nil
+}
+
+func luaPushHex(L *LState, b []byte) {
+	luaPushStr(L, "0x"+hex.EncodeToString(b))
+}
+
+//export LuaCryptoHash
+func LuaCryptoHash(L *LState, kind C.int, data *C.char, dataLen C.size_t, isHex C.int) C.int {
+	b, err := cryptoArg(data, dataLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaCryptoHash]"+err.Error())
+		return -1
+	}
+	var h hash.Hash
+	switch kind {
+	case C.CRYPTO_SHA256:
+		h = sha256.New()
+	case C.CRYPTO_KECCAK256:
+		h = sha3.NewLegacyKeccak256()
+	case C.CRYPTO_RIPEMD160:
+		h = ripemd160.New()
+	default:
+		luaPushStr(L, "[Contract.LuaCryptoHash]unknown hash function")
+		return -1
+	}
+	h.Write(b)
+	luaPushHex(L, h.Sum(nil))
+	return 0
+}
+
+//export LuaECVerify
+func LuaECVerify(L *LState, hash *C.char, hashLen C.size_t, sign *C.char, signLen C.size_t, address *C.char, isHex C.int) C.int {
+	bHash, err := cryptoArg(hash, hashLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaECVerify]invalid hash: "+err.Error())
+		return -1
+	}
+	bSign, err := cryptoArg(sign, signLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaECVerify]invalid sign: "+err.Error())
+		return -1
+	}
+	// the address is the compressed public key
+	bAddress, err := types.DecodeAddress(C.GoString(address))
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaECVerify]invalid address: "+err.Error())
+		return -1
+	}
+	pubKey, err := btcec.ParsePubKey(bAddress, btcec.S256())
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaECVerify]invalid address: "+err.Error())
+		return -1
+	}
+	verified := false
+	if signature, err := btcec.ParseSignature(bSign, btcec.S256()); err == nil {
+		verified = signature.Verify(bHash, pubKey)
+	}
+	C.lua_pushboolean(L, boolToInt(verified))
+	return 0
+}
+
+//export LuaECRecover
+func LuaECRecover(L *LState, hash *C.char, hashLen C.size_t, sign *C.char, signLen C.size_t, isHex C.int) C.int {
+	bHash, err := cryptoArg(hash, hashLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaECRecover]invalid hash: "+err.Error())
+		return -1
+	}
+	bSign, err := cryptoArg(sign, signLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaECRecover]invalid sign: "+err.Error())
+		return -1
+	}
+	pubKey, _, err := btcec.RecoverCompact(btcec.S256(), bSign, bHash)
+	if err != nil {
+		// the sign is not of the hash
+		C.lua_pushnil(L)
+		return 0
+	}
+	luaPushStr(L, types.EncodeAddress(key.GenerateAddress(pubKey.ToECDSA())))
+	return 0
+}
+
+//export LuaCryptoToAddress
+func LuaCryptoToAddress(L *LState, pubkey *C.char, pubkeyLen C.size_t, isHex C.int) C.int {
+	b, err := cryptoArg(pubkey, pubkeyLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaCryptoToAddress]"+err.Error())
+		return -1
+	}
+	pubKey, err := btcec.ParsePubKey(b, btcec.S256())
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaCryptoToAddress]invalid public key: "+err.Error())
+		return -1
+	}
+	luaPushStr(L, types.EncodeAddress(key.GenerateAddress(pubKey.ToECDSA())))
+	return 0
+}
+
+//export LuaCryptoVerifyProof
+func LuaCryptoVerifyProof(L *LState, root *C.char, rootLen C.size_t, k *C.char, kLen C.size_t,
+	value *C.char, valueLen C.size_t, items **C.char, itemLens *C.size_t, n C.int, isHex C.int) C.int {
+	bRoot, err := cryptoArg(root, rootLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaCryptoVerifyProof]invalid root: "+err.Error())
+		return -1
+	}
+	bKey, err := cryptoArg(k, kLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaCryptoVerifyProof]invalid key: "+err.Error())
+		return -1
+	}
+	bValue, err := cryptoArg(value, valueLen, isHex)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaCryptoVerifyProof]invalid value: "+err.Error())
+		return -1
+	}
+	if n > trie.HashLength*8 {
+		C.lua_pushboolean(L, 0)
+		return 0
+	}
+	var ap [][]byte
+	if n > 0 {
+		cItems := (*[1 << 20]*C.char)(unsafe.Pointer(items))[:n:n]
+		cLens := (*[1 << 20]C.size_t)(unsafe.Pointer(itemLens))[:n:n]
+		ap = make([][]byte, n)
+		for i := range ap {
+			ap[i], err = cryptoArg(cItems[i], cLens[i], isHex)
+			if err != nil {
+				luaPushStr(L, "[Contract.LuaCryptoVerifyProof]invalid proof: "+err.Error())
+				return -1
+			}
+		}
+	}
+	if len(bKey) != trie.HashLength {
+		C.lua_pushboolean(L, 0)
+		return 0
+	}
+	tr := trie.NewTrie(bRoot, common.Hasher, nil)
+	C.lua_pushboolean(L, boolToInt(tr.VerifyInclusion(ap, bKey, bValue)))
+	return 0
+}
+
+func boolToInt(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
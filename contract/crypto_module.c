@@ -0,0 +1,174 @@
+#include <string.h>
+#include <stdlib.h>
+#include "vm.h"
+#include "crypto_module.h"
+#include "_cgo_export.h"
+
+/*
+ * The binary arguments are strings of the bytes. If the optional encoding
+ * argument, the last one, is "hex", they are hex strings with or without the
+ * 0x prefix. The hashes are returned in hex strings with the 0x prefix.
+ */
+
+static const char *const crypto_encodings[] = {"raw", "hex", NULL};
+
+/* the depth of the state trie, trie.HashLength * 8 */
+#define CRYPTO_MAX_PROOF_ITEMS 256
+
+/* crypto_hex returns 1 if the encoding argument at idx is "hex" */
+static int crypto_hex(lua_State *L, int idx)
+{
+	return luaL_checkoption(L, idx, "raw", crypto_encodings);
+}
+
+static int crypto_hash(lua_State *L, int kind)
+{
+	const char *data;
+	size_t len;
+	int hex;
+
+	data = luaL_checklstring(L, 1, &len);
+	hex = crypto_hex(L, 2);
+	vm_use_gas(L, GAS_CRYPTO_HASH + GAS_CRYPTO_HASH_BYTE * len);
+	if (LuaCryptoHash(L, kind, (char *)data, len, hex) < 0) {
+		lua_error(L);
+	}
+	return 1;
+}
+
+static int crypto_sha256(lua_State *L)
+{
+	return crypto_hash(L, CRYPTO_SHA256);
+}
+
+static int crypto_keccak256(lua_State *L)
+{
+	return crypto_hash(L, CRYPTO_KECCAK256);
+}
+
+static int crypto_ripemd160(lua_State *L)
+{
+	return crypto_hash(L, CRYPTO_RIPEMD160);
+}
+
+/* crypto.ecverify(hash, sign, address[, encoding]) checks the DER signature of hash */
+static int crypto_ecverify(lua_State *L)
+{
+	const char *hash, *sign, *address;
+	size_t hashLen, signLen;
+	int hex;
+
+	hash = luaL_checklstring(L, 1, &hashLen);
+	sign = luaL_checklstring(L, 2, &signLen);
+	address = luaL_checkstring(L, 3);
+	hex = crypto_hex(L, 4);
+	vm_use_gas(L, GAS_CRYPTO_ECVERIFY);
+	if (LuaECVerify(L, (char *)hash, hashLen, (char *)sign, signLen, (char *)address, hex) < 0) {
+		lua_error(L);
+	}
+	return 1;
+}
+
+/* crypto.ecrecover(hash, sign[, encoding]) returns the address of the compact signature */
+static int crypto_ecrecover(lua_State *L)
+{
+	const char *hash, *sign;
+	size_t hashLen, signLen;
+	int hex;
+
+	hash = luaL_checklstring(L, 1, &hashLen);
+	sign = luaL_checklstring(L, 2, &signLen);
+	hex = crypto_hex(L, 3);
+	vm_use_gas(L, GAS_CRYPTO_ECRECOVER);
+	if (LuaECRecover(L, (char *)hash, hashLen, (char *)sign, signLen, hex) < 0) {
+		lua_error(L);
+	}
+	return 1;
+}
+
+/* crypto.toAddress(pubkey[, encoding]) returns the address of the public key */
+static int crypto_to_address(lua_State *L)
+{
+	const char *pubkey;
+	size_t len;
+	int hex;
+
+	pubkey = luaL_checklstring(L, 1, &len);
+	hex = crypto_hex(L, 2);
+	vm_use_gas(L, GAS_CRYPTO_ADDRESS);
+	if (LuaCryptoToAddress(L, (char *)pubkey, len, hex) < 0) {
+		lua_error(L);
+	}
+	return 1;
+}
+
+/*
+ * crypto.verifyProof(root, key, value, proof[, encoding]) checks the merkle
+ * proof, an array of the hashes, of key and value in the state trie of root.
+ */
+static int crypto_verify_proof(lua_State *L)
+{
+	const char *root, *key, *value;
+	size_t rootLen, keyLen, valueLen;
+	char **items;
+	size_t *itemLens;
+	int i, n, hex, ret;
+
+	root = luaL_checklstring(L, 1, &rootLen);
+	key = luaL_checklstring(L, 2, &keyLen);
+	value = luaL_checklstring(L, 3, &valueLen);
+	luaL_checktype(L, 4, LUA_TTABLE);
+	hex = crypto_hex(L, 5);
+	n = lua_objlen(L, 4);
+	vm_use_gas(L, GAS_CRYPTO_HASH + GAS_CRYPTO_PROOF * n);
+	if (n > CRYPTO_MAX_PROOF_ITEMS) {
+		/* no merkle proof is longer than the depth of the trie */
+		lua_pushboolean(L, 0);
+		return 1;
+	}
+
+	items = malloc(sizeof(char *) * (n + 1));
+	itemLens = malloc(sizeof(size_t) * (n + 1));
+	if (items == NULL || itemLens == NULL) {
+		free(items);
+		free(itemLens);
+		luaL_error(L, "not enough memory");
+	}
+	for (i = 0; i < n; i++) {
+		lua_rawgeti(L, 4, i + 1);
+		if (lua_type(L, -1) != LUA_TSTRING) {
+			free(items);
+			free(itemLens);
+			luaL_error(L, "invalid merkle proof item(%d)", i + 1);
+		}
+		/* the strings are kept in the table during the call */
+		items[i] = (char *)lua_tolstring(L, -1, &itemLens[i]);
+		lua_pop(L, 1);
+	}
+	ret = LuaCryptoVerifyProof(L, (char *)root, rootLen, (char *)key, keyLen,
+	                           (char *)value, valueLen, items, itemLens, n, hex);
+	free(items);
+	free(itemLens);
+	if (ret < 0) {
+		lua_error(L);
+	}
+	return 1;
+}
+
+static const luaL_Reg crypto_lib[] = {
+	{"sha256", crypto_sha256},
+	{"keccak256", crypto_keccak256},
+	{"ripemd160", crypto_ripemd160},
+	{"ecverify", crypto_ecverify},
+	{"ecrecover", crypto_ecrecover},
+	{"toAddress", crypto_to_address},
+	{"verifyProof", crypto_verify_proof},
+	{NULL, NULL}
+};
+
+int luaopen_crypto(lua_State *L)
+{
+	luaL_register(L, "crypto", crypto_lib);
+	lua_pop(L, 1);
+	return 1;
+}
@@ -0,0 +1,12 @@
+#ifndef _CRYPTO_MODULE_H
+#define _CRYPTO_MODULE_H
+
+#include "lua.h"
+
+#define CRYPTO_SHA256    1
+#define CRYPTO_KECCAK256 2
+#define CRYPTO_RIPEMD160 3
+
+extern int luaopen_crypto(lua_State *L);
+
+#endif /* _CRYPTO_MODULE_H */
@@ -163,6 +163,7 @@ static int db_rs_next(lua_State *L)
     db_rs_t *rs = get_db_rs(L, 1);
     int rc;
 
+    vm_use_gas(L, GAS_DB_NEXT);
     rc = sqlite3_step(rs->s);
     if (rc == SQLITE_DONE) {
         db_rs_close(L, rs);
@@ -292,12 +293,20 @@ static int db_pstmt_exec(lua_State *L)
     int rc, n;
     db_pstmt_t *pstmt = get_db_pstmt(L, 1);
 
+    vm_use_gas(L, GAS_DB_EXEC);
     rc = bind(L, pstmt);
     if (rc == -1) {
         sqlite3_reset(pstmt->s);
         sqlite3_clear_bindings(pstmt->s);
         luaL_error(L, lua_tostring(L, -1));
     }
+    vm_trace_sql(L, "sql.exec", sqlite3_sql(pstmt->s));
+    if (vm_is_replay(L) && !sqlite3_stmt_readonly(pstmt->s)) {
+        /* a replayed tx doesn't change the database */
+        vm_trace_sql_write(L);
+        lua_pushinteger(L, 0);
+        return 1;
+    }
     rc = sqlite3_step(pstmt->s);
     if (rc != SQLITE_ROW && rc != SQLITE_OK && rc != SQLITE_DONE) {
         sqlite3_reset(pstmt->s);
@@ -315,12 +324,14 @@ static int db_pstmt_query(lua_State *L)
     db_pstmt_t *pstmt = get_db_pstmt(L, 1);
     db_rs_t *rs;
 
+    vm_use_gas(L, GAS_DB_QUERY);
     rc = bind(L, pstmt);
     if (rc != 0) {
         sqlite3_reset(pstmt->s);
         sqlite3_clear_bindings(pstmt->s);
         luaL_error(L, lua_tostring(L, -1));
     }
+    vm_trace_sql(L, "sql.query", sqlite3_sql(pstmt->s));
 
     rs = (db_rs_t *)lua_newuserdata(L, sizeof(db_rs_t));
     luaL_getmetatable(L, DB_RS_ID);
@@ -364,9 +375,17 @@ static int db_exec(lua_State *L)
     int rc, n;
 
     cmd = luaL_checkstring(L, 1);
+    vm_use_gas(L, GAS_DB_EXEC + GAS_DB_SQL_BYTE * strlen(cmd));
     if (!sqlcheck_is_permitted_sql(cmd)) {
         luaL_error(L, "invalid sql command");
     }
+    vm_trace_sql(L, "sql.exec", cmd);
+    if (vm_is_replay(L) && !sqlcheck_is_readonly_sql(cmd)) {
+        /* a replayed tx doesn't change the database */
+        vm_trace_sql_write(L);
+        lua_pushinteger(L, 0);
+        return 1;
+    }
     db = vm_get_db(L);
     rc = sqlite3_exec(db, cmd, 0, 0, 0);
     LAST_ERROR(L, db, rc);
@@ -384,9 +403,11 @@ static int db_query(lua_State *L)
     db_rs_t *rs;
 
     query = luaL_checkstring(L, 1);
+    vm_use_gas(L, GAS_DB_QUERY + GAS_DB_SQL_BYTE * strlen(query));
     if (!sqlcheck_is_permitted_sql(query)) {
         luaL_error(L, "invalid sql command");
     }
+    vm_trace_sql(L, "sql.query", query);
     db = vm_get_db(L);
     rc = sqlite3_prepare_v2(db, query, -1, &s, NULL);
     LAST_ERROR(L, db, rc);
@@ -413,6 +434,7 @@ static int db_prepare(lua_State *L)
     db_pstmt_t *pstmt;
 
     sql = luaL_checkstring(L, 1);
+    vm_use_gas(L, GAS_DB_QUERY + GAS_DB_SQL_BYTE * strlen(sql));
     if (!sqlcheck_is_permitted_sql(sql)) {
         luaL_error(L, "invalid sql command");
     }
@@ -7,6 +7,12 @@ package contract
 
 import "errors"
 
+var (
+	ErrUpgradeNotPermitted = errors.New("only the creator can upgrade the contract")
+	ErrUpgradeNotPayable   = errors.New("amount is not allowed in upgrade")
+	ErrContractDestroyed   = errors.New("contract address was destroyed")
+)
+
 type VmError error
 
 type DbSystemError error
@@ -0,0 +1,69 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package contract
+
+import "errors"
+
+var ErrOutOfGas = errors.New("out of gas")
+
+// gasMeter accounts the gas consumed by a contract call. A meter created for
+// a nested call with a gas limit charges its parent as well, so the gas used
+// by a callee is always included in the gas used by the tx.
+type gasMeter struct {
+	limit  uint64
+	used   uint64
+	parent *gasMeter
+	// free is set before gas is metered by the chain parameters. Then nothing
+	// is charged and the gas never runs out.
+	free bool
+}
+
+func newGasMeter(limit uint64) *gasMeter {
+	return &gasMeter{limit: limit}
+}
+
+// newTxGasMeter returns the meter of a tx with the gas limit in the block of
+// blockNo
+func newTxGasMeter(limit uint64, blockNo uint64) *gasMeter {
+	if !chainParams.GasAt(blockNo) {
+		return &gasMeter{free: true}
+	}
+	return newGasMeter(limit)
+}
+
+// subMeter returns a meter for a nested call. The limit of the new meter is
+// capped by the remaining gas of g; zero means no extra limit.
+func (g *gasMeter) subMeter(limit uint64) *gasMeter {
+	if remaining := g.remaining(); limit == 0 || limit > remaining {
+		limit = remaining
+	}
+	return &gasMeter{limit: limit, parent: g, free: g.free}
+}
+
+func (g *gasMeter) remaining() uint64 {
+	return g.limit - g.used
+}
+
+func (g *gasMeter) isOutOfGas() bool {
+	return !g.free && g.used >= g.limit
+}
+
+// use charges gas. When the remaining gas is insufficient, the meter is
+// exhausted and ErrOutOfGas is returned.
+func (g *gasMeter) use(gas uint64) error {
+	if g.free {
+		return nil
+	}
+	var err error
+	if gas > g.remaining() {
+		gas = g.remaining()
+		err = ErrOutOfGas
+	}
+	for m := g; m != nil; m = m.parent {
+		m.used += gas
+	}
+	return err
+}
@@ -0,0 +1,136 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package contract
+
+/*
+#include <stdlib.h>
+#include "vm.h"
+*/
+import "C"
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	ErrInstructionLimit = errors.New("exceeded the maximum instruction count")
+	ErrMemoryLimit      = errors.New("exceeded the maximum memory")
+	ErrCallDepth        = errors.New("exceeded the maximum call depth")
+	ErrReentrancy       = errors.New("reentrant call is not allowed")
+)
+
+// newVMLimits returns the limits of a tx in the block of blockNo, or nil if
+// neither the VM limits nor the call limits are activated by the chain
+// parameters. Before the VM limits are activated, each call is limited to
+// MAX_INSTRUCTION_COUNT instructions. The instruction limit is capped by the
+// instructions the gas limit of the tx can pay for.
+func newVMLimits(gas *gasMeter, blockNo uint64) *C.vm_limits_t {
+	if !chainParams.VMLimitsAt(blockNo) {
+		if !chainParams.CallLimitsAt(blockNo) {
+			return nil
+		}
+		limits := C.vm_limits_new(0, 0)
+		if limits != nil {
+			limits.legacyInst = 1
+		}
+		return limits
+	}
+	instLimit := chainParams.MaxInstructionCount
+	if gas != nil && !gas.free {
+		if n := gas.limit / C.GAS_INSTRUCTION; n > 0 && (instLimit == 0 || n < instLimit) {
+			instLimit = n
+		}
+	}
+	return C.vm_limits_new(C.ulonglong(instLimit), C.longlong(chainParams.MaxCallMemory))
+}
+
+// freeLimits frees the limits of the tx owned by the root state
+func (s *StateSet) freeLimits() {
+	if s.rootState != s || s.limits == nil {
+		return
+	}
+	C.free(unsafe.Pointer(s.limits))
+	s.limits = nil
+}
+
+// enterCall pushes the contract of id on the call stack of the tx before it is
+// called in the block of blockNo. reentrant tells whether the called function
+// may reenter the contract. Before the call limits are activated by the chain
+// parameters, the depth is not limited and every contract may be reentered. A
+// call exceeding the limits aborts the tx like the other limits of the VM, so
+// it cannot be caught by contract.pcall.
+func (s *StateSet) enterCall(id string, reentrant bool, blockNo uint64) error {
+	root := s.rootState
+	var err error
+	var exceeded C.int
+	if chainParams.CallLimitsAt(blockNo) {
+		if depth := chainParams.MaxCallDepth; depth > 0 && uint64(len(root.callStack)) >= depth {
+			err, exceeded = ErrCallDepth, C.VM_LIMIT_CALL_DEPTH
+		} else if !chainParams.AllowReentrancy && !reentrant && root.onCallStack(id) {
+			err, exceeded = ErrReentrancy, C.VM_LIMIT_REENTRANCY
+		}
+	}
+	if err != nil {
+		if root.limits != nil && root.limits.exceeded == 0 {
+			root.limits.exceeded = exceeded
+		}
+		return err
+	}
+	root.callStack = append(root.callStack, id)
+	return nil
+}
+
+// exitCall pops the contract of the call returned
+func (s *StateSet) exitCall() {
+	root := s.rootState
+	if n := len(root.callStack); n > 0 {
+		root.callStack = root.callStack[:n-1]
+	}
+}
+
+func (s *StateSet) onCallStack(id string) bool {
+	for _, c := range s.callStack {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+// limitError returns the error of the limit exceeded by the tx, or nil
+func limitError(limits *C.vm_limits_t) error {
+	if limits == nil {
+		return nil
+	}
+	switch limits.exceeded {
+	case C.VM_LIMIT_INSTRUCTION:
+		return ErrInstructionLimit
+	case C.VM_LIMIT_MEMORY:
+		return ErrMemoryLimit
+	case C.VM_LIMIT_CALL_DEPTH:
+		return ErrCallDepth
+	case C.VM_LIMIT_REENTRANCY:
+		return ErrReentrancy
+	}
+	return nil
+}
+
+// ReceiptStatus returns the status of the receipt of a tx failed with err
+func ReceiptStatus(err error) string {
+	switch err {
+	case ErrInstructionLimit:
+		return types.ReceiptInstructionLimit
+	case ErrMemoryLimit:
+		return types.ReceiptMemoryLimit
+	case ErrCallDepth:
+		return types.ReceiptCallDepth
+	case ErrReentrancy:
+		return types.ReceiptReentrancy
+	}
+	return err.Error()
+}
@@ -0,0 +1,152 @@
+#ifndef _SANDBOX_H
+#define _SANDBOX_H
+
+/*
+ * The Lua environment of the contracts, which must give the same results on
+ * every node. It is applied to the states of both the node (vm_sandbox_open),
+ * once activated by the chain parameters, and the compiler (luac_vm_newstate). The two packages are linked together, so
+ * the functions are static.
+ */
+
+#include <lualib.h>
+#include <lauxlib.h>
+
+/* globals with the file system, the process, the GC or dynamic code */
+static const char *sandbox_forbidden_globals[] = {
+	"os", "io", "debug", "package", "require", "module", "jit", "ffi",
+	"dofile", "loadfile", "load", "loadstring",
+	"collectgarbage", "gcinfo", "newproxy", "getfenv", "setfenv", "print",
+	NULL
+};
+
+/* library functions with a random or platform dependent result */
+static const char *sandbox_forbidden_fields[][2] = {
+	{"string", "dump"},
+	{"math", "random"},
+	{"math", "randomseed"},
+	{NULL, NULL}
+};
+
+static int sandbox_is_reference(lua_State *L, int idx)
+{
+	switch (lua_type(L, idx)) {
+	case LUA_TTABLE:
+	case LUA_TFUNCTION:
+	case LUA_TUSERDATA:
+	case LUA_TLIGHTUSERDATA:
+	case LUA_TTHREAD:
+		return 1;
+	default:
+		return 0;
+	}
+}
+
+/* the address of a value without __tostring is replaced by its type name */
+static int sandbox_hides_address(lua_State *L, int idx)
+{
+	if (!sandbox_is_reference(L, idx))
+		return 0;
+	if (luaL_getmetafield(L, idx, "__tostring")) {
+		lua_pop(L, 1);
+		return 0;
+	}
+	return 1;
+}
+
+/*
+ * The order of the keys of reference types depends on their addresses, so
+ * the iteration over them is refused.
+ */
+static int sandbox_next(lua_State *L)
+{
+	luaL_checktype(L, 1, LUA_TTABLE);
+	lua_settop(L, 2);
+	if (lua_next(L, 1)) {
+		if (sandbox_is_reference(L, -2))
+			luaL_error(L, "iteration over the %s keys is not deterministic", luaL_typename(L, -2));
+		return 2;
+	}
+	lua_pushnil(L);
+	return 1;
+}
+
+static int sandbox_pairs(lua_State *L)
+{
+	luaL_checktype(L, 1, LUA_TTABLE);
+	lua_pushvalue(L, lua_upvalueindex(1));
+	lua_pushvalue(L, 1);
+	lua_pushnil(L);
+	return 3;
+}
+
+static int sandbox_tostring(lua_State *L)
+{
+	luaL_checkany(L, 1);
+	if (sandbox_hides_address(L, 1)) {
+		lua_pushstring(L, luaL_typename(L, 1));
+		return 1;
+	}
+	lua_pushvalue(L, lua_upvalueindex(1));
+	lua_pushvalue(L, 1);
+	lua_call(L, 1, 1);
+	return 1;
+}
+
+static int sandbox_format(lua_State *L)
+{
+	int i;
+	int n = lua_gettop(L);
+
+	for (i = 2; i <= n; i++) {
+		if (sandbox_hides_address(L, i)) {
+			lua_pushstring(L, luaL_typename(L, i));
+			lua_replace(L, i);
+		}
+	}
+	lua_pushvalue(L, lua_upvalueindex(1));
+	lua_insert(L, 1);
+	lua_call(L, n, 1);
+	return 1;
+}
+
+/* replace the function of lib (or global) with f, which has the original one as an upvalue */
+static void sandbox_wrap(lua_State *L, const char *lib, const char *name, lua_CFunction f)
+{
+	if (lib == NULL)
+		lua_pushvalue(L, LUA_GLOBALSINDEX);
+	else
+		lua_getfield(L, LUA_GLOBALSINDEX, lib);
+	lua_getfield(L, -1, name);
+	lua_pushcclosure(L, f, 1);
+	lua_setfield(L, -2, name);
+	lua_pop(L, 1);
+}
+
+static void sandbox_open(lua_State *L)
+{
+	int i;
+
+	for (i = 0; sandbox_forbidden_globals[i] != NULL; i++) {
+		lua_pushnil(L);
+		lua_setfield(L, LUA_GLOBALSINDEX, sandbox_forbidden_globals[i]);
+	}
+	for (i = 0; sandbox_forbidden_fields[i][0] != NULL; i++) {
+		lua_getfield(L, LUA_GLOBALSINDEX, sandbox_forbidden_fields[i][0]);
+		if (lua_istable(L, -1)) {
+			lua_pushnil(L);
+			lua_setfield(L, -2, sandbox_forbidden_fields[i][1]);
+		}
+		lua_pop(L, 1);
+	}
+
+	lua_pushcfunction(L, sandbox_next);
+	lua_pushvalue(L, -1);
+	lua_setfield(L, LUA_GLOBALSINDEX, "next");
+	lua_pushcclosure(L, sandbox_pairs, 1);
+	lua_setfield(L, LUA_GLOBALSINDEX, "pairs");
+
+	sandbox_wrap(L, NULL, "tostring", sandbox_tostring);
+	sandbox_wrap(L, "string", "format", sandbox_format);
+}
+
+#endif /* _SANDBOX_H */
@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/aergoio/aergo-lib/log"
@@ -23,6 +25,8 @@ var (
 	ErrDBOpen = errors.New("failed to open the sql database")
 	ErrUndo   = errors.New("failed to undo the sql database")
 	ErrFindRp = errors.New("cannot find a recover point")
+	ErrDBName = errors.New("invalid name of the sql database")
+	ErrDBUsed = errors.New("cannot overwrite the sql database in use")
 
 	database = &Database{}
 	load     sync.Once
@@ -102,6 +106,99 @@ func CloseDatabase() {
 	}
 }
 
+// DatabaseNames returns the names of the sql databases of the contracts in the
+// sorted order.
+func DatabaseNames() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(database.DataDir, "*.db"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = strings.TrimSuffix(filepath.Base(file), ".db")
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func dbFilePath(dbName string) (string, error) {
+	if _, err := types.DecodeAddress(dbName); err != nil {
+		return "", ErrDBName
+	}
+	return filepath.Join(database.DataDir, dbName+".db"), nil
+}
+
+// ReadDatabaseFile reads at most size bytes from offset of the sql database
+// file of dbName, to be sent to a peer for the snapshot sync. It also returns
+// the size of the file.
+func ReadDatabaseFile(dbName string, offset int64, size int) ([]byte, int64, error) {
+	path, err := dbFilePath(dbName)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset >= fi.Size() {
+		return nil, fi.Size(), nil
+	}
+	if remain := fi.Size() - offset; remain < int64(size) {
+		size = int(remain)
+	}
+	data := make([]byte, size)
+	if _, err := f.ReadAt(data, offset); err != nil {
+		return nil, 0, err
+	}
+	return data, fi.Size(), nil
+}
+
+// WriteDatabaseFile writes data at offset of the sql database file of dbName,
+// received from a peer by the snapshot sync. The file is truncated if offset
+// is 0. The database must not be opened.
+func WriteDatabaseFile(dbName string, offset int64, data []byte) error {
+	path, err := dbFilePath(dbName)
+	if err != nil {
+		return err
+	}
+	if _, ok := database.DBs[dbName]; ok {
+		return ErrDBUsed
+	}
+	flag := os.O_WRONLY | os.O_CREATE
+	if offset == 0 {
+		flag |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteAt(data, offset); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// VerifyDatabase checks that the sql database of dbName, received by the
+// snapshot sync, has the commits up to rp, the recovery point of the contract
+// in the state of the snapshot. The commits after rp are truncated.
+func VerifyDatabase(dbName string, rp uint64) error {
+	if _, err := dbFilePath(dbName); err != nil {
+		return err
+	}
+	db, err := conn(dbName)
+	if err != nil {
+		return err
+	}
+	return db.restoreRecoveryPoint(rp)
+}
+
 func SaveRecoveryPoint(bs *state.BlockState) error {
 	for id, db := range database.DBs {
 		if db.tx != nil {
@@ -333,6 +430,7 @@ type Tx interface {
 	SubSavepoint(string) error
 	SubRelease(string) error
 	RollbackToSubSavepoint(string) error
+	DropAll() error
 	GetHandle() *C.sqlite3
 }
 
@@ -411,6 +509,37 @@ func (tx *WritableTx) RollbackToSubSavepoint(name string) error {
 	return err
 }
 
+// DropAll drops all the tables and views created by the contract
+func (tx *WritableTx) DropAll() error {
+	if logger.IsDebugEnabled() {
+		logger.Debug().Str("db_name", tx.db.name).Msg("drop all")
+	}
+	rows, err := tx.Tx.Query("SELECT type, name FROM sqlite_master " +
+		"WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%' AND name != '_dummy'")
+	if err != nil {
+		return err
+	}
+	var objects [][2]string
+	for rows.Next() {
+		var typ, name string
+		if err := rows.Scan(&typ, &name); err != nil {
+			rows.Close()
+			return err
+		}
+		objects = append(objects, [2]string{typ, name})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, o := range objects {
+		if _, err := tx.Tx.Exec("DROP " + o[0] + " IF EXISTS \"" + strings.Replace(o[1], "\"", "\"\"", -1) + "\""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type ReadOnlyTx struct {
 	TxCommon
 }
@@ -459,3 +588,7 @@ func (tx *ReadOnlyTx) SubRelease(name string) error {
 func (tx *ReadOnlyTx) RollbackToSubSavepoint(name string) error {
 	return nil
 }
+
+func (tx *ReadOnlyTx) DropAll() error {
+	return errors.New("only select queries allowed")
+}
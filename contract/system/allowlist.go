@@ -0,0 +1,171 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package system
+
+import (
+	"bytes"
+	"encoding/gob"
+	"sort"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+var allowlistkey = []byte("peerallowlist")
+var allowlistapprovalkey = []byte("peerallowlistapproval")
+
+// The commands of the system txs which change the peer allowlist. The payload
+// is the command followed by the peer ids.
+const (
+	AllowPeerCmd    = 'a'
+	DisallowPeerCmd = 'd'
+)
+
+// IsAllowlistTx returns true if txBody is a system tx which changes the peer allowlist
+func IsAllowlistTx(txBody *types.TxBody) bool {
+	if string(txBody.GetRecipient()) != types.AergoSystem || len(txBody.GetPayload()) == 0 {
+		return false
+	}
+	cmd := txBody.GetPayload()[0]
+	return cmd == AllowPeerCmd || cmd == DisallowPeerCmd
+}
+
+// changeAllowlist approves the change of the peer allowlist in the payload by
+// the sender, and applies the change once it is approved by the quorum of the
+// admins
+func changeAllowlist(txBody *types.TxBody, scs *state.ContractState) error {
+	if err := validateForAllowlist(txBody, scs); err != nil {
+		return err
+	}
+	approvals, err := getAllowlistApprovals(scs, txBody.Payload)
+	if err != nil {
+		return err
+	}
+	approvals = append(approvals, txBody.Account)
+	if len(approvals) < allowlistQuorum() {
+		return setAllowlistApprovals(scs, txBody.Payload, approvals)
+	}
+	if err := scs.DeleteData(allowlistApprovalKey(txBody.Payload)); err != nil {
+		return err
+	}
+
+	allowlist, err := GetPeerAllowlist(scs)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[string]bool, len(allowlist))
+	for _, id := range allowlist {
+		allowed[string(id)] = true
+	}
+	ids := txBody.Payload[1:]
+	for offset := 0; offset < len(ids); offset += PeerIDLength {
+		id := string(ids[offset : offset+PeerIDLength])
+		if txBody.Payload[0] == AllowPeerCmd {
+			allowed[id] = true
+		} else {
+			delete(allowed, id)
+		}
+	}
+
+	sorted := make([]string, 0, len(allowed))
+	for id := range allowed {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	var data bytes.Buffer
+	for _, id := range sorted {
+		data.WriteString(id)
+	}
+	return scs.SetData(allowlistkey, data.Bytes())
+}
+
+// validateForAllowlist checks the peer ids in the payload, and that the sender
+// is an admin of the allowlist who didn't approve the same change yet.
+func validateForAllowlist(txBody *types.TxBody, scs *state.ContractState) error {
+	ids := txBody.Payload[1:]
+	if len(ids) == 0 || len(ids)%PeerIDLength != 0 {
+		return types.ErrTxFormatInvalid
+	}
+	for offset := 0; offset < len(ids); offset += PeerIDLength {
+		if _, err := peer.IDFromBytes(ids[offset : offset+PeerIDLength]); err != nil {
+			return err
+		}
+	}
+	if !isAllowlistAdmin(txBody.Account) {
+		return types.ErrNotAllowlistAdmin
+	}
+	approvals, err := getAllowlistApprovals(scs, txBody.Payload)
+	if err != nil {
+		return err
+	}
+	for _, approval := range approvals {
+		if bytes.Equal(approval, txBody.Account) {
+			return types.ErrAllowlistAlreadyApproved
+		}
+	}
+	return nil
+}
+
+func isAllowlistAdmin(account []byte) bool {
+	for _, admin := range chainParams.AllowlistAdmins {
+		addr, err := types.DecodeAddress(admin)
+		if err == nil && bytes.Equal(addr, account) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowlistQuorum returns the number of the admins who must approve a change
+func allowlistQuorum() int {
+	if chainParams.AllowlistQuorum > 0 {
+		return int(chainParams.AllowlistQuorum)
+	}
+	return len(chainParams.AllowlistAdmins)/2 + 1
+}
+
+// allowlistApprovalKey returns the key of the approvals of the change in payload
+func allowlistApprovalKey(payload []byte) []byte {
+	return append(append([]byte{}, allowlistapprovalkey...), common.Hasher(payload)...)
+}
+
+func getAllowlistApprovals(scs *state.ContractState, payload []byte) ([][]byte, error) {
+	data, err := scs.GetData(allowlistApprovalKey(payload))
+	if err != nil {
+		return nil, err
+	}
+	var approvals [][]byte
+	if len(data) != 0 {
+		dec := gob.NewDecoder(bytes.NewBuffer(data))
+		if err := dec.Decode(&approvals); err != nil {
+			return nil, err
+		}
+	}
+	return approvals, nil
+}
+
+func setAllowlistApprovals(scs *state.ContractState, payload []byte, approvals [][]byte) error {
+	var data bytes.Buffer
+	enc := gob.NewEncoder(&data)
+	if err := enc.Encode(approvals); err != nil {
+		return err
+	}
+	return scs.SetData(allowlistApprovalKey(payload), data.Bytes())
+}
+
+// GetPeerAllowlist returns the peer ids in the on-chain peer allowlist
+func GetPeerAllowlist(scs *state.ContractState) ([][]byte, error) {
+	data, err := scs.GetData(allowlistkey)
+	if err != nil {
+		return nil, err
+	}
+	allowlist := make([][]byte, 0, len(data)/PeerIDLength)
+	for offset := 0; offset+PeerIDLength <= len(data); offset += PeerIDLength {
+		allowlist = append(allowlist, data[offset:offset+PeerIDLength])
+	}
+	return allowlist, nil
+}
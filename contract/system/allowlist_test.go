@@ -0,0 +1,93 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package system
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerAllowlist(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	const testSender = "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	const testPeer1 = "16Uiu2HAmUJhjwotQqm7eGyZh1ZHrVviQJrdm2roQouD329vxZEkx"
+	const testPeer2 = "16Uiu2HAmFqptXPfcdaCdwipB2fhHATgKGVFVPehDAPZsDKSU7jRm"
+	peer1, err := base58.Decode(testPeer1)
+	assert.NoError(t, err, "could not decode peer id")
+	peer2, err := base58.Decode(testPeer2)
+	assert.NoError(t, err, "could not decode peer id")
+
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("aergo.system")))
+	assert.NoError(t, err, "could not open contract state")
+	account, err := types.DecodeAddress(testSender)
+	assert.NoError(t, err, "could not decode test address")
+
+	admin2 := append([]byte{}, account...)
+	admin2[len(admin2)-1]++
+	params := types.DefaultChainParams()
+	params.AllowlistAdmins = []string{testSender, types.EncodeAddress(admin2)}
+	params.AllowlistHeight = 1
+	SetChainParams(params)
+	defer SetChainParams(types.LegacyChainParams())
+
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Payload:   append([]byte{AllowPeerCmd}, append(peer1, peer2...)...),
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	assert.True(t, IsAllowlistTx(tx.GetBody()))
+	// the command is no-op before the activation height
+	err = ExecuteSystemTx(tx.GetBody(), &types.State{}, scs, 0)
+	assert.NoError(t, err, "could not execute no-op system tx")
+	allowlist, err := GetPeerAllowlist(scs)
+	assert.NoError(t, err, "could not get allowlist")
+	assert.Equal(t, 0, len(allowlist))
+
+	otherTx := &types.TxBody{Account: admin2[:len(admin2)-1], Recipient: []byte(types.AergoSystem), Payload: tx.Body.Payload}
+	err = ValidateSystemTx(otherTx, scs, 1)
+	assert.EqualError(t, types.ErrNotAllowlistAdmin, err.Error(), "only the admins can change allowlist")
+
+	// the change is applied when the majority of the admins approve it
+	err = ExecuteSystemTx(tx.GetBody(), &types.State{}, scs, 1)
+	assert.NoError(t, err, "could not approve allowing peers")
+	allowlist, err = GetPeerAllowlist(scs)
+	assert.NoError(t, err, "could not get allowlist")
+	assert.Equal(t, 0, len(allowlist))
+	err = ValidateSystemTx(tx.GetBody(), scs, 1)
+	assert.EqualError(t, types.ErrAllowlistAlreadyApproved, err.Error(), "an admin approves a change once")
+
+	tx2 := &types.TxBody{Account: admin2, Recipient: []byte(types.AergoSystem), Payload: tx.Body.Payload}
+	err = ExecuteSystemTx(tx2, &types.State{}, scs, 2)
+	assert.NoError(t, err, "could not allow peers")
+	allowlist, err = GetPeerAllowlist(scs)
+	assert.NoError(t, err, "could not get allowlist")
+	assert.Equal(t, 2, len(allowlist))
+
+	// the approvals are cleared after the change is applied
+	err = ValidateSystemTx(tx.GetBody(), scs, 3)
+	assert.NoError(t, err, "could not approve the change again")
+
+	tx.Body.Payload = append([]byte{DisallowPeerCmd}, peer1...)
+	tx2.Payload = tx.Body.Payload
+	err = ExecuteSystemTx(tx.GetBody(), &types.State{}, scs, 3)
+	assert.NoError(t, err, "could not approve disallowing peer")
+	err = ExecuteSystemTx(tx2, &types.State{}, scs, 4)
+	assert.NoError(t, err, "could not disallow peer")
+	allowlist, err = GetPeerAllowlist(scs)
+	assert.NoError(t, err, "could not get allowlist")
+	assert.Equal(t, [][]byte{peer2}, allowlist)
+
+	tx.Body.Payload = append([]byte{AllowPeerCmd}, peer1[1:]...)
+	err = ValidateSystemTx(tx.GetBody(), scs, 5)
+	assert.EqualError(t, types.ErrTxFormatInvalid, err.Error(), "invalid peer id length")
+	assert.False(t, IsAllowlistTx(&types.TxBody{Account: account, Amount: 1000, Payload: []byte{'s'}}))
+}
@@ -26,6 +26,11 @@ func ExecuteSystemTx(txBody *types.TxBody, senderState *types.State,
 		err = voting(txBody, scs, blockNo)
 	case 'u':
 		err = unstaking(txBody, senderState, scs, blockNo)
+	case AllowPeerCmd, DisallowPeerCmd:
+		// they are no-op commands before the activation height
+		if chainParams.AllowlistAt(blockNo) {
+			err = changeAllowlist(txBody, scs)
+		}
 	}
 	if err != nil {
 		return err
@@ -62,6 +67,10 @@ func ValidateSystemTx(txBody *types.TxBody, scs *state.ContractState, blockNo ui
 		}
 	case 'u':
 		_, err = validateForUnstaking(txBody, scs, blockNo)
+	case AllowPeerCmd, DisallowPeerCmd:
+		if chainParams.AllowlistAt(blockNo) {
+			err = validateForAllowlist(txBody, scs)
+		}
 	}
 	if err != nil {
 		return err
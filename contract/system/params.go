@@ -0,0 +1,16 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package system
+
+import "github.com/aergoio/aergo/types"
+
+// chainParams are the consensus parameters of the chain the system txs run on.
+// No rule is activated until the chain service sets them from its genesis.
+var chainParams = types.LegacyChainParams()
+
+// SetChainParams sets the consensus parameters of the chain
+func SetChainParams(p *types.ChainParams) {
+	chainParams = p
+}
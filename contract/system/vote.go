@@ -76,7 +76,7 @@ func voting(txBody *types.TxBody, scs *state.ContractState, blockNo types.BlockN
 		}
 	}
 
-	err = syncVoteResult(scs, voteResult)
+	err = syncVoteResult(scs, voteResult, chainParams.ElectionAt(blockNo))
 	if err != nil {
 		return err
 	}
@@ -137,14 +137,18 @@ func loadVoteResult(scs *state.ContractState) (*map[string]uint64, error) {
 	return &voteResult, nil
 }
 
-func InitVoteResult(scs *state.ContractState, voteResult *map[string]uint64) error {
+// InitVoteResult puts the initial vote result of the genesis block whose
+// parameters are params.
+func InitVoteResult(scs *state.ContractState, voteResult *map[string]uint64, params *types.ChainParams) error {
 	if voteResult == nil {
 		return errors.New("Invalid argument : voteReult should not nil")
 	}
-	return syncVoteResult(scs, voteResult)
+	return syncVoteResult(scs, voteResult, params.ElectionAt(0))
 }
 
-func syncVoteResult(scs *state.ContractState, voteResult *map[string]uint64) error {
+// syncVoteResult puts the vote result sorted by amount. If ordered is set, the
+// candidates of the same amount are sorted by their IDs.
+func syncVoteResult(scs *state.ContractState, voteResult *map[string]uint64, ordered bool) error {
 	var voteList types.VoteList
 	for k, v := range *voteResult {
 		c, _ := base58.Decode(k)
@@ -154,7 +158,11 @@ func syncVoteResult(scs *state.ContractState, voteResult *map[string]uint64) err
 		}
 		voteList.Votes = append(voteList.Votes, vote)
 	}
-	sort.Sort(sort.Reverse(voteList))
+	if ordered {
+		sort.Sort(sort.Reverse(types.OrderedVoteList{VoteList: voteList}))
+	} else {
+		sort.Sort(sort.Reverse(voteList))
+	}
 	//logger.Info().Msgf("VOTE set list %v", voteList.Votes)
 	var data bytes.Buffer
 	enc := gob.NewEncoder(&data)
@@ -165,16 +173,39 @@ func syncVoteResult(scs *state.ContractState, voteResult *map[string]uint64) err
 	return scs.SetData(sortedlistkey, data.Bytes())
 }
 
+// GetVoteResult returns the top n candidates sorted by the amount of votes.
+// If n is 0, all the candidates are returned.
 func GetVoteResult(scs *state.ContractState, n int) (*types.VoteList, error) {
 	data, err := scs.GetData(sortedlistkey)
 	if err != nil {
 		return nil, err
 	}
-	dec := gob.NewDecoder(bytes.NewBuffer(data))
-	var voteList types.VoteList
-	err = dec.Decode(&voteList)
+	voteList, err := DecodeVoteResult(data)
 	if err != nil {
 		return nil, err
 	}
+	if n > 0 && len(voteList.Votes) > n {
+		voteList.Votes = voteList.Votes[:n]
+	}
+	return voteList, nil
+}
+
+// VoteResultKey returns the key of the vote result in the storage trie of the
+// system contract.
+func VoteResultKey() []byte {
+	id := types.GetHashID(sortedlistkey)
+	return id[:]
+}
+
+// DecodeVoteResult decodes the vote result stored in the system contract.
+func DecodeVoteResult(data []byte) (*types.VoteList, error) {
+	var voteList types.VoteList
+	if len(data) == 0 {
+		return &voteList, nil
+	}
+	dec := gob.NewDecoder(bytes.NewBuffer(data))
+	if err := dec.Decode(&voteList); err != nil {
+		return nil, err
+	}
 	return &voteList, nil
 }
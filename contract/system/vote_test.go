@@ -47,9 +47,9 @@ func TestVoteResult(t *testing.T) {
 		to := fmt.Sprintf("%39d", i) //39:peer id length
 		(*testResult)[base58.Encode([]byte(to))] = uint64(i * i)
 	}
-	err = InitVoteResult(scs, nil)
+	err = InitVoteResult(scs, nil, types.DefaultChainParams())
 	assert.NotNil(t, err, "argument should not nil")
-	err = InitVoteResult(scs, testResult)
+	err = InitVoteResult(scs, testResult, types.DefaultChainParams())
 	assert.NoError(t, err, "failed to InitVoteResult")
 
 	const getTestSize = 23
@@ -65,6 +65,27 @@ func TestVoteResult(t *testing.T) {
 	}
 }
 
+func TestVoteResultOrdered(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	scs, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("testOrderedVoteResult")))
+	assert.NoError(t, err, "could not open contract state")
+	testResult := &map[string]uint64{}
+	for i := 0; i < 16; i++ {
+		to := fmt.Sprintf("%39d", i)
+		(*testResult)[base58.Encode([]byte(to))] = 100
+	}
+	err = InitVoteResult(scs, testResult, types.DefaultChainParams())
+	assert.NoError(t, err, "failed to InitVoteResult")
+
+	// the candidates of the same amount are sorted by their IDs
+	result, err := GetVoteResult(scs, 0)
+	assert.NoError(t, err, "could not get vote result")
+	for i, v := range result.Votes {
+		assert.Equal(t, []byte(fmt.Sprintf("%39d", 15-i)), v.Candidate)
+	}
+}
+
 func TestVoteData(t *testing.T) {
 	const testSize = 64
 	initTest(t)
@@ -11,6 +11,7 @@ static int systemPrint(lua_State *L)
     char *jsonValue;
 	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
 
+	vm_use_gas(L, GAS_PRINT);
     jsonValue = lua_util_get_json_from_stack (L, 1, lua_gettop(L), true);
     if (jsonValue == NULL) {
 		lua_error(L);
@@ -25,6 +26,7 @@ int setItem(lua_State *L)
 	const char *key;
 	char *jsonValue;
 	char *dbKey;
+	size_t nbytes;
 	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
 
 	if (exec == NULL) {
@@ -49,8 +51,10 @@ int setItem(lua_State *L)
 		free(dbKey);
 		lua_error(L);
 	}
+	nbytes = strlen(dbKey) + strlen(jsonValue);
 	free(jsonValue);
 	free(dbKey);
+	vm_use_gas(L, GAS_STATE_SET + GAS_STATE_BYTE * nbytes);
 
 	return 0;
 }
@@ -67,6 +71,7 @@ int getItem(lua_State *L)
 		luaL_error(L, "cannot find execution context");
 	}
 	key = luaL_checkstring(L, 1);
+	vm_use_gas(L, GAS_STATE_GET);
 	dbKey = lua_util_get_db_key(exec, key);
 
 	ret = LuaGetDB(L, exec->stateKey, dbKey);
@@ -92,6 +97,7 @@ static int getSender(lua_State *L)
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
 	}
+	vm_use_gas(L, GAS_SYSTEM_CALL);
 	lua_pushstring(L, exec->sender);
 	return 1;
 }
@@ -102,6 +108,7 @@ static int getTxhash(lua_State *L)
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
 	}
+	vm_use_gas(L, GAS_SYSTEM_CALL);
 	lua_pushstring(L, exec->txHash);
 	return 1;
 }
@@ -112,6 +119,7 @@ static int getBlockHeight(lua_State *L)
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
 	}
+	vm_use_gas(L, GAS_SYSTEM_CALL);
 	lua_pushinteger(L, exec->blockHeight);
 	return 1;
 }
@@ -122,6 +130,7 @@ static int getTimestamp(lua_State *L)
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
 	}
+	vm_use_gas(L, GAS_SYSTEM_CALL);
 	lua_pushinteger(L, exec->timestamp);
 	return 1;
 }
@@ -132,6 +141,7 @@ static int getContractID(lua_State *L)
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
 	}
+	vm_use_gas(L, GAS_SYSTEM_CALL);
 	lua_pushstring(L, exec->contractId);
 	return 1;
 }
@@ -144,6 +154,7 @@ static int getCreator(lua_State *L)
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
 	}
+	vm_use_gas(L, GAS_STATE_GET);
 	ret = LuaGetDB(L, exec->stateKey, "Creator");
 	if (ret < 0) {
 		lua_error(L);
@@ -161,6 +172,7 @@ static int getAmount(lua_State *L)
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
 	}
+	vm_use_gas(L, GAS_SYSTEM_CALL);
 	lua_pushinteger(L, exec->amount);
 	return 1;
 }
@@ -172,6 +184,7 @@ static int getOrigin(lua_State *L)
 	if (exec == NULL) {
 		luaL_error(L, "cannot find execution context");
 	}
+	vm_use_gas(L, GAS_SYSTEM_CALL);
 	lua_pushstring(L, exec->origin);
 	return 1;
 }
@@ -0,0 +1,176 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package contract
+
+/*
+#include "vm.h"
+*/
+import "C"
+import (
+	"encoding/json"
+
+	"github.com/aergoio/aergo/types"
+)
+
+// The kinds of the call frames recorded by a Tracer
+const (
+	FrameCall         = "call"
+	FrameDelegateCall = "delegatecall"
+	FrameCreate       = "create"
+	FrameQuery        = "query"
+)
+
+// The operations of the steps recorded by a Tracer
+const (
+	StepStateGet = "state.get"
+	StepStateSet = "state.set"
+	StepSqlExec  = "sql.exec"
+	StepSqlQuery = "sql.query"
+	StepSend     = "send"
+	StepDestroy  = "destroy"
+)
+
+// Tracer records the execution of a contract call: the nested call frames, the
+// reads and the writes of the state module and the executed sql statements.
+// A nil Tracer records nothing.
+type Tracer struct {
+	gas        *gasMeter
+	root       *types.CallFrame
+	stack      []*types.CallFrame
+	start      []uint64
+	sqlWritten bool
+}
+
+func NewTracer() *Tracer {
+	return &Tracer{}
+}
+
+// Trace returns the frame of the outermost call, or nil if nothing is traced
+func (t *Tracer) Trace() *types.CallFrame {
+	if t == nil {
+		return nil
+	}
+	return t.root
+}
+
+// SqlWritten reports whether the traced txs, which are replayed, skipped any
+// sql statement changing the database
+func (t *Tracer) SqlWritten() bool {
+	return t != nil && t.sqlWritten
+}
+
+// GasUsed returns the gas used so far by the traced tx
+func (t *Tracer) GasUsed() uint64 {
+	if t == nil || t.gas == nil {
+		return 0
+	}
+	return t.gas.used
+}
+
+// enter pushes a frame of a call. A nested frame is recorded as a step of the
+// frame of its caller.
+func (t *Tracer) enter(kind, caller, callee, function, args string, amount uint64) {
+	if t == nil {
+		return
+	}
+	frame := &types.CallFrame{
+		Kind:     kind,
+		Caller:   caller,
+		Callee:   callee,
+		Function: function,
+		Args:     args,
+		Amount:   amount,
+	}
+	if n := len(t.stack); n > 0 {
+		parent := t.stack[n-1]
+		parent.Steps = append(parent.Steps, &types.TraceStep{Op: kind, Call: frame})
+	} else {
+		t.root = frame
+	}
+	t.stack = append(t.stack, frame)
+	t.start = append(t.start, t.GasUsed())
+}
+
+// exit pops the frame of the current call with its result
+func (t *Tracer) exit(result string, err error) {
+	if t == nil || len(t.stack) == 0 {
+		return
+	}
+	n := len(t.stack) - 1
+	frame := t.stack[n]
+	frame.GasUsed = t.GasUsed() - t.start[n]
+	if err != nil {
+		frame.Error = err.Error()
+	} else {
+		frame.Result = result
+	}
+	t.stack = t.stack[:n]
+	t.start = t.start[:n]
+}
+
+// step records an operation of the current call
+func (t *Tracer) step(op, key, value string) {
+	if t == nil || len(t.stack) == 0 {
+		return
+	}
+	frame := t.stack[len(t.stack)-1]
+	frame.Steps = append(frame.Steps, &types.TraceStep{Op: op, Key: key, Value: value})
+}
+
+func argsJSON(args []interface{}) string {
+	if args == nil {
+		return "[]"
+	}
+	b, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// setTracer records the execution of the context and its nested calls with t
+func (bcCtx *LBlockchainCtx) setTracer(t *Tracer) {
+	if t == nil {
+		return
+	}
+	stateSet := contractMap.lookup(C.GoString(bcCtx.stateKey))
+	if stateSet == nil {
+		return
+	}
+	t.gas = stateSet.rootState.gas
+	stateSet.rootState.tracer = t
+	bcCtx.trace = C.int(1)
+}
+
+// tracer returns the tracer of the context, or nil if it is not traced
+func (bcCtx *LBlockchainCtx) tracer() *Tracer {
+	if bcCtx.trace == C.int(0) {
+		return nil
+	}
+	stateSet := contractMap.lookup(C.GoString(bcCtx.stateKey))
+	if stateSet == nil {
+		return nil
+	}
+	return stateSet.rootState.tracer
+}
+
+//export LuaTraceSql
+func LuaTraceSql(stateKey *C.char, op *C.char, sql *C.char) {
+	stateSet := contractMap.lookup(C.GoString(stateKey))
+	if stateSet == nil {
+		return
+	}
+	stateSet.rootState.tracer.step(C.GoString(op), "", C.GoString(sql))
+}
+
+//export LuaTraceSqlWrite
+func LuaTraceSqlWrite(stateKey *C.char) {
+	stateSet := contractMap.lookup(C.GoString(stateKey))
+	if stateSet == nil || stateSet.rootState.tracer == nil {
+		return
+	}
+	stateSet.rootState.tracer.sqlWritten = true
+}
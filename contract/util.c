@@ -4,6 +4,7 @@
 #include <ctype.h>
 #include "util.h"
 #include "vm.h"
+#include "bignum_module.h"
 #include "math.h"
 
 typedef struct tcall {
@@ -245,6 +246,20 @@ static bool lua_util_dump_json (lua_State *L, int idx, sbuff_t *sbuf, bool json_
 		unregister_tcall(callinfo);
 		break;
 	}
+	case LUA_TUSERDATA:
+		if (lua_bignum_is(L, idx)) {
+			src_val = (char *)lua_bignum_tostr(L, idx);
+			if (iskey) {
+				copy_to_buffer ("\"", 1, sbuf);
+			} else {
+				copy_to_buffer ("{\"" BIGNUM_JSON_KEY "\":\"", strlen(BIGNUM_JSON_KEY) + 5, sbuf);
+			}
+			copy_to_buffer (src_val, strlen (src_val), sbuf);
+			src_val = iskey ? "\"," : "\"},";
+			break;
+		}
+		lua_pushfstring(L, "unsupport type: %s", lua_typename (L, lua_type(L, idx)));
+		return false;
 	default:
 		lua_pushfstring(L, "unsupport type: %s", lua_typename (L, lua_type(L, idx)));
 		return false;
@@ -276,6 +291,33 @@ static int json_array_to_lua_table(lua_State *L, char **start, bool check) {
 	return 0;
 }
 
+/* replace the table of {"_bignum":"<decimal>"} on the top with the bignum */
+static int json_table_to_bignum(lua_State *L)
+{
+	int n = 0;
+
+	lua_getfield(L, -1, BIGNUM_JSON_KEY);
+	if (lua_type(L, -1) != LUA_TSTRING) {
+		lua_pop(L, 1);
+		return 0;
+	}
+	lua_pushnil(L);
+	while (lua_next(L, -3) != 0) {
+		lua_pop(L, 1);
+		n++;
+	}
+	if (n != 1) {
+		lua_pop(L, 1);
+		return 0;
+	}
+	if (lua_bignum_push(L, lua_tostring(L, -1)) != 0) {
+		return -1;
+	}
+	lua_replace(L, -3);
+	lua_pop(L, 1);
+	return 0;
+}
+
 static int json_to_lua_table(lua_State *L, char **start, bool check) {
 	char *json = (*start) + 1;
 	int index = 1;
@@ -301,6 +343,8 @@ static int json_to_lua_table(lua_State *L, char **start, bool check) {
 		    return -1;
 		lua_rawset(L, -3);
 	}
+	if (json_table_to_bignum(L) != 0)
+		return -1;
 	*start = json + 1;
 	return 0;
 }
@@ -5,7 +5,11 @@
 #include "contract_module.h"
 #include "db_module.h"
 #include "state_module.h"
+#include "crypto_module.h"
+#include "bignum_module.h"
 #include "util.h"
+#include "sandbox.h"
+#include "abi_types.h"
 #include "_cgo_export.h"
 
 const char *luaExecContext= "__exec_context__";
@@ -17,6 +21,8 @@ static void preloadModules(lua_State *L)
     luaopen_db(L);
 	luaopen_state(L);
 	luaopen_json(L);
+	luaopen_crypto(L);
+	luaopen_bignum(L);
 }
 
 static void setLuaExecContext(lua_State *L, bc_ctx_t *bc_ctx)
@@ -46,16 +52,133 @@ void bc_ctx_delete(bc_ctx_t *bc_ctx) {
 	free(bc_ctx->node);
 }
 
+/*
+ * the allocator of a lua state, which counts the memory used by a tx. used is
+ * the change of the memory of the state since it was attached to the limits.
+ */
+typedef struct vm_alloc {
+	lua_Alloc allocf;
+	void *ud;
+	vm_limits_t *limits;
+	long long used;
+} vm_alloc_t;
+
+/*
+ * the memory charged to a tx when the memory of a state changes by delta. The
+ * memory freed below the baseline of the state is not credited to the tx, so
+ * freeing the objects allocated before the call gives no room to the others.
+ */
+static long long vm_mem_charge(long long used, long long delta)
+{
+	long long prev = used > 0 ? used : 0;
+
+	used += delta;
+	return (used > 0 ? used : 0) - prev;
+}
+
+static void *vm_alloc(void *ud, void *ptr, size_t osize, size_t nsize)
+{
+	vm_alloc_t *alloc = (vm_alloc_t *)ud;
+	vm_limits_t *limits = alloc->limits;
+	long long delta;
+	void *p;
+
+	if (ptr == NULL)
+		osize = 0;
+	delta = (long long)nsize - (long long)osize;
+	/* shrinking a block must not fail */
+	if (limits != NULL && limits->memLimit > 0 && delta > 0 &&
+		limits->memUsed + vm_mem_charge(alloc->used, delta) > limits->memLimit) {
+		if (limits->exceeded == 0)
+			limits->exceeded = VM_LIMIT_MEMORY;
+		return NULL;
+	}
+	p = alloc->allocf(alloc->ud, ptr, osize, nsize);
+	if (limits != NULL && (p != NULL || nsize == 0)) {
+		limits->memUsed += vm_mem_charge(alloc->used, delta);
+		alloc->used += delta;
+	}
+	return p;
+}
+
 lua_State *vm_newstate()
 {
+	vm_alloc_t *alloc;
 	lua_State *L = luaL_newstate();
 	if (L == NULL)
 		return NULL;
+	alloc = (vm_alloc_t *)malloc(sizeof(vm_alloc_t));
+	if (alloc == NULL) {
+		lua_close(L);
+		return NULL;
+	}
+	alloc->allocf = lua_getallocf(L, &alloc->ud);
+	alloc->limits = NULL;
+	alloc->used = 0;
+	lua_setallocf(L, vm_alloc, alloc);
 	luaL_openlibs(L);
 	preloadModules(L);
+	abi_types_open(L);
 	return L;
 }
 
+/* applied to a new state before the code of a contract is loaded */
+void vm_sandbox_open(lua_State *L)
+{
+	sandbox_open(L);
+}
+
+void vm_closestate(lua_State *L)
+{
+	void *alloc;
+
+	lua_getallocf(L, &alloc);
+	lua_close(L);
+	free(alloc);
+}
+
+vm_limits_t *vm_limits_new(unsigned long long instLimit, long long memLimit)
+{
+	vm_limits_t *limits = (vm_limits_t *)calloc(1, sizeof(vm_limits_t));
+
+	if (limits == NULL)
+		return NULL;
+	limits->instLimit = instLimit;
+	limits->memLimit = memLimit;
+	return limits;
+}
+
+/*
+ * attach the state to the limits of a tx, or detach it with NULL. The garbage
+ * of the state is collected before it is attached, so its memory is counted
+ * from the same baseline whether the state was preloaded or not. A state
+ * already attached to the limits is kept as it is, so the memory allocated by
+ * its previous calls in the tx is not counted again from zero.
+ */
+void vm_set_limits(lua_State *L, vm_limits_t *limits)
+{
+	vm_alloc_t *alloc;
+
+	lua_getallocf(L, (void **)&alloc);
+	if (alloc->limits == limits)
+		return;
+	alloc->limits = NULL;
+	if (limits != NULL) {
+		lua_gc(L, LUA_GCCOLLECT, 0);
+		alloc->used = 0;
+	}
+	alloc->limits = limits;
+}
+
+int vm_limit_exceeded(lua_State *L)
+{
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+
+	if (exec == NULL || exec->limits == NULL)
+		return 0;
+	return exec->limits->exceeded;
+}
+
 const char *vm_loadbuff(lua_State *L, const char *code, size_t sz, bc_ctx_t *bc_ctx)
 {
 	int err;
@@ -94,8 +217,29 @@ void vm_remove_construct(lua_State *L, const char *construct_name)
 
 void count_hook(lua_State *L, lua_Debug *ar)
 {
-	lua_pushstring(L, "exceeded the maximum instruction count");
-	lua_error(L);
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+	vm_limits_t *limits;
+
+	if (exec == NULL) {
+		luaL_error(L, "cannot find execution context");
+	}
+	limits = exec->limits;
+	if (limits != NULL && !limits->legacyInst) {
+		limits->instCount += GAS_INSTRUCTION_STEP;
+		if (limits->instLimit > 0 && limits->instCount >= limits->instLimit) {
+			if (limits->exceeded == 0)
+				limits->exceeded = VM_LIMIT_INSTRUCTION;
+			lua_pushstring(L, "exceeded the maximum instruction count");
+			lua_error(L);
+		}
+	} else {
+		exec->instCount += GAS_INSTRUCTION_STEP;
+		if (exec->instCount >= MAX_INSTRUCTION_COUNT) {
+			lua_pushstring(L, "exceeded the maximum instruction count");
+			lua_error(L);
+		}
+	}
+	vm_use_gas(L, GAS_INSTRUCTION_STEP * GAS_INSTRUCTION);
 }
 
 const char *vm_pcall(lua_State *L, int argc, int *nresult)
@@ -103,8 +247,12 @@ const char *vm_pcall(lua_State *L, int argc, int *nresult)
 	int err;
 	const char *errMsg = NULL;
 	int nr = lua_gettop(L) - argc - 1;
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
 
-	lua_sethook (L, count_hook, LUA_MASKCOUNT, 500000);
+	/* the memory of the state is counted from its first call in the tx */
+	if (exec != NULL)
+		vm_set_limits(L, exec->limits);
+	lua_sethook (L, count_hook, LUA_MASKCOUNT, GAS_INSTRUCTION_STEP);
 
 	err = lua_pcall(L, argc, LUA_MULTRET, 0);
 	if (err != 0) {
@@ -157,7 +305,9 @@ sqlite3 *vm_get_db(lua_State *L)
     sqlite3 *db;
 
     ctx = (bc_ctx_t *)getLuaExecContext(L);
-    db = LuaGetDbHandle(ctx->stateKey, ctx->contractId, ctx->rp, ctx->isQuery);
+    /* a replayed tx reads the snapshot of the database at the recovery point */
+    db = LuaGetDbHandle(ctx->stateKey, ctx->contractId, ctx->rp,
+                        ctx->isQuery || ctx->service == VM_SERVICE_REPLAY);
     if (db == NULL) {
         ctx->dbSystemError = 1;
         lua_pushstring(L, "can't open a database connection");
@@ -165,3 +315,54 @@ sqlite3 *vm_get_db(lua_State *L)
     }
     return db;
 }
+
+void vm_use_gas(lua_State *L, unsigned long long gas)
+{
+	bc_ctx_t *exec;
+
+	/* metering starts when vm_pcall installs the count hook; the chunk
+	 * loaded by vm_loadbuff is not charged */
+	if (lua_gethook(L) == NULL)
+		return;
+	exec = (bc_ctx_t *)getLuaExecContext(L);
+	if (exec == NULL || exec->stateKey == NULL)
+		return;
+	if (LuaUseGas(L, exec->stateKey, gas) < 0)
+		lua_error(L);
+}
+
+int vm_is_out_of_gas(lua_State *L)
+{
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+
+	if (exec == NULL || exec->stateKey == NULL)
+		return 0;
+	return LuaIsOutOfGas(exec->stateKey);
+}
+
+int vm_is_replay(lua_State *L)
+{
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+
+	return exec != NULL && exec->service == VM_SERVICE_REPLAY;
+}
+
+/* record a sql statement in the trace of the tx */
+void vm_trace_sql(lua_State *L, const char *op, const char *sql)
+{
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+
+	if (exec == NULL || exec->stateKey == NULL || !exec->trace)
+		return;
+	LuaTraceSql(exec->stateKey, (char *)op, (char *)sql);
+}
+
+/* record that a replayed tx skipped a sql statement changing the database */
+void vm_trace_sql_write(lua_State *L)
+{
+	bc_ctx_t *exec = (bc_ctx_t *)getLuaExecContext(L);
+
+	if (exec == NULL || exec->stateKey == NULL || !exec->trace)
+		return;
+	LuaTraceSqlWrite(exec->stateKey);
+}
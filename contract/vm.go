@@ -12,6 +12,7 @@ package contract
 #include <stdlib.h>
 #include <string.h>
 #include "vm.h"
+#include "bignum_module.h"
 */
 import "C"
 import (
@@ -19,11 +20,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
+	"strconv"
 	"sync"
 	"unsafe"
 
 	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/internal/common"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 )
@@ -39,6 +43,7 @@ var (
 type Contract struct {
 	code    []byte
 	address []byte
+	abi     []byte
 }
 
 type CallState struct {
@@ -46,6 +51,7 @@ type CallState struct {
 	prevState *types.State
 	curState  *types.State
 	tx        Tx
+	destroyed bool
 }
 
 type StateSet struct {
@@ -54,6 +60,11 @@ type StateSet struct {
 	callState         map[string]*CallState
 	rootState         *StateSet
 	lastRecoveryEntry *recoveryEntry
+	gas               *gasMeter
+	events            []*types.Event
+	limits            *C.vm_limits_t
+	tracer            *Tracer
+	callStack         []string
 	refCnt            uint
 }
 
@@ -69,7 +80,9 @@ type recoveryEntry struct {
 	callState     *CallState
 	sqlSaveName   *string
 	stateRevision state.Snapshot
+	eventCount    int
 	prev          *recoveryEntry
+	undo          func()
 }
 
 type LState = C.struct_lua_State
@@ -90,24 +103,31 @@ func init() {
 }
 
 func registerMap(bcCtx *LBlockchainCtx, blockState *state.BlockState, senderState *types.State,
-	contractState *state.ContractState, root *StateSet) {
+	contractState *state.ContractState, root *StateSet, gas *gasMeter) {
 	contractId := C.GoString(bcCtx.contractId)
 	sender := C.GoString(bcCtx.sender)
 	stateKey := C.GoString(bcCtx.stateKey)
-	stateSet := &StateSet{contract: contractState, bs: blockState, rootState: root}
+	stateSet := &StateSet{contract: contractState, bs: blockState, rootState: root, gas: gas}
 	if root == nil {
 		stateSet.callState = make(map[string]*CallState)
 		stateSet.callState[contractId] = &CallState{ctrState: contractState, curState: contractState.State}
 		stateSet.callState[sender] = &CallState{curState: senderState}
 		stateSet.rootState = stateSet
+		stateSet.limits = newVMLimits(gas, uint64(bcCtx.blockHeight))
+		stateSet.callStack = []string{contractId}
+	}
+	stateSet = contractMap.register(stateKey, stateSet)
+	bcCtx.limits = stateSet.rootState.limits
+	if stateSet.rootState.tracer != nil {
+		bcCtx.trace = C.int(1)
 	}
-	contractMap.register(stateKey, stateSet)
 }
 
 func NewContext(blockState *state.BlockState, senderState *types.State,
 	contractState *state.ContractState, Sender string,
 	txHash string, blockHeight uint64, timestamp int64, node string, confirmed int,
-	contractId string, query int, root *StateSet, rp uint64, service int, amount uint64) *LBlockchainCtx {
+	contractId string, query int, root *StateSet, rp uint64, service int, amount uint64,
+	gas *gasMeter) *LBlockchainCtx {
 
 	stateKey := fmt.Sprintf("%d%s%s", service, contractId, txHash)
 
@@ -126,7 +146,7 @@ func NewContext(blockState *state.BlockState, senderState *types.State,
 		amount:      C.ulonglong(amount),
 	}
 	bcCtx.origin = bcCtx.sender
-	registerMap(bcCtx, blockState, senderState, contractState, root)
+	registerMap(bcCtx, blockState, senderState, contractState, root, gas)
 
 	return bcCtx
 }
@@ -151,7 +171,7 @@ func NewLState() *LState {
 
 func (L *LState) Close() {
 	if L != nil {
-		C.lua_close(L)
+		C.vm_closestate(L)
 	}
 }
 
@@ -166,6 +186,9 @@ func newExecutor(contract *Contract, bcCtx *LBlockchainCtx) *Executor {
 		ce.err = types.ErrVmStart
 		return ce
 	}
+	if chainParams.SandboxAt(uint64(bcCtx.blockHeight)) {
+		C.vm_sandbox_open(ce.L)
+	}
 	if cErrMsg := C.vm_loadbuff(
 		ce.L,
 		(*C.char)(unsafe.Pointer(&contract.code[0])),
@@ -212,6 +235,9 @@ func pushValue(L *LState, v interface{}) error {
 	case []interface{}:
 		toLuaArray(L, arg)
 	case map[string]interface{}:
+		if n, ok := bignumValue(arg); ok {
+			return pushBignum(L, n)
+		}
 		toLuaTable(L, arg)
 	default:
 		return errors.New("unsupported type:" + reflect.TypeOf(v).Name())
@@ -219,6 +245,28 @@ func pushValue(L *LState, v interface{}) error {
 	return nil
 }
 
+// bignumValue returns the decimal string of a bignum in JSON,
+// {"_bignum":"<decimal>"}
+func bignumValue(m map[string]interface{}) (string, bool) {
+	if len(m) != 1 {
+		return "", false
+	}
+	n, ok := m[types.BignumJSONKey].(string)
+	return n, ok
+}
+
+func pushBignum(L *LState, n string) error {
+	if _, err := parseBignum(n); err != nil {
+		return err
+	}
+	cStr := C.CString(n)
+	defer C.free(unsafe.Pointer(cStr))
+	if C.lua_bignum_push(L, cStr) != 0 {
+		return errors.New("bignum: invalid number '" + n + "'")
+	}
+	return nil
+}
+
 func toLuaArray(L *LState, arr []interface{}) error {
 	C.lua_createtable(L, C.int(len(arr)), C.int(0))
 	n := C.lua_gettop(L)
@@ -260,6 +308,16 @@ func (ce *Executor) call(ci *types.CallInfo, target *LState) C.int {
 	defer C.free(unsafe.Pointer(callStr))
 	defer C.free(unsafe.Pointer(abiName))
 
+	// the arguments are checked before the execution not to charge for a bad call
+	fn := ce.contract.function(ci.Name)
+	if fn != nil {
+		if err := fn.CheckArgs(ci.Args); err != nil {
+			ce.err = err
+			return 0
+		}
+		toBignumArgs(fn, ci.Args)
+	}
+
 	C.vm_getfield(ce.L, abiStr)
 	C.lua_getfield(ce.L, -1, callStr)
 	C.lua_pushstring(ce.L, abiName)
@@ -270,7 +328,9 @@ func (ce *Executor) call(ci *types.CallInfo, target *LState) C.int {
 		errMsg := C.GoString(cErrMsg)
 		C.free(unsafe.Pointer(cErrMsg))
 		ctrLog.Warn().Str("error", errMsg).Msgf("contract %s", types.EncodeAddress(ce.contract.address))
-		if ce.blockchainCtx.transferFailed == C.int(1) {
+		if err := limitError(ce.blockchainCtx.limits); err != nil {
+			ce.err = err
+		} else if ce.blockchainCtx.transferFailed == C.int(1) {
 			ce.err = types.ErrInsufficientBalance
 		} else if ce.blockchainCtx.dbSystemError == C.int(1) {
 			ce.err = newDbSystemError(errMsg)
@@ -282,15 +342,59 @@ func (ce *Executor) call(ci *types.CallInfo, target *LState) C.int {
 
 	if target == nil {
 		ce.jsonRet = C.GoString(C.vm_get_json_ret(ce.L, nret))
+		if fn != nil {
+			if err := checkReturns(fn, ce.jsonRet, int(nret)); err != nil {
+				ce.err = err
+			}
+		}
 	} else {
 		if cErrMsg := C.vm_copy_result(ce.L, target, nret); cErrMsg != nil {
 			errMsg := C.GoString(cErrMsg)
 			ce.err = errors.New(errMsg)
+		} else if ce.blockchainCtx.trace == C.int(1) {
+			// the result is kept only for the trace
+			ce.jsonRet = C.GoString(C.vm_get_json_ret(ce.L, nret))
 		}
 	}
 	return nret
 }
 
+// toBignumArgs converts the arguments of the bignum type to bignums in JSON.
+// The numbers are exact, since CheckArgs rejects those beyond 2^53.
+func toBignumArgs(fn *types.Function, args []interface{}) {
+	for i, arg := range fn.Arguments {
+		if arg.Type != types.AbiBignum || i >= len(args) {
+			continue
+		}
+		switch v := args[i].(type) {
+		case string:
+			args[i] = map[string]interface{}{types.BignumJSONKey: v}
+		case float64:
+			args[i] = map[string]interface{}{types.BignumJSONKey: strconv.FormatFloat(v, 'f', 0, 64)}
+		}
+	}
+}
+
+func checkReturns(fn *types.Function, jsonRet string, nret int) error {
+	if len(fn.Returns) == 0 {
+		return nil
+	}
+	var rets []interface{}
+	switch {
+	case nret == 1:
+		var ret interface{}
+		if err := json.Unmarshal([]byte(jsonRet), &ret); err != nil {
+			return err
+		}
+		rets = append(rets, ret)
+	case nret > 1:
+		if err := json.Unmarshal([]byte(jsonRet), &rets); err != nil {
+			return err
+		}
+	}
+	return fn.CheckReturns(rets)
+}
+
 func (ce *Executor) constructCall(ci *types.CallInfo) {
 	if ce.err != nil {
 		return
@@ -312,7 +416,9 @@ func (ce *Executor) constructCall(ci *types.CallInfo) {
 		errMsg := C.GoString(cErrMsg)
 		C.free(unsafe.Pointer(cErrMsg))
 		ctrLog.Warn().Str("error", errMsg).Msgf("contract %s constructor call", types.EncodeAddress(ce.contract.address))
-		if ce.blockchainCtx.transferFailed == C.int(1) {
+		if err := limitError(ce.blockchainCtx.limits); err != nil {
+			ce.err = err
+		} else if ce.blockchainCtx.transferFailed == C.int(1) {
 			ce.err = types.ErrInsufficientBalance
 		} else if ce.blockchainCtx.dbSystemError == C.int(1) {
 			ce.err = newDbSystemError(errMsg)
@@ -337,11 +443,24 @@ func (ce *Executor) commitCalledContract() error {
 	}
 
 	bs := stateSet.bs
+	// the sql databases of a replayed tx are read-only snapshots
+	replay := ce.blockchainCtx.service == C.int(Replay)
 
 	var err error
 	for k, v := range stateSet.callState {
+		if v.destroyed {
+			if replay {
+				v.ctrState.Destroy()
+			} else if err = destroyContract(k, v); err != nil {
+				return err
+			}
+		}
 		if v.tx != nil {
-			err = v.tx.Release()
+			if replay {
+				err = v.tx.Rollback()
+			} else {
+				err = v.tx.Release()
+			}
 			if err != nil {
 				return DbSystemError(err)
 			}
@@ -365,6 +484,9 @@ func (ce *Executor) commitCalledContract() error {
 			return DbSystemError(err)
 		}
 	}
+	if len(stateSet.events) != 0 {
+		bs.AddEvents(stateSet.events)
+	}
 	return nil
 }
 
@@ -394,6 +516,10 @@ func (ce *Executor) rollbackToSavepoint() error {
 
 func (ce *Executor) close(bcCtxFree bool) {
 	if ce != nil {
+		if ce.L != nil {
+			// the state may be closed after the limits of the tx are freed
+			C.vm_set_limits(ce.L, nil)
+		}
 		FreeLState(ce.L)
 		if bcCtxFree {
 			ce.blockchainCtx.Close()
@@ -426,8 +552,12 @@ func Call(contractState *state.ContractState, code, contractAddress []byte,
 
 	ce := newExecutor(contract, bcCtx)
 	defer ce.close(true)
+	t := bcCtx.tracer()
+	t.enter(FrameCall, C.GoString(bcCtx.sender), types.EncodeAddress(contractAddress), ci.Name,
+		argsJSON(ci.Args), uint64(bcCtx.amount))
 	ce.call(&ci, nil)
 	err = ce.err
+	t.exit(ce.jsonRet, err)
 	if err == nil {
 		err = ce.commitCalledContract()
 		if err != nil {
@@ -444,7 +574,7 @@ func Call(contractState *state.ContractState, code, contractAddress []byte,
 }
 
 func PreCall(ce *Executor, bs *state.BlockState, senderState *types.State, contractState *state.ContractState,
-	blockNo uint64, ts int64, rp uint64) (string, error) {
+	blockNo uint64, ts int64, rp uint64, gas *gasMeter) (string, error) {
 	var err error
 
 	defer ce.close(true)
@@ -454,10 +584,10 @@ func PreCall(ce *Executor, bs *state.BlockState, senderState *types.State, contr
 	stateKey := fmt.Sprintf("%d%s%s", C.int(bcCtx.service),
 		C.GoString(bcCtx.contractId), C.GoString(bcCtx.txHash))
 	bcCtx.stateKey = C.CString(stateKey)
-	registerMap(bcCtx, bs, senderState, contractState, nil)
 	bcCtx.blockHeight = C.ulonglong(blockNo)
 	bcCtx.timestamp = C.longlong(ts)
 	bcCtx.rp = C.ulonglong(rp)
+	registerMap(bcCtx, bs, senderState, contractState, nil, gas)
 
 	ce.call(ce.args, nil)
 	err = ce.err
@@ -559,14 +689,20 @@ func Create(contractState *state.ContractState, code, contractAddress []byte,
 	ce = newExecutor(contract, bcCtx)
 	defer ce.close(true)
 
-	// create a sql database for the contract
-	db := LuaGetDbHandle(bcCtx.stateKey, bcCtx.contractId, bcCtx.rp, bcCtx.isQuery)
-	if db == nil {
-		return "", newDbSystemError("can't open a database connection")
+	// create a sql database for the contract, which is not created in a replay
+	if bcCtx.service != C.int(Replay) {
+		db := LuaGetDbHandle(bcCtx.stateKey, bcCtx.contractId, bcCtx.rp, bcCtx.isQuery)
+		if db == nil {
+			return "", newDbSystemError("can't open a database connection")
+		}
 	}
 
+	t := bcCtx.tracer()
+	t.enter(FrameCreate, C.GoString(bcCtx.sender), types.EncodeAddress(contractAddress), constructorName,
+		argsJSON(ci.Args), uint64(bcCtx.amount))
 	ce.constructCall(&ci)
 	err = ce.err
+	t.exit(ce.jsonRet, err)
 
 	if err != nil {
 		logger.Warn().Err(err).Msg("constructor is failed")
@@ -575,7 +711,7 @@ func Create(contractState *state.ContractState, code, contractAddress []byte,
 			logger.Error().Err(dbErr).Msg("constructor is failed")
 			return string(ret), dbErr
 		}
-		if err == types.ErrVmStart {
+		if err == types.ErrVmStart || limitError(bcCtx.limits) != nil {
 			return string(ret), err
 		}
 		return string(ret), nil
@@ -590,7 +726,68 @@ func Create(contractState *state.ContractState, code, contractAddress []byte,
 
 }
 
-func Query(contractAddress []byte, bs *state.BlockState, contractState *state.ContractState, queryInfo []byte) (res []byte, err error) {
+// Upgrade replaces the code of the contract, keeping its storage and sql
+// database. Only the creator of the contract can upgrade it.
+func Upgrade(contractState *state.ContractState, code, contractAddress []byte, sender string) error {
+	if len(contractState.State.GetCodeHash()) == 0 {
+		return fmt.Errorf("cannot find contract %s", types.EncodeAddress(contractAddress))
+	}
+	creator, err := contractState.GetData([]byte("Creator"))
+	if err != nil {
+		return err
+	}
+	if string(creator) != sender {
+		return ErrUpgradeNotPermitted
+	}
+	_, codeLen, err := setContract(contractState, code, contractAddress)
+	if err != nil {
+		return err
+	}
+	if len(code) != int(codeLen) {
+		return errors.New("constructor arguments are not allowed in upgrade")
+	}
+	if ctrLog.IsDebugEnabled() {
+		ctrLog.Debug().Str("contractAddress", types.EncodeAddress(contractAddress)).Msg("contract is upgraded")
+	}
+	return nil
+}
+
+// destroyContract drops the sql database and the storage of the contract
+// destroyed in the call.
+func destroyContract(contractId string, callState *CallState) error {
+	if callState.tx == nil {
+		// open the database only when the contract has created it
+		if path, err := dbFilePath(contractId); err == nil {
+			if _, err := os.Stat(path); err == nil {
+				tx, err := BeginTx(contractId, callState.curState.SqlRecoveryPoint)
+				if err != nil {
+					return DbSystemError(err)
+				}
+				if err = tx.Savepoint(); err != nil {
+					return DbSystemError(err)
+				}
+				callState.tx = tx
+			}
+		}
+	}
+	if callState.tx != nil {
+		if err := callState.tx.DropAll(); err != nil {
+			return DbSystemError(err)
+		}
+	}
+	callState.ctrState.Destroy()
+	return nil
+}
+
+func Query(contractAddress []byte, bs *state.BlockState, contractState *state.ContractState, queryInfo []byte,
+	blockNo uint64) (res []byte, err error) {
+	return TraceQuery(contractAddress, bs, contractState, queryInfo, blockNo, nil)
+}
+
+// TraceQuery runs a query on the state of the block of blockNo and records its
+// execution with t
+func TraceQuery(contractAddress []byte, bs *state.BlockState, contractState *state.ContractState, queryInfo []byte,
+	blockNo uint64, t *Tracer) (res []byte, err error) {
 	var ci types.CallInfo
 	contract := getContract(contractState, contractAddress, nil)
 	if contract != nil {
@@ -602,6 +799,9 @@ func Query(contractAddress []byte, bs *state.BlockState, contractState *state.Co
 		err = fmt.Errorf("cannot find contract %s", types.EncodeAddress(contractAddress))
 		ctrLog.Warn().AnErr("err", err)
 	}
+	if err == nil {
+		err = contract.checkView(ci.Name, blockNo)
+	}
 	if err != nil {
 		return
 	}
@@ -610,11 +810,12 @@ func Query(contractAddress []byte, bs *state.BlockState, contractState *state.Co
 
 	bcCtx := NewContext(bs, nil, contractState, "", "",
 		0, 0, "", 0, types.EncodeAddress(contractAddress),
-		1, nil, contractState.SqlRecoveryPoint, ChainService, 0)
+		1, nil, contractState.SqlRecoveryPoint, ChainService, 0, newGasMeter(types.DefaultTxGasLimit))
 
 	if ctrLog.IsDebugEnabled() {
 		ctrLog.Debug().Str("abi", string(queryInfo)).Msgf("contract %s", types.EncodeAddress(contractAddress))
 	}
+	bcCtx.setTracer(t)
 	ce = newExecutor(contract, bcCtx)
 	defer ce.close(true)
 	defer func() {
@@ -622,7 +823,9 @@ func Query(contractAddress []byte, bs *state.BlockState, contractState *state.Co
 			err = dbErr
 		}
 	}()
+	t.enter(FrameQuery, "", types.EncodeAddress(contractAddress), ci.Name, argsJSON(ci.Args), 0)
 	ce.call(&ci, nil)
+	t.exit(ce.jsonRet, ce.err)
 	return []byte(ce.jsonRet), ce.err
 }
 
@@ -648,7 +851,79 @@ func getContract(contractState *state.ContractState, contractAddress []byte, cod
 	return &Contract{
 		code:    val[4 : 4+l],
 		address: contractAddress[:],
+		abi:     val[4+l:],
+	}
+}
+
+// getABI returns the ABI of the contract, or nil if it has no ABI
+func (c *Contract) getABI() *types.ABI {
+	if len(c.abi) == 0 {
+		return nil
+	}
+	abi := new(types.ABI)
+	if err := json.Unmarshal(c.abi, abi); err != nil {
+		return nil
+	}
+	return abi
+}
+
+// function returns the ABI of the function name, or nil if the contract has
+// no ABI or the function is not in it.
+func (c *Contract) function(name string) *types.Function {
+	abi := c.getABI()
+	if abi == nil {
+		return nil
+	}
+	return abi.FindFunction(name)
+}
+
+// checkView returns an error if the function name is not registered as a view,
+// once the rule is active at blockNo. A contract without an ABI declaring the
+// flags can be queried.
+func (c *Contract) checkView(name string, blockNo uint64) error {
+	if !chainParams.ViewPayableAt(blockNo) {
+		return nil
+	}
+	abi := c.getABI()
+	if abi == nil || !abi.HasFlags() {
+		return nil
+	}
+	if !abi.FindFunction(name).GetView() {
+		return fmt.Errorf("%s is not a view function", name)
+	}
+	return nil
+}
+
+// checkPayable returns an error if amount is sent to the function name, which
+// is not registered as payable, once the rule is active at blockNo. Any
+// function of a contract without an ABI declaring the flags accepts an amount.
+func (c *Contract) checkPayable(name string, amount uint64, blockNo uint64) error {
+	if amount == 0 || !chainParams.ViewPayableAt(blockNo) {
+		return nil
+	}
+	abi := c.getABI()
+	if abi == nil || !abi.HasFlags() {
+		return nil
+	}
+	if !abi.FindFunction(name).GetPayable() {
+		return fmt.Errorf("%s is not payable", name)
 	}
+	return nil
+}
+
+// CheckPayable returns an error if the call of payload in the block of blockNo
+// sends amount to a function of the contract, which is not payable.
+func CheckPayable(contractState *state.ContractState, contractAddress, payload []byte, amount uint64,
+	blockNo uint64) error {
+	contract := getContract(contractState, contractAddress, nil)
+	if contract == nil {
+		return nil
+	}
+	var ci types.CallInfo
+	if err := json.Unmarshal(payload, &ci); err != nil {
+		return nil
+	}
+	return contract.checkPayable(ci.Name, amount, blockNo)
 }
 
 func GetABI(contractState *state.ContractState) (*types.ABI, error) {
@@ -682,17 +957,21 @@ func (sm *stateMap) init() {
 	sm.states = make(map[string]*StateSet)
 }
 
-func (sm *stateMap) register(key string, item *StateSet) {
+// register registers item with key and returns the registered state, which is
+// the one already registered with key if any.
+func (sm *stateMap) register(key string, item *StateSet) *StateSet {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	found := sm.states[key]
 	if found != nil {
 		found.refCnt++
-		return
+		item.freeLimits()
+		return found
 	}
 	item.refCnt++
 	sm.states[key] = item
+	return item
 }
 
 func (sm *stateMap) unregister(key string) {
@@ -709,6 +988,7 @@ func (sm *stateMap) unregister(key string) {
 	item.refCnt--
 	if item.refCnt == 0 {
 		delete(sm.states, key)
+		item.freeLimits()
 	}
 }
 
@@ -742,6 +1022,7 @@ func LuaSetDB(L *LState, stateKey *C.char, key *C.char, value *C.char) C.int {
 		luaPushStr(L, err.Error())
 		return -1
 	}
+	stateSet.rootState.tracer.step(StepStateSet, keyString, valueString)
 	return 0
 }
 
@@ -761,6 +1042,7 @@ func LuaGetDB(L *LState, stateKey *C.char, key *C.char) C.int {
 		luaPushStr(L, err.Error())
 		return -1
 	}
+	stateSet.rootState.tracer.step(StepStateGet, keyString, string(data))
 
 	if data == nil {
 		return 0
@@ -824,11 +1106,20 @@ func LuaCallContract(L *LState, bcCtx *LBlockchainCtx, contractId *C.char, fname
 		luaPushStr(L, "[System.LuaGetContract]cannot find contract "+string(contractIdStr))
 		return -1
 	}
+	if err := callee.checkPayable(fnameStr, amount, uint64(bcCtx.blockHeight)); err != nil {
+		luaPushStr(L, "[System.LuaCallContract]"+err.Error())
+		return -1
+	}
+	if err := stateSet.enterCall(contractIdStr, callee.function(fnameStr).GetReentrant(), uint64(bcCtx.blockHeight)); err != nil {
+		luaPushStr(L, "[System.LuaCallContract]"+err.Error())
+		return -1
+	}
+	defer stateSet.exitCall()
 
 	newBcCtx := NewContext(nil, nil, callState.ctrState,
 		C.GoString(bcCtx.contractId), C.GoString(bcCtx.txHash), uint64(bcCtx.blockHeight), int64(bcCtx.timestamp),
 		"", int(bcCtx.confirmed), contractIdStr, int(bcCtx.isQuery), rootState, callState.curState.SqlRecoveryPoint,
-		int(bcCtx.service), amount)
+		int(bcCtx.service), amount, stateSet.gas.subMeter(gas))
 	newBcCtx.origin = bcCtx.origin
 	ce := newExecutor(callee, newBcCtx)
 	defer ce.close(true)
@@ -855,7 +1146,9 @@ func LuaCallContract(L *LState, bcCtx *LBlockchainCtx, contractId *C.char, fname
 	if rootState.lastRecoveryEntry != nil {
 		setRecoveryPoint(&contractIdStr, rootState, senderState, callState, amount, callState.ctrState.Snapshot())
 	}
+	rootState.tracer.enter(FrameCall, C.GoString(bcCtx.contractId), contractIdStr, fnameStr, argsStr, amount)
 	ret := ce.call(&ci, L)
+	rootState.tracer.exit(ce.jsonRet, ce.err)
 	if ce.err != nil {
 		luaPushStr(L, "[System.LuaCallContract] call err:"+ce.err.Error())
 		return -1
@@ -890,6 +1183,13 @@ func LuaDelegateCallContract(L *LState, bcCtx *LBlockchainCtx, contractId *C.cha
 		luaPushStr(L, "[System.LuaGetContract]cannot find contract "+string(contractIdStr))
 		return -1
 	}
+	// the callee runs in the context of the caller, which is already on the
+	// call stack, so only the depth of the call is limited
+	if err := stateSet.enterCall(C.GoString(bcCtx.contractId), true, uint64(bcCtx.blockHeight)); err != nil {
+		luaPushStr(L, "[System.LuaDelegateCallContract]"+err.Error())
+		return -1
+	}
+	defer stateSet.exitCall()
 	ce := newExecutor(contract, bcCtx)
 	defer ce.close(false)
 
@@ -911,7 +1211,16 @@ func LuaDelegateCallContract(L *LState, bcCtx *LBlockchainCtx, contractId *C.cha
 		callState := rootState.callState[selfContractId]
 		setRecoveryPoint(&selfContractId, rootState, nil, callState, 0, callState.ctrState.Snapshot())
 	}
+	if gas > 0 {
+		// the callee shares the context of the caller, so its gas limit is
+		// applied by swapping the meter during the call
+		callerGas := stateSet.gas
+		stateSet.gas = callerGas.subMeter(gas)
+		defer func() { stateSet.gas = callerGas }()
+	}
+	rootState.tracer.enter(FrameDelegateCall, C.GoString(bcCtx.contractId), contractIdStr, fnameStr, argsStr, 0)
 	ret := ce.call(&ci, L)
+	rootState.tracer.exit(ce.jsonRet, ce.err)
 	if ce.err != nil {
 		luaPushStr(L, "[System.LuaCallContract] call err:"+ce.err.Error())
 		return -1
@@ -952,6 +1261,7 @@ func LuaSendAmount(L *LState, bcCtx *LBlockchainCtx, contractId *C.char, amount
 			&CallState{prevState: prevState, curState: &curState}
 		rootState.callState[contractIdStr] = callState
 	}
+	// sending runs no code of the receiver, so it cannot reenter a contract
 	if sendBalance(L, stateSet.contract.State, callState.curState, amount) == false {
 		bcCtx.transferFailed = 1
 		return -1
@@ -959,6 +1269,200 @@ func LuaSendAmount(L *LState, bcCtx *LBlockchainCtx, contractId *C.char, amount
 	if rootState.lastRecoveryEntry != nil {
 		setRecoveryPoint(nil, rootState, stateSet.contract.State, callState, amount, 0)
 	}
+	rootState.tracer.step(StepSend, contractIdStr, strconv.FormatUint(amount, 10))
+	return 0
+}
+
+// getCallState returns the state of the account in the call. It is loaded
+// from the block state on the first access.
+func getCallState(rootState *StateSet, address string, aid types.AccountID) (*CallState, error) {
+	if callState := rootState.callState[address]; callState != nil {
+		return callState, nil
+	}
+	prevState, err := rootState.bs.GetAccountState(aid)
+	if err != nil {
+		return nil, err
+	}
+	curState := types.Clone(*prevState).(types.State)
+	callState := &CallState{prevState: prevState, curState: &curState}
+	rootState.callState[address] = callState
+	return callState, nil
+}
+
+//export LuaDeployContract
+func LuaDeployContract(L *LState, bcCtx *LBlockchainCtx, template *C.char, salt *C.char, saltLen C.size_t,
+	args *C.char) C.int {
+	stateKeyStr := C.GoString(bcCtx.stateKey)
+	templateStr := C.GoString(template)
+	deployerStr := C.GoString(bcCtx.contractId)
+
+	tid, err := types.DecodeAddress(templateStr)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaDeployContract]invalid template :"+err.Error())
+		return -1
+	}
+	stateSet := contractMap.lookup(stateKeyStr)
+	if stateSet == nil {
+		luaPushStr(L, "[Contract.LuaDeployContract]not found contract state")
+		return -1
+	}
+	rootState := stateSet.rootState
+	bs := rootState.bs
+
+	var templateState *state.ContractState
+	if callState := rootState.callState[templateStr]; callState != nil && callState.ctrState != nil {
+		templateState = callState.ctrState
+	} else if templateState, err = bs.OpenContractStateAccount(types.ToAccountID(tid)); err != nil {
+		luaPushStr(L, "[Contract.LuaDeployContract]getAccount Error :"+err.Error())
+		return -1
+	}
+	code, err := templateState.GetCode()
+	if err != nil || len(code) == 0 {
+		luaPushStr(L, "[Contract.LuaDeployContract]cannot find contract "+templateStr)
+		return -1
+	}
+
+	deployer, _ := types.DecodeAddress(deployerStr)
+	cid := CreateContractID2(deployer, C.GoBytes(unsafe.Pointer(salt), C.int(saltLen)), common.Hasher(code))
+	contractIdStr := types.EncodeAddress(cid)
+	aid := types.ToAccountID(cid)
+
+	callState, err := getCallState(rootState, contractIdStr, aid)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaDeployContract]getAccount Error :"+err.Error())
+		return -1
+	}
+	if len(callState.curState.GetCodeHash()) != 0 {
+		luaPushStr(L, "[Contract.LuaDeployContract]contract already exists "+contractIdStr)
+		return -1
+	}
+	if callState.curState.GetDestroyed() {
+		luaPushStr(L, "[Contract.LuaDeployContract]"+ErrContractDestroyed.Error()+" "+contractIdStr)
+		return -1
+	}
+	if callState.ctrState == nil {
+		callState.ctrState, err = bs.OpenContractState(aid, callState.curState)
+		if err != nil {
+			luaPushStr(L, "[Contract.LuaDeployContract]getAccount Error :"+err.Error())
+			return -1
+		}
+	}
+	contractState := callState.ctrState
+
+	if rootState.lastRecoveryEntry != nil {
+		setRecoveryPoint(&contractIdStr, rootState, nil, callState, 0, contractState.Snapshot())
+		rootState.lastRecoveryEntry.undo = func() {
+			contractState.Clear()
+		}
+	}
+	if err := contractState.SetCode(code); err != nil {
+		luaPushStr(L, "[Contract.LuaDeployContract]"+err.Error())
+		return -1
+	}
+	contract := getContract(contractState, cid, code)
+	if contract == nil {
+		luaPushStr(L, "[Contract.LuaDeployContract]cannot deploy contract "+contractIdStr)
+		return -1
+	}
+	contractState.SetData([]byte("Creator"), []byte(deployerStr))
+
+	var ci types.CallInfo
+	if err = json.Unmarshal([]byte(C.GoString(args)), &ci.Args); err != nil {
+		luaPushStr(L, "[Contract.LuaDeployContract] invalid args:"+err.Error())
+		return -1
+	}
+	if err := stateSet.enterCall(contractIdStr, false, uint64(bcCtx.blockHeight)); err != nil {
+		luaPushStr(L, "[Contract.LuaDeployContract]"+err.Error())
+		return -1
+	}
+	defer stateSet.exitCall()
+
+	newBcCtx := NewContext(nil, nil, contractState,
+		deployerStr, C.GoString(bcCtx.txHash), uint64(bcCtx.blockHeight), int64(bcCtx.timestamp),
+		"", int(bcCtx.confirmed), contractIdStr, int(bcCtx.isQuery), rootState, callState.curState.SqlRecoveryPoint,
+		int(bcCtx.service), 0, stateSet.gas.subMeter(0))
+	newBcCtx.origin = bcCtx.origin
+	ce := newExecutor(contract, newBcCtx)
+	defer ce.close(true)
+
+	if ce.err != nil {
+		luaPushStr(L, "[Contract.LuaDeployContract]newExecutor Error :"+ce.err.Error())
+		return -1
+	}
+	// create a sql database for the contract, which is not created in a replay
+	if newBcCtx.service != C.int(Replay) {
+		if db := LuaGetDbHandle(newBcCtx.stateKey, newBcCtx.contractId, newBcCtx.rp, newBcCtx.isQuery); db == nil {
+			bcCtx.dbSystemError = 1
+			luaPushStr(L, "[Contract.LuaDeployContract]can't open a database connection")
+			return -1
+		}
+	}
+	rootState.tracer.enter(FrameCreate, deployerStr, contractIdStr, constructorName, C.GoString(args), 0)
+	ce.constructCall(&ci)
+	rootState.tracer.exit(ce.jsonRet, ce.err)
+	if ce.err != nil {
+		luaPushStr(L, "[Contract.LuaDeployContract] constructor err:"+ce.err.Error())
+		return -1
+	}
+	luaPushStr(L, contractIdStr)
+	return 1
+}
+
+//export LuaDestroyContract
+func LuaDestroyContract(L *LState, bcCtx *LBlockchainCtx, beneficiary *C.char) C.int {
+	stateSet := contractMap.lookup(C.GoString(bcCtx.stateKey))
+	if stateSet == nil {
+		luaPushStr(L, "[Contract.LuaDestroyContract]not found contract state")
+		return -1
+	}
+	rootState := stateSet.rootState
+	contractIdStr := C.GoString(bcCtx.contractId)
+	self := rootState.callState[contractIdStr]
+	if self == nil || self.ctrState == nil {
+		luaPushStr(L, "[Contract.LuaDestroyContract]cannot find contract "+contractIdStr)
+		return -1
+	}
+
+	// the balance is refunded to the creator by default
+	var toStr string
+	if beneficiary == nil {
+		creator, err := self.ctrState.GetData([]byte("Creator"))
+		if err != nil {
+			luaPushStr(L, "[Contract.LuaDestroyContract]"+err.Error())
+			return -1
+		}
+		toStr = string(creator)
+	} else {
+		toStr = C.GoString(beneficiary)
+	}
+	if toStr == contractIdStr {
+		luaPushStr(L, "[Contract.LuaDestroyContract]cannot refund to the destroyed contract")
+		return -1
+	}
+	to, err := types.DecodeAddress(toStr)
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaDestroyContract]invalid beneficiary :"+err.Error())
+		return -1
+	}
+	toState, err := getCallState(rootState, toStr, types.ToAccountID(to))
+	if err != nil {
+		luaPushStr(L, "[Contract.LuaDestroyContract]getAccount Error :"+err.Error())
+		return -1
+	}
+
+	amount := self.curState.GetBalance()
+	if sendBalance(L, self.curState, toState.curState, amount) == false {
+		bcCtx.transferFailed = 1
+		return -1
+	}
+	if rootState.lastRecoveryEntry != nil {
+		setRecoveryPoint(nil, rootState, self.curState, toState, amount, 0)
+		rootState.lastRecoveryEntry.undo = func() {
+			self.destroyed = false
+		}
+	}
+	self.destroyed = true
+	rootState.tracer.step(StepDestroy, toStr, strconv.FormatUint(amount, 10))
 	return 0
 }
 
@@ -978,6 +1482,50 @@ func sendBalance(L *LState, sender *types.State, receiver *types.State, amount u
 	return true
 }
 
+//export LuaEvent
+func LuaEvent(L *LState, bcCtx *LBlockchainCtx, eventName *C.char, args *C.char) C.int {
+	stateSet := contractMap.lookup(C.GoString(bcCtx.stateKey))
+	if stateSet == nil {
+		luaPushStr(L, "[System.LuaEvent]not found contract state")
+		return -1
+	}
+	contractId, err := types.DecodeAddress(C.GoString(bcCtx.contractId))
+	if err != nil {
+		luaPushStr(L, "[System.LuaEvent]invalid contractId :"+err.Error())
+		return -1
+	}
+	rootState := stateSet.rootState
+	rootState.events = append(rootState.events, &types.Event{
+		ContractAddress: contractId,
+		EventName:       C.GoString(eventName),
+		JsonArgs:        C.GoString(args),
+	})
+	return 0
+}
+
+//export LuaUseGas
+func LuaUseGas(L *LState, stateKey *C.char, gas uint64) C.int {
+	stateSet := contractMap.lookup(C.GoString(stateKey))
+	if stateSet == nil {
+		luaPushStr(L, "[System.LuaUseGas]not found contract state")
+		return -1
+	}
+	if err := stateSet.gas.use(gas); err != nil {
+		luaPushStr(L, err.Error())
+		return -1
+	}
+	return 0
+}
+
+//export LuaIsOutOfGas
+func LuaIsOutOfGas(stateKey *C.char) C.int {
+	stateSet := contractMap.lookup(C.GoString(stateKey))
+	if stateSet == nil || !stateSet.gas.isOutOfGas() {
+		return 0
+	}
+	return 1
+}
+
 //export LuaPrint
 func LuaPrint(contractId *C.char, args *C.char) {
 	logger.Info().Str("Contract SystemPrint", C.GoString(contractId)).Msg(C.GoString(args))
@@ -1014,6 +1562,9 @@ func LuaClearRecovery(L *LState, stateKey *C.char, start int, error bool) C.int
 			item.recovery()
 		}
 		if item.seq == start {
+			if error {
+				rootState.events = rootState.events[:item.eventCount]
+			}
 			if error || item.prev == nil {
 				rootState.lastRecoveryEntry = item.prev
 			}
@@ -1055,6 +1606,9 @@ func LuaGetDbHandle(ctxKey *C.char, contract *C.char, rp C.ulonglong, readOnly C
 
 func (re *recoveryEntry) recovery() {
 	callState := re.callState
+	if re.undo != nil {
+		re.undo()
+	}
 	if re.amount > 0 {
 		re.senderState.Balance += re.amount
 		callState.curState.Balance -= re.amount
@@ -1087,7 +1641,9 @@ func setRecoveryPoint(contractId *string, rootState *StateSet, senderState *type
 		callState,
 		nil,
 		snapshot,
+		len(rootState.events),
 		prev,
+		nil,
 	}
 	tx := callState.tx
 	if tx != nil {
@@ -6,6 +6,61 @@
 #include <luajit.h>
 #include "sqlite3-binding.h"
 
+/* gas schedule */
+#define GAS_INSTRUCTION_STEP 1000   /* instructions executed between charges */
+#define GAS_INSTRUCTION      1
+#define GAS_SYSTEM_CALL      10
+#define GAS_PRINT            100
+#define GAS_STATE_GET        200
+#define GAS_STATE_SET        5000
+#define GAS_STATE_BYTE       10
+#define GAS_DB_EXEC          2000
+#define GAS_DB_QUERY         1000
+#define GAS_DB_NEXT          100
+#define GAS_DB_SQL_BYTE      10
+#define GAS_CALL             2000
+#define GAS_SEND             2000
+#define GAS_DEPLOY           10000
+#define GAS_DESTROY          2000
+#define GAS_BALANCE          200
+#define GAS_EVENT            500
+#define GAS_EVENT_BYTE       10
+#define GAS_CRYPTO_HASH      100
+#define GAS_CRYPTO_HASH_BYTE 1
+#define GAS_CRYPTO_ECVERIFY  3000
+#define GAS_CRYPTO_ECRECOVER 3000
+#define GAS_CRYPTO_ADDRESS   1000
+#define GAS_CRYPTO_PROOF     200  /* per item of the merkle proof */
+#define GAS_BIGNUM           20
+
+/* the instruction limit of a call before the limits of the tx are activated */
+#define MAX_INSTRUCTION_COUNT 500000
+
+/* the limit of a tx exceeded */
+#define VM_LIMIT_INSTRUCTION 1
+#define VM_LIMIT_MEMORY      2
+#define VM_LIMIT_CALL_DEPTH  3
+#define VM_LIMIT_REENTRANCY  4
+
+/* the service of a replayed tx, which is contract.Replay */
+#define VM_SERVICE_REPLAY 2
+
+/*
+ * The limits of the execution of a tx, which are shared by the nested calls of
+ * the tx. A zero limit means no limit. The memory used by a tx is the memory
+ * allocated by the lua states of the tx while they are called, each counted
+ * from the live objects of the state when its call started.
+ */
+typedef struct vm_limits {
+	unsigned long long instCount;
+	unsigned long long instLimit;
+	long long memUsed;
+	long long memLimit;
+	int exceeded;
+	/* each call is limited to MAX_INSTRUCTION_COUNT instead of instLimit */
+	int legacyInst;
+} vm_limits_t;
+
 typedef struct blockchain_ctx {
 	char *stateKey;
 	char *sender;
@@ -22,9 +77,17 @@ typedef struct blockchain_ctx {
 	unsigned long long rp;
 	int service;
 	unsigned long long amount;
+	unsigned long long instCount;
+	vm_limits_t *limits;
+	int trace;
 } bc_ctx_t;
 
 lua_State *vm_newstate();
+void vm_closestate(lua_State *L);
+void vm_sandbox_open(lua_State *L);
+vm_limits_t *vm_limits_new(unsigned long long instLimit, long long memLimit);
+void vm_set_limits(lua_State *L, vm_limits_t *limits);
+int vm_limit_exceeded(lua_State *L);
 int vm_isnil(lua_State *L, int idx);
 void vm_getfield(lua_State *L, const char *name);
 void vm_remove_construct(lua_State *L, const char *constructName);
@@ -35,5 +98,10 @@ const char *vm_tostring(lua_State *L, int idx);
 const char *vm_copy_result(lua_State *L, lua_State *target, int cnt);
 void bc_ctx_delete(bc_ctx_t *bcctx);
 sqlite3 *vm_get_db(lua_State *L);
+void vm_use_gas(lua_State *L, unsigned long long gas);
+int vm_is_out_of_gas(lua_State *L);
+int vm_is_replay(lua_State *L);
+void vm_trace_sql(lua_State *L, const char *op, const char *sql);
+void vm_trace_sql_write(lua_State *L);
 
 #endif /* _VM_H */
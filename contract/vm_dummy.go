@@ -174,6 +174,7 @@ type luaTxCommon struct {
 	amount   uint64
 	code     []byte
 	id       uint64
+	gasLimit uint64
 }
 
 type luaTxDef struct {
@@ -196,6 +197,7 @@ func NewLuaTxDef(sender, contract string, amount uint64, code string) *luaTxDef
 			code:     codeWithInit,
 			amount:   amount,
 			id:       newTxId(),
+			gasLimit: types.DefaultTxGasLimit,
 		},
 		cErr: nil,
 	}
@@ -289,7 +291,8 @@ func (l *luaTxDef) run(bs *state.BlockState, blockNo uint64, ts int64,
 			bcCtx := NewContext(bs, senderState, eContractState,
 				types.EncodeAddress(l.sender), hex.EncodeToString(l.hash()), blockNo, ts,
 				"", 1, types.EncodeAddress(l.contract),
-				0, nil, uContractState.SqlRecoveryPoint, ChainService, l.luaTxCommon.amount)
+				0, nil, uContractState.SqlRecoveryPoint, ChainService, l.luaTxCommon.amount,
+				newTxGasMeter(l.gasLimit, blockNo))
 
 			_, err := Create(eContractState, l.code, l.contract, bcCtx)
 			if err != nil {
@@ -304,9 +307,36 @@ func (l *luaTxDef) run(bs *state.BlockState, blockNo uint64, ts int64,
 	)
 }
 
+type luaTxRedeploy struct {
+	luaTxDef
+}
+
+func NewLuaTxRedeploy(sender, contract string, code string) *luaTxRedeploy {
+	return &luaTxRedeploy{luaTxDef: *NewLuaTxDef(sender, contract, 0, code)}
+}
+
+func (l *luaTxRedeploy) run(bs *state.BlockState, blockNo uint64, ts int64,
+	receiptTx db.Transaction) error {
+
+	if l.cErr != nil {
+		return l.cErr
+	}
+
+	return contractFrame(&l.luaTxCommon, bs,
+		func(senderState, uContractState *types.State, contractId types.AccountID, eContractState *state.ContractState) error {
+			err := Upgrade(eContractState, l.code, l.contract, types.EncodeAddress(l.sender))
+			if err != nil {
+				return err
+			}
+			return bs.StageContractState(eContractState)
+		},
+	)
+}
+
 type luaTxCall struct {
 	luaTxCommon
 	expectedErr string
+	tracer      *Tracer
 }
 
 func NewLuaTxCall(sender, contract string, amount uint64, code string) *luaTxCall {
@@ -317,6 +347,7 @@ func NewLuaTxCall(sender, contract string, amount uint64, code string) *luaTxCal
 			amount:   amount,
 			code:     []byte(code),
 			id:       newTxId(),
+			gasLimit: types.DefaultTxGasLimit,
 		},
 	}
 }
@@ -334,15 +365,35 @@ func (l *luaTxCall) fail(expectedErr string) *luaTxCall {
 	return l
 }
 
+func (l *luaTxCall) gas(limit uint64) *luaTxCall {
+	l.gasLimit = limit
+	return l
+}
+
+// Trace records the execution of the call with t
+func (l *luaTxCall) Trace(t *Tracer) *luaTxCall {
+	l.tracer = t
+	return l
+}
+
 func (l *luaTxCall) run(bs *state.BlockState, blockNo uint64, ts int64, receiptTx db.Transaction) error {
 	err := contractFrame(&l.luaTxCommon, bs,
 		func(senderState, uContractState *types.State, contractId types.AccountID, eContractState *state.ContractState) error {
 			bcCtx := NewContext(bs, senderState, eContractState,
 				types.EncodeAddress(l.sender), hex.EncodeToString(l.hash()), blockNo, ts,
 				"", 1, types.EncodeAddress(l.contract),
-				0, nil, uContractState.SqlRecoveryPoint, ChainService, l.luaTxCommon.amount)
+				0, nil, uContractState.SqlRecoveryPoint, ChainService, l.luaTxCommon.amount,
+				newTxGasMeter(l.gasLimit, blockNo))
+			if err := CheckPayable(eContractState, l.contract, l.code, l.amount, blockNo); err != nil {
+				bcCtx.Close()
+				return err
+			}
+			bcCtx.setTracer(l.tracer)
 			rv, err := Call(eContractState, l.code, l.contract, bcCtx)
 			if err != nil {
+				r := types.NewReceipt(l.contract, ReceiptStatus(err), "")
+				b, _ := r.MarshalBinary()
+				receiptTx.Set(l.hash(), b)
 				return err
 			}
 			err = bs.StageContractState(eContractState)
@@ -353,6 +404,7 @@ func (l *luaTxCall) run(bs *state.BlockState, blockNo uint64, ts int64, receiptT
 				return err
 			}
 			r := types.NewReceipt(l.contract, "SUCCESS", rv)
+			bs.AddReceipt(r)
 			b, _ := r.MarshalBinary()
 			receiptTx.Set(l.hash(), b)
 			return nil
@@ -418,7 +470,7 @@ func (bc *DummyChain) Query(contract, queryInfo, expectedErr string, expectedRvs
 	if err != nil {
 		return err
 	}
-	rv, err := Query(strHash(contract), bc.newBState(), cState, []byte(queryInfo))
+	rv, err := Query(strHash(contract), bc.newBState(), cState, []byte(queryInfo), bc.bestBlockNo)
 	if expectedErr != "" {
 		if err == nil || !strings.Contains(err.Error(), expectedErr) {
 			return err
@@ -444,7 +496,7 @@ func (bc *DummyChain) QueryOnly(contract, queryInfo string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	rv, err := Query(strHash(contract), bc.newBState(), cState, []byte(queryInfo))
+	rv, err := Query(strHash(contract), bc.newBState(), cState, []byte(queryInfo), bc.bestBlockNo)
 
 	if err != nil {
 		return "", err
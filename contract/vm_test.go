@@ -1,12 +1,19 @@
 package contract
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
 	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
 )
 
 const (
@@ -143,7 +150,7 @@ abi.register(hello)`),
 	if err != nil {
 		t.Error(err)
 	}
-	if string(b) != `{"version":"0.2","language":"lua","functions":[{"name":"hello","arguments":[{"name":"say"}]}],"state_variables":[{"name":"Say","type":"value"}]}` {
+	if string(b) != `{"version":"0.3","language":"lua","functions":[{"name":"hello","arguments":[{"name":"say"}]}],"state_variables":[{"name":"Say","type":"value"}]}` {
 		t.Error(string(b))
 	}
 }
@@ -2068,7 +2075,8 @@ func TestPingpongCall(t *testing.T) {
 		return system.getItem("key")
 	end
 
-	abi.register(start, callback, get)
+	abi.register(start, get)
+	abi.reentrant(callback)
 	`
 
 	bc, err := LoadDummyChain()
@@ -2360,4 +2368,1233 @@ abi.register(InvalidUpdateAge, ValidUpdateAge, GetPerson)
 	}
 }
 
+func TestGas(t *testing.T) {
+	definition := `
+function loop(n)
+	local s = 0
+	for i = 1, n do
+		s = s + i
+	end
+	return s
+end
+
+function catch(n)
+	return contract.pcall(loop, n)
+end
+
+function callLoop(addr, gas, n)
+	local ok = contract.pcall(contract.call.gas(gas), addr, "loop", n)
+	return ok
+end
+abi.register(loop, catch, callLoop)`
+
+	SetChainParams(types.DefaultChainParams())
+	defer SetChainParams(types.LegacyChainParams())
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "gas1", 0, definition),
+		NewLuaTxDef("ktlee", "gas2", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "gas1", 0, `{"Name":"loop", "Args":[1000]}`).gas(100000),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "gas1", 0, `{"Name":"loop", "Args":[100000]}`).gas(50000).
+			fail("out of gas"),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "gas1", 0, `{"Name":"catch", "Args":[100000]}`).gas(50000).
+			fail("out of gas"),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tx := NewLuaTxCall("ktlee", "gas1", 0,
+		fmt.Sprintf(`{"Name":"callLoop", "Args":["%s", 10000, 100000]}`, types.EncodeAddress(strHash("gas2"))))
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	receipt := bc.getReceipt(tx.hash())
+	if receipt.GetRet() != `false` {
+		t.Errorf("contract Call ret error :%s", receipt.GetRet())
+	}
+
+	// no gas is charged before the activation height
+	params := types.DefaultChainParams()
+	params.GasHeight = 100
+	SetChainParams(params)
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "gas1", 0, `{"Name":"loop", "Args":[10000]}`).gas(5000),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVMLimits(t *testing.T) {
+	definition := `
+function loop(n)
+	local s = 0
+	for i = 1, n do
+		s = s + i
+	end
+	return s
+end
+
+function alloc(n)
+	local t = {}
+	for i = 1, n do
+		t[i] = string.rep(tostring(i), 256 * 1024)
+	end
+	return #t
+end
+
+function callLoop(addr, n)
+	local ok = contract.pcall(contract.call, addr, "loop", n)
+	return ok
+end
+
+function callLoopTwice(addr, n)
+	contract.call(addr, "loop", n)
+	return loop(n)
+end
+abi.register(loop, alloc, callLoop, callLoopTwice)`
+
+	params := types.DefaultChainParams()
+	params.MaxInstructionCount = 100000
+	params.MaxCallMemory = 2 << 20
+	SetChainParams(params)
+	defer SetChainParams(types.LegacyChainParams())
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "limit1", 0, definition),
+		NewLuaTxDef("ktlee", "limit2", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "limit1", 0, `{"Name":"loop", "Args":[10000]}`),
+		NewLuaTxCall("ktlee", "limit1", 0, `{"Name":"alloc", "Args":[2]}`),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tx := NewLuaTxCall("ktlee", "limit1", 0, `{"Name":"loop", "Args":[1000000]}`).
+		fail("exceeded the maximum instruction count")
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if status := bc.getReceipt(tx.hash()).GetStatus(); status != types.ReceiptInstructionLimit {
+		t.Errorf("receipt status: expected %s, but got %s", types.ReceiptInstructionLimit, status)
+	}
+
+	// the gas limit of the tx caps the instruction limit
+	tx = NewLuaTxCall("ktlee", "limit1", 0, `{"Name":"loop", "Args":[10000]}`).gas(20000).
+		fail("exceeded the maximum instruction count")
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if status := bc.getReceipt(tx.hash()).GetStatus(); status != types.ReceiptInstructionLimit {
+		t.Errorf("receipt status: expected %s, but got %s", types.ReceiptInstructionLimit, status)
+	}
+
+	tx = NewLuaTxCall("ktlee", "limit1", 0, `{"Name":"alloc", "Args":[16]}`).fail("exceeded the maximum memory")
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if status := bc.getReceipt(tx.hash()).GetStatus(); status != types.ReceiptMemoryLimit {
+		t.Errorf("receipt status: expected %s, but got %s", types.ReceiptMemoryLimit, status)
+	}
+
+	// the error of the limit in a callee cannot be caught
+	callee := types.EncodeAddress(strHash("limit2"))
+	tx = NewLuaTxCall("ktlee", "limit1", 0, fmt.Sprintf(`{"Name":"callLoop", "Args":["%s", 1000000]}`, callee)).
+		fail("exceeded the maximum instruction count")
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if status := bc.getReceipt(tx.hash()).GetStatus(); status != types.ReceiptInstructionLimit {
+		t.Errorf("receipt status: expected %s, but got %s", types.ReceiptInstructionLimit, status)
+	}
+
+	// the instructions of the callee are counted for the tx
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "limit1", 0, fmt.Sprintf(`{"Name":"callLoopTwice", "Args":["%s", 10000]}`, callee)),
+		NewLuaTxCall("ktlee", "limit1", 0, fmt.Sprintf(`{"Name":"callLoopTwice", "Args":["%s", 40000]}`, callee)).
+			fail("exceeded the maximum instruction count"),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReentrancy(t *testing.T) {
+	bank := `
+state.var {
+	Balances = state.map()
+}
+
+function deposit()
+	local s = system.getSender()
+	Balances[s] = (Balances[s] or 0) + tonumber(system.getAmount())
+end
+
+function withdraw()
+	local s = system.getSender()
+	local b = Balances[s] or 0
+	if b > 0 then
+		contract.call.value(b)(s, "receive")
+		Balances[s] = 0
+	end
+end
+abi.payable(deposit)
+abi.%s(withdraw)`
+
+	attacker := `
+function attack(bank)
+	system.setItem("bank", bank)
+	contract.call.value(10)(bank, "deposit")
+	contract.call(bank, "withdraw")
+end
+
+function receive()
+	local bank = system.getItem("bank")
+	if contract.balance(bank) >= 10 then
+		contract.pcall(contract.call, bank, "withdraw")
+	end
+end
+abi.register(attack)
+abi.reentrant(receive)`
+
+	deep := `
+function down(n)
+	if n == 0 then
+		return 0
+	end
+	return contract.call(system.getContractID(), "down", n - 1) + 1
+end
+abi.reentrant(down)`
+
+	params := types.DefaultChainParams()
+	SetChainParams(params)
+	defer SetChainParams(types.LegacyChainParams())
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "bank", 0, fmt.Sprintf(bank, "register")),
+		NewLuaTxDef("ktlee", "unsafe", 0, fmt.Sprintf(bank, "reentrant")),
+		NewLuaTxDef("ktlee", "attacker", 0, attacker),
+		NewLuaTxDef("ktlee", "deep", 0, deep),
+		NewLuaTxCall("ktlee", "bank", 30, `{"Name":"deposit", "Args":[]}`),
+		NewLuaTxCall("ktlee", "unsafe", 30, `{"Name":"deposit", "Args":[]}`),
+		NewLuaTxSend("ktlee", "attacker", 10),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// the callback into withdraw is denied, even if it is caught
+	tx := NewLuaTxCall("ktlee", "attacker", 0,
+		fmt.Sprintf(`{"Name":"attack", "Args":["%s"]}`, types.EncodeAddress(strHash("bank")))).
+		fail("reentrant call is not allowed")
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if status := bc.getReceipt(tx.hash()).GetStatus(); status != types.ReceiptReentrancy {
+		t.Errorf("receipt status: expected %s, but got %s", types.ReceiptReentrancy, status)
+	}
+	if state, _ := bc.GetAccountState("bank"); state.GetBalance() != 30 {
+		t.Errorf("bank balance: expected 30, but got %d", state.GetBalance())
+	}
+
+	// a contract opting in to the reentrancy is drained
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "attacker", 0,
+			fmt.Sprintf(`{"Name":"attack", "Args":["%s"]}`, types.EncodeAddress(strHash("unsafe")))),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	if state, _ := bc.GetAccountState("unsafe"); state.GetBalance() != 0 {
+		t.Errorf("unsafe balance: expected 0, but got %d", state.GetBalance())
+	}
+	if state, _ := bc.GetAccountState("attacker"); state.GetBalance() != 40 {
+		t.Errorf("attacker balance: expected 40, but got %d", state.GetBalance())
+	}
+
+	params.MaxCallDepth = 4
+
+	tx = NewLuaTxCall("ktlee", "deep", 0, `{"Name":"down", "Args":[3]}`)
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if ret := bc.getReceipt(tx.hash()).GetRet(); ret != "3" {
+		t.Errorf("contract Call ret error :%s", ret)
+	}
+	tx = NewLuaTxCall("ktlee", "deep", 0, `{"Name":"down", "Args":[4]}`).fail("exceeded the maximum call depth")
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if status := bc.getReceipt(tx.hash()).GetStatus(); status != types.ReceiptCallDepth {
+		t.Errorf("receipt status: expected %s, but got %s", types.ReceiptCallDepth, status)
+	}
+
+	// the reentrancy can be allowed for all the contracts
+	params.MaxCallDepth = types.DefaultMaxCallDepth
+	params.AllowReentrancy = true
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "attacker", 0,
+			fmt.Sprintf(`{"Name":"attack", "Args":["%s"]}`, types.EncodeAddress(strHash("bank")))),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	if state, _ := bc.GetAccountState("bank"); state.GetBalance() != 0 {
+		t.Errorf("bank balance: expected 0, but got %d", state.GetBalance())
+	}
+	if state, _ := bc.GetAccountState("attacker"); state.GetBalance() != 70 {
+		t.Errorf("attacker balance: expected 70, but got %d", state.GetBalance())
+	}
+
+	// every contract can be reentered before the call limits are activated
+	params.AllowReentrancy = false
+	params.CallLimitsHeight = bc.bestBlockNo + 2
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "bank", 30, `{"Name":"deposit", "Args":[]}`),
+		NewLuaTxCall("ktlee", "attacker", 0,
+			fmt.Sprintf(`{"Name":"attack", "Args":["%s"]}`, types.EncodeAddress(strHash("bank")))),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	if state, _ := bc.GetAccountState("attacker"); state.GetBalance() != 100 {
+		t.Errorf("attacker balance: expected 100, but got %d", state.GetBalance())
+	}
+}
+
+func TestTrace(t *testing.T) {
+	callee := `
+state.var {
+	Count = state.value()
+}
+
+function constructor()
+	db.exec("create table if not exists log(msg text)")
+end
+
+function inc(msg)
+	local n = (Count:get() or 0) + 1
+	Count:set(n)
+	db.exec("insert into log values('" .. msg .. "')")
+	return n
+end
+
+function count()
+	return Count:get()
+end
+
+function fail()
+	error("boom")
+end
+abi.register(inc, count, fail)`
+
+	caller := `
+function run(addr)
+	local n = contract.call(addr, "inc", "hello")
+	contract.pcall(contract.call, addr, "fail")
+	return n
+end
+abi.register(run)`
+
+	SetChainParams(types.DefaultChainParams())
+	defer SetChainParams(types.LegacyChainParams())
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "tcallee", 0, callee),
+		NewLuaTxDef("ktlee", "tcaller", 0, caller),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	calleeAddr := types.EncodeAddress(strHash("tcallee"))
+	tracer := NewTracer()
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "tcaller", 0, fmt.Sprintf(`{"Name":"run", "Args":["%s"]}`, calleeAddr)).Trace(tracer),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	root := tracer.Trace()
+	if root == nil {
+		t.Fatal("no trace")
+	}
+	if root.Kind != FrameCall || root.Function != "run" || root.Caller != StrToAddress("ktlee") ||
+		root.Callee != StrToAddress("tcaller") || root.Result != "1" || root.Error != "" {
+		t.Errorf("root frame: %v", root)
+	}
+	if len(root.Steps) != 2 {
+		t.Fatalf("root steps: expected 2, but got %d", len(root.Steps))
+	}
+
+	inc := root.Steps[0].Call
+	if root.Steps[0].Op != FrameCall || inc == nil {
+		t.Fatalf("first step: %v", root.Steps[0])
+	}
+	if inc.Caller != root.Callee || inc.Callee != calleeAddr || inc.Function != "inc" ||
+		!strings.Contains(inc.Args, "hello") || inc.Result != "1" {
+		t.Errorf("inc frame: %v", inc)
+	}
+	if inc.GasUsed == 0 || inc.GasUsed > root.GasUsed {
+		t.Errorf("gas used: inc %d, run %d", inc.GasUsed, root.GasUsed)
+	}
+	var ops []string
+	for _, step := range inc.Steps {
+		ops = append(ops, step.Op+" "+step.Key+" "+step.Value)
+	}
+	expected := []string{
+		StepStateGet + " _Count ",
+		StepStateSet + " _Count 1",
+		StepSqlExec + "  insert into log values('hello')",
+	}
+	if strings.Join(ops, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("inc steps: expected %v, but got %v", expected, ops)
+	}
+
+	fail := root.Steps[1].Call
+	if fail == nil || fail.Function != "fail" || !strings.Contains(fail.Error, "boom") || fail.Result != "" {
+		t.Errorf("fail frame: %v", fail)
+	}
+
+	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash("tcallee")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer = NewTracer()
+	rv, err := TraceQuery(strHash("tcallee"), bc.newBState(), cState, []byte(`{"Name":"count"}`), bc.bestBlockNo, tracer)
+	if err != nil || string(rv) != "1" {
+		t.Errorf("query: %s, %v", rv, err)
+	}
+	if q := tracer.Trace(); q == nil || q.Kind != FrameQuery || len(q.Steps) != 1 || q.Steps[0].Op != StepStateGet {
+		t.Errorf("query frame: %v", q)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	definition := `
+function transfer(to, amount)
+	contract.event("transfer", system.getSender(), to, amount)
+	contract.pcall(function()
+		contract.event("rollback")
+		error("failed")
+	end)
+	contract.event("done")
+end
+abi.register(transfer)`
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "event", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tx := NewLuaTxCall("ktlee", "event", 0, `{"Name":"transfer", "Args":["bob", 10]}`)
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	receipt := bc.getReceipt(tx.hash())
+	events := receipt.GetEvents()
+	if len(events) != 2 {
+		t.Fatalf("event count error :%d", len(events))
+	}
+	if events[0].EventName != "transfer" ||
+		events[0].JsonArgs != fmt.Sprintf(`["%s","bob",10]`, types.EncodeAddress(strHash("ktlee"))) {
+		t.Errorf("event error :%s %s", events[0].EventName, events[0].JsonArgs)
+	}
+	if events[1].EventName != "done" || events[1].EventIdx != 1 {
+		t.Errorf("event error :%s %d", events[1].EventName, events[1].EventIdx)
+	}
+
+	err = bc.Query("event", `{"Name":"transfer", "Args":["bob", 10]}`, "event not permitted in query", "")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSandbox(t *testing.T) {
+	definition := `
+function globals()
+	return _G.os == nil, _G.io == nil, _G.loadstring == nil, _G.print == nil,
+		math.random == nil, string.dump == nil
+end
+function tableKey()
+	local t = {}
+	t[{}] = 1
+	for k, v in pairs(t) do
+	end
+end
+function str()
+	return tostring({}), string.format("%s", function() end)
+end
+abi.register_view(globals, tableKey, str)`
+
+	SetChainParams(types.DefaultChainParams())
+	defer SetChainParams(types.LegacyChainParams())
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "sandbox", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.Query("sandbox", `{"Name":"globals", "Args":[]}`, "", "[true,true,true,true,true,true]")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("sandbox", `{"Name":"tableKey", "Args":[]}`, "not deterministic", "")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("sandbox", `{"Name":"str", "Args":[]}`, "", `["table","function"]`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, code := range []string{
+		"function f() return os.time() end abi.register(f)",
+		"function f(s) return loadstring(s)() end abi.register(f)",
+		"function f() print(1) end abi.register(f)",
+	} {
+		err = bc.ConnectBlock(NewLuaTxDef("ktlee", "forbidden", 0, code))
+		if err == nil || !strings.Contains(err.Error(), "forbidden global") {
+			t.Errorf("forbidden global is compiled: %s, %v", code, err)
+		}
+	}
+
+	// the contracts run as before until the sandbox is activated
+	SetChainParams(types.LegacyChainParams())
+	err = bc.Query("sandbox", `{"Name":"globals", "Args":[]}`, "", "[false,false,false,false,false,false]")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrypto(t *testing.T) {
+	definition := `
+function hash()
+	return crypto.sha256("hello"), crypto.keccak256(""), crypto.ripemd160("0x", "hex")
+end
+function hashEncoding()
+	return crypto.sha256("0x00"), crypto.sha256("0x00", "hex"), crypto.sha256("00", "hex")
+end
+function badEncoding()
+	return crypto.sha256("00", "base64")
+end
+function ecverify(hash, sign, address)
+	return crypto.ecverify(hash, sign, address, "hex")
+end
+function ecrecover(hash, sign)
+	return crypto.ecrecover(hash, sign, "hex")
+end
+function toAddress(pubkey)
+	return crypto.toAddress(pubkey, "hex")
+end
+function verifyProof(root, key, value, proof)
+	return crypto.verifyProof(root, key, value, proof, "hex")
+end
+abi.register(hash, hashEncoding, badEncoding, ecverify, ecrecover, toAddress, verifyProof)`
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "crypto", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.Query("crypto", `{"Name":"hash", "Args":[]}`, "",
+		`["0x2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",`+
+			`"0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",`+
+			`"0x9c1185a5c5e9fc54612808977ee8f548b2258d31"]`)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("crypto", `{"Name":"hashEncoding", "Args":[]}`, "",
+		`["0xc4dd67368286d02d62bdaa7a775b7594765d5210c9ad20cc3c24148d493353d7",`+
+			`"0x6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",`+
+			`"0x6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d"]`)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("crypto", `{"Name":"badEncoding", "Args":[]}`, "invalid option", "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := types.EncodeAddress(key.GenerateAddress(&privKey.PublicKey))
+	msgHash := sha256.Sum256([]byte("message"))
+	hexHash := "0x" + hex.EncodeToString(msgHash[:])
+
+	sign, err := privKey.Sign(msgHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.Query("crypto", fmt.Sprintf(`{"Name":"ecverify", "Args":["%s", "0x%s", "%s"]}`,
+		hexHash, hex.EncodeToString(sign.Serialize()), address), "", "true")
+	if err != nil {
+		t.Error(err)
+	}
+	otherKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.Query("crypto", fmt.Sprintf(`{"Name":"ecverify", "Args":["%s", "0x%s", "%s"]}`,
+		hexHash, hex.EncodeToString(sign.Serialize()), types.EncodeAddress(key.GenerateAddress(&otherKey.PublicKey))), "", "false")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("crypto", fmt.Sprintf(`{"Name":"ecverify", "Args":["%s", "0x%s", "%s"]}`,
+		hexHash, hex.EncodeToString(sign.Serialize()), types.EncodeAddress(strHash("ktlee"))), "invalid address", "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	compact, err := btcec.SignCompact(btcec.S256(), privKey, msgHash[:], true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bc.Query("crypto", fmt.Sprintf(`{"Name":"ecrecover", "Args":["%s", "0x%s"]}`,
+		hexHash, hex.EncodeToString(compact)), "", fmt.Sprintf(`"%s"`, address))
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("crypto", fmt.Sprintf(`{"Name":"toAddress", "Args":["0x%s"]}`,
+		hex.EncodeToString(privKey.PubKey().SerializeUncompressed())), "", fmt.Sprintf(`"%s"`, address))
+	if err != nil {
+		t.Error(err)
+	}
+
+	tr := trie.NewTrie(nil, common.Hasher, nil)
+	keys := [][]byte{common.Hasher([]byte("a")), common.Hasher([]byte("b"))}
+	if bytes.Compare(keys[0], keys[1]) > 0 {
+		keys[0], keys[1] = keys[1], keys[0]
+	}
+	values := [][]byte{common.Hasher([]byte("1")), common.Hasher([]byte("2"))}
+	root, err := tr.Update(keys, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ap, _, _, _, err := tr.MerkleProof(keys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var proof []string
+	for _, item := range ap {
+		proof = append(proof, `"0x`+hex.EncodeToString(item)+`"`)
+	}
+	query := `{"Name":"verifyProof", "Args":["0x%s", "0x%s", "0x%s", [%s]]}`
+	err = bc.Query("crypto", fmt.Sprintf(query, hex.EncodeToString(root), hex.EncodeToString(keys[0]),
+		hex.EncodeToString(values[0]), strings.Join(proof, ",")), "", "true")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("crypto", fmt.Sprintf(query, hex.EncodeToString(root), hex.EncodeToString(keys[0]),
+		hex.EncodeToString(values[1]), strings.Join(proof, ",")), "", "false")
+	if err != nil {
+		t.Error(err)
+	}
+	// a proof longer than the depth of the trie
+	long := make([]string, trie.HashLength*8+1)
+	for i := range long {
+		long[i] = proof[0]
+	}
+	err = bc.Query("crypto", fmt.Sprintf(query, hex.EncodeToString(root), hex.EncodeToString(keys[0]),
+		hex.EncodeToString(values[0]), strings.Join(long, ",")), "", "false")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestContractLifecycle(t *testing.T) {
+	counter := `
+function inc()
+	system.setItem("n", (system.getItem("n") or 0) + 1)
+end
+function get()
+	return system.getItem("n")
+end
+abi.register(inc, get)`
+	counter2 := `
+function get()
+	return system.getItem("n") * 10
+end
+abi.register(get)`
+	mortal := `
+function constructor(n)
+	system.setItem("n", n)
+end
+function get()
+	return system.getItem("n")
+end
+function kill()
+	contract.destroy()
+end
+abi.register(get, kill)`
+	factory := `
+function create(template, salt, n)
+	return contract.deploy(template, salt, n)
+end
+function get(addr)
+	return contract.call(addr, "get")
+end
+function kill(addr)
+	contract.call(addr, "kill")
+end
+abi.register(create, get, kill)`
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxAccount("other", 100),
+		NewLuaTxDef("ktlee", "counter", 0, counter),
+		NewLuaTxCall("ktlee", "counter", 0, `{"Name":"inc", "Args":[]}`),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// upgrade
+	err = bc.ConnectBlock(NewLuaTxRedeploy("other", "counter", counter2))
+	if err != ErrUpgradeNotPermitted {
+		t.Errorf("upgrade by the other account: %v", err)
+	}
+	err = bc.ConnectBlock(NewLuaTxRedeploy("ktlee", "counter", counter2))
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("counter", `{"Name":"get", "Args":[]}`, "", "10")
+	if err != nil {
+		t.Error(err)
+	}
+
+	// destroy
+	err = bc.ConnectBlock(
+		NewLuaTxDef("ktlee", "mortal", 10, mortal).Constructor("[1]"),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "mortal", 0, `{"Name":"kill", "Args":[]}`),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	state, err := bc.GetAccountState("mortal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.GetBalance() != 0 || len(state.GetCodeHash()) != 0 || len(state.GetStorageRoot()) != 0 {
+		t.Errorf("contract is not destroyed: %v", state)
+	}
+	state, err = bc.GetAccountState("ktlee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.GetBalance() != 100 {
+		t.Errorf("balance is not refunded: %d", state.GetBalance())
+	}
+
+	// create2
+	mortalAddr := types.EncodeAddress(strHash("mortal"))
+	err = bc.ConnectBlock(
+		NewLuaTxDef("ktlee", "mortal2", 0, mortal).Constructor("[1]"),
+		NewLuaTxDef("ktlee", "factory", 0, factory),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	templateAddr := types.EncodeAddress(strHash("mortal2"))
+	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash("mortal2")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := cState.GetCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := types.EncodeAddress(CreateContractID2(strHash("factory"), []byte("salt"), common.Hasher(code)))
+
+	create := fmt.Sprintf(`{"Name":"create", "Args":["%s", "salt", 2]}`, templateAddr)
+	tx := NewLuaTxCall("ktlee", "factory", 0, create)
+	err = bc.ConnectBlock(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	receipt := bc.getReceipt(tx.hash())
+	if receipt.GetRet() != `"`+expected+`"` {
+		t.Errorf("contract address: expected %s, but got %s", expected, receipt.GetRet())
+	}
+	err = bc.Query("factory", fmt.Sprintf(`{"Name":"get", "Args":["%s"]}`, expected), "", "2")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.ConnectBlock(NewLuaTxCall("ktlee", "factory", 0, create).fail("already exists"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "factory", 0, fmt.Sprintf(`{"Name":"kill", "Args":["%s"]}`, expected)),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("factory", fmt.Sprintf(`{"Name":"get", "Args":["%s"]}`, expected), "cannot find contract", "")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("factory", fmt.Sprintf(`{"Name":"get", "Args":["%s"]}`, mortalAddr), "cannot find contract", "")
+	if err != nil {
+		t.Error(err)
+	}
+	// the address of a destroyed contract can't be used again
+	err = bc.ConnectBlock(NewLuaTxCall("ktlee", "factory", 0, create).fail("contract address was destroyed"))
+	if err != nil {
+		t.Error(err)
+	}
+	expectedID, _ := types.DecodeAddress(expected)
+	state, err = bc.sdb.GetStateDB().GetAccountState(types.ToAccountID(expectedID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.GetDestroyed() {
+		t.Errorf("no tombstone of the destroyed contract: %v", state)
+	}
+}
+
+func TestTypedABI(t *testing.T) {
+	definition := `
+function transfer(to, amount, memo)
+	system.setItem(to, amount)
+	return true
+end
+function balanceOf(owner)
+	return system.getItem(owner)
+end
+function count(list)
+	return #list
+end
+function broken()
+	return "not a number"
+end
+abi.register(transfer, {"address", "bignum"}, balanceOf, {"address", returns = "bignum"},
+	count, {"array", returns = {"integer"}}, broken, {returns = "integer"})`
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxDef("ktlee", "typed", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	owner := types.EncodeAddress(strHash("ktlee"))
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "typed", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", "1000000000000000000000"]}`, owner)),
+		NewLuaTxCall("ktlee", "typed", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", 10, "memo"]}`, owner)),
+		NewLuaTxCall("ktlee", "typed", 0, `{"Name":"transfer", "Args":["ktlee", 10]}`).fail("argument 1 (to) must be address, got string"),
+		NewLuaTxCall("ktlee", "typed", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", 1.5]}`, owner)).fail("argument 2 (amount) must be bignum, got number"),
+		NewLuaTxCall("ktlee", "typed", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s"]}`, owner)).fail("missing argument 2"),
+		NewLuaTxCall("ktlee", "typed", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", 1, "", 2]}`, owner)).fail("too many arguments"),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.Query("typed", fmt.Sprintf(`{"Name":"balanceOf", "Args":["%s"]}`, owner), "", "10")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("typed", `{"Name":"count", "Args":[[1, 2, 3]]}`, "", "3")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("typed", `{"Name":"count", "Args":[{"a":1}]}`, "must be array, got map", "")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("typed", `{"Name":"broken", "Args":[]}`, "return value 1 must be integer, got string", "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	abi, err := bc.GetABI("typed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := abi.FindFunction("transfer")
+	if fn == nil || fn.Arguments[0].Type != types.AbiAddress || fn.Arguments[1].Type != types.AbiBignum ||
+		fn.Arguments[2].Type != "" {
+		t.Errorf("unexpected ABI of transfer: %v", fn)
+	}
+	fn = abi.FindFunction("balanceOf")
+	if fn == nil || len(fn.Returns) != 1 || fn.Returns[0] != types.AbiBignum {
+		t.Errorf("unexpected ABI of balanceOf: %v", fn)
+	}
+
+	invalid := `
+function f(a) end
+abi.register(f, {"uint"})`
+	err = bc.ConnectBlock(NewLuaTxDef("ktlee", "invalid", 0, invalid))
+	if err == nil || !strings.Contains(err.Error(), "invalid argument type 'uint'") {
+		t.Errorf("expected the invalid type error, but got %v", err)
+	}
+}
+
+func TestViewPayable(t *testing.T) {
+	definition := `
+function deposit()
+	system.setItem("total", (system.getItem("total") or 0) + system.getAmount())
+end
+function reset()
+	system.setItem("total", 0)
+end
+function total()
+	return system.getItem("total")
+end
+abi.payable(deposit)
+abi.register(reset)
+abi.register_view(total)`
+	caller := `
+function depositTo(addr)
+	contract.call.value(10)(addr, "deposit")
+end
+function resetTo(addr)
+	contract.call.value(10)(addr, "reset")
+end
+abi.payable(depositTo, resetTo)`
+
+	SetChainParams(types.DefaultChainParams())
+	defer SetChainParams(types.LegacyChainParams())
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 1000),
+		NewLuaTxDef("ktlee", "bank", 0, definition),
+		NewLuaTxDef("ktlee", "caller", 0, caller),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "bank", 100, `{"Name":"deposit", "Args":[]}`),
+		NewLuaTxCall("ktlee", "bank", 0, `{"Name":"reset", "Args":[]}`),
+		NewLuaTxCall("ktlee", "bank", 100, `{"Name":"reset", "Args":[]}`).fail("reset is not payable"),
+		NewLuaTxCall("ktlee", "bank", 100, `{"Name":"deposit", "Args":[]}`),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("bank", `{"Name":"total", "Args":[]}`, "", "100")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("bank", `{"Name":"reset", "Args":[]}`, "reset is not a view function", "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	bank := types.EncodeAddress(strHash("bank"))
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "caller", 100, fmt.Sprintf(`{"Name":"depositTo", "Args":["%s"]}`, bank)),
+		NewLuaTxCall("ktlee", "caller", 100, fmt.Sprintf(`{"Name":"resetTo", "Args":["%s"]}`, bank)).fail("reset is not payable"),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("bank", `{"Name":"total", "Args":[]}`, "", "110")
+	if err != nil {
+		t.Error(err)
+	}
+
+	abi, err := bc.GetABI("bank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !abi.FindFunction("deposit").GetPayable() || abi.FindFunction("deposit").GetView() ||
+		!abi.FindFunction("total").GetView() || abi.FindFunction("reset").GetPayable() {
+		t.Errorf("unexpected ABI: %v", abi)
+	}
+}
+
+func TestViewPayableUnannotated(t *testing.T) {
+	definition := `
+function deposit()
+	system.setItem("total", (system.getItem("total") or 0) + system.getAmount())
+end
+function total()
+	return system.getItem("total")
+end
+abi.register(deposit, total)`
+
+	params := types.DefaultChainParams()
+	params.ViewPayableHeight = 3
+	SetChainParams(params)
+	defer SetChainParams(types.LegacyChainParams())
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 1000),
+		NewLuaTxDef("ktlee", "bank", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// any function accepts an amount and can be queried before the activation
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "bank", 100, `{"Name":"deposit", "Args":[]}`),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("bank", `{"Name":"total", "Args":[]}`, "", "100")
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "bank", 100, `{"Name":"deposit", "Args":[]}`).fail("deposit is not payable"),
+		NewLuaTxCall("ktlee", "bank", 0, `{"Name":"deposit", "Args":[]}`),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("bank", `{"Name":"total", "Args":[]}`, "total is not a view function", "")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestViewPayableLegacyABI(t *testing.T) {
+	SetChainParams(types.DefaultChainParams())
+	defer SetChainParams(types.LegacyChainParams())
+
+	// the contracts compiled before the flags are called as before
+	legacy := &Contract{abi: []byte(`{"version":"0.2","language":"lua","functions":[{"name":"f"}]}`)}
+	if err := legacy.checkView("f", 1); err != nil {
+		t.Error(err)
+	}
+	if err := legacy.checkPayable("f", 100, 1); err != nil {
+		t.Error(err)
+	}
+
+	flagged := &Contract{abi: []byte(`{"version":"0.3","language":"lua","functions":[{"name":"f"}]}`)}
+	if err := flagged.checkView("f", 1); err == nil {
+		t.Error("non-view function is queried")
+	}
+	if err := flagged.checkPayable("f", 100, 1); err == nil {
+		t.Error("non-payable function accepts an amount")
+	}
+}
+
+func TestBignum(t *testing.T) {
+	definition := `
+state.var {
+	balances = state.map()
+}
+function constructor()
+	balances[system.getSender()] = bignum.number("100000000000000000000000000")
+end
+function transfer(to, amount)
+	local from = system.getSender()
+	if balances[from] < amount then
+		error("not enough balance")
+	end
+	balances[from] = balances[from] - amount
+	balances[to] = (balances[to] or bignum.number(0)) + amount
+end
+function balanceOf(owner)
+	return balances[owner]
+end
+function calc()
+	local a = bignum.number("9007199254740993")
+	return tostring(a + 1), tostring(a * a), tostring(bignum.pow(2, 100)), tostring(-a % 10),
+		bignum.compare(a, 1), a == bignum.number("09007199254740993"), bignum.isbignum(a)
+end
+function roundtrip()
+	local b = bignum.number("-123456789012345678901234567890")
+	local t = json.decode(json.encode({v = b}))
+	return bignum.isbignum(t.v) and t.v == b
+end
+function divzero()
+	return bignum.number(1) / 0
+end
+function overflow()
+	return bignum.pow(2, 1024)
+end
+function sendBig(to)
+	contract.send(to, bignum.number("10"))
+end
+abi.register(transfer, {"address", "bignum"}, balanceOf, {"address", returns = "bignum"})
+abi.register_view(calc, roundtrip, divzero, overflow)
+abi.payable(sendBig)`
+
+	bc, err := LoadDummyChain()
+	if err != nil {
+		t.Errorf("failed to create test database: %v", err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("ktlee", 100),
+		NewLuaTxAccount("receiver", 0),
+		NewLuaTxDef("ktlee", "token", 0, definition),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	owner := types.EncodeAddress(strHash("ktlee"))
+	receiver := types.EncodeAddress(strHash("receiver"))
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "token", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", "9007199254740993"]}`, receiver)),
+		NewLuaTxCall("ktlee", "token", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", {"_bignum":"1"}]}`, receiver)),
+		NewLuaTxCall("ktlee", "token", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", "200000000000000000000000000"]}`, receiver)).fail("not enough balance"),
+		// a number beyond 2^53 is rounded by the JSON decoding
+		NewLuaTxCall("ktlee", "token", 0, fmt.Sprintf(`{"Name":"transfer", "Args":["%s", 9007199254740993]}`, receiver)).fail("must be bignum"),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("token", fmt.Sprintf(`{"Name":"balanceOf", "Args":["%s"]}`, owner), "", `{"_bignum":"99999999990992800745259006"}`)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("token", fmt.Sprintf(`{"Name":"balanceOf", "Args":["%s"]}`, receiver), "", `{"_bignum":"9007199254740994"}`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.Query("token", `{"Name":"calc", "Args":[]}`, "",
+		`["9007199254740994","81129638414606699710187514626049","1267650600228229401496703205376","-3",1,true,true]`)
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("token", `{"Name":"roundtrip", "Args":[]}`, "", "true")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("token", `{"Name":"divzero", "Args":[]}`, "division by zero", "")
+	if err != nil {
+		t.Error(err)
+	}
+	err = bc.Query("token", `{"Name":"overflow", "Args":[]}`, "bignum: overflow", "")
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = bc.ConnectBlock(
+		NewLuaTxCall("ktlee", "token", 50, fmt.Sprintf(`{"Name":"sendBig", "Args":["%s"]}`, receiver)),
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	state, err := bc.GetAccountState("receiver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.GetBalance() != 10 {
+		t.Errorf("balance: expected 10, but got %d", state.GetBalance())
+	}
+}
+
 // end of test-cases
@@ -0,0 +1,26 @@
+package common
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSipHash24(t *testing.T) {
+	// the test vectors of the reference implementation, keyed by 00 01 .. 0f
+	key := make([]byte, 16)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:])
+	data := make([]byte, 16)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	assert.Equal(t, uint64(0x726fdb47dd0e0e31), SipHash24(k0, k1, data[:0]))
+	assert.Equal(t, uint64(0x93f5f5799a932462), SipHash24(k0, k1, data[:8]))
+	assert.Equal(t, uint64(0xa129ca6149be45e5), SipHash24(k0, k1, data[:15]))
+}
@@ -8,6 +8,7 @@ package mempool
 import (
 	"bufio"
 	"bytes"
+	"container/heap"
 	"encoding/csv"
 	"io"
 	"os"
@@ -18,7 +19,6 @@ import (
 	"github.com/aergoio/aergo-actor/actor"
 	"github.com/aergoio/aergo-actor/router"
 	"github.com/aergoio/aergo-lib/log"
-	"github.com/aergoio/aergo/account/key"
 	cfg "github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/internal/enc"
@@ -44,29 +44,47 @@ type MemPool struct {
 
 	//curBestBlockHash
 	sdb         *state.ChainStateDB
+	chainIdHash []byte
+	params      *types.ChainParams
 	bestBlockID types.BlockID
+	bestBlockNo types.BlockNo
 	stateDB     *state.StateDB
 	verifier    *actor.PID
 	orphan      int
 	cache       map[types.TxID]*types.Tx
+	arrival     map[types.TxID]time.Time
+	bytes       int
 	pool        map[types.AccountID]*TxList
 	dumpPath    string
+	evicted     int
+	expired     int
 	status      int32
 	// followings are for test
 	testConfig bool
 	deadtx     int
 }
 
-// NewMemPoolService create and return new MemPool
-func NewMemPoolService(cfg *cfg.Config, sdb *state.ChainStateDB) *MemPool {
+// NewMemPoolService create and return new MemPool. If genesis is not nil, the
+// pool accepts only the txs bound to the chain of genesis and valid under its
+// parameters.
+func NewMemPoolService(cfg *cfg.Config, sdb *state.ChainStateDB, genesis *types.Genesis) *MemPool {
+	var chainIdHash []byte
+	params := types.LegacyChainParams()
+	if genesis != nil {
+		chainIdHash = genesis.ChainIdHash()
+		params = genesis.ChainParams()
+	}
 	actor := &MemPool{
-		cfg:      cfg,
-		sdb:      sdb,
-		cache:    map[types.TxID]*types.Tx{},
-		pool:     map[types.AccountID]*TxList{},
-		dumpPath: cfg.Mempool.DumpFilePath,
-		status:   initial,
-		verifier: nil,
+		cfg:         cfg,
+		sdb:         sdb,
+		chainIdHash: chainIdHash,
+		params:      params,
+		cache:       map[types.TxID]*types.Tx{},
+		arrival:     map[types.TxID]time.Time{},
+		pool:        map[types.AccountID]*TxList{},
+		dumpPath:    cfg.Mempool.DumpFilePath,
+		status:      initial,
+		verifier:    nil,
 		//testConfig:    true, // FIXME test config should be removed
 	}
 
@@ -127,7 +145,7 @@ func (mp *MemPool) Receive(context actor.Context) {
 
 	switch msg := context.Message().(type) {
 	case *message.MemPoolPut:
-		mp.verifier.Request(msg.Tx, context.Sender())
+		mp.verifier.Request(msg, context.Sender())
 	case *message.MemPoolGet:
 		txs, err := mp.get(msg.MaxBlockBodySize)
 		context.Respond(&message.MemPoolGetRsp{
@@ -144,6 +162,15 @@ func (mp *MemPool) Receive(context actor.Context) {
 		context.Respond(&message.MemPoolExistRsp{
 			Tx: tx,
 		})
+	case *message.MemPoolExistEx:
+		txs := mp.existsEx(msg.BlockHash, msg.ShortIDs)
+		context.Respond(&message.MemPoolExistExRsp{
+			Txs: txs,
+		})
+	case *message.MemPoolMetric:
+		context.Respond(&message.MemPoolMetricRsp{
+			Metric: mp.metric(),
+		})
 	case *actor.Started:
 		mp.loadTxs() // FIXME :work-around for actor settled
 
@@ -154,9 +181,26 @@ func (mp *MemPool) Receive(context actor.Context) {
 
 func (mp *MemPool) Statistics() *map[string]interface{} {
 	return &map[string]interface{}{
-		"total":  len(mp.cache),
-		"orphan": mp.orphan,
-		"dead":   mp.deadtx,
+		"total":    len(mp.cache),
+		"orphan":   mp.orphan,
+		"dead":     mp.deadtx,
+		"bytes":    mp.bytes,
+		"accounts": len(mp.pool),
+		"evicted":  mp.evicted,
+		"expired":  mp.expired,
+	}
+}
+
+func (mp *MemPool) metric() *types.MempoolMetric {
+	mp.RLock()
+	defer mp.RUnlock()
+	return &types.MempoolMetric{
+		Txs:      int64(len(mp.cache)),
+		Orphans:  int64(mp.orphan),
+		Accounts: int64(len(mp.pool)),
+		Bytes:    int64(mp.bytes),
+		Evicted:  int64(mp.evicted),
+		Expired:  int64(mp.expired),
 	}
 }
 
@@ -167,14 +211,26 @@ func (mp *MemPool) get(maxBlockBodySize uint32) ([]*types.Tx, error) {
 	count := 0
 	size := 0
 	txs := make([]*types.Tx, 0)
-Gather:
+
+	// pick the tx of the highest price among the first txs of the accounts
+	queue := make(txQueue, 0, len(mp.pool))
 	for _, list := range mp.pool {
-		for _, tx := range list.Get() {
-			if size += proto.Size(tx); uint32(size) > maxBlockBodySize {
-				break Gather
-			}
-			txs = append(txs, tx)
-			count++
+		if ready := list.Get(); len(ready) > 0 {
+			queue = append(queue, ready)
+		}
+	}
+	heap.Init(&queue)
+	for queue.Len() > 0 {
+		tx := queue[0][0]
+		if size += proto.Size(tx); uint32(size) > maxBlockBodySize {
+			break
+		}
+		txs = append(txs, tx)
+		count++
+		if queue[0] = queue[0][1:]; len(queue[0]) == 0 {
+			heap.Pop(&queue)
+		} else {
+			heap.Fix(&queue, 0)
 		}
 	}
 	elapsed := time.Since(start)
@@ -211,14 +267,40 @@ func (mp *MemPool) put(tx *types.Tx) error {
 		return err
 	}
 	defer mp.releaseMemPoolList(list)
+	if err = list.CheckLimit(tx, mp.cfg.Mempool.MaxAccountTxs, mp.cfg.Mempool.MaxAccountOrphans); err != nil {
+		return err
+	}
+	var replaced *types.Tx
+	var replacedArrival time.Time
 	diff, err := list.Put(tx)
+	if err == types.ErrSameNonceAlreadyInMempool {
+		if replaced, err = list.ReplaceByFee(tx, mp.cfg.Mempool.ReplaceBump); err == nil {
+			replacedArrival = mp.arrival[types.ToTxID(replaced.GetHash())]
+			mp.removeCache(replaced)
+			mp.Debug().Str("old", enc.ToString(replaced.GetHash())).
+				Str("new", enc.ToString(tx.GetHash())).Msg("tx replaced by fee")
+		}
+	}
 	if err != nil {
 		mp.Debug().Err(err).Msg("fail to put at a mempool list")
 		return err
 	}
 
 	mp.orphan -= diff
-	mp.cache[id] = tx
+	mp.addCache(tx)
+
+	for mp.overflow() {
+		if evicted := mp.evictLowestFee(list); evicted == tx {
+			// the tx replaced by fee is kept instead
+			if replaced != nil {
+				diff, _ = list.Put(replaced)
+				mp.orphan -= diff
+				mp.addCache(replaced)
+				mp.arrival[types.ToTxID(replaced.GetHash())] = replacedArrival
+			}
+			return types.ErrTxPoolFull
+		}
+	}
 	//mp.Debugf("tx add-ed size(%d, %d)[%s]", len(mp.cache), mp.orphan, tx.GetBody().String())
 
 	if !mp.testConfig {
@@ -248,6 +330,7 @@ func (mp *MemPool) setStateDB(block *types.Block) bool {
 			normal = false
 		}
 		mp.bestBlockID = newBlockID
+		mp.bestBlockNo = block.BlockNo()
 
 		stateRoot := block.GetHeader().GetBlocksRootHash()
 		if mp.stateDB == nil {
@@ -295,14 +378,15 @@ func (mp *MemPool) removeOnBlockArrival(block *types.Block) error {
 			// TODO : ????
 			continue
 		}
-		diff, delTxs := list.FilterByState(ns)
+		diff, delTxs := list.FilterByState(ns, mp.params.GasAt(mp.bestBlockNo+1))
 		mp.orphan -= diff
 		for _, tx := range delTxs {
-			delete(mp.cache, types.ToTxID(tx.GetHash())) // need lock
+			mp.removeCache(tx) // need lock
 		}
 		mp.releaseMemPoolList(list)
 		check++
 	}
+	mp.expireOrphans(start)
 
 	//FOR TEST
 	for _, tx := range block.GetBody().GetTxs() {
@@ -322,15 +406,27 @@ func (mp *MemPool) removeOnBlockArrival(block *types.Block) error {
 	return nil
 }
 
-// signiture verification
+// verifyTx verifies the sanity of tx without its sign, which is verified
+// by TxVerifier to report the peers sending wrong signs
 func (mp *MemPool) verifyTx(tx *types.Tx) error {
 	err := tx.Validate()
 	if err != nil {
 		return err
 	}
-	err = key.VerifyTx(tx)
-	if err != nil {
-		return err
+	// a tx without the chain ID hash is accepted before its activation height
+	if mp.chainIdHash != nil &&
+		(len(tx.GetBody().GetChainIdHash()) > 0 || mp.params.ChainIdAt(mp.bestBlockNo+1)) {
+		if err = tx.ValidateChainIdHash(mp.chainIdHash); err != nil {
+			return err
+		}
+	}
+	switch tx.GetBody().GetType() {
+	case types.TxType_NORMAL, types.TxType_REDEPLOY:
+		// the minimum price is applied before its activation height, which
+		// is only a policy of the pool
+		if err = tx.ValidateGasPrice(mp.params.MinGasPrice); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -344,7 +440,7 @@ func (mp *MemPool) validateTx(tx *types.Tx) error {
 	if err != nil {
 		return err
 	}
-	err = tx.ValidateWithSenderState(ns)
+	err = tx.ValidateWithSenderState(ns, mp.params.GasAt(mp.bestBlockNo+1))
 	if err != nil {
 		return err
 	}
@@ -368,6 +464,81 @@ func (mp *MemPool) validateTx(tx *types.Tx) error {
 	return nil
 }
 
+// evictLowestFee removes the tx of the lowest price among the txs of the
+// highest nonce in each account, so the remaining txs don't become orphans.
+// The last tx of the given list is evicted first among the txs of the same
+// price. It returns the evicted tx.
+func (mp *MemPool) evictLowestFee(list *TxList) *types.Tx {
+	target, lowest := list, list.Last()
+	for _, l := range mp.pool {
+		if last := l.Last(); last != nil &&
+			last.GetBody().GetPrice() < lowest.GetBody().GetPrice() {
+			target, lowest = l, last
+		}
+	}
+
+	diff, evicted := target.RemoveLast()
+	mp.orphan -= diff
+	mp.removeCache(evicted)
+	mp.evicted++
+	if target != list {
+		mp.releaseMemPoolList(target)
+	}
+	mp.Debug().Str("hash", enc.ToString(evicted.GetHash())).
+		Uint64("price", evicted.GetBody().GetPrice()).Msg("tx evicted from full mempool")
+
+	return evicted
+}
+
+// overflow checks whether mempool exceeds the maximum number or total size of
+// txs.
+func (mp *MemPool) overflow() bool {
+	if max := mp.cfg.Mempool.MaxPoolSize; max > 0 && len(mp.cache) > max {
+		return true
+	}
+	if max := mp.cfg.Mempool.MaxPoolBytes; max > 0 && mp.bytes > max {
+		return true
+	}
+	return false
+}
+
+// expireOrphans removes the orphans staying in mempool longer than the TTL.
+func (mp *MemPool) expireOrphans(now time.Time) {
+	ttl := time.Duration(mp.cfg.Mempool.OrphanTTL) * time.Second
+	if ttl <= 0 || mp.orphan == 0 {
+		return
+	}
+	expired := func(tx *types.Tx) bool {
+		return now.Sub(mp.arrival[types.ToTxID(tx.GetHash())]) > ttl
+	}
+	for _, list := range mp.pool {
+		removed := list.RemoveOrphans(expired)
+		for _, tx := range removed {
+			mp.removeCache(tx)
+		}
+		mp.orphan -= len(removed)
+		mp.expired += len(removed)
+		mp.releaseMemPoolList(list)
+	}
+}
+
+func (mp *MemPool) addCache(tx *types.Tx) {
+	id := types.ToTxID(tx.GetHash())
+	mp.cache[id] = tx
+	mp.arrival[id] = time.Now()
+	mp.bytes += proto.Size(tx)
+}
+
+func (mp *MemPool) removeCache(tx *types.Tx) {
+	id := types.ToTxID(tx.GetHash())
+	if _, ok := mp.cache[id]; !ok {
+		return
+	}
+	delete(mp.cache, id)
+	delete(mp.arrival, id)
+	mp.bytes -= proto.Size(tx)
+}
+
 func (mp *MemPool) exists(hash []byte) *types.Tx {
 	mp.RLock()
 	defer mp.RUnlock()
@@ -377,6 +548,41 @@ func (mp *MemPool) exists(hash []byte) *types.Tx {
 	return nil
 }
 
+// existsEx returns the txs of the short ids in the compact block of
+// blockHash. The tx is nil if no tx or more than one tx has the short id.
+func (mp *MemPool) existsEx(blockHash []byte, shortIDs [][]byte) []*types.Tx {
+	txs := make([]*types.Tx, len(shortIDs))
+	if len(shortIDs) == 0 {
+		return txs
+	}
+	indexes := make(map[string][]int, len(shortIDs))
+	for i, id := range shortIDs {
+		indexes[string(id)] = append(indexes[string(id)], i)
+	}
+	ambiguous := make(map[string]bool)
+
+	mp.RLock()
+	defer mp.RUnlock()
+	for _, tx := range mp.cache {
+		key := string(types.ShortTxID(blockHash, tx.Hash))
+		idx, ok := indexes[key]
+		if !ok || ambiguous[key] {
+			continue
+		}
+		if txs[idx[0]] != nil {
+			ambiguous[key] = true
+			for _, i := range idx {
+				txs[i] = nil
+			}
+			continue
+		}
+		for _, i := range idx {
+			txs[i] = tx
+		}
+	}
+	return txs
+}
+
 func (mp *MemPool) acquireMemPoolList(acc []byte) (*TxList, error) {
 	list := mp.getMemPoolList(acc)
 	if list != nil {
@@ -436,6 +642,7 @@ func (mp *MemPool) notifyNewTx(tx types.Tx) {
 	mp.RequestTo(message.P2PSvc, &message.NotifyNewTransactions{
 		Txs: []*types.Tx{&tx},
 	})
+	mp.TellTo(message.RPCSvc, &tx)
 }
 
 func (mp *MemPool) loadTxs() {
@@ -10,11 +10,13 @@ import (
 	"os"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aergoio/aergo/account/key"
 	"github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/types"
 	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,7 +71,7 @@ func simulateBlockGen(txs ...*types.Tx) error {
 func initTest(t *testing.T) {
 	serverCtx := config.NewServerContext("", "")
 	cfg := serverCtx.GetDefaultConfig().(*config.Config)
-	pool = NewMemPoolService(cfg, nil)
+	pool = NewMemPoolService(cfg, nil, nil)
 	pool.testConfig = true
 	pool.BeforeStart()
 
@@ -124,6 +126,14 @@ func genTx(acc int, rec int, nonce uint64, amount uint64) *types.Tx {
 	return &tx
 }
 
+func genTxWithPrice(acc int, rec int, nonce uint64, amount uint64, price uint64) *types.Tx {
+	tx := genTx(acc, rec, nonce, amount)
+	tx.Body.Price = price
+	tx.Body.Limit = 1000
+	tx.Hash = tx.CalculateTxHash()
+	return tx
+}
+
 func TestInvalidTransaction(t *testing.T) {
 
 	initTest(t)
@@ -222,6 +232,12 @@ func TestBasics2(t *testing.T) {
 	defer deinitTest()
 	txs := make([]*types.Tx, 0)
 
+	// more txs than the default limits
+	pool.cfg.Mempool.MaxPoolSize = 0
+	pool.cfg.Mempool.MaxPoolBytes = 0
+	pool.cfg.Mempool.MaxAccountTxs = 0
+	pool.cfg.Mempool.MaxAccountOrphans = 0
+
 	accCount := 1000
 	txCount := 1000
 	nonce := make([]uint64, txCount)
@@ -575,3 +591,200 @@ func TestDeleteInvokePriceFilterOut(t *testing.T) {
 	simulateBlockGen(txs[1:2]...)
 	checkRemainder(0, 0)
 }
+
+func TestGetOrderedByPrice(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	txs := []*types.Tx{
+		genTxWithPrice(0, 0, 1, 1, 1),
+		genTxWithPrice(0, 0, 2, 1, 5),
+		genTxWithPrice(1, 0, 1, 1, 3),
+		genTxWithPrice(2, 0, 1, 1, 2),
+		genTxWithPrice(2, 0, 2, 1, 4),
+	}
+	for _, tx := range txs {
+		assert.NoError(t, pool.put(tx), "tx should be accepted")
+	}
+
+	ret, err := pool.get(maxBlockBodySize)
+	assert.NoError(t, err, "get failed")
+	// the higher price comes first, but not before the tx of the lower nonce
+	expected := []*types.Tx{txs[2], txs[3], txs[4], txs[0], txs[1]}
+	assert.Equal(t, len(expected), len(ret))
+	for i := range expected {
+		assert.True(t, sameTx(expected[i], ret[i]), "%dth tx is wrong", i)
+	}
+}
+
+func TestReplaceByFee(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	pool.cfg.Mempool.ReplaceBump = 10
+
+	old := genTxWithPrice(0, 0, 1, 1, 100)
+	assert.NoError(t, pool.put(old))
+
+	err := pool.put(genTxWithPrice(0, 0, 1, 2, 105))
+	assert.EqualError(t, err, types.ErrSameNonceAlreadyInMempool.Error(), "bump is not enough")
+
+	tx := genTxWithPrice(0, 0, 1, 2, 110)
+	assert.NoError(t, pool.put(tx), "tx should replace the old one")
+	assert.Nil(t, pool.exists(old.GetHash()))
+	assert.NotNil(t, pool.exists(tx.GetHash()))
+
+	total, orphan := pool.Size()
+	assert.EqualValuesf(t, []int{total, orphan}, []int{1, 0}, "wrong mempool stat")
+}
+
+func TestEvictOnPoolFull(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	pool.cfg.Mempool.MaxPoolSize = 3
+
+	txs := []*types.Tx{
+		genTxWithPrice(0, 0, 1, 1, 5),
+		genTxWithPrice(0, 0, 2, 1, 1),
+		genTxWithPrice(1, 0, 1, 1, 3),
+	}
+	for _, tx := range txs {
+		assert.NoError(t, pool.put(tx), "tx should be accepted")
+	}
+
+	err := pool.put(genTxWithPrice(2, 0, 1, 1, 1))
+	assert.EqualError(t, err, types.ErrTxPoolFull.Error(), "tx of the lowest price should be denied")
+
+	// the last tx of account 0 is evicted, even though account 0 has a tx of a higher price
+	tx := genTxWithPrice(2, 0, 1, 1, 2)
+	assert.NoError(t, pool.put(tx), "tx should be accepted")
+	assert.Nil(t, pool.exists(txs[1].GetHash()))
+	assert.NotNil(t, pool.exists(txs[0].GetHash()))
+
+	total, orphan := pool.Size()
+	assert.EqualValuesf(t, []int{total, orphan}, []int{3, 0}, "wrong mempool stat")
+}
+
+func TestReplaceEvictedOnPoolFull(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	old := genTxWithPrice(0, 0, 1, 1, 5)
+	other := genTxWithPrice(1, 0, 1, 1, 2000)
+	pool.cfg.Mempool.MaxPoolBytes = proto.Size(old) + proto.Size(other)
+	assert.NoError(t, pool.put(old), "tx should be accepted")
+	assert.NoError(t, pool.put(other), "tx should be accepted")
+
+	// the replacement is larger than the old tx and evicted for its price
+	tx := genTxWithPrice(0, 0, 1, 1, 1000)
+	assert.EqualError(t, pool.put(tx), types.ErrTxPoolFull.Error())
+	assert.Nil(t, pool.exists(tx.GetHash()))
+	assert.NotNil(t, pool.exists(old.GetHash()), "replaced tx should be restored")
+	assert.Equal(t, pool.cfg.Mempool.MaxPoolBytes, pool.bytes)
+
+	total, orphan := pool.Size()
+	assert.EqualValuesf(t, []int{total, orphan}, []int{2, 0}, "wrong mempool stat")
+}
+
+func TestAccountLimit(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	pool.cfg.Mempool.MaxAccountTxs = 3
+	pool.cfg.Mempool.MaxAccountOrphans = 1
+
+	assert.NoError(t, pool.put(genTx(0, 0, 1, 1)), "tx should be accepted")
+	assert.NoError(t, pool.put(genTx(0, 0, 3, 1)), "orphan should be accepted")
+	err := pool.put(genTx(0, 0, 5, 1))
+	assert.EqualError(t, err, types.ErrTxOrphanLimit.Error(), "too many orphans")
+
+	assert.NoError(t, pool.put(genTx(0, 0, 2, 1)), "tx should be accepted")
+	err = pool.put(genTx(0, 0, 4, 1))
+	assert.EqualError(t, err, types.ErrTxAccountLimit.Error(), "too many txs")
+
+	// other accounts are not affected
+	assert.NoError(t, pool.put(genTx(1, 0, 1, 1)), "tx should be accepted")
+
+	total, orphan := pool.Size()
+	assert.EqualValuesf(t, []int{total, orphan}, []int{4, 0}, "wrong mempool stat")
+}
+
+func TestEvictOnPoolBytes(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	txs := []*types.Tx{
+		genTxWithPrice(0, 0, 1, 1, 3),
+		genTxWithPrice(1, 0, 1, 1, 1),
+	}
+	pool.cfg.Mempool.MaxPoolBytes = len(txs) * proto.Size(txs[0])
+	for _, tx := range txs {
+		assert.NoError(t, pool.put(tx), "tx should be accepted")
+	}
+
+	tx := genTxWithPrice(2, 0, 1, 1, 2)
+	assert.NoError(t, pool.put(tx), "tx should be accepted")
+	assert.Nil(t, pool.exists(txs[1].GetHash()))
+	assert.Equal(t, 1, pool.evicted)
+	assert.Equal(t, pool.cfg.Mempool.MaxPoolBytes, pool.bytes)
+}
+
+func TestExpireOrphans(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	txs := []*types.Tx{
+		genTx(0, 0, 1, 1),
+		genTx(0, 0, 3, 1),
+		genTx(1, 0, 2, 1),
+	}
+	errs := pool.puts(txs...)
+	for _, err := range errs {
+		assert.NoError(t, err, "tx should be accepted")
+	}
+
+	// nothing is expired before the TTL
+	pool.expireOrphans(time.Now())
+	total, orphan := pool.Size()
+	assert.EqualValuesf(t, []int{total, orphan}, []int{3, 2}, "wrong mempool stat")
+
+	ttl := time.Duration(pool.cfg.Mempool.OrphanTTL) * time.Second
+	pool.expireOrphans(time.Now().Add(ttl + time.Second))
+	total, orphan = pool.Size()
+	assert.EqualValuesf(t, []int{total, orphan}, []int{1, 0}, "wrong mempool stat")
+	assert.NotNil(t, pool.exists(txs[0].GetHash()), "processible tx should be kept")
+	assert.Equal(t, 2, pool.expired)
+	assert.Equal(t, 1, len(pool.pool))
+}
+
+func TestExistsEx(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	txs := []*types.Tx{
+		genTx(0, 0, 1, 1),
+		genTx(1, 0, 1, 1),
+	}
+	errs := pool.puts(txs...)
+	for _, err := range errs {
+		assert.NoError(t, err, "tx should be accepted")
+	}
+	unknown := genTx(2, 0, 1, 1)
+
+	blockHash := []byte("the hash of the compact block....")
+	shortIDs := [][]byte{
+		types.ShortTxID(blockHash, txs[1].Hash),
+		types.ShortTxID(blockHash, unknown.Hash),
+		types.ShortTxID(blockHash, txs[0].Hash),
+		types.ShortTxID(blockHash, txs[1].Hash),
+	}
+	found := pool.existsEx(blockHash, shortIDs)
+	assert.Equal(t, 4, len(found))
+	assert.True(t, sameTx(txs[1], found[0]))
+	assert.Nil(t, found[1])
+	assert.True(t, sameTx(txs[0], found[2]))
+	assert.True(t, sameTx(txs[1], found[3]))
+
+	// the short ids of other block don't match
+	found = pool.existsEx([]byte("the hash of the other block......"), shortIDs)
+	assert.Nil(t, found[0])
+	assert.Nil(t, found[2])
+}
@@ -115,9 +115,103 @@ func (tl *TxList) Put(tx *types.Tx) (int, error) {
 	return oldCnt - newCnt, nil
 }
 
+// CheckLimit checks whether tx can be put without exceeding the maximum number
+// of txs and orphans of the account. Replacing the tx of the same nonce is
+// always allowed. Zero limit means unlimited.
+func (tl *TxList) CheckLimit(tx *types.Tx, maxTxs int, maxOrphans int) error {
+	tl.RLock()
+	defer tl.RUnlock()
+
+	if _, found := tl.search(tx); found {
+		return nil
+	}
+	if maxTxs > 0 && len(tl.list) >= maxTxs {
+		return types.ErrTxAccountLimit
+	}
+	next := tl.base.Nonce + 1
+	if tl.ready > 0 {
+		next = tl.list[tl.ready-1].GetBody().GetNonce() + 1
+	}
+	if maxOrphans > 0 && tx.GetBody().GetNonce() > next && len(tl.list)-tl.ready >= maxOrphans {
+		return types.ErrTxOrphanLimit
+	}
+	return nil
+}
+
+// RemoveOrphans removes the orphans for which expired returns true. The
+// processible txs are not affected. It returns the removed txs.
+func (tl *TxList) RemoveOrphans(expired func(*types.Tx) bool) []*types.Tx {
+	tl.Lock()
+	defer tl.Unlock()
+
+	var removed []*types.Tx
+	left := tl.list[:tl.ready]
+	for _, tx := range tl.list[tl.ready:] {
+		if expired(tx) {
+			removed = append(removed, tx)
+		} else {
+			left = append(left, tx)
+		}
+	}
+	tl.list = left
+	return removed
+}
+
+// ReplaceByFee replaces the tx of the same nonce with tx, if the price of tx
+// is higher than the old one at least by bump percent. It returns the
+// replaced tx.
+func (tl *TxList) ReplaceByFee(tx *types.Tx, bump uint64) (*types.Tx, error) {
+	tl.Lock()
+	defer tl.Unlock()
+
+	index, found := tl.search(tx)
+	if !found {
+		return nil, types.ErrTxNotFound
+	}
+	old := tl.list[index]
+	oldPrice := old.GetBody().GetPrice()
+	// oldPrice * (100 + bump) / 100 without overflow
+	minPrice := oldPrice + oldPrice/100*bump + oldPrice%100*bump/100
+	if price := tx.GetBody().GetPrice(); price <= oldPrice || price < minPrice {
+		return nil, types.ErrSameNonceAlreadyInMempool
+	}
+	tl.list[index] = tx
+
+	return old, nil
+}
+
+// Last returns the tx of the highest nonce
+func (tl *TxList) Last() *types.Tx {
+	tl.RLock()
+	defer tl.RUnlock()
+	if len(tl.list) == 0 {
+		return nil
+	}
+	return tl.list[len(tl.list)-1]
+}
+
+// RemoveLast removes the tx of the highest nonce. Removing it doesn't make
+// any other tx orphan. It returns the number of orphans removed.
+func (tl *TxList) RemoveLast() (int, *types.Tx) {
+	tl.Lock()
+	defer tl.Unlock()
+	if len(tl.list) == 0 {
+		return 0, nil
+	}
+	last := len(tl.list) - 1
+	tx := tl.list[last]
+	tl.list = tl.list[:last]
+	if tl.ready > last {
+		tl.ready = last
+		return 0, tx
+	}
+	return 1, tx
+}
+
 // SetMinNonce sets new minimum nonce for TxList
 // evict on some transactions is possible due to minimum nonce
-func (tl *TxList) FilterByState(st *types.State) (int, []*types.Tx) {
+// gasMetered tells whether the fee of the txs is derived from their gas limit
+func (tl *TxList) FilterByState(st *types.State, gasMetered bool) (int, []*types.Tx) {
 	tl.Lock()
 	defer tl.Unlock()
 
@@ -137,7 +231,7 @@ func (tl *TxList) FilterByState(st *types.State) (int, []*types.Tx) {
 	var left []*types.Tx
 	removed := tl.list[:0]
 	for i, x := range tl.list {
-		err := x.ValidateWithSenderState(st)
+		err := x.ValidateWithSenderState(st, gasMetered)
 		if err == nil || err == types.ErrTxNonceToohigh {
 			if err != nil && !balCheck {
 				left = append(left, tl.list[i:]...)
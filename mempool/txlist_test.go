@@ -87,12 +87,12 @@ func TestListDel(t *testing.T) {
 	defer deinitTest()
 	mpl := NewTxList(nil, NewState(0, 0))
 
-	ret, txs := mpl.FilterByState(NewState(2, 100))
+	ret, txs := mpl.FilterByState(NewState(2, 100), true)
 	if ret != 0 || mpl.Len() != 0 || len(txs) != 0 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
 
-	ret, txs = mpl.FilterByState(NewState(0, 100))
+	ret, txs = mpl.FilterByState(NewState(0, 100), true)
 	if ret != 0 || mpl.Len() != 0 || len(txs) != 0 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
@@ -107,27 +107,27 @@ func TestListDel(t *testing.T) {
 		mpl.Put(genTx(0, 0, uint64(i+1), 0))
 	}
 	// 1, |2, 3, | x, 5, x, 7, | x, 9... 14, |15... 100
-	ret, txs = mpl.FilterByState(NewState(0, 100))
+	ret, txs = mpl.FilterByState(NewState(0, 100), true)
 	if ret != 0 || mpl.Len() != 3 || len(txs) != 0 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
 
-	ret, txs = mpl.FilterByState(NewState(1, 100))
+	ret, txs = mpl.FilterByState(NewState(1, 100), true)
 	if ret != 0 || mpl.Len() != 2 || len(txs) != 1 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
 
-	ret, txs = mpl.FilterByState(NewState(3, 100))
+	ret, txs = mpl.FilterByState(NewState(3, 100), true)
 	if ret != 0 || mpl.Len() != 0 || len(txs) != 2 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
 
-	ret, txs = mpl.FilterByState(NewState(7, 100))
+	ret, txs = mpl.FilterByState(NewState(7, 100), true)
 	if ret != 2 || mpl.Len() != 0 || len(txs) != 2 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
 
-	ret, txs = mpl.FilterByState(NewState(14, 100))
+	ret, txs = mpl.FilterByState(NewState(14, 100), true)
 	if ret != 92 || mpl.Len() != count-14 || len(txs) != 6 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
@@ -151,11 +151,11 @@ func TestListDelMiddle(t *testing.T) {
 	if mpl.Len() != 3 {
 		t.Error("should be 3 not ", len(mpl.list))
 	}
-	ret, txs := mpl.FilterByState(NewState(1, 100))
+	ret, txs := mpl.FilterByState(NewState(1, 100), true)
 	if ret != -3 || mpl.Len() != 0 || len(txs) != 0 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
-	ret, txs = mpl.FilterByState(NewState(4, 100))
+	ret, txs = mpl.FilterByState(NewState(4, 100), true)
 	if ret != 3 || mpl.Len() != 2 || len(txs) != 1 {
 		t.Error(ret, mpl.Len(), len(txs))
 	}
@@ -186,3 +186,85 @@ func TestListPutRandom(t *testing.T) {
 		t.Error("put failed", len(ret), count)
 	}
 }
+
+func TestListReplaceByFee(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	mpl := NewTxList(nil, NewState(0, 0))
+
+	old := genTxWithPrice(0, 0, 1, 0, 100)
+	mpl.Put(old)
+
+	if _, err := mpl.ReplaceByFee(genTxWithPrice(0, 0, 2, 0, 200), 10); err != types.ErrTxNotFound {
+		t.Errorf("replace should be failed with ErrTxNotFound, but %s", err)
+	}
+	if _, err := mpl.ReplaceByFee(genTxWithPrice(0, 0, 1, 0, 109), 10); err != types.ErrSameNonceAlreadyInMempool {
+		t.Errorf("replace should be failed with ErrSameNonceAlreadyInMempool, but %s", err)
+	}
+	tx := genTxWithPrice(0, 0, 1, 0, 110)
+	replaced, err := mpl.ReplaceByFee(tx, 10)
+	if err != nil || replaced != old || mpl.Get()[0] != tx {
+		t.Errorf("replace should be succeeded, but %s", err)
+	}
+}
+
+func TestListRemoveLast(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	mpl := NewTxList(nil, NewState(0, 0))
+
+	mpl.Put(genTx(0, 0, uint64(1), 0))
+	mpl.Put(genTx(0, 0, uint64(2), 0))
+	mpl.Put(genTx(0, 0, uint64(4), 0))
+
+	ret, tx := mpl.RemoveLast()
+	if ret != 1 || tx.GetBody().GetNonce() != 4 || mpl.Len() != 2 {
+		t.Error(ret, tx, mpl.Len())
+	}
+	ret, tx = mpl.RemoveLast()
+	if ret != 0 || tx.GetBody().GetNonce() != 2 || mpl.Len() != 1 {
+		t.Error(ret, tx, mpl.Len())
+	}
+	mpl.RemoveLast()
+	if ret, tx = mpl.RemoveLast(); ret != 0 || tx != nil || !mpl.Empty() {
+		t.Error(ret, tx, mpl.Empty())
+	}
+}
+
+func TestListCheckLimit(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	mpl := NewTxList(nil, NewState(0, 0))
+
+	mpl.Put(genTx(0, 0, uint64(1), 0))
+	mpl.Put(genTx(0, 0, uint64(3), 0))
+
+	if err := mpl.CheckLimit(genTx(0, 0, uint64(2), 0), 3, 1); err != nil {
+		t.Error("tx filling the gap should be allowed", err)
+	}
+	if err := mpl.CheckLimit(genTx(0, 0, uint64(4), 0), 3, 1); err != types.ErrTxOrphanLimit {
+		t.Error("orphan should be limited", err)
+	}
+	if err := mpl.CheckLimit(genTx(0, 0, uint64(2), 0), 2, 0); err != types.ErrTxAccountLimit {
+		t.Error("tx should be limited", err)
+	}
+	if err := mpl.CheckLimit(genTx(0, 0, uint64(1), 0), 2, 1); err != nil {
+		t.Error("tx of the same nonce should be allowed", err)
+	}
+}
+
+func TestListRemoveOrphans(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	mpl := NewTxList(nil, NewState(0, 0))
+
+	for _, nonce := range []uint64{1, 2, 4, 5, 7} {
+		mpl.Put(genTx(0, 0, nonce, 0))
+	}
+	removed := mpl.RemoveOrphans(func(tx *types.Tx) bool {
+		return tx.GetBody().GetNonce() != 5
+	})
+	if len(removed) != 2 || mpl.Len() != 2 || len(mpl.GetAll()) != 3 {
+		t.Error(len(removed), mpl.Len(), len(mpl.GetAll()))
+	}
+}
@@ -0,0 +1,41 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package mempool
+
+import (
+	"bytes"
+
+	"github.com/aergoio/aergo/types"
+)
+
+// txQueue is a max-heap of the processible txs per account, ordered by the
+// price of the first tx of each account. Popping the first tx of an account
+// exposes the tx of the next nonce, so the nonce order in an account is kept.
+type txQueue [][]*types.Tx
+
+func (q txQueue) Len() int { return len(q) }
+
+func (q txQueue) Less(i, j int) bool {
+	pi, pj := q[i][0].GetBody().GetPrice(), q[j][0].GetBody().GetPrice()
+	if pi == pj {
+		return bytes.Compare(q[i][0].GetBody().GetAccount(), q[j][0].GetBody().GetAccount()) < 0
+	}
+	return pi > pj
+}
+
+func (q txQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *txQueue) Push(x interface{}) {
+	*q = append(*q, x.([]*types.Tx))
+}
+
+func (q *txQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	txs := old[n-1]
+	*q = old[:n-1]
+	return txs
+}
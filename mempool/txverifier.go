@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"github.com/aergoio/aergo-actor/actor"
+	"github.com/aergoio/aergo/account/key"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/types"
 )
@@ -17,16 +18,30 @@ func NewTxVerifier(p *MemPool) *TxVerifier {
 //Receive actor message
 func (s *TxVerifier) Receive(context actor.Context) {
 	switch msg := context.Message().(type) {
-	case *types.Tx:
+	case *message.MemPoolPut:
+		tx := msg.Tx
 		var err error
-		if s.mp.exists(msg.GetHash()) != nil {
+		if s.mp.exists(tx.GetHash()) != nil {
 			err = types.ErrTxAlreadyInMempool
 		} else {
-			err = s.mp.verifyTx(msg)
+			err = s.mp.verifyTx(tx)
 			if err == nil {
-				err = s.mp.put(msg)
+				err = s.verifySign(msg)
+			}
+			if err == nil {
+				err = s.mp.put(tx)
 			}
 		}
 		context.Respond(&message.MemPoolPutRsp{Err: err})
 	}
 }
+
+// verifySign verifies the sign of the tx, and raises the misbehavior score
+// of the remote peer which sent the tx of a wrong sign
+func (s *TxVerifier) verifySign(msg *message.MemPoolPut) error {
+	err := key.VerifyTx(msg.Tx)
+	if err != nil && msg.PeerID != "" {
+		s.mp.TellTo(message.P2PSvc, &message.PeerMisbehavior{PeerID: msg.PeerID, Kind: message.MisbehaveInvalidTxSign})
+	}
+	return err
+}
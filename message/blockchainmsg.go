@@ -97,6 +97,30 @@ type GetABIRsp struct {
 	Err error
 }
 
+type ListEvents struct {
+	Filter *types.FilterInfo
+}
+type ListEventsRsp struct {
+	Events []*types.Event
+	Err    error
+}
+
+type TraceTx struct {
+	TxHash []byte
+}
+type TraceTxRsp struct {
+	Trace *types.TxTrace
+	Err   error
+}
+
+type SimulateTx struct {
+	Tx *types.Tx
+}
+type SimulateTxRsp struct {
+	Simulation *types.TxSimulation
+	Err        error
+}
+
 type GetQuery struct {
 	Contract  []byte
 	Queryinfo []byte
@@ -148,6 +172,14 @@ type GetStakingRsp struct {
 	Err     error
 }
 
+// GetPeerAllowlist requests the peer ids in the on-chain peer allowlist
+type GetPeerAllowlist struct{}
+
+type GetPeerAllowlistRsp struct {
+	PeerIDs []peer.ID
+	Err     error
+}
+
 type GetAnchors struct{}
 type GetAnchorsRsp struct {
 	Hashes [][]byte
@@ -166,3 +198,84 @@ type GetAncestorRsp struct {
 	Ancestor *types.BlockInfo
 	Err      error
 }
+
+// ExportStateChunk is request from p2p to get the leaves of the state trie of
+// Root from the key Start, for the snapshot sync of a remote peer.
+type ExportStateChunk struct {
+	Root       []byte
+	Start      []byte
+	Accounts   bool
+	MaxEntries int
+	MaxSize    int
+}
+type ExportStateChunkRsp struct {
+	Entries []*types.StateChunkEntry
+	Next    []byte
+	Err     error
+}
+
+// ExportSQLChunk is request from p2p to get a part of the sql database file of
+// a contract. The first database is read if Name is empty.
+type ExportSQLChunk struct {
+	Name    string
+	Offset  uint64
+	MaxSize int
+}
+type ExportSQLChunkRsp struct {
+	Name string
+	Data []byte
+	Size uint64
+	Next string
+	Err  error
+}
+
+// ImportStateChunk adds the verified leaves of a snapshot to the state trie of
+// Root. It returns the root of the updated trie.
+type ImportStateChunk struct {
+	Root    []byte
+	Entries []*types.StateChunkEntry
+}
+type ImportStateChunkRsp struct {
+	Root []byte
+	Err  error
+}
+
+// ImportSQLChunk writes a part of the sql database file of a contract, which
+// must be in the state of StateRoot.
+type ImportSQLChunk struct {
+	StateRoot []byte
+	Name      string
+	Offset    uint64
+	Data      []byte
+}
+type ImportSQLChunkRsp struct {
+	Err error
+}
+
+// GetSnapshotNo returns the number of the block at or below BlockNo whose
+// snapshot can be installed.
+type GetSnapshotNo struct {
+	BlockNo types.BlockNo
+}
+type GetSnapshotNoRsp struct {
+	BlockNo types.BlockNo
+}
+
+// GetAncestorVerifier returns a verifier of the consensus for the headers
+// below the block of a snapshot.
+type GetAncestorVerifier struct{}
+type GetAncestorVerifierRsp struct {
+	Verifier types.AncestorVerifier
+	Err      error
+}
+
+// InstallSnapshot sets the block of an imported snapshot as the best block.
+// Descendants are the headers of the blocks following it in the ascending
+// order, which confirm that the block is irreversible.
+type InstallSnapshot struct {
+	Block       *types.Block
+	Descendants []*types.BlockHeader
+}
+type InstallSnapshotRsp struct {
+	Err error
+}
@@ -7,6 +7,7 @@ package message
 
 import (
 	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-peer"
 )
 
 // MemPoolSvc is exported name for MemPool service
@@ -15,6 +16,8 @@ const MemPoolSvc = "MemPoolSvc"
 // MemPoolPut is interface of MemPool service for inserting transactions
 type MemPoolPut struct {
 	Tx *types.Tx
+	// PeerID is the remote peer which sent the tx, or empty if the tx is not from a peer
+	PeerID peer.ID
 }
 
 // MemPoolPutRsp defines struct of result for MemPoolPut
@@ -44,6 +47,19 @@ type MemPoolExistRsp struct {
 	Tx *types.Tx
 }
 
+// MemPoolExistEx is interface of MemPool service for retrieving transactions
+// according to given short ids in the compact block of BlockHash
+type MemPoolExistEx struct {
+	BlockHash []byte
+	ShortIDs  [][]byte
+}
+
+// MemPoolExistExRsp defines struct of result for MemPoolExistEx. Txs are in
+// the order of the short ids, and nil if not found or ambiguous
+type MemPoolExistExRsp struct {
+	Txs []*types.Tx
+}
+
 // MemPoolDel is interface of MemPool service for deleting transactions
 // including given transactions
 type MemPoolDel struct {
@@ -54,3 +70,13 @@ type MemPoolDel struct {
 type MemPoolDelRsp struct {
 	Err error
 }
+
+// MemPoolMetric is interface of MemPool service for retrieving the metric of
+// mempool
+type MemPoolMetric struct {
+}
+
+// MemPoolMetricRsp defines struct of result for MemPoolMetric
+type MemPoolMetricRsp struct {
+	Metric *types.MempoolMetric
+}
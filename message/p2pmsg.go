@@ -128,6 +128,10 @@ type GetPeersRsp struct {
 	// last received block notice
 	LastBlks []*types.NewBlockNotice
 	States   []types.PeerState
+	// misbehavior scores of peers
+	Scores []int32
+	// banned peers, which are not connected
+	Banned []*types.Peer
 }
 
 type GetMetrics struct {
@@ -167,3 +171,107 @@ type GetHashByNoRsp struct {
 	BlockHash   BlockHash
 	Err      error
 }
+
+// GetStateChunk is sent from Syncer, send types.GetStateChunkRequest to dest peer.
+// At most MaxEntries entries are returned if it is not 0.
+type GetStateChunk struct {
+	ToWhom     peer.ID
+	Root       []byte
+	Start      []byte
+	Accounts   bool
+	MaxEntries uint32
+}
+
+// GetStateChunkRsp is data from other peer, as a response of types.GetStateChunkRequest
+type GetStateChunkRsp struct {
+	Entries []*types.StateChunkEntry
+	Next    []byte
+	Err     error
+}
+
+// GetSyncHeaders is sent from Syncer, send types.GetBlockHeadersRequest to dest peer.
+// The headers are returned from Height in the descending order.
+type GetSyncHeaders struct {
+	ToWhom  peer.ID
+	Height  uint64
+	MaxSize uint32
+}
+
+// GetSyncHeadersRsp is data from other peer, as a response of types.GetBlockHeadersRequest
+type GetSyncHeadersRsp struct {
+	Hashes  []BlockHash
+	Headers []*types.BlockHeader
+	Err     error
+}
+
+// GetSQLChunk is sent from Syncer, send types.GetSQLChunkRequest to dest peer.
+type GetSQLChunk struct {
+	ToWhom peer.ID
+	Name   string
+	Offset uint64
+}
+
+// GetSQLChunkRsp is data from other peer, as a response of types.GetSQLChunkRequest
+type GetSQLChunkRsp struct {
+	Name string
+	Data []byte
+	Size uint64
+	Next string
+	Err  error
+}
+
+// Misbehavior is a kind of misbehaviors of a remote peer
+type Misbehavior int
+
+const (
+	// MisbehaveInvalidMessage is sending a malformed or unknown message
+	MisbehaveInvalidMessage Misbehavior = iota
+	// MisbehaveInvalidBlock is sending a block failed to be validated
+	MisbehaveInvalidBlock
+	// MisbehaveInvalidTxSign is sending a tx with an invalid signature
+	MisbehaveInvalidTxSign
+	// MisbehaveFetchFail is failing or timing out to respond to a fetch of blocks
+	MisbehaveFetchFail
+	// MisbehaveStateMismatch is sending a block whose state root differs from
+	// the one executed locally
+	MisbehaveStateMismatch
+)
+
+// PeerMisbehavior is sent to p2p actor to raise the misbehavior score of a
+// remote peer. The peer is disconnected or banned if the score is too high.
+type PeerMisbehavior struct {
+	PeerID peer.ID
+	Kind   Misbehavior
+}
+
+// GetAddrBook requests the entries of the address book of known peers. The
+// actor returns GetAddrBookRsp
+type GetAddrBook struct {
+}
+
+type GetAddrBookRsp struct {
+	Entries []*types.AddrBookEntry
+}
+
+// PruneAddrBook removes the entry of PeerID, or the entries which are not
+// seen for MaxAge if PeerID is empty, from the address book. The actor
+// returns PruneAddrBookRsp with the removed entries.
+type PruneAddrBook struct {
+	PeerID peer.ID
+	MaxAge time.Duration
+}
+
+type PruneAddrBookRsp struct {
+	Pruned []*types.AddrBookEntry
+}
+
+// PeerAllowlistChanged is sent from chain service when the on-chain peer
+// allowlist is changed by a connected block or a reorganization.
+type PeerAllowlistChanged struct {
+	PeerIDs []peer.ID
+}
+
+// GetAllowedPeers requests the effective peer allowlist of the permissioned
+// network. The actor returns *types.PeerAllowlist
+type GetAllowedPeers struct {
+}
@@ -29,3 +29,8 @@ type SyncStop struct {
 type CloseFetcher struct {
 	FromWho string
 }
+
+type SnapshotResult struct {
+	Block *types.Block
+	Err   error
+}
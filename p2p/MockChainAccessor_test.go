@@ -60,6 +60,22 @@ func (_m *MockChainAccessor) GetBlock(blockHash []byte) (*types.Block, error) {
 	return r0, r1
 }
 
+// GetGenesisInfo provides a mock function with given fields:
+func (_m *MockChainAccessor) GetGenesisInfo() *types.Genesis {
+	ret := _m.Called()
+
+	var r0 *types.Genesis
+	if rf, ok := ret.Get(0).(func() *types.Genesis); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Genesis)
+		}
+	}
+
+	return r0
+}
+
 // GetBlock provides a mock function with given fields: blockHash
 func (_m *MockChainAccessor)GetHashByNo(blockNo types.BlockNo) ([]byte, error) {
 	ret := _m.Called(blockNo)
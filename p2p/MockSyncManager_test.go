@@ -17,6 +17,11 @@ func (_m *MockSyncManager) HandleNewBlockNotice(peer RemotePeer, hash BlkHash, d
 	_m.Called(peer, hash, data)
 }
 
+// HandleNewCompactBlock provides a mock function with given fields: peer, hash, data
+func (_m *MockSyncManager) HandleNewCompactBlock(peer RemotePeer, hash BlkHash, data *types.CompactBlock) {
+	_m.Called(peer, hash, data)
+}
+
 // HandleNewBlockNotice provides a mock function with given fields: peer, hash, data
 func (_m *MockSyncManager) HandleGetBlockResponse(peer RemotePeer, msg Message, resp *types.GetBlockResponse) {
 	_m.Called(peer, msg, resp)
@@ -116,19 +116,69 @@ func (p2ps *P2P) GetBlockHashByNo(context actor.Context, msg *message.GetHashByN
 	receiver.StartGet()
 }
 
-// NotifyNewBlock send notice message of new block to a peer
+// GetStateChunk send request message to peer and make response message for the leaves of a state trie
+func (p2ps *P2P) GetStateChunk(context actor.Context, msg *message.GetStateChunk) {
+	peerID := msg.ToWhom
+	remotePeer, exists := p2ps.pm.GetPeer(peerID)
+	if !exists {
+		p2ps.Warn().Str(LogPeerID, peerID.Pretty()).Str(LogProtoID, GetStateChunkRequest.String()).Msg("Invalid peerID")
+		context.Respond(&message.GetStateChunkRsp{Err: message.PeerNotFoundError})
+		return
+	}
+	receiver := NewStateChunkReceiver(p2ps, remotePeer, msg, fetchTimeOut)
+	receiver.StartGet()
+}
+
+// GetSyncHeaders send request message to peer and make response message for the headers of blocks
+func (p2ps *P2P) GetSyncHeaders(context actor.Context, msg *message.GetSyncHeaders) {
+	peerID := msg.ToWhom
+	remotePeer, exists := p2ps.pm.GetPeer(peerID)
+	if !exists {
+		p2ps.Warn().Str(LogPeerID, peerID.Pretty()).Str(LogProtoID, GetBlockHeadersRequest.String()).Msg("Invalid peerID")
+		context.Respond(&message.GetSyncHeadersRsp{Err: message.PeerNotFoundError})
+		return
+	}
+	receiver := NewSyncHeadersReceiver(p2ps, remotePeer, msg, fetchTimeOut)
+	receiver.StartGet()
+}
+
+// GetSQLChunk send request message to peer and make response message for a part of sql database file
+func (p2ps *P2P) GetSQLChunk(context actor.Context, msg *message.GetSQLChunk) {
+	peerID := msg.ToWhom
+	remotePeer, exists := p2ps.pm.GetPeer(peerID)
+	if !exists {
+		p2ps.Warn().Str(LogPeerID, peerID.Pretty()).Str(LogProtoID, GetSQLChunkRequest.String()).Msg("Invalid peerID")
+		context.Respond(&message.GetSQLChunkRsp{Err: message.PeerNotFoundError})
+		return
+	}
+	receiver := NewSQLChunkReceiver(p2ps, remotePeer, msg, fetchTimeOut)
+	receiver.StartGet()
+}
+
+// NotifyNewBlock send notice message of new block to a peer. The notice is the compact block,
+// if compact block is enabled and the peer told in handshake that it supports compact blocks.
 func (p2ps *P2P) NotifyNewBlock(newBlock message.NotifyNewBlock) bool {
-	req := &types.NewBlockNotice{
-		BlockHash: newBlock.Block.BlockHash(),
-		BlockNo:   newBlock.BlockNo}
-	msg := p2ps.mf.newMsgBlkBroadcastOrder(req)
+	var noticeMsg, compactMsg msgOrder
 
 	skipped, sent := 0, 0
 	// create message data
 	for _, neighbor := range p2ps.pm.GetPeers() {
 		if neighbor != nil && neighbor.State() == types.RUNNING {
 			sent++
-			neighbor.sendMessage(msg)
+			if p2ps.compactBlock && neighbor.Meta().CompactBlock {
+				if compactMsg == nil {
+					compactMsg = p2ps.mf.newMsgCompactBlkBroadcastOrder(newCompactBlock(newBlock.Block))
+				}
+				neighbor.sendMessage(compactMsg)
+				continue
+			}
+			if noticeMsg == nil {
+				req := &types.NewBlockNotice{
+					BlockHash: newBlock.Block.BlockHash(),
+					BlockNo:   newBlock.BlockNo}
+				noticeMsg = p2ps.mf.newMsgBlkBroadcastOrder(req)
+			}
+			neighbor.sendMessage(noticeMsg)
 		} else {
 			skipped++
 		}
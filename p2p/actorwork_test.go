@@ -9,6 +9,7 @@ import (
 	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/types"
 	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
@@ -87,3 +88,44 @@ func TestP2P_GetBlockHashByNo(t *testing.T) {
 	mockMF.AssertNumberOfCalls(t, "newMsgBlockRequestOrder", 1)
 	mockPeer.AssertNumberOfCalls(t, "sendMessage", 1)
 }
+
+func TestP2P_NotifyNewBlock(t *testing.T) {
+	block := types.NewBlock(nil, nil, make(types.Receipts, 0), make([]*types.Tx, 0), nil, 0)
+	sampleMsg := message.NotifyNewBlock{BlockNo: 1, Block: block}
+
+	// the compact block is sent only to the peer which supports it
+	mockPM := new(MockPeerManager)
+	mockMF := new(MockMoFactory)
+	compactPeer := new(MockRemotePeer)
+	noticePeer := new(MockRemotePeer)
+	for _, p := range []*MockRemotePeer{compactPeer, noticePeer} {
+		p.On("State").Return(types.RUNNING)
+		p.On("sendMessage", mock.Anything)
+	}
+	compactPeer.On("Meta").Return(PeerMeta{ID: dummyPeerID, CompactBlock: true})
+	noticePeer.On("Meta").Return(PeerMeta{ID: dummyPeerID2})
+	mockPM.On("GetPeers").Return([]RemotePeer{compactPeer, noticePeer})
+	mockMF.On("newMsgCompactBlkBroadcastOrder", mock.AnythingOfType("*types.CompactBlock")).Return(dummyMo)
+	mockMF.On("newMsgBlkBroadcastOrder", mock.AnythingOfType("*types.NewBlockNotice")).Return(dummyMo)
+
+	ps := &P2P{compactBlock: true}
+	ps.BaseComponent = component.NewBaseComponent(message.P2PSvc, ps, log.NewLogger("p2p"))
+	ps.pm = mockPM
+	ps.mf = mockMF
+	ps.NotifyNewBlock(sampleMsg)
+
+	mockMF.AssertNumberOfCalls(t, "newMsgCompactBlkBroadcastOrder", 1)
+	mockMF.AssertNumberOfCalls(t, "newMsgBlkBroadcastOrder", 1)
+	compactPeer.AssertNumberOfCalls(t, "sendMessage", 1)
+	noticePeer.AssertNumberOfCalls(t, "sendMessage", 1)
+
+	// block notices are sent to every peer if compact block is disabled
+	mockMF = new(MockMoFactory)
+	mockMF.On("newMsgBlkBroadcastOrder", mock.AnythingOfType("*types.NewBlockNotice")).Return(dummyMo)
+	ps.compactBlock = false
+	ps.mf = mockMF
+	ps.NotifyNewBlock(sampleMsg)
+
+	mockMF.AssertNotCalled(t, "newMsgCompactBlkBroadcastOrder", mock.Anything)
+	mockMF.AssertNumberOfCalls(t, "newMsgBlkBroadcastOrder", 1)
+}
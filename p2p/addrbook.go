@@ -0,0 +1,395 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+const (
+	addrBookFileName = "addrbook.json"
+
+	// addrBackoffBase is the time to wait before connecting again to a peer
+	// which failed once. It doubles on each consecutive failure up to addrBackoffMax.
+	addrBackoffBase = time.Second * 30
+	addrBackoffMax  = time.Hour
+)
+
+// The sources of the addresses in the address book
+const (
+	AddrSourceConfig   = "config"
+	AddrSourceExchange = "exchange"
+	AddrSourceInbound  = "inbound"
+)
+
+// maxAddrsPerSource limits the entries learned from each source, so that
+// the addresses spread by others can't fill the address book. The entries of
+// the config are not limited.
+var maxAddrsPerSource = map[string]int{
+	AddrSourceExchange: 1000,
+	AddrSourceInbound:  200,
+}
+
+// knownAddress is an entry of the address book
+type knownAddress struct {
+	meta        PeerMeta
+	source      string
+	added       time.Time
+	lastSeen    time.Time
+	lastAttempt time.Time
+	successes   uint32
+	failures    uint32
+	// failStreak is the count of the consecutive failures, which decides the backoff
+	failStreak uint32
+}
+
+// nextAttempt returns the time after which the peer can be connected again
+func (ka *knownAddress) nextAttempt() time.Time {
+	if ka.failStreak == 0 {
+		return ka.lastAttempt
+	}
+	backoff := addrBackoffMax
+	if ka.failStreak <= 7 {
+		backoff = addrBackoffBase << (ka.failStreak - 1)
+		if backoff > addrBackoffMax {
+			backoff = addrBackoffMax
+		}
+	}
+	return ka.lastAttempt.Add(backoff)
+}
+
+// worseThan returns true if ka has the worse failure record than other, or
+// was less recently active for the same record
+func (ka *knownAddress) worseThan(other *knownAddress) bool {
+	if ka.failStreak != other.failStreak {
+		return ka.failStreak > other.failStreak
+	}
+	if ka.failures != other.failures {
+		return ka.failures > other.failures
+	}
+	return ka.lastActive().Before(other.lastActive())
+}
+
+// lastActive returns the last time the peer was seen, or added if never seen
+func (ka *knownAddress) lastActive() time.Time {
+	if ka.lastSeen.After(ka.added) {
+		return ka.lastSeen
+	}
+	return ka.added
+}
+
+func (ka *knownAddress) toEntry() *types.AddrBookEntry {
+	addr := ka.meta.ToPeerAddress()
+	entry := &types.AddrBookEntry{
+		Address:   &addr,
+		Source:    ka.source,
+		Successes: ka.successes,
+		Failures:  ka.failures,
+	}
+	if !ka.lastSeen.IsZero() {
+		entry.LastSeen = ka.lastSeen.Unix()
+	}
+	if !ka.lastAttempt.IsZero() {
+		entry.LastAttempt = ka.lastAttempt.Unix()
+	}
+	return entry
+}
+
+// knownAddressJSON is the form of knownAddress in the address book file
+type knownAddressJSON struct {
+	ID          string `json:"id"`
+	IP          string `json:"ip"`
+	Port        uint32 `json:"port"`
+	Source      string `json:"source"`
+	Added       int64  `json:"added"`
+	LastSeen    int64  `json:"lastSeen,omitempty"`
+	LastAttempt int64  `json:"lastAttempt,omitempty"`
+	Successes   uint32 `json:"successes,omitempty"`
+	Failures    uint32 `json:"failures,omitempty"`
+	FailStreak  uint32 `json:"failStreak,omitempty"`
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func timeOrZero(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// addrBook keeps the addresses of the known peers with the results of the
+// connections to them, and saves them in the data directory so that the
+// peers learned from others are still known after restart.
+type addrBook struct {
+	logger *log.Logger
+	mutex  sync.Mutex
+	file   string
+	dirty  bool
+
+	addrs map[peer.ID]*knownAddress
+}
+
+func newAddrBook(dataDir string, logger *log.Logger) *addrBook {
+	ab := &addrBook{
+		logger: logger,
+		addrs:  make(map[peer.ID]*knownAddress),
+	}
+	if dataDir != "" {
+		ab.file = filepath.Join(dataDir, addrBookFileName)
+		if err := ab.load(); err != nil {
+			logger.Warn().Err(err).Str("file", ab.file).Msg("failed to load address book")
+		}
+	}
+	return ab
+}
+
+// add adds the address of meta learned from source, or updates the address
+// of the known peer. It must be called inside ab.mutex
+func (ab *addrBook) add(meta PeerMeta, source string, now time.Time) *knownAddress {
+	meta.Designated = false
+	meta.Outbound = true
+	ka, found := ab.addrs[meta.ID]
+	if !found {
+		ab.evictFor(source)
+		ka = &knownAddress{meta: meta, source: source, added: now}
+		ab.addrs[meta.ID] = ka
+		ab.dirty = true
+	} else if ka.meta.IPAddress != meta.IPAddress || ka.meta.Port != meta.Port {
+		ka.meta = meta
+		ab.dirty = true
+	}
+	return ka
+}
+
+// evictFor removes the entry of the worst failure record from source if it
+// is full to add a new entry. It must be called inside ab.mutex
+func (ab *addrBook) evictFor(source string) {
+	max, limited := maxAddrsPerSource[source]
+	if !limited {
+		return
+	}
+	var worst *knownAddress
+	count := 0
+	for _, ka := range ab.addrs {
+		if ka.source != source {
+			continue
+		}
+		count++
+		if worst == nil || ka.worseThan(worst) {
+			worst = ka
+		}
+	}
+	if count >= max {
+		ab.logger.Debug().Str(LogPeerID, worst.meta.ID.Pretty()).Str("source", source).Uint32("failures", worst.failures).Msg("Evicting address from full address book")
+		delete(ab.addrs, worst.meta.ID)
+	}
+}
+
+// addAddresses adds the addresses of metas learned from source. The address
+// of a peer connected before is not replaced by the one exchanged from
+// others, which may be forged to divert the peer. It is updated once the peer
+// is connected at the new address.
+func (ab *addrBook) addAddresses(metas []PeerMeta, source string) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	now := time.Now()
+	for _, meta := range metas {
+		if meta.IPAddress == "" || meta.Port == 0 {
+			continue
+		}
+		if ka, found := ab.addrs[meta.ID]; found && source == AddrSourceExchange && ka.successes > 0 {
+			continue
+		}
+		ab.add(meta, source, now)
+	}
+}
+
+// markSuccess records that the peer of meta is connected
+func (ab *addrBook) markSuccess(meta PeerMeta, source string) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	now := time.Now()
+	ka := ab.add(meta, source, now)
+	ka.lastSeen = now
+	ka.lastAttempt = now
+	ka.successes++
+	ka.failStreak = 0
+	ab.dirty = true
+}
+
+// markFailure records that the connection to the peer of meta is failed
+func (ab *addrBook) markFailure(meta PeerMeta, source string) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	now := time.Now()
+	ka := ab.add(meta, source, now)
+	ka.lastAttempt = now
+	ka.failures++
+	ka.failStreak++
+	ab.dirty = true
+}
+
+// isReady returns false if peer id failed recently and is waiting for the backoff
+func (ab *addrBook) isReady(id peer.ID, now time.Time) bool {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	ka, found := ab.addrs[id]
+	return !found || !now.Before(ka.nextAttempt())
+}
+
+// candidates returns at most max peers which are not waiting for the backoff,
+// the recently seen and less failed ones first
+func (ab *addrBook) candidates(now time.Time, max int) []PeerMeta {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	ready := make([]*knownAddress, 0, len(ab.addrs))
+	for _, ka := range ab.addrs {
+		if !now.Before(ka.nextAttempt()) {
+			ready = append(ready, ka)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		if !ready[i].lastSeen.Equal(ready[j].lastSeen) {
+			return ready[i].lastSeen.After(ready[j].lastSeen)
+		}
+		return ready[i].failures < ready[j].failures
+	})
+	if len(ready) > max {
+		ready = ready[:max]
+	}
+	metas := make([]PeerMeta, len(ready))
+	for i, ka := range ready {
+		metas[i] = ka.meta
+	}
+	return metas
+}
+
+// entries returns all the entries of the address book
+func (ab *addrBook) entries() []*types.AddrBookEntry {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	entries := make([]*types.AddrBookEntry, 0, len(ab.addrs))
+	for _, ka := range ab.addrs {
+		entries = append(entries, ka.toEntry())
+	}
+	return entries
+}
+
+// prune removes the entry of peer id, or the entries which are not seen for
+// maxAge if id is empty, and returns the removed entries
+func (ab *addrBook) prune(id peer.ID, maxAge time.Duration) []*types.AddrBookEntry {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	pruned := make([]*types.AddrBookEntry, 0)
+	if id != "" {
+		if ka, found := ab.addrs[id]; found {
+			pruned = append(pruned, ka.toEntry())
+			delete(ab.addrs, id)
+		}
+	} else if maxAge > 0 {
+		limit := time.Now().Add(-maxAge)
+		for pid, ka := range ab.addrs {
+			if ka.lastActive().Before(limit) {
+				pruned = append(pruned, ka.toEntry())
+				delete(ab.addrs, pid)
+			}
+		}
+	}
+	if len(pruned) > 0 {
+		ab.dirty = true
+	}
+	return pruned
+}
+
+// load reads the entries from the address book file
+func (ab *addrBook) load() error {
+	data, err := ioutil.ReadFile(ab.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var saved []knownAddressJSON
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	for _, a := range saved {
+		id, err := peer.IDB58Decode(a.ID)
+		if err != nil {
+			ab.logger.Warn().Str(LogPeerID, a.ID).Msg("invalid peer id in address book")
+			continue
+		}
+		ab.addrs[id] = &knownAddress{
+			meta:        PeerMeta{ID: id, IPAddress: a.IP, Port: a.Port, Outbound: true},
+			source:      a.Source,
+			added:       timeOrZero(a.Added),
+			lastSeen:    timeOrZero(a.LastSeen),
+			lastAttempt: timeOrZero(a.LastAttempt),
+			successes:   a.Successes,
+			failures:    a.Failures,
+			failStreak:  a.FailStreak,
+		}
+	}
+	return nil
+}
+
+// save writes the entries to the address book file if they are changed
+func (ab *addrBook) save() error {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	if ab.file == "" || !ab.dirty {
+		return nil
+	}
+	saved := make([]knownAddressJSON, 0, len(ab.addrs))
+	for _, ka := range ab.addrs {
+		saved = append(saved, knownAddressJSON{
+			ID:          peer.IDB58Encode(ka.meta.ID),
+			IP:          ka.meta.IPAddress,
+			Port:        ka.meta.Port,
+			Source:      ka.source,
+			Added:       unixOrZero(ka.added),
+			LastSeen:    unixOrZero(ka.lastSeen),
+			LastAttempt: unixOrZero(ka.lastAttempt),
+			Successes:   ka.successes,
+			Failures:    ka.failures,
+			FailStreak:  ka.failStreak,
+		})
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(ab.file, data, 0644); err != nil {
+		return err
+	}
+	ab.dirty = false
+	return nil
+}
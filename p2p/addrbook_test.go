@@ -0,0 +1,160 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnownAddress_nextAttempt(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		failStreak uint32
+		want       time.Duration
+	}{
+		{"TNoFail", 0, 0},
+		{"TOnce", 1, addrBackoffBase},
+		{"TTwice", 2, addrBackoffBase * 2},
+		{"TLong", 7, addrBackoffBase * 64},
+		{"TMax", 8, addrBackoffMax},
+		{"TOverflow", 100, addrBackoffMax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ka := &knownAddress{lastAttempt: now, failStreak: tt.failStreak}
+			assert.Equal(t, now.Add(tt.want), ka.nextAttempt())
+		})
+	}
+}
+
+func TestAddrBook_backoff(t *testing.T) {
+	ab := newAddrBook("", logger)
+	meta1 := PeerMeta{ID: dummyPeerID, IPAddress: "192.168.1.2", Port: 7846}
+	meta2 := PeerMeta{ID: dummyPeerID2, IPAddress: "192.168.1.3", Port: 7846}
+	ab.addAddresses([]PeerMeta{meta1, meta2}, AddrSourceExchange)
+
+	now := time.Now()
+	assert.True(t, ab.isReady(dummyPeerID, now))
+	assert.Equal(t, 2, len(ab.candidates(now, 10)))
+
+	ab.markFailure(meta1, AddrSourceExchange)
+	ab.markSuccess(meta2, AddrSourceExchange)
+	now = time.Now()
+	assert.False(t, ab.isReady(dummyPeerID, now))
+	assert.True(t, ab.isReady(dummyPeerID2, now))
+	candidates := ab.candidates(now, 10)
+	assert.Equal(t, 1, len(candidates))
+	assert.Equal(t, dummyPeerID2, candidates[0].ID)
+	assert.True(t, candidates[0].Outbound)
+
+	// the failed peer is tried again after the backoff, but after the seen peer
+	later := now.Add(addrBackoffBase)
+	assert.True(t, ab.isReady(dummyPeerID, later))
+	candidates = ab.candidates(later, 10)
+	assert.Equal(t, 2, len(candidates))
+	assert.Equal(t, dummyPeerID2, candidates[0].ID)
+	assert.Equal(t, 1, len(ab.candidates(later, 1)))
+
+	// a success resets the backoff
+	ab.markSuccess(meta1, AddrSourceExchange)
+	assert.True(t, ab.isReady(dummyPeerID, time.Now()))
+}
+
+func TestAddrBook_exchangeKeepsConnected(t *testing.T) {
+	ab := newAddrBook("", logger)
+	meta := PeerMeta{ID: dummyPeerID, IPAddress: "192.168.1.2", Port: 7846}
+	forged := PeerMeta{ID: dummyPeerID, IPAddress: "203.0.113.9", Port: 7846}
+
+	// the address of a peer never connected is updated
+	ab.addAddresses([]PeerMeta{meta}, AddrSourceExchange)
+	ab.addAddresses([]PeerMeta{forged}, AddrSourceExchange)
+	assert.Equal(t, forged.IPAddress, ab.addrs[dummyPeerID].meta.IPAddress)
+
+	// but not once it is connected
+	ab.markSuccess(meta, AddrSourceExchange)
+	ab.addAddresses([]PeerMeta{forged}, AddrSourceExchange)
+	assert.Equal(t, meta.IPAddress, ab.addrs[dummyPeerID].meta.IPAddress)
+
+	// the peer connected at a new address is updated
+	ab.markSuccess(forged, AddrSourceExchange)
+	assert.Equal(t, forged.IPAddress, ab.addrs[dummyPeerID].meta.IPAddress)
+}
+
+func TestAddrBook_prune(t *testing.T) {
+	ab := newAddrBook("", logger)
+	meta1 := PeerMeta{ID: dummyPeerID, IPAddress: "192.168.1.2", Port: 7846}
+	meta2 := PeerMeta{ID: dummyPeerID2, IPAddress: "192.168.1.3", Port: 7846}
+	meta3 := PeerMeta{ID: dummyPeerID3, IPAddress: "192.168.1.4", Port: 7846}
+	ab.addAddresses([]PeerMeta{meta1, meta2, meta3}, AddrSourceExchange)
+	ab.addrs[dummyPeerID2].added = time.Now().Add(-time.Hour * 2)
+
+	pruned := ab.prune(dummyPeerID, 0)
+	assert.Equal(t, 1, len(pruned))
+	assert.Equal(t, []byte(dummyPeerID), pruned[0].Address.PeerID)
+
+	pruned = ab.prune("", time.Hour)
+	assert.Equal(t, 1, len(pruned))
+	assert.Equal(t, []byte(dummyPeerID2), pruned[0].Address.PeerID)
+	assert.Equal(t, 1, len(ab.entries()))
+}
+
+func TestAddrBook_evict(t *testing.T) {
+	ab := newAddrBook("", logger)
+	max := maxAddrsPerSource[AddrSourceInbound]
+	for i := 0; i < max; i++ {
+		meta := PeerMeta{ID: peer.ID(fmt.Sprintf("inbound%d", i)), IPAddress: "192.168.1.2", Port: uint32(7000 + i)}
+		ab.markSuccess(meta, AddrSourceInbound)
+	}
+	failed := PeerMeta{ID: peer.ID("inbound3"), IPAddress: "192.168.1.2", Port: 7003}
+	ab.markFailure(failed, AddrSourceInbound)
+	ab.markFailure(failed, AddrSourceInbound)
+	ab.addAddresses([]PeerMeta{{ID: dummyPeerID, IPAddress: "192.168.1.3", Port: 7846}}, AddrSourceExchange)
+	assert.Equal(t, max+1, len(ab.entries()))
+
+	// the entry of the worst failure record is evicted from the full source
+	ab.markSuccess(PeerMeta{ID: dummyPeerID2, IPAddress: "192.168.1.4", Port: 7846}, AddrSourceInbound)
+	assert.Equal(t, max+1, len(ab.entries()))
+	_, found := ab.addrs[failed.ID]
+	assert.False(t, found)
+	_, found = ab.addrs[dummyPeerID2]
+	assert.True(t, found)
+	// the entries of other sources are kept
+	_, found = ab.addrs[dummyPeerID]
+	assert.True(t, found)
+}
+
+func TestAddrBook_persist(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "addrbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	ab := newAddrBook(dataDir, logger)
+	meta1 := PeerMeta{ID: dummyPeerID, IPAddress: "192.168.1.2", Port: 7846, Designated: true}
+	ab.markSuccess(meta1, AddrSourceConfig)
+	ab.markFailure(meta1, AddrSourceExchange)
+	assert.Nil(t, ab.save())
+
+	// the known peers are kept after restart
+	restarted := newAddrBook(dataDir, logger)
+	entries := restarted.entries()
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, AddrSourceConfig, entries[0].Source)
+	assert.Equal(t, uint32(1), entries[0].Successes)
+	assert.Equal(t, uint32(1), entries[0].Failures)
+	assert.Equal(t, "192.168.1.2", restarted.addrs[dummyPeerID].meta.IPAddress)
+	assert.False(t, restarted.addrs[dummyPeerID].meta.Designated)
+	assert.False(t, restarted.isReady(dummyPeerID, time.Now()))
+}
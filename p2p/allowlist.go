@@ -0,0 +1,94 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/aergoio/aergo-lib/log"
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+// peerAllowlist decides the peers which can connect in permissioned mode. The
+// allowed peers are the ones in the config and the ones in the on-chain
+// allowlist, which is reloaded whenever chain service notices its change.
+type peerAllowlist struct {
+	logger       *log.Logger
+	permissioned bool
+
+	mutex       sync.RWMutex
+	configPeers map[peer.ID]bool
+	chainPeers  map[peer.ID]bool
+}
+
+func newPeerAllowlist(conf *cfg.P2PConfig, logger *log.Logger) *peerAllowlist {
+	al := &peerAllowlist{
+		logger:       logger,
+		permissioned: conf.NPPermissioned,
+		configPeers:  make(map[peer.ID]bool, len(conf.NPAllowPeers)),
+		chainPeers:   make(map[peer.ID]bool),
+	}
+	for _, idStr := range conf.NPAllowPeers {
+		id, err := peer.IDB58Decode(idStr)
+		if err != nil {
+			logger.Warn().Str(LogPeerID, idStr).Msg("invalid peer id in npallowpeers")
+			continue
+		}
+		al.configPeers[id] = true
+	}
+	return al
+}
+
+// isAllowed returns true if peer id can connect. All peers are allowed if the
+// network is not permissioned.
+func (al *peerAllowlist) isAllowed(id peer.ID) bool {
+	if !al.permissioned {
+		return true
+	}
+	al.mutex.RLock()
+	defer al.mutex.RUnlock()
+
+	return al.configPeers[id] || al.chainPeers[id]
+}
+
+// setChainPeers replaces the peers of the on-chain allowlist
+func (al *peerAllowlist) setChainPeers(ids []peer.ID) {
+	chainPeers := make(map[peer.ID]bool, len(ids))
+	for _, id := range ids {
+		chainPeers[id] = true
+	}
+
+	al.mutex.Lock()
+	al.chainPeers = chainPeers
+	al.mutex.Unlock()
+}
+
+// toPeerAllowlist returns the rpc form of the allowlist
+func (al *peerAllowlist) toPeerAllowlist() *types.PeerAllowlist {
+	al.mutex.RLock()
+	defer al.mutex.RUnlock()
+
+	return &types.PeerAllowlist{
+		Permissioned: al.permissioned,
+		ConfigPeers:  sortedPeerIDs(al.configPeers),
+		ChainPeers:   sortedPeerIDs(al.chainPeers),
+	}
+}
+
+func sortedPeerIDs(ids map[peer.ID]bool) [][]byte {
+	sorted := make([][]byte, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, []byte(id))
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted
+}
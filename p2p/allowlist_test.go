@@ -0,0 +1,56 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"testing"
+
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerAllowlist_isAllowed(t *testing.T) {
+	tests := []struct {
+		name         string
+		permissioned bool
+		configPeers  []string
+		chainPeers   []peer.ID
+		want         map[peer.ID]bool
+	}{
+		{"TNotPermissioned", false, nil, nil, map[peer.ID]bool{dummyPeerID: true, dummyPeerID2: true}},
+		{"TEmpty", true, nil, nil, map[peer.ID]bool{dummyPeerID: false, dummyPeerID2: false}},
+		{"TConfig", true, []string{peer.IDB58Encode(dummyPeerID)}, nil, map[peer.ID]bool{dummyPeerID: true, dummyPeerID2: false}},
+		{"TChain", true, nil, []peer.ID{dummyPeerID2}, map[peer.ID]bool{dummyPeerID: false, dummyPeerID2: true}},
+		{"TBoth", true, []string{peer.IDB58Encode(dummyPeerID), "invalidid"}, []peer.ID{dummyPeerID2}, map[peer.ID]bool{dummyPeerID: true, dummyPeerID2: true, dummyPeerID3: false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			al := newPeerAllowlist(&cfg.P2PConfig{NPPermissioned: tt.permissioned, NPAllowPeers: tt.configPeers}, logger)
+			al.setChainPeers(tt.chainPeers)
+			for id, want := range tt.want {
+				assert.Equal(t, want, al.isAllowed(id))
+			}
+		})
+	}
+}
+
+func TestPeerAllowlist_setChainPeers(t *testing.T) {
+	al := newPeerAllowlist(&cfg.P2PConfig{NPPermissioned: true, NPAllowPeers: []string{peer.IDB58Encode(dummyPeerID)}}, logger)
+	al.setChainPeers([]peer.ID{dummyPeerID2, dummyPeerID3})
+	assert.True(t, al.isAllowed(dummyPeerID3))
+
+	// the on-chain peers are replaced, but the config peers are kept
+	al.setChainPeers([]peer.ID{dummyPeerID2})
+	assert.True(t, al.isAllowed(dummyPeerID))
+	assert.True(t, al.isAllowed(dummyPeerID2))
+	assert.False(t, al.isAllowed(dummyPeerID3))
+
+	actual := al.toPeerAllowlist()
+	assert.True(t, actual.Permissioned)
+	assert.Equal(t, [][]byte{[]byte(dummyPeerID)}, actual.ConfigPeers)
+	assert.Equal(t, [][]byte{[]byte(dummyPeerID2)}, actual.ChainPeers)
+}
@@ -0,0 +1,156 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// newCompactBlock makes the compact block of block
+func newCompactBlock(block *types.Block) *types.CompactBlock {
+	blockHash := block.BlockHash()
+	txs := block.GetBody().GetTxs()
+	ids := make([][]byte, len(txs))
+	for i, tx := range txs {
+		ids[i] = types.ShortTxID(blockHash, tx.Hash)
+	}
+	return &types.CompactBlock{BlockHash: blockHash, Header: block.Header, ShortTxIDs: ids}
+}
+
+// CompactBlockReceiver rebuilds a new block from the compact block with the txs in mempool, and
+// sends p2p getTXsRequest to the notifier peer for the txs which are not in mempool.
+// It adds the rebuilt block to chain, or requests the full block if the block can't be rebuilt.
+// Nothing is done if the response is not came until timeout.
+type CompactBlockReceiver struct {
+	requestID MsgID
+
+	peer  RemotePeer
+	actor ActorService
+
+	compact  *types.CompactBlock
+	timeout  time.Time
+	finished bool
+
+	txs     []*types.Tx
+	missing []int
+	offset  int
+}
+
+func NewCompactBlockReceiver(actor ActorService, peer RemotePeer, compact *types.CompactBlock, ttl time.Duration) *CompactBlockReceiver {
+	timeout := time.Now().Add(ttl)
+	return &CompactBlockReceiver{actor: actor, peer: peer, compact: compact, timeout: timeout}
+}
+
+func (br *CompactBlockReceiver) StartGet() {
+	if br.compact.Header == nil {
+		br.getFullBlock()
+		return
+	}
+	ids := br.compact.ShortTxIDs
+	br.txs = make([]*types.Tx, len(ids))
+	if len(ids) > 0 {
+		rawResp, err := br.actor.CallRequestDefaultTimeout(message.MemPoolSvc, &message.MemPoolExistEx{BlockHash: br.compact.BlockHash, ShortIDs: ids})
+		if err != nil {
+			br.getFullBlock()
+			return
+		}
+		resp, ok := rawResp.(*message.MemPoolExistExRsp)
+		if !ok || len(resp.Txs) != len(ids) {
+			br.getFullBlock()
+			return
+		}
+		copy(br.txs, resp.Txs)
+	}
+	for i, tx := range br.txs {
+		if tx == nil {
+			br.missing = append(br.missing, i)
+		}
+	}
+	if len(br.missing) == 0 {
+		br.finished = true
+		br.addBlock()
+		return
+	}
+
+	hashes := make([][]byte, len(br.missing))
+	for i, idx := range br.missing {
+		hashes[i] = ids[idx]
+	}
+	// create message data
+	req := &types.GetTransactionsRequest{Hashes: hashes, BlockHash: br.compact.BlockHash}
+	mo := br.peer.MF().newMsgBlockRequestOrder(br.ReceiveResp, GetTXsRequest, req)
+	br.requestID = mo.GetMsgID()
+	br.peer.sendMessage(mo)
+}
+
+// ReceiveResp must be called just in read go routine
+func (br *CompactBlockReceiver) ReceiveResp(msg Message, msgBody proto.Message) (ret bool) {
+	ret = true
+	// timeout
+	if br.finished || br.timeout.Before(time.Now()) {
+		// silently ignore already finished job
+		br.finished = true
+		br.peer.consumeRequest(br.requestID)
+		return
+	}
+	// remote peer response failure
+	body := msgBody.(*types.GetTransactionsResponse)
+	if body.Status != types.ResultStatus_OK {
+		br.finish()
+		br.getFullBlock()
+		return
+	}
+
+	for _, tx := range body.Txs {
+		// unexpected tx
+		if br.offset >= len(br.missing) || !bytes.Equal(types.ShortTxID(br.compact.BlockHash, tx.GetHash()), br.compact.ShortTxIDs[br.missing[br.offset]]) ||
+			!bytes.Equal(tx.CalculateTxHash(), tx.GetHash()) {
+			br.finish()
+			br.getFullBlock()
+			return
+		}
+		br.txs[br.missing[br.offset]] = tx
+		br.offset++
+	}
+	// is it end?
+	if !body.HasNext {
+		br.finish()
+		if br.offset < len(br.missing) {
+			// not all txs were filled.
+			br.getFullBlock()
+		} else {
+			br.addBlock()
+		}
+	}
+	return
+}
+
+func (br *CompactBlockReceiver) finish() {
+	br.finished = true
+	br.peer.consumeRequest(br.requestID)
+}
+
+// addBlock sends the rebuilt block to chain service if it is the block of the compact block
+func (br *CompactBlockReceiver) addBlock() {
+	block := &types.Block{Header: br.compact.Header, Body: &types.BlockBody{Txs: br.txs}}
+	if !bytes.Equal(block.BlockHash(), br.compact.BlockHash) ||
+		!bytes.Equal(types.CalculateTxsRootHash(br.txs), block.Header.TxsRootHash) {
+		br.getFullBlock()
+		return
+	}
+	br.actor.SendRequest(message.ChainSvc, &message.AddBlock{PeerID: br.peer.ID(), Block: block, Bstate: nil})
+}
+
+// getFullBlock falls back to request the full block to the remote peer
+func (br *CompactBlockReceiver) getFullBlock() {
+	br.actor.SendRequest(message.P2PSvc, &message.GetBlockInfos{ToWhom: br.peer.ID(),
+		Hashes: []message.BlockHash{message.BlockHash(br.compact.BlockHash)}})
+}
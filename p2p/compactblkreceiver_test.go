@@ -0,0 +1,183 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func sampleCompactBlock(txCount int) (*types.Block, *types.CompactBlock) {
+	txs := make([]*types.Tx, txCount)
+	for i := range txs {
+		tx := &types.Tx{Body: &types.TxBody{Nonce: uint64(i + 1), Amount: 1}}
+		tx.Hash = tx.CalculateTxHash()
+		txs[i] = tx
+	}
+	block := types.NewBlock(nil, nil, make(types.Receipts, 0), txs, nil, 0)
+	return block, newCompactBlock(block)
+}
+
+func TestNewCompactBlock(t *testing.T) {
+	block, compact := sampleCompactBlock(3)
+
+	assert.Equal(t, block.BlockHash(), compact.BlockHash)
+	assert.Equal(t, block.Header, compact.Header)
+	assert.Equal(t, 3, len(compact.ShortTxIDs))
+	for i, tx := range block.Body.Txs {
+		assert.Equal(t, types.ShortTxID(block.BlockHash(), tx.Hash), compact.ShortTxIDs[i])
+	}
+	// the compact block is much smaller than the block
+	assert.True(t, proto.Size(compact) < proto.Size(block))
+}
+
+func matchAddBlock(hash []byte) interface{} {
+	return mock.MatchedBy(func(arg *message.AddBlock) bool {
+		return bytes.Equal(hash, arg.Block.BlockHash()) && arg.PeerID == dummyPeerID
+	})
+}
+
+func matchGetFullBlock(hash []byte) interface{} {
+	return mock.MatchedBy(func(arg *message.GetBlockInfos) bool {
+		return len(arg.Hashes) == 1 && bytes.Equal(hash, arg.Hashes[0]) && arg.ToWhom == dummyPeerID
+	})
+}
+
+func TestCompactBlockReceiver_StartGet(t *testing.T) {
+	block, compact := sampleCompactBlock(3)
+	txs := block.Body.Txs
+	tests := []struct {
+		name    string
+		poolTxs []*types.Tx
+		poolErr error
+
+		// to verify
+		wantAdded    bool
+		wantFull     bool
+		wantRequests [][]byte
+	}{
+		{"TAllInPool", []*types.Tx{txs[0], txs[1], txs[2]}, nil, true, false, nil},
+		{"TMissing", []*types.Tx{txs[0], nil, txs[2]}, nil, false, false, [][]byte{compact.ShortTxIDs[1]}},
+		{"TAllMissing", []*types.Tx{nil, nil, nil}, nil, false, false, compact.ShortTxIDs},
+		{"TPoolFail", nil, fmt.Errorf("timeout"), false, true, nil},
+		// other tx of the same short id is in mempool
+		{"TWrongTx", []*types.Tx{txs[0], txs[2], txs[1]}, nil, false, true, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockActor := new(MockActorService)
+			mockActor.On("CallRequestDefaultTimeout", message.MemPoolSvc, mock.AnythingOfType("*message.MemPoolExistEx")).
+				Return(&message.MemPoolExistExRsp{Txs: test.poolTxs}, test.poolErr)
+			mockActor.On("SendRequest", mock.Anything, mock.Anything)
+			mockMF := new(MockMoFactory)
+			mockPeer := new(MockRemotePeer)
+			mockPeer.On("ID").Return(dummyPeerID)
+			mockPeer.On("MF").Return(mockMF)
+			mockPeer.On("sendMessage", mock.Anything)
+			var requested *types.GetTransactionsRequest
+			mockMF.On("newMsgBlockRequestOrder", mock.Anything, GetTXsRequest, mock.AnythingOfType("*types.GetTransactionsRequest")).Run(func(args mock.Arguments) {
+				requested = args[2].(*types.GetTransactionsRequest)
+			}).Return(dummyMo)
+
+			br := NewCompactBlockReceiver(mockActor, mockPeer, compact, time.Minute)
+			br.StartGet()
+
+			if test.wantAdded {
+				mockActor.AssertCalled(t, "SendRequest", message.ChainSvc, matchAddBlock(compact.BlockHash))
+			} else {
+				mockActor.AssertNotCalled(t, "SendRequest", message.ChainSvc, mock.Anything)
+			}
+			if test.wantFull {
+				mockActor.AssertCalled(t, "SendRequest", message.P2PSvc, matchGetFullBlock(compact.BlockHash))
+			} else {
+				mockActor.AssertNotCalled(t, "SendRequest", message.P2PSvc, mock.Anything)
+			}
+			if len(test.wantRequests) > 0 {
+				mockPeer.AssertCalled(t, "sendMessage", dummyMo)
+				assert.Equal(t, test.wantRequests, requested.Hashes)
+				assert.Equal(t, compact.BlockHash, requested.BlockHash)
+			} else {
+				mockPeer.AssertNotCalled(t, "sendMessage", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCompactBlockReceiver_ReceiveResp(t *testing.T) {
+	block, compact := sampleCompactBlock(4)
+	txs := block.Body.Txs
+	poolTxs := []*types.Tx{txs[0], nil, txs[2], nil}
+	tests := []struct {
+		name       string
+		ttl        time.Duration
+		respDelay  time.Duration
+		status     types.ResultStatus
+		respInputs [][]*types.Tx
+
+		// to verify
+		wantAdded bool
+		wantFull  bool
+	}{
+		{"TSingleResp", time.Minute, 0, types.ResultStatus_OK, [][]*types.Tx{{txs[1], txs[3]}}, true, false},
+		{"TMultiResp", time.Minute, 0, types.ResultStatus_OK, [][]*types.Tx{{txs[1]}, {txs[3]}}, true, false},
+		{"TNotFound", time.Minute, 0, types.ResultStatus_NOT_FOUND, [][]*types.Tx{{}}, false, true},
+		{"TMissingTx", time.Minute, 0, types.ResultStatus_OK, [][]*types.Tx{{txs[1]}}, false, true},
+		{"TUnexpectedTx", time.Minute, 0, types.ResultStatus_OK, [][]*types.Tx{{txs[3], txs[1]}}, false, true},
+		// response sent after timeout
+		{"TTimeout", time.Millisecond * 10, time.Millisecond * 20, types.ResultStatus_OK, [][]*types.Tx{{txs[1], txs[3]}}, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockActor := new(MockActorService)
+			mockActor.On("CallRequestDefaultTimeout", message.MemPoolSvc, mock.AnythingOfType("*message.MemPoolExistEx")).
+				Return(&message.MemPoolExistExRsp{Txs: poolTxs}, nil)
+			mockActor.On("SendRequest", mock.Anything, mock.Anything)
+			mockMF := new(MockMoFactory)
+			mockPeer := new(MockRemotePeer)
+			mockPeer.On("ID").Return(dummyPeerID)
+			mockPeer.On("MF").Return(mockMF)
+			mockPeer.On("sendMessage", mock.Anything)
+			mockPeer.On("consumeRequest", mock.AnythingOfType("p2p.MsgID"))
+			mockMF.On("newMsgBlockRequestOrder", mock.Anything, mock.Anything, mock.Anything).Return(dummyMo)
+
+			br := NewCompactBlockReceiver(mockActor, mockPeer, compact, test.ttl)
+			br.StartGet()
+
+			msg := &V030Message{subProtocol: GetTxsResponse, id: sampleMsgID}
+			for i, respTxs := range test.respInputs {
+				if test.respDelay > 0 {
+					time.Sleep(test.respDelay)
+				}
+				body := &types.GetTransactionsResponse{Status: test.status, Txs: respTxs, HasNext: i < len(test.respInputs)-1}
+				br.ReceiveResp(msg, body)
+				if br.finished {
+					break
+				}
+			}
+
+			assert.True(t, br.finished)
+			mockPeer.AssertNumberOfCalls(t, "consumeRequest", 1)
+			if test.wantAdded {
+				mockActor.AssertCalled(t, "SendRequest", message.ChainSvc, matchAddBlock(compact.BlockHash))
+			} else {
+				mockActor.AssertNotCalled(t, "SendRequest", message.ChainSvc, mock.Anything)
+			}
+			if test.wantFull {
+				mockActor.AssertCalled(t, "SendRequest", message.P2PSvc, matchGetFullBlock(compact.BlockHash))
+			} else {
+				mockActor.AssertNotCalled(t, "SendRequest", message.P2PSvc, mock.Anything)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package p2p
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/types"
 	"github.com/libp2p/go-libp2p-peer"
@@ -161,22 +163,48 @@ func (h *PeerHandshaker) doInitialSync() {
 }
 
 func createStatusMsg(pm PeerManager, actorServ ActorService) (*types.Status, error) {
+	ca := actorServ.GetChainAccessor()
 	// find my best block
-	bestBlock, err := actorServ.GetChainAccessor().GetBestBlock()
+	bestBlock, err := ca.GetBestBlock()
 	if err != nil {
 		return nil, err
 	}
+	// and the identity of my chain
+	genesisHash, err := ca.GetHashByNo(0)
+	if err != nil {
+		return nil, err
+	}
+	var chainID []byte
+	if genesis := ca.GetGenesisInfo(); genesis != nil {
+		chainID = genesis.ChainID()
+	}
 	selfAddr := pm.SelfMeta().ToPeerAddress()
 	// create message data
 	statusMsg := &types.Status{
 		Sender:        &selfAddr,
 		BestBlockHash: bestBlock.BlockHash(),
 		BestHeight:    bestBlock.GetHeader().GetBlockNo(),
+		ChainID:       chainID,
+		Genesis:       genesisHash,
+		// this node can rebuild the blocks from compact blocks
+		CompactBlock: true,
 	}
 
 	return statusMsg, nil
 }
 
+// checkSameChain returns an error if the remote peer of remote status is on the
+// other chain than that of local status.
+func checkSameChain(local, remote *types.Status) error {
+	if !bytes.Equal(local.GetChainID(), remote.GetChainID()) {
+		return fmt.Errorf("different chain id")
+	}
+	if !bytes.Equal(local.GetGenesis(), remote.GetGenesis()) {
+		return fmt.Errorf("different genesis block %s", enc.ToString(remote.GetGenesis()))
+	}
+	return nil
+}
+
 func (h *PeerHandshaker) selectProtocolVersion(head HSHeader, r *bufio.Reader, w *bufio.Writer) (innerHandshaker, error) {
 	switch head.Version {
 	case P2PVersion030:
@@ -68,6 +68,8 @@ func TestPeerHandshaker_handshakeOutboundPeerTimeout(t *testing.T) {
 	mockActor.On("CallRequest", mock.Anything, mock.AnythingOfType("*message.GetBestBlock")).Return(dummyBlkRsp, nil)
 	mockActor.On("GetChainAccessor").Return(mockCA)
 	mockCA.On("GetBestBlock").Return(dummyBestBlock, nil)
+	mockCA.On("GetGenesisInfo").Return(types.GetTestGenesis())
+	mockCA.On("GetHashByNo", types.BlockNo(0)).Return(dummyBlockHash, nil)
 	// dummyStatusMsg := &types.Status{}
 	tests := []struct {
 		name    string
@@ -25,6 +25,22 @@ func (_m *MockMoFactory) newMsgBlkBroadcastOrder(noticeMsg *types.NewBlockNotice
 	return r0
 }
 
+// newMsgCompactBlkBroadcastOrder provides a mock function with given fields: compactBlock
+func (_m *MockMoFactory) newMsgCompactBlkBroadcastOrder(compactBlock *types.CompactBlock) msgOrder {
+	ret := _m.Called(compactBlock)
+
+	var r0 msgOrder
+	if rf, ok := ret.Get(0).(func(*types.CompactBlock) msgOrder); ok {
+		r0 = rf(compactBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(msgOrder)
+		}
+	}
+
+	return r0
+}
+
 // newMsgRequestOrder provides a mock function with given fields: expectedResponse, protocolID, message
 func (_m *MockMoFactory) newMsgRequestOrder(expectResponse bool, protocolID SubProtocol, message pbMessage) msgOrder {
 	ret := _m.Called(expectResponse, protocolID, message)
@@ -7,7 +7,9 @@ package p2p
 
 import (
 	"context"
+	"time"
 
+	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/types"
 	"github.com/golang/protobuf/proto"
@@ -540,3 +542,91 @@ func (_m *MockPeerManager) HandleNewBlockNotice(peerID peer.ID, hash BlkHash, da
 func (_m *MockPeerManager) HandleNewTxNotice(peerID peer.ID, hash []TxHash, data *types.NewTransactionsNotice) {
 	_m.Called(peerID, hash, data)
 }
+
+// ReportMisbehavior provides a mock function with given fields: peerID, kind
+func (_m *MockPeerManager) ReportMisbehavior(peerID peer.ID, kind message.Misbehavior) {
+	_m.Called(peerID, kind)
+}
+
+// PeerScore provides a mock function with given fields: peerID
+func (_m *MockPeerManager) PeerScore(peerID peer.ID) int32 {
+	ret := _m.Called(peerID)
+
+	var r0 int32
+	if rf, ok := ret.Get(0).(func(peer.ID) int32); ok {
+		r0 = rf(peerID)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	return r0
+}
+
+// BannedPeers provides a mock function with given fields:
+func (_m *MockPeerManager) BannedPeers() []BannedPeer {
+	ret := _m.Called()
+
+	var r0 []BannedPeer
+	if rf, ok := ret.Get(0).(func() []BannedPeer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BannedPeer)
+		}
+	}
+
+	return r0
+}
+
+// GetAddrBook provides a mock function with given fields:
+func (_m *MockPeerManager) GetAddrBook() []*types.AddrBookEntry {
+	ret := _m.Called()
+
+	var r0 []*types.AddrBookEntry
+	if rf, ok := ret.Get(0).(func() []*types.AddrBookEntry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.AddrBookEntry)
+		}
+	}
+
+	return r0
+}
+
+// PruneAddrBook provides a mock function with given fields: peerID, maxAge
+func (_m *MockPeerManager) PruneAddrBook(peerID peer.ID, maxAge time.Duration) []*types.AddrBookEntry {
+	ret := _m.Called(peerID, maxAge)
+
+	var r0 []*types.AddrBookEntry
+	if rf, ok := ret.Get(0).(func(peer.ID, time.Duration) []*types.AddrBookEntry); ok {
+		r0 = rf(peerID, maxAge)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.AddrBookEntry)
+		}
+	}
+
+	return r0
+}
+
+// UpdateChainAllowlist provides a mock function with given fields: peerIDs
+func (_m *MockPeerManager) UpdateChainAllowlist(peerIDs []peer.ID) {
+	_m.Called(peerIDs)
+}
+
+// GetAllowlist provides a mock function with given fields:
+func (_m *MockPeerManager) GetAllowlist() *types.PeerAllowlist {
+	ret := _m.Called()
+
+	var r0 *types.PeerAllowlist
+	if rf, ok := ret.Get(0).(func() *types.PeerAllowlist); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PeerAllowlist)
+		}
+	}
+
+	return r0
+}
@@ -40,6 +40,9 @@ type P2P struct {
 	mf     moFactory
 	signer msgSigner
 	ca     types.ChainAccessor
+
+	// compactBlock is true if new blocks are notified as compact blocks
+	compactBlock bool
 }
 
 type HandlerFactory interface {
@@ -144,6 +147,7 @@ func (p2ps *P2P) Statistics() *map[string]interface{} {
 
 func (p2ps *P2P) init(cfg *config.Config, chainsvc *chain.ChainService) {
 	p2ps.ca = chainsvc
+	p2ps.compactBlock = cfg.P2P.NPCompactBlock
 
 	signer := newDefaultMsgSigner(ni.privKey, ni.pubKey, ni.id)
 	mf := &pbMOFactory{signer: signer}
@@ -181,6 +185,12 @@ func (p2ps *P2P) Receive(context actor.Context) {
 		p2ps.GetBlockHashes(context, msg)
 	case *message.GetHashByNo:
 		p2ps.GetBlockHashByNo(context, msg)
+	case *message.GetStateChunk:
+		p2ps.GetStateChunk(context, msg)
+	case *message.GetSQLChunk:
+		p2ps.GetSQLChunk(context, msg)
+	case *message.GetSyncHeaders:
+		p2ps.GetSyncHeaders(context, msg)
 	case *message.NotifyNewBlock:
 		p2ps.NotifyNewBlock(*msg)
 	case *message.GetMissingBlocks:
@@ -194,9 +204,28 @@ func (p2ps *P2P) Receive(context actor.Context) {
 
 	case *message.GetPeers:
 		peers, lastBlks, states := p2ps.pm.GetPeerAddresses()
-		context.Respond(&message.GetPeersRsp{Peers: peers, LastBlks: lastBlks, States: states})
+		scores := make([]int32, len(peers))
+		for i, addr := range peers {
+			scores[i] = p2ps.pm.PeerScore(peer.ID(addr.PeerID))
+		}
+		bans := p2ps.pm.BannedPeers()
+		banned := make([]*types.Peer, len(bans))
+		for i, b := range bans {
+			banned[i] = b.ToPeer()
+		}
+		context.Respond(&message.GetPeersRsp{Peers: peers, LastBlks: lastBlks, States: states, Scores: scores, Banned: banned})
 	case *message.GetSyncAncestor:
 		p2ps.GetSyncAncestor(msg.ToWhom, msg.Hashes)
+	case *message.PeerMisbehavior:
+		p2ps.pm.ReportMisbehavior(msg.PeerID, msg.Kind)
+	case *message.GetAddrBook:
+		context.Respond(&message.GetAddrBookRsp{Entries: p2ps.pm.GetAddrBook()})
+	case *message.PruneAddrBook:
+		context.Respond(&message.PruneAddrBookRsp{Pruned: p2ps.pm.PruneAddrBook(msg.PeerID, msg.MaxAge)})
+	case *message.PeerAllowlistChanged:
+		p2ps.pm.UpdateChainAllowlist(msg.PeerIDs)
+	case *message.GetAllowedPeers:
+		context.Respond(p2ps.pm.GetAllowlist())
 	}
 }
 
@@ -254,6 +283,7 @@ func (p2ps *P2P) insertHandlers(peer *remotePeerImpl) {
 	peer.handlers[GetBlockHeadersResponse] = newListBlockRespHandler(p2ps.pm, peer, logger, p2ps)
 	peer.handlers[GetMissingRequest] = newGetMissingReqHandler(p2ps.pm, peer, logger, p2ps)
 	peer.handlers[NewBlockNotice] = newNewBlockNoticeHandler(p2ps.pm, peer, logger, p2ps, p2ps.sm)
+	peer.handlers[NewCompactBlockNotice] = newNewCompactBlockNoticeHandler(p2ps.pm, peer, logger, p2ps, p2ps.sm)
 	peer.handlers[GetAncestorRequest] = newGetAncestorReqHandler(p2ps.pm, peer, logger, p2ps)
 	peer.handlers[GetAncestorResponse] = newGetAncestorRespHandler(p2ps.pm, peer, logger, p2ps)
 	peer.handlers[GetHashesRequest] = newGetHashesReqHandler(p2ps.pm, peer, logger, p2ps)
@@ -265,4 +295,10 @@ func (p2ps *P2P) insertHandlers(peer *remotePeerImpl) {
 	peer.handlers[GetTXsRequest] = newTxReqHandler(p2ps.pm, peer, logger, p2ps)
 	peer.handlers[GetTxsResponse] = newTxRespHandler(p2ps.pm, peer, logger, p2ps)
 	peer.handlers[NewTxNotice] = newNewTxNoticeHandler(p2ps.pm, peer, logger, p2ps, p2ps.sm)
+
+	// StateHandlers
+	peer.handlers[GetStateChunkRequest] = newStateChunkReqHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetStateChunkResponse] = newStateChunkRespHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetSQLChunkRequest] = newSQLChunkReqHandler(p2ps.pm, peer, logger, p2ps)
+	peer.handlers[GetSQLChunkResponse] = newSQLChunkRespHandler(p2ps.pm, peer, logger, p2ps)
 }
@@ -68,6 +68,23 @@ type PeerManager interface {
 	GetPeer(ID peer.ID) (RemotePeer, bool)
 	GetPeers() []RemotePeer
 	GetPeerAddresses() ([]*types.PeerAddress, []*types.NewBlockNotice, []types.PeerState)
+
+	// ReportMisbehavior raises the misbehavior score of remote peer, and disconnects or bans the peer if the score is too high
+	ReportMisbehavior(peerID peer.ID, kind message.Misbehavior)
+	// PeerScore returns the misbehavior score of remote peer
+	PeerScore(peerID peer.ID) int32
+	// BannedPeers returns the peers which are banned now
+	BannedPeers() []BannedPeer
+
+	// GetAddrBook returns the entries of the address book of known peers
+	GetAddrBook() []*types.AddrBookEntry
+	// PruneAddrBook removes the entry of peerID, or the entries not seen for maxAge if peerID is empty, from the address book
+	PruneAddrBook(peerID peer.ID, maxAge time.Duration) []*types.AddrBookEntry
+
+	// UpdateChainAllowlist replaces the on-chain peer allowlist, and disconnects the peers not allowed any more
+	UpdateChainAllowlist(peerIDs []peer.ID)
+	// GetAllowlist returns the effective peer allowlist
+	GetAllowlist() *types.PeerAllowlist
 }
 
 /**
@@ -88,6 +105,9 @@ type peerManager struct {
 	mf             moFactory
 	rm             ReconnectManager
 	mm             metric.MetricsManager
+	scorer         *peerScorer
+	addrBook       *addrBook
+	allowlist      *peerAllowlist
 
 	designatedPeers map[peer.ID]PeerMeta
 
@@ -131,6 +151,9 @@ func NewPeerManager(handlerFactory HandlerFactory, iServ ActorService, cfg *cfg.
 		mf:             mf,
 		rm:             rm,
 		mm:             mm,
+		scorer:         newPeerScorer(p2pConf, cfg.DataDir, logger),
+		addrBook:       newAddrBook(cfg.DataDir, logger),
+		allowlist:      newPeerAllowlist(p2pConf, logger),
 		logger:         logger,
 		mutex:          &sync.Mutex{},
 
@@ -239,6 +262,7 @@ func (pm *peerManager) run() {
 	// FIXME: adhoc code
 	go func() {
 		time.Sleep(time.Second * 3)
+		pm.loadChainAllowlist()
 		pm.startListener()
 
 		// addition should start after all modules are started
@@ -247,6 +271,10 @@ func (pm *peerManager) run() {
 			for _, meta := range pm.designatedPeers {
 				pm.addPeerChannel <- meta
 			}
+			// and then the peers known before restart
+			if known := pm.addrBook.candidates(time.Now(), pm.conf.NPPeerPool); len(known) > 0 {
+				pm.fillPoolChannel <- known
+			}
 		}()
 	}()
 }
@@ -314,11 +342,14 @@ MANLOOP:
 		case <-addrTicker.C:
 			pm.checkAndCollectPeerListFromAll()
 		    pm.logPeerMetrics()
+			pm.retryKnownPeers()
+			pm.saveAddrBook()
 		case peerMetas := <-pm.fillPoolChannel:
 			pm.tryFillPool(&peerMetas)
 		case <-pm.finishChannel:
 			addrTicker.Stop()
 			pm.rm.Stop()
+			pm.saveAddrBook()
 			// TODO need to keep loop till all remote peer objects are removed, otherwise panic or channel deadlock can come.
 			break MANLOOP
 		}
@@ -330,6 +361,41 @@ MANLOOP:
 	}
 }
 
+// retryKnownPeers refills the pool with the known peers whose backoff is over, if more peers are needed
+func (pm *peerManager) retryKnownPeers() {
+	if len(pm.remotePeers) >= pm.conf.NPMaxPeers {
+		return
+	}
+	if known := pm.addrBook.candidates(time.Now(), pm.conf.NPPeerPool); len(known) > 0 {
+		pm.tryFillPool(&known)
+	}
+}
+
+// loadChainAllowlist gets the on-chain peer allowlist from chain service in permissioned mode.
+// The later changes are sent by chain service.
+func (pm *peerManager) loadChainAllowlist() {
+	if !pm.allowlist.permissioned {
+		return
+	}
+	result, err := pm.actorServ.CallRequestDefaultTimeout(message.ChainSvc, &message.GetPeerAllowlist{})
+	if err != nil {
+		pm.logger.Warn().Err(err).Msg("failed to get peer allowlist of chain")
+		return
+	}
+	rsp, ok := result.(*message.GetPeerAllowlistRsp)
+	if !ok || rsp.Err != nil {
+		pm.logger.Warn().Interface("rsp", result).Msg("failed to get peer allowlist of chain")
+		return
+	}
+	pm.allowlist.setChainPeers(rsp.PeerIDs)
+}
+
+func (pm *peerManager) saveAddrBook() {
+	if err := pm.addrBook.save(); err != nil {
+		pm.logger.Warn().Err(err).Msg("failed to save address book")
+	}
+}
+
 func (pm *peerManager) logPeerMetrics() {
 	if pm.logger.IsDebugEnabled() {
 		pm.logger.Debug().Msg(pm.mm.Summary())
@@ -339,6 +405,18 @@ func (pm *peerManager) logPeerMetrics() {
 // addOutboundPeer try to connect and handshake to remote peer. it can be called after peermanager is inited.
 // It return true if peer is added or already exist, or return false if failed to add peer.
 func (pm *peerManager) addOutboundPeer(meta PeerMeta) bool {
+	if pm.scorer.isBanned(meta.ID, meta.IPAddress) {
+		pm.logger.Debug().Str(LogPeerID, meta.ID.Pretty()).Str("addr", meta.IPAddress).Msg("Skipping banned peer")
+		return false
+	}
+	if !pm.allowlist.isAllowed(meta.ID) {
+		pm.logger.Debug().Str(LogPeerID, meta.ID.Pretty()).Msg("Skipping peer not in allowlist")
+		return false
+	}
+	source := AddrSourceExchange
+	if meta.Designated {
+		source = AddrSourceConfig
+	}
 	addrString := fmt.Sprintf("/ip4/%s/tcp/%d", meta.IPAddress, meta.Port)
 	var peerAddr, err = ma.NewMultiaddr(addrString)
 	if err != nil {
@@ -365,6 +443,7 @@ func (pm *peerManager) addOutboundPeer(meta PeerMeta) bool {
 	s, err := pm.NewStream(ctx, meta.ID, aergoP2PSub)
 	if err != nil {
 		pm.logger.Info().Err(err).Str("addr", addrString).Str(LogPeerID, meta.ID.Pretty()).Str(LogProtoID, string(aergoP2PSub)).Msg("Error while get stream")
+		pm.addrBook.markFailure(meta, source)
 		return false
 	}
 
@@ -376,6 +455,7 @@ func (pm *peerManager) addOutboundPeer(meta PeerMeta) bool {
 		pm.logger.Debug().Err(err).Str(LogPeerID, meta.ID.Pretty()).Msg("Failed to handshake")
 		//pm.sendGoAway(rw, "Failed to handshake")
 		s.Close()
+		pm.addrBook.markFailure(meta, source)
 		return false
 	}
 
@@ -398,8 +478,11 @@ func (pm *peerManager) addOutboundPeer(meta PeerMeta) bool {
 
 	// update peer info to remote sent infor
 	meta = FromPeerAddress(remoteStatus.Sender)
+	meta.CompactBlock = remoteStatus.GetCompactBlock()
+	pm.addrBook.markSuccess(meta, source)
 
 	outboundPeer := newRemotePeer(meta, pm, pm.actorServ, pm.logger, pm.mf, pm.signer, rw)
+	outboundPeer.remoteIP = observedIP(s)
 	// insert Handlers
 	pm.handlerFactory.insertHandlers(outboundPeer)
 	go outboundPeer.runPeer()
@@ -518,6 +601,17 @@ func (pm *peerManager) startListener() {
 
 func (pm *peerManager) onHandshake(s inet.Stream) {
 	peerID := s.Conn().RemotePeer()
+	remoteIP := observedIP(s)
+	if pm.scorer.isBanned(peerID, remoteIP) {
+		pm.logger.Info().Str(LogPeerID, peerID.Pretty()).Str("addr", remoteIP).Msg("Refusing banned peer")
+		s.Close()
+		return
+	}
+	if !pm.allowlist.isAllowed(peerID) {
+		pm.logger.Info().Str(LogPeerID, peerID.Pretty()).Msg("Refusing peer not in allowlist")
+		s.Close()
+		return
+	}
 	h := newHandshaker(pm, pm.actorServ, pm.logger, peerID)
 	rd := metric.NewReader(s)
 	wt := metric.NewWriter(s)
@@ -529,8 +623,9 @@ func (pm *peerManager) onHandshake(s inet.Stream) {
 	}
 	// TODO: check status
 	meta := FromPeerAddress(statusMsg.Sender)
+	meta.CompactBlock = statusMsg.GetCompactBlock()
 	// try Add peer
-	if inboundPeer, success := pm.tryAddInboundPeer(meta, rw); !success {
+	if inboundPeer, success := pm.tryAddInboundPeer(meta, remoteIP, rw); !success {
 		// failed to add
 		pm.sendGoAway(rw, "Concurrent handshake")
 		s.Close()
@@ -538,14 +633,21 @@ func (pm *peerManager) onHandshake(s inet.Stream) {
 	} else {
 		inboundPeer.metric = pm.mm.Add(peerID, rd, wt)
 	}
-
+	// only the address observed from the connection is recorded, since the
+	// peer can report any address. The port is the reported one, because the
+	// port of an inbound connection is not the one the peer listens on.
+	if remoteIP != "" {
+		observed := meta
+		observed.IPAddress = remoteIP
+		pm.addrBook.markSuccess(observed, AddrSourceInbound)
+	}
 
 	h.doInitialSync()
 	// notice to p2pmanager that handshaking is finished
 	pm.NotifyPeerHandshake(peerID)
 }
 
-func (pm *peerManager) tryAddInboundPeer(meta PeerMeta, rw MsgReadWriter) (*remotePeerImpl, bool) {
+func (pm *peerManager) tryAddInboundPeer(meta PeerMeta, remoteIP string, rw MsgReadWriter) (*remotePeerImpl, bool) {
 	pm.mutex.Lock()
 	defer pm.mutex.Unlock()
 	peerID := meta.ID
@@ -564,6 +666,7 @@ func (pm *peerManager) tryAddInboundPeer(meta PeerMeta, rw MsgReadWriter) (*remo
 		}
 	}
 	inboundPeer := newRemotePeer(meta, pm, pm.actorServ, pm.logger, pm.mf, pm.signer, rw)
+	inboundPeer.remoteIP = remoteIP
 	pm.handlerFactory.insertHandlers(inboundPeer)
 	go inboundPeer.runPeer()
 	pm.insertPeer(peerID, inboundPeer)
@@ -632,6 +735,7 @@ func (pm *peerManager) tryFillPool(metas *[]PeerMeta) {
 			invalid = append(invalid, meta.String())
 			continue
 		}
+		pm.addrBook.addAddresses([]PeerMeta{meta}, AddrSourceExchange)
 		_, found := pm.peerPool[meta.ID]
 		if !found {
 			// change some properties
@@ -651,6 +755,7 @@ func (pm *peerManager) tryFillPool(metas *[]PeerMeta) {
 // tryConnectPeers should be called in runManagePeers() only
 func (pm *peerManager) tryConnectPeers() {
 	remained := pm.conf.NPMaxPeers - len(pm.remotePeers)
+	now := time.Now()
 	for ID, meta := range pm.peerPool {
 		if _, found := pm.GetPeer(ID); found {
 			delete(pm.peerPool, ID)
@@ -661,6 +766,14 @@ func (pm *peerManager) tryConnectPeers() {
 				Uint32("port", meta.Port).Msg("Invalid peer meta informations")
 			continue
 		}
+		if pm.scorer.isBanned(ID, meta.IPAddress) {
+			delete(pm.peerPool, ID)
+			continue
+		}
+		// the peer failed recently is tried again after the backoff
+		if !pm.addrBook.isReady(ID, now) {
+			continue
+		}
 		// in same go rountine.
 		pm.addOutboundPeer(meta)
 		remained--
@@ -701,6 +814,79 @@ func (pm *peerManager) GetPeerAddresses() ([]*types.PeerAddress, []*types.NewBlo
 	return peers, blks, states
 }
 
+func (pm *peerManager) ReportMisbehavior(peerID peer.ID, kind message.Misbehavior) {
+	// the ip address is the one observed from the connection, since the one in
+	// the meta is reported by the peer itself. The designated peers are never
+	// banned by their ip addresses.
+	var ip string
+	pm.mutex.Lock()
+	remotePeer, found := pm.remotePeers[peerID]
+	_, designated := pm.designatedPeers[peerID]
+	if found && !designated && !remotePeer.meta.Designated {
+		ip = remotePeer.remoteIP
+	}
+	pm.mutex.Unlock()
+
+	score, disconnect, banned := pm.scorer.addPenalty(peerID, ip, kind)
+	switch {
+	case banned:
+		pm.logger.Info().Str(LogPeerID, peerID.Pretty()).Str("addr", ip).Float64("score", score).Msg("Banning misbehaving peer")
+	case disconnect:
+		pm.logger.Info().Str(LogPeerID, peerID.Pretty()).Float64("score", score).Msg("Disconnecting misbehaving peer")
+	default:
+		pm.logger.Debug().Str(LogPeerID, peerID.Pretty()).Float64("score", score).Msg("Peer misbehaved")
+		return
+	}
+	if found {
+		pm.RemovePeer(peerID)
+	}
+}
+
+func (pm *peerManager) PeerScore(peerID peer.ID) int32 {
+	return pm.scorer.score(peerID)
+}
+
+func (pm *peerManager) BannedPeers() []BannedPeer {
+	return pm.scorer.bannedPeers()
+}
+
+func (pm *peerManager) UpdateChainAllowlist(peerIDs []peer.ID) {
+	pm.allowlist.setChainPeers(peerIDs)
+	pm.logger.Info().Int("peers", len(peerIDs)).Msg("Peer allowlist of chain is changed")
+	for _, remotePeer := range pm.GetPeers() {
+		if !pm.allowlist.isAllowed(remotePeer.ID()) {
+			pm.logger.Info().Str(LogPeerID, remotePeer.ID().Pretty()).Msg("Disconnecting peer removed from allowlist")
+			pm.RemovePeer(remotePeer.ID())
+		}
+	}
+}
+
+func (pm *peerManager) GetAllowlist() *types.PeerAllowlist {
+	return pm.allowlist.toPeerAllowlist()
+}
+
+func (pm *peerManager) GetAddrBook() []*types.AddrBookEntry {
+	return pm.addrBook.entries()
+}
+
+func (pm *peerManager) PruneAddrBook(peerID peer.ID, maxAge time.Duration) []*types.AddrBookEntry {
+	pruned := pm.addrBook.prune(peerID, maxAge)
+	if len(pruned) > 0 {
+		pm.saveAddrBook()
+	}
+	return pruned
+}
+
+// observedIP returns the ip address of the remote peer observed from the connection of stream s
+func observedIP(s inet.Stream) string {
+	addr := s.Conn().RemoteMultiaddr()
+	if ip, err := addr.ValueForProtocol(ma.P_IP4); err == nil {
+		return ip
+	}
+	ip, _ := addr.ValueForProtocol(ma.P_IP6)
+	return ip
+}
+
 // this method should be called inside pm.mutex
 func (pm *peerManager) insertPeer(ID peer.ID, peer *remotePeerImpl) {
 	pm.remotePeers[ID] = peer
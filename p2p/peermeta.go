@@ -30,6 +30,8 @@ type PeerMeta struct {
 	Port       uint32
 	Designated bool // Designated means this peer is designated in config file and connect to in startup phase
 	Outbound   bool
+	// CompactBlock means the peer told in handshake that it can rebuild the blocks from compact blocks
+	CompactBlock bool
 }
 
 func (m PeerMeta) String() string {
@@ -0,0 +1,300 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo-lib/log"
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+const (
+	// scoreHalfLife is the time in which the misbehavior score of a peer is halved
+	scoreHalfLife = time.Minute * 10
+
+	banFileName = "bannedpeers.json"
+
+	// minScore is the score below which a decayed score is forgotten
+	minScore = 1
+)
+
+// misbehaviorPenalties are the scores which the misbehaviors add to a peer
+var misbehaviorPenalties = map[message.Misbehavior]float64{
+	message.MisbehaveInvalidMessage: 20,
+	message.MisbehaveInvalidBlock:   50,
+	message.MisbehaveInvalidTxSign:  30,
+	message.MisbehaveFetchFail:      10,
+}
+
+// disconnectMisbehaviors disconnect the peer without raising its score. A
+// state root mismatch may be caused by a fault of the local node as well, so
+// it doesn't lead to a ban.
+var disconnectMisbehaviors = map[message.Misbehavior]bool{
+	message.MisbehaveStateMismatch: true,
+}
+
+// peerScore is the misbehavior score of a peer, which decays over time
+type peerScore struct {
+	value   float64
+	updated time.Time
+}
+
+func (s *peerScore) get(now time.Time) float64 {
+	return s.value * math.Pow(0.5, float64(now.Sub(s.updated))/float64(scoreHalfLife))
+}
+
+// BannedPeer is a peer which is refused to connect until a time
+type BannedPeer struct {
+	ID        peer.ID
+	IPAddress string
+	Until     time.Time
+}
+
+// ToPeer converts b to the rpc form of a peer
+func (b BannedPeer) ToPeer() *types.Peer {
+	return &types.Peer{
+		Address:     &types.PeerAddress{Address: []byte(net.ParseIP(b.IPAddress)), PeerID: []byte(b.ID)},
+		State:       int32(types.BANNED),
+		BannedUntil: b.Until.Unix(),
+	}
+}
+
+// bannedPeerJSON is the form of BannedPeer in the ban file
+type bannedPeerJSON struct {
+	ID    string `json:"id"`
+	IP    string `json:"ip,omitempty"`
+	Until int64  `json:"until"`
+}
+
+// peerScorer keeps the misbehavior scores of the remote peers, and bans the
+// peers of too high scores by their peer ids and ip addresses. The bans are
+// saved in the data directory, so that they are kept across restarts.
+type peerScorer struct {
+	logger *log.Logger
+	mutex  sync.Mutex
+
+	disconnectScore float64
+	banScore        float64
+	banDuration     time.Duration
+	banLocalIP      bool
+	banFile         string
+
+	scores map[peer.ID]*peerScore
+	bans   map[peer.ID]*BannedPeer
+}
+
+func newPeerScorer(conf *cfg.P2PConfig, dataDir string, logger *log.Logger) *peerScorer {
+	ps := &peerScorer{
+		logger:          logger,
+		disconnectScore: float64(conf.NPDisconnectScore),
+		banScore:        float64(conf.NPBanScore),
+		banDuration:     time.Duration(conf.NPBanDuration) * time.Second,
+		banLocalIP:      conf.NPBanLocalIP,
+		scores:          make(map[peer.ID]*peerScore),
+		bans:            make(map[peer.ID]*BannedPeer),
+	}
+	if dataDir != "" {
+		ps.banFile = filepath.Join(dataDir, banFileName)
+		if err := ps.load(); err != nil {
+			logger.Warn().Err(err).Str("file", ps.banFile).Msg("failed to load banned peers")
+		}
+	}
+	return ps
+}
+
+// addPenalty adds the penalty of the misbehavior to the score of peer id at
+// ip, and returns whether the peer should be disconnected or is banned by the
+// new score.
+func (ps *peerScorer) addPenalty(id peer.ID, ip string, kind message.Misbehavior) (score float64, disconnect bool, banned bool) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	now := time.Now()
+	ps.pruneScores(now)
+	s, found := ps.scores[id]
+	if disconnectMisbehaviors[kind] {
+		if found {
+			score = s.get(now)
+		}
+		return score, true, false
+	}
+	if !found {
+		s = &peerScore{}
+		ps.scores[id] = s
+	}
+	s.value = s.get(now) + misbehaviorPenalties[kind]
+	s.updated = now
+	score = s.value
+
+	if ps.banScore > 0 && score >= ps.banScore {
+		// the score is reset, since the peer can't misbehave while it is banned
+		delete(ps.scores, id)
+		ps.bans[id] = &BannedPeer{ID: id, IPAddress: ps.bannableIP(ip), Until: now.Add(ps.banDuration)}
+		if err := ps.save(); err != nil {
+			ps.logger.Warn().Err(err).Str("file", ps.banFile).Msg("failed to save banned peers")
+		}
+		return score, true, true
+	}
+	return score, ps.disconnectScore > 0 && score >= ps.disconnectScore, false
+}
+
+// score returns the current misbehavior score of peer id
+func (ps *peerScorer) score(id peer.ID) int32 {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	s, found := ps.scores[id]
+	if !found {
+		return 0
+	}
+	return int32(s.get(time.Now()))
+}
+
+// isBanned returns true if peer id or ip address ip is banned
+func (ps *peerScorer) isBanned(id peer.ID, ip string) bool {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	ps.pruneBans(time.Now())
+	if _, found := ps.bans[id]; found {
+		return true
+	}
+	ip = normalizeIP(ip)
+	if ip == "" {
+		return false
+	}
+	for _, b := range ps.bans {
+		if b.IPAddress == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// bannedPeers returns the peers banned now
+func (ps *peerScorer) bannedPeers() []BannedPeer {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	ps.pruneBans(time.Now())
+	bans := make([]BannedPeer, 0, len(ps.bans))
+	for _, b := range ps.bans {
+		bans = append(bans, *b)
+	}
+	return bans
+}
+
+// pruneBans removes the expired bans. It must be called inside ps.mutex
+// pruneScores removes the scores decayed below minScore
+func (ps *peerScorer) pruneScores(now time.Time) {
+	for id, s := range ps.scores {
+		if s.get(now) < minScore {
+			delete(ps.scores, id)
+		}
+	}
+}
+
+func (ps *peerScorer) pruneBans(now time.Time) {
+	for id, b := range ps.bans {
+		if !now.Before(b.Until) {
+			delete(ps.bans, id)
+		}
+	}
+}
+
+// load reads the bans from the ban file
+func (ps *peerScorer) load() error {
+	data, err := ioutil.ReadFile(ps.banFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var saved []bannedPeerJSON
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	for _, b := range saved {
+		id, err := peer.IDB58Decode(b.ID)
+		if err != nil {
+			ps.logger.Warn().Str(LogPeerID, b.ID).Msg("invalid peer id in banned peers")
+			continue
+		}
+		ps.bans[id] = &BannedPeer{ID: id, IPAddress: b.IP, Until: time.Unix(b.Until, 0)}
+	}
+	ps.pruneBans(time.Now())
+	return nil
+}
+
+// save writes the bans to the ban file. It must be called inside ps.mutex
+func (ps *peerScorer) save() error {
+	if ps.banFile == "" {
+		return nil
+	}
+	ps.pruneBans(time.Now())
+	saved := make([]bannedPeerJSON, 0, len(ps.bans))
+	for _, b := range ps.bans {
+		saved = append(saved, bannedPeerJSON{ID: peer.IDB58Encode(b.ID), IP: b.IPAddress, Until: b.Until.Unix()})
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(ps.banFile, data, 0644)
+}
+
+// bannableIP returns the normalized form of ip, or empty string if ip
+// shouldn't be banned. The loopback and private addresses are shared by many
+// peers, so they are banned only if configured.
+func (ps *peerScorer) bannableIP(ip string) string {
+	ip = normalizeIP(ip)
+	if ip == "" || ps.banLocalIP || !isLocalIP(net.ParseIP(ip)) {
+		return ip
+	}
+	return ""
+}
+
+// localNets are the private networks of RFC 1918 and RFC 4193
+var localNets = func() []*net.IPNet {
+	cidrs := []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, nets[i], _ = net.ParseCIDR(cidr)
+	}
+	return nets
+}()
+
+func isLocalIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+		return true
+	}
+	for _, n := range localNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeIP(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsUnspecified() {
+		return ""
+	}
+	return parsed.String()
+}
@@ -0,0 +1,156 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	cfg "github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleScoreConf = &cfg.P2PConfig{NPDisconnectScore: 50, NPBanScore: 100, NPBanDuration: 3600}
+
+func TestPeerScorer_addPenalty(t *testing.T) {
+	tests := []struct {
+		name           string
+		kinds          []message.Misbehavior
+		wantDisconnect bool
+		wantBanned     bool
+	}{
+		{"TLow", []message.Misbehavior{message.MisbehaveFetchFail}, false, false},
+		{"TDisconnect", []message.Misbehavior{message.MisbehaveInvalidBlock}, true, false},
+		{"TAccumulate", []message.Misbehavior{message.MisbehaveInvalidMessage, message.MisbehaveInvalidTxSign}, true, false},
+		{"TBan", []message.Misbehavior{message.MisbehaveInvalidBlock, message.MisbehaveInvalidBlock}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := newPeerScorer(sampleScoreConf, "", logger)
+			var disconnect, banned bool
+			for _, kind := range tt.kinds {
+				_, disconnect, banned = ps.addPenalty(dummyPeerID, "203.0.113.2", kind)
+			}
+			assert.Equal(t, tt.wantDisconnect, disconnect)
+			assert.Equal(t, tt.wantBanned, banned)
+			assert.Equal(t, tt.wantBanned, ps.isBanned(dummyPeerID, ""))
+			// a peer of other id at the same address is banned too
+			assert.Equal(t, tt.wantBanned, ps.isBanned(dummyPeerID2, "203.0.113.2"))
+			assert.False(t, ps.isBanned(dummyPeerID2, "203.0.113.3"))
+			if tt.wantBanned {
+				assert.Equal(t, int32(0), ps.score(dummyPeerID))
+				assert.Equal(t, 1, len(ps.bannedPeers()))
+			} else {
+				assert.NotEqual(t, int32(0), ps.score(dummyPeerID))
+				assert.Equal(t, 0, len(ps.bannedPeers()))
+			}
+		})
+	}
+}
+
+func TestPeerScorer_decay(t *testing.T) {
+	ps := newPeerScorer(sampleScoreConf, "", logger)
+	ps.addPenalty(dummyPeerID, "", message.MisbehaveInvalidBlock)
+	assert.Equal(t, int32(50), ps.score(dummyPeerID))
+
+	// the score is halved in a half life, so that the next misbehavior doesn't make a ban
+	ps.scores[dummyPeerID].updated = time.Now().Add(-scoreHalfLife)
+	assert.Equal(t, int32(25), ps.score(dummyPeerID))
+	_, disconnect, banned := ps.addPenalty(dummyPeerID, "", message.MisbehaveInvalidBlock)
+	assert.True(t, disconnect)
+	assert.False(t, banned)
+}
+
+func TestPeerScorer_prune(t *testing.T) {
+	ps := newPeerScorer(sampleScoreConf, "", logger)
+	ps.addPenalty(dummyPeerID, "", message.MisbehaveFetchFail)
+	ps.scores[dummyPeerID].updated = time.Now().Add(-scoreHalfLife * 10)
+
+	// the decayed score is forgotten on the next misbehavior of any peer
+	ps.addPenalty(dummyPeerID2, "", message.MisbehaveFetchFail)
+	_, found := ps.scores[dummyPeerID]
+	assert.False(t, found)
+	assert.Equal(t, 1, len(ps.scores))
+}
+
+func TestPeerScorer_stateMismatch(t *testing.T) {
+	ps := newPeerScorer(sampleScoreConf, "", logger)
+	for i := 0; i < 3; i++ {
+		_, disconnect, banned := ps.addPenalty(dummyPeerID, "203.0.113.2", message.MisbehaveStateMismatch)
+		assert.True(t, disconnect)
+		assert.False(t, banned)
+	}
+	assert.Equal(t, int32(0), ps.score(dummyPeerID))
+	assert.False(t, ps.isBanned(dummyPeerID, "203.0.113.2"))
+}
+
+func TestPeerScorer_banExpire(t *testing.T) {
+	ps := newPeerScorer(sampleScoreConf, "", logger)
+	ps.addPenalty(dummyPeerID, "203.0.113.2", message.MisbehaveInvalidBlock)
+	ps.addPenalty(dummyPeerID, "203.0.113.2", message.MisbehaveInvalidBlock)
+	assert.True(t, ps.isBanned(dummyPeerID, ""))
+
+	ps.bans[dummyPeerID].Until = time.Now().Add(-time.Second)
+	assert.False(t, ps.isBanned(dummyPeerID, "203.0.113.2"))
+	assert.Equal(t, 0, len(ps.bannedPeers()))
+}
+
+func TestPeerScorer_persist(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "peerscore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	ps := newPeerScorer(sampleScoreConf, dataDir, logger)
+	ps.addPenalty(dummyPeerID, "203.0.113.2", message.MisbehaveInvalidBlock)
+	ps.addPenalty(dummyPeerID, "203.0.113.2", message.MisbehaveInvalidBlock)
+
+	// the bans are kept after restart
+	restarted := newPeerScorer(sampleScoreConf, dataDir, logger)
+	assert.True(t, restarted.isBanned(dummyPeerID, ""))
+	assert.True(t, restarted.isBanned(dummyPeerID3, "203.0.113.2"))
+
+	bans := restarted.bannedPeers()
+	assert.Equal(t, 1, len(bans))
+	actual := bans[0].ToPeer()
+	assert.Equal(t, int32(types.BANNED), actual.State)
+	assert.Equal(t, []byte(dummyPeerID), actual.Address.PeerID)
+	assert.Equal(t, ps.bans[dummyPeerID].Until.Unix(), actual.BannedUntil)
+}
+
+func TestPeerScorer_localIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		ip         string
+		banLocalIP bool
+		wantBanIP  bool
+	}{
+		{"TPublic", "203.0.113.2", false, true},
+		{"TLoopback", "127.0.0.1", false, false},
+		{"TPrivate", "192.168.1.2", false, false},
+		{"TPrivate6", "fd00::2", false, false},
+		{"TLoopbackConf", "127.0.0.1", true, true},
+		{"TPrivateConf", "10.1.2.3", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := *sampleScoreConf
+			conf.NPBanLocalIP = tt.banLocalIP
+			ps := newPeerScorer(&conf, "", logger)
+			ps.addPenalty(dummyPeerID, tt.ip, message.MisbehaveInvalidBlock)
+			ps.addPenalty(dummyPeerID, tt.ip, message.MisbehaveInvalidBlock)
+
+			// the peer id is banned anyway
+			assert.True(t, ps.isBanned(dummyPeerID, ""))
+			assert.Equal(t, tt.wantBanIP, ps.isBanned(dummyPeerID2, tt.ip))
+		})
+	}
+}
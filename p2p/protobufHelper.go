@@ -213,6 +213,16 @@ func (mf *pbMOFactory) newMsgBlkBroadcastOrder(noticeMsg *types.NewBlockNotice)
 	return nil
 }
 
+func (mf *pbMOFactory) newMsgCompactBlkBroadcastOrder(compactBlock *types.CompactBlock) msgOrder {
+	rmo := &pbBlkNoticeOrder{}
+	reqID := uuid.Must(uuid.NewV4()).String()
+	if newPbMsgOrder(&rmo.pbMessageOrder, reqID, "", NewCompactBlockNotice, compactBlock, mf.signer) {
+		rmo.blkHash = compactBlock.BlockHash
+		return rmo
+	}
+	return nil
+}
+
 func (mf *pbMOFactory) newMsgTxBroadcastOrder(message *types.NewTransactionsNotice) msgOrder {
 	rmo := &pbTxNoticeOrder{}
 	reqID := uuid.Must(uuid.NewV4()).String()
@@ -28,7 +28,12 @@ const (
 	MaxBlockResponseCount       = 2000
 	MaxResponseSplitCount = 5
 
+	// limits of a response of the snapshot sync
+	MaxStateChunkEntries = 256
+	MaxStateChunkSize    = MaxPayloadLength >> 1
+
 	SyncWorkTTL = time.Second * 30
+	CompactBlockTTL = time.Second * 5
 	AddBlockCheckpoint = 100
 	AddBlockWaitTime = time.Second * 10
 )
@@ -61,12 +66,19 @@ const (
 	GetHashesResponse
 	GetHashByNoRequest
 	GetHashByNoResponse
+	NewCompactBlockNotice
 )
 const (
 	GetTXsRequest SubProtocol = 0x020 + iota
 	GetTxsResponse
 	NewTxNotice
 )
+const (
+	GetStateChunkRequest SubProtocol = 0x030 + iota
+	GetStateChunkResponse
+	GetSQLChunkRequest
+	GetSQLChunkResponse
+)
 
 //go:generate stringer -type=SubProtocol
 
@@ -78,7 +90,6 @@ func (sp SubProtocol) Uint32() uint32 {
 const (
 	txhashLen  = 32
 	blkhashLen = 32
-
 )
 
 type BlkHash [blkhashLen]byte
@@ -13,7 +13,7 @@ var subProtocolCodeMap map[uint32]SubProtocol
 func init() {
 	subProtocolMap = make(map[string]SubProtocol)
 	subProtocolCodeMap = make(map[uint32]SubProtocol)
-	for i:=StatusRequest; i<=0x030 ; i++ {
+	for i:=StatusRequest; i<=0x040 ; i++ {
 		if strings.HasPrefix(i.String(),"SubProtocol(") {
 			continue
 		}
@@ -83,6 +83,8 @@ type remotePeerImpl struct {
 	pingDuration time.Duration
 
 	meta      PeerMeta
+	// remoteIP is the ip address observed from the connection, not reported by the peer
+	remoteIP  string
 	state     types.PeerState
 	actorServ ActorService
 	pm        PeerManager
@@ -300,11 +302,13 @@ func (p *remotePeerImpl) handleMsg(msg Message) error {
 	handler, found := p.handlers[proto]
 	if !found {
 		p.logger.Debug().Str(LogPeerID, p.ID().Pretty()).Str(LogMsgID, msg.ID().String()).Str(LogProtoID, proto.String()).Msg("Invalid protocol")
+		// unknown subprotocols may be sent by the peers of newer versions, so they aren't scored
 		return fmt.Errorf("invalid protocol %s", proto)
 	}
 	payload, err := handler.parsePayload(msg.Payload())
 	if err != nil {
 		p.logger.Warn().Err(err).Str(LogPeerID, p.ID().Pretty()).Str(LogMsgID, msg.ID().String()).Str(LogProtoID, proto.String()).Msg("Invalid message data")
+		p.reportMisbehavior(message.MisbehaveInvalidMessage)
 		return fmt.Errorf("Invalid message data")
 	}
 	//err = p.signer.verifyMsg(msg, p.meta.ID)
@@ -322,6 +326,11 @@ func (p *remotePeerImpl) handleMsg(msg Message) error {
 	return nil
 }
 
+// reportMisbehavior lets p2p service raise the misbehavior score of this peer
+func (p *remotePeerImpl) reportMisbehavior(kind message.Misbehavior) {
+	p.actorServ.TellRequest(message.P2PSvc, &message.PeerMisbehavior{PeerID: p.meta.ID, Kind: kind})
+}
+
 // Stop stops aPeer works
 func (p *remotePeerImpl) stop() {
 	p.stopChan <- struct{}{}
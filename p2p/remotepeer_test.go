@@ -383,6 +383,7 @@ func TestRemotePeer_handleMsg(t *testing.T) {
 			mockMsgHandler.On("checkAuth", mock.Anything, mock.Anything).Return(tt.args.autherr)
 			mockMsgHandler.On("handle", mock.Anything, mock.Anything)
 			mockSigner.On("verifyMsg", mock.Anything, mock.Anything).Return(nil)
+			mockActorServ.On("TellRequest", message.P2PSvc, mock.AnythingOfType("*message.PeerMisbehavior"))
 
 			target := newRemotePeer(sampleMeta, mockPeerManager, mockActorServ, logger, mockMF, mockSigner, nil)
 			target.handlers[PingRequest] = mockMsgHandler
@@ -405,6 +406,12 @@ func TestRemotePeer_handleMsg(t *testing.T) {
 			} else {
 				mockMsgHandler.AssertCalled(t, "handle", msg, bodyStub)
 			}
+			// only malformed messages are scored, not unknown subprotocols
+			if !tt.args.nohandler && tt.args.parerr != nil {
+				mockActorServ.AssertCalled(t, "TellRequest", message.P2PSvc, mock.AnythingOfType("*message.PeerMisbehavior"))
+			} else {
+				mockActorServ.AssertNotCalled(t, "TellRequest", message.P2PSvc, mock.AnythingOfType("*message.PeerMisbehavior"))
+			}
 		})
 	}
 }
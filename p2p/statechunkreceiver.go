@@ -0,0 +1,156 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// StateChunkReceiver sends p2p GetStateChunkRequest to target peer and receives the response.
+// It will send response actor message to syncer, but not send response if timeout expired.
+type StateChunkReceiver struct {
+	requestID MsgID
+
+	peer  RemotePeer
+	actor ActorService
+
+	req      *types.GetStateChunkRequest
+	timeout  time.Time
+	finished bool
+}
+
+func NewStateChunkReceiver(actor ActorService, peer RemotePeer, msg *message.GetStateChunk, ttl time.Duration) *StateChunkReceiver {
+	timeout := time.Now().Add(ttl)
+	req := &types.GetStateChunkRequest{Root: msg.Root, Start: msg.Start, Accounts: msg.Accounts, MaxEntries: msg.MaxEntries}
+	return &StateChunkReceiver{actor: actor, peer: peer, req: req, timeout: timeout}
+}
+
+func (br *StateChunkReceiver) StartGet() {
+	mo := br.peer.MF().newMsgBlockRequestOrder(br.ReceiveResp, GetStateChunkRequest, br.req)
+	br.requestID = mo.GetMsgID()
+	br.peer.sendMessage(mo)
+}
+
+// ReceiveResp must be called just in read go routine
+func (br *StateChunkReceiver) ReceiveResp(msg Message, msgBody proto.Message) (ret bool) {
+	ret = true
+	br.peer.consumeRequest(br.requestID)
+	// timeout
+	if br.finished || br.timeout.Before(time.Now()) {
+		// silently ignore already finished job
+		br.finished = true
+		return
+	}
+	br.finished = true
+	// remote peer response failure
+	body := msgBody.(*types.GetStateChunkResponse)
+	if body.Status != types.ResultStatus_OK {
+		br.actor.TellRequest(message.SyncerSvc, &message.GetStateChunkRsp{Err: message.RemotePeerFailError})
+		return
+	}
+	br.actor.TellRequest(message.SyncerSvc, &message.GetStateChunkRsp{Entries: body.Entries, Next: body.Next})
+	return
+}
+
+// SQLChunkReceiver sends p2p GetSQLChunkRequest to target peer and receives the response.
+// It will send response actor message to syncer, but not send response if timeout expired.
+type SQLChunkReceiver struct {
+	requestID MsgID
+
+	peer  RemotePeer
+	actor ActorService
+
+	req      *types.GetSQLChunkRequest
+	timeout  time.Time
+	finished bool
+}
+
+func NewSQLChunkReceiver(actor ActorService, peer RemotePeer, msg *message.GetSQLChunk, ttl time.Duration) *SQLChunkReceiver {
+	timeout := time.Now().Add(ttl)
+	req := &types.GetSQLChunkRequest{Name: msg.Name, Offset: msg.Offset}
+	return &SQLChunkReceiver{actor: actor, peer: peer, req: req, timeout: timeout}
+}
+
+func (br *SQLChunkReceiver) StartGet() {
+	mo := br.peer.MF().newMsgBlockRequestOrder(br.ReceiveResp, GetSQLChunkRequest, br.req)
+	br.requestID = mo.GetMsgID()
+	br.peer.sendMessage(mo)
+}
+
+// ReceiveResp must be called just in read go routine
+func (br *SQLChunkReceiver) ReceiveResp(msg Message, msgBody proto.Message) (ret bool) {
+	ret = true
+	br.peer.consumeRequest(br.requestID)
+	// timeout
+	if br.finished || br.timeout.Before(time.Now()) {
+		// silently ignore already finished job
+		br.finished = true
+		return
+	}
+	br.finished = true
+	// remote peer response failure
+	body := msgBody.(*types.GetSQLChunkResponse)
+	if body.Status != types.ResultStatus_OK {
+		br.actor.TellRequest(message.SyncerSvc, &message.GetSQLChunkRsp{Err: message.RemotePeerFailError})
+		return
+	}
+	br.actor.TellRequest(message.SyncerSvc, &message.GetSQLChunkRsp{Name: body.Name, Data: body.Data, Size: body.Size, Next: body.Next})
+	return
+}
+
+// SyncHeadersReceiver sends p2p GetBlockHeadersRequest to target peer and receives the response.
+// It will send response actor message to syncer, but not send response if timeout expired.
+type SyncHeadersReceiver struct {
+	requestID MsgID
+
+	peer  RemotePeer
+	actor ActorService
+
+	req      *types.GetBlockHeadersRequest
+	timeout  time.Time
+	finished bool
+}
+
+func NewSyncHeadersReceiver(actor ActorService, peer RemotePeer, msg *message.GetSyncHeaders, ttl time.Duration) *SyncHeadersReceiver {
+	timeout := time.Now().Add(ttl)
+	req := &types.GetBlockHeadersRequest{Height: msg.Height, Size: msg.MaxSize}
+	return &SyncHeadersReceiver{actor: actor, peer: peer, req: req, timeout: timeout}
+}
+
+func (br *SyncHeadersReceiver) StartGet() {
+	mo := br.peer.MF().newMsgBlockRequestOrder(br.ReceiveResp, GetBlockHeadersRequest, br.req)
+	br.requestID = mo.GetMsgID()
+	br.peer.sendMessage(mo)
+}
+
+// ReceiveResp must be called just in read go routine
+func (br *SyncHeadersReceiver) ReceiveResp(msg Message, msgBody proto.Message) (ret bool) {
+	ret = true
+	br.peer.consumeRequest(br.requestID)
+	// timeout
+	if br.finished || br.timeout.Before(time.Now()) {
+		// silently ignore already finished job
+		br.finished = true
+		return
+	}
+	br.finished = true
+	// remote peer response failure
+	body := msgBody.(*types.GetBlockHeadersResponse)
+	if body.Status != types.ResultStatus_OK || len(body.Hashes) != len(body.Headers) {
+		br.actor.TellRequest(message.SyncerSvc, &message.GetSyncHeadersRsp{Err: message.RemotePeerFailError})
+		return
+	}
+	hashes := make([]message.BlockHash, len(body.Hashes))
+	for i, hash := range body.Hashes {
+		hashes[i] = hash
+	}
+	br.actor.TellRequest(message.SyncerSvc, &message.GetSyncHeadersRsp{Hashes: hashes, Headers: body.Headers})
+	return
+}
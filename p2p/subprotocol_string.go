@@ -6,14 +6,16 @@ import "strconv"
 
 const (
 	_SubProtocol_name_0 = "StatusRequestPingRequestPingResponseGoAwayAddressesRequestAddressesResponse"
-	_SubProtocol_name_1 = "GetBlocksRequestGetBlocksResponseGetBlockHeadersRequestGetBlockHeadersResponseGetMissingRequestGetMissingResponseNewBlockNoticeGetAncestorRequestGetAncestorResponseGetHashesRequestGetHashesResponseGetHashByNoRequestGetHashByNoResponse"
+	_SubProtocol_name_1 = "GetBlocksRequestGetBlocksResponseGetBlockHeadersRequestGetBlockHeadersResponseGetMissingRequestGetMissingResponseNewBlockNoticeGetAncestorRequestGetAncestorResponseGetHashesRequestGetHashesResponseGetHashByNoRequestGetHashByNoResponseNewCompactBlockNotice"
 	_SubProtocol_name_2 = "GetTXsRequestGetTxsResponseNewTxNotice"
+	_SubProtocol_name_3 = "GetStateChunkRequestGetStateChunkResponseGetSQLChunkRequestGetSQLChunkResponse"
 )
 
 var (
 	_SubProtocol_index_0 = [...]uint8{0, 13, 24, 36, 42, 58, 75}
-	_SubProtocol_index_1 = [...]uint8{0, 16, 33, 55, 78, 95, 113, 127, 145, 164, 180, 197, 215, 234}
+	_SubProtocol_index_1 = [...]uint8{0, 16, 33, 55, 78, 95, 113, 127, 145, 164, 180, 197, 215, 234, 255}
 	_SubProtocol_index_2 = [...]uint8{0, 13, 27, 38}
+	_SubProtocol_index_3 = [...]uint8{0, 20, 41, 59, 78}
 )
 
 func (i SubProtocol) String() string {
@@ -21,12 +23,15 @@ func (i SubProtocol) String() string {
 	case 1 <= i && i <= 6:
 		i -= 1
 		return _SubProtocol_name_0[_SubProtocol_index_0[i]:_SubProtocol_index_0[i+1]]
-	case 16 <= i && i <= 28:
+	case 16 <= i && i <= 29:
 		i -= 16
 		return _SubProtocol_name_1[_SubProtocol_index_1[i]:_SubProtocol_index_1[i+1]]
 	case 32 <= i && i <= 34:
 		i -= 32
 		return _SubProtocol_name_2[_SubProtocol_index_2[i]:_SubProtocol_index_2[i+1]]
+	case 48 <= i && i <= 51:
+		i -= 48
+		return _SubProtocol_name_3[_SubProtocol_index_3[i]:_SubProtocol_index_3[i+1]]
 	default:
 		return "SubProtocol(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -33,6 +33,12 @@ type newBlockNoticeHandler struct {
 
 var _ MessageHandler = (*newBlockNoticeHandler)(nil)
 
+type newCompactBlockNoticeHandler struct {
+	BaseMsgHandler
+}
+
+var _ MessageHandler = (*newCompactBlockNoticeHandler)(nil)
+
 type getMissingRequestHandler struct {
 	BaseMsgHandler
 }
@@ -132,10 +138,10 @@ func (bh *listBlockHeadersResponseHandler) handle(msg Message, msgBody proto.Mes
 	data := msgBody.(*types.GetBlockHeadersResponse)
 	debugLogReceiveResponseMsg(bh.logger, bh.protocol, msg.ID().String(), msg.OriginalID().String(), peerID, len(data.Hashes))
 
-	// send block headers to blockchain service
-	remotePeer.consumeRequest(msg.OriginalID())
-
-	// TODO: it's not used yet, but used in RPC and can be used in future performance tuning
+	// locate request data and remove it if found
+	if !remotePeer.GetReceiver(msg.OriginalID())(msg, data) {
+		remotePeer.consumeRequest(msg.OriginalID())
+	}
 }
 
 // newNewBlockNoticeHandler creates handler for NewBlockNotice
@@ -162,6 +168,29 @@ func (bh *newBlockNoticeHandler) handle(msg Message, msgBody proto.Message) {
 	}
 }
 
+// newNewCompactBlockNoticeHandler creates handler for NewCompactBlockNotice
+func newNewCompactBlockNoticeHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService, sm SyncManager) *newCompactBlockNoticeHandler {
+	bh := &newCompactBlockNoticeHandler{BaseMsgHandler: BaseMsgHandler{protocol: NewCompactBlockNotice, pm: pm, sm: sm, peer: peer, actor: actor, logger: logger}}
+	return bh
+}
+
+func (bh *newCompactBlockNoticeHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.CompactBlock{})
+}
+
+func (bh *newCompactBlockNoticeHandler) handle(msg Message, msgBody proto.Message) {
+	remotePeer := bh.peer
+	data := msgBody.(*types.CompactBlock)
+
+	// the compact block is also the notice of new block
+	var hash BlkHash
+	copy(hash[:], data.BlockHash)
+	notice := &types.NewBlockNotice{BlockHash: data.BlockHash, BlockNo: data.GetHeader().GetBlockNo()}
+	if !remotePeer.updateBlkCache(hash, notice) {
+		bh.sm.HandleNewCompactBlock(remotePeer, hash, data)
+	}
+}
+
 func max(a, b uint32) uint32 {
 	if a > b {
 		return a
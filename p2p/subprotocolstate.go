@@ -0,0 +1,142 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+type getStateChunkRequestHandler struct {
+	BaseMsgHandler
+}
+
+type getStateChunkResponseHandler struct {
+	BaseMsgHandler
+}
+
+type getSQLChunkRequestHandler struct {
+	BaseMsgHandler
+}
+
+type getSQLChunkResponseHandler struct {
+	BaseMsgHandler
+}
+
+// newStateChunkReqHandler creates handler for GetStateChunkRequest
+func newStateChunkReqHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getStateChunkRequestHandler {
+	bh := &getStateChunkRequestHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetStateChunkRequest, pm: pm, peer: peer, actor: actor, logger: logger}}
+	return bh
+}
+
+func (bh *getStateChunkRequestHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetStateChunkRequest{})
+}
+
+func (bh *getStateChunkRequestHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetStateChunkRequest)
+	debugLogReceiveMsg(bh.logger, bh.protocol, msg.ID().String(), peerID, fmt.Sprintf("root=%s,start=%s", enc.ToString(data.Root), enc.ToString(data.Start)))
+
+	maxEntries := MaxStateChunkEntries
+	if data.MaxEntries != 0 && int(data.MaxEntries) < maxEntries {
+		maxEntries = int(data.MaxEntries)
+	}
+	resp := &types.GetStateChunkResponse{Status: types.ResultStatus_OK}
+	rawResponse, err := bh.actor.CallRequestDefaultTimeout(message.ChainSvc,
+		&message.ExportStateChunk{Root: data.Root, Start: data.Start, Accounts: data.Accounts,
+			MaxEntries: maxEntries, MaxSize: MaxStateChunkSize})
+	if err != nil {
+		bh.logger.Warn().Err(err).Msg("failed to get state chunk")
+		resp.Status = types.ResultStatus_INTERNAL
+	} else if chunk := rawResponse.(message.ExportStateChunkRsp); chunk.Err != nil {
+		// the state of the root is not found or pruned
+		resp.Status = types.ResultStatus_NOT_FOUND
+	} else {
+		resp.Entries = chunk.Entries
+		resp.Next = chunk.Next
+	}
+	remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetStateChunkResponse, resp))
+}
+
+// newStateChunkRespHandler creates handler for GetStateChunkResponse
+func newStateChunkRespHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getStateChunkResponseHandler {
+	bh := &getStateChunkResponseHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetStateChunkResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
+	return bh
+}
+
+func (bh *getStateChunkResponseHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetStateChunkResponse{})
+}
+
+func (bh *getStateChunkResponseHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetStateChunkResponse)
+	debugLogReceiveResponseMsg(bh.logger, bh.protocol, msg.ID().String(), msg.OriginalID().String(), peerID, fmt.Sprintf("status=%s,entries=%d", data.Status.String(), len(data.Entries)))
+
+	// locate request data and remove it if found
+	remotePeer.GetReceiver(msg.OriginalID())(msg, data)
+}
+
+// newSQLChunkReqHandler creates handler for GetSQLChunkRequest
+func newSQLChunkReqHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getSQLChunkRequestHandler {
+	bh := &getSQLChunkRequestHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetSQLChunkRequest, pm: pm, peer: peer, actor: actor, logger: logger}}
+	return bh
+}
+
+func (bh *getSQLChunkRequestHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetSQLChunkRequest{})
+}
+
+func (bh *getSQLChunkRequestHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetSQLChunkRequest)
+	debugLogReceiveMsg(bh.logger, bh.protocol, msg.ID().String(), peerID, fmt.Sprintf("name=%s,offset=%d", data.Name, data.Offset))
+
+	resp := &types.GetSQLChunkResponse{Status: types.ResultStatus_OK}
+	rawResponse, err := bh.actor.CallRequestDefaultTimeout(message.ChainSvc,
+		&message.ExportSQLChunk{Name: data.Name, Offset: data.Offset, MaxSize: MaxStateChunkSize})
+	if err != nil {
+		bh.logger.Warn().Err(err).Msg("failed to get sql chunk")
+		resp.Status = types.ResultStatus_INTERNAL
+	} else if chunk := rawResponse.(message.ExportSQLChunkRsp); chunk.Err != nil {
+		resp.Status = types.ResultStatus_NOT_FOUND
+	} else {
+		resp.Name = chunk.Name
+		resp.Data = chunk.Data
+		resp.Size = chunk.Size
+		resp.Next = chunk.Next
+	}
+	remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetSQLChunkResponse, resp))
+}
+
+// newSQLChunkRespHandler creates handler for GetSQLChunkResponse
+func newSQLChunkRespHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *getSQLChunkResponseHandler {
+	bh := &getSQLChunkResponseHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetSQLChunkResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
+	return bh
+}
+
+func (bh *getSQLChunkResponseHandler) parsePayload(rawbytes []byte) (proto.Message, error) {
+	return unmarshalAndReturn(rawbytes, &types.GetSQLChunkResponse{})
+}
+
+func (bh *getSQLChunkResponseHandler) handle(msg Message, msgBody proto.Message) {
+	peerID := bh.peer.ID()
+	remotePeer := bh.peer
+	data := msgBody.(*types.GetSQLChunkResponse)
+	debugLogReceiveResponseMsg(bh.logger, bh.protocol, msg.ID().String(), msg.OriginalID().String(), peerID, fmt.Sprintf("status=%s,name=%s,size=%d", data.Status.String(), data.Name, len(data.Data)))
+
+	// locate request data and remove it if found
+	remotePeer.GetReceiver(msg.OriginalID())(msg, data)
+}
@@ -50,7 +50,11 @@ func (th *txRequestHandler) handle(msg Message, msgBody proto.Message) {
 
 	// TODO consider to make async if deadlock with remote peer can occurs
 	// NOTE size estimation is tied to protobuf3 it should be changed when protobuf is changed.
-	// find transactions from chainservice
+	// find transactions from mempool, or from the block if it is the request for a compact block
+	findTx := th.findInMemPool
+	if len(data.BlockHash) > 0 {
+		findTx = th.newBlockTxFinder(data.BlockHash)
+	}
 	idx := 0
 	status := types.ResultStatus_OK
 	hashes := make([][]byte, 0, 100)
@@ -58,8 +62,7 @@ func (th *txRequestHandler) handle(msg Message, msgBody proto.Message) {
 	payloadSize := EmptyGetBlockResponseSize
 	var txSize, fieldSize int
 	for _, hash := range data.Hashes {
-		tx, err := th.msgHelper.ExtractTxFromResponseAndError(th.actor.CallRequestDefaultTimeout(message.MemPoolSvc,
-			&message.MemPoolExist{Hash: hash}))
+		tx, err := findTx(hash)
 		if err != nil {
 			// response error to peer
 			resp := &types.GetTransactionsResponse{Status: types.ResultStatus_INTERNAL}
@@ -106,6 +109,26 @@ func (th *txRequestHandler) handle(msg Message, msgBody proto.Message) {
 	remotePeer.sendMessage(remotePeer.MF().newMsgResponseOrder(msg.ID(), GetTxsResponse, resp))
 }
 
+func (th *txRequestHandler) findInMemPool(hash []byte) (*types.Tx, error) {
+	return th.msgHelper.ExtractTxFromResponseAndError(th.actor.CallRequestDefaultTimeout(message.MemPoolSvc,
+		&message.MemPoolExist{Hash: hash}))
+}
+
+// newBlockTxFinder returns the function which finds the tx of the hash or the short id in the
+// block of blockHash. The function finds nothing if selfnode does not have the block.
+func (th *txRequestHandler) newBlockTxFinder(blockHash []byte) func(hash []byte) (*types.Tx, error) {
+	txs := make(map[string]*types.Tx)
+	if block, _ := th.actor.GetChainAccessor().GetBlock(blockHash); block != nil {
+		for _, tx := range block.GetBody().GetTxs() {
+			txs[string(tx.Hash)] = tx
+			txs[string(types.ShortTxID(blockHash, tx.Hash))] = tx
+		}
+	}
+	return func(hash []byte) (*types.Tx, error) {
+		return txs[string(hash)], nil
+	}
+}
+
 // newTxRespHandler creates handler for GetTransactionsResponse
 func newTxRespHandler(pm PeerManager, peer RemotePeer, logger *log.Logger, actor ActorService) *txResponseHandler {
 	th := &txResponseHandler{BaseMsgHandler: BaseMsgHandler{protocol: GetTxsResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
@@ -121,12 +144,16 @@ func (th *txResponseHandler) handle(msg Message, msgBody proto.Message) {
 	data := msgBody.(*types.GetTransactionsResponse)
 	debugLogReceiveResponseMsg(th.logger, th.protocol, msg.ID().String(), msg.OriginalID().String(), peerID, len(data.Txs))
 
+	// the txs requested for a compact block are sent to its receiver
+	if th.peer.GetReceiver(msg.OriginalID())(msg, data) {
+		return
+	}
 	// TODO: Is there any better solution than passing everything to mempool service?
 	if len(data.Txs) > 0 {
 		th.logger.Debug().Int(LogTxCount, len(data.Txs)).Msg("Request mempool to add txs")
 		//th.actor.SendRequest(message.MemPoolSvc, &message.MemPoolPut{Txs: data.Txs})
 		for _, tx := range data.Txs {
-			th.actor.SendRequest(message.MemPoolSvc, &message.MemPoolPut{Tx: tx})
+			th.actor.SendRequest(message.MemPoolSvc, &message.MemPoolPut{Tx: tx, PeerID: peerID})
 		}
 	}
 }
@@ -20,6 +20,7 @@ import (
 
 type SyncManager interface {
 	HandleNewBlockNotice(peer RemotePeer, hash BlkHash, data *types.NewBlockNotice)
+	HandleNewCompactBlock(peer RemotePeer, hash BlkHash, data *types.CompactBlock)
 	HandleGetBlockResponse(peer RemotePeer, msg Message, resp *types.GetBlockResponse)
 	HandleNewTxNotice(peer RemotePeer, hashes []TxHash, data *types.NewTransactionsNotice)
 
@@ -106,6 +107,22 @@ func (sm *syncManager) HandleNewBlockNotice(peer RemotePeer, hashArr BlkHash, da
 			Hashes: []message.BlockHash{message.BlockHash(data.BlockHash)}})
 	}
 }
+
+// HandleNewCompactBlock rebuilds the block of compact block with the txs in mempool if selfnode
+// does not have block already.
+func (sm *syncManager) HandleNewCompactBlock(peer RemotePeer, hashArr BlkHash, data *types.CompactBlock) {
+	ok, _ := sm.blkCache.ContainsOrAdd(hashArr, cachePlaceHolder)
+	if ok {
+		// this block is already sent to chainservice
+		return
+	}
+
+	foundBlock, _ := sm.actor.GetChainAccessor().GetBlock(data.BlockHash)
+	if foundBlock == nil {
+		NewCompactBlockReceiver(sm.actor, peer, data, CompactBlockTTL).StartGet()
+	}
+}
+
 // HandleGetBlockResponse handle when remote peer send a block information.
 // TODO this method will be removed after newer syncer is developed
 func (sm *syncManager) HandleGetBlockResponse(peer RemotePeer, msg Message, resp *types.GetBlockResponse) {
@@ -76,6 +76,14 @@ func (h *V030Handshaker) doForOutbound() (*types.Status, error) {
 		return nil, err
 	}
 
+	if data.Subprotocol() == GoAway {
+		// remote peer refused the handshake
+		goAway := &types.GoAwayNotice{}
+		if err = unmarshalMessage(data.Payload(), goAway); err != nil {
+			return nil, fmt.Errorf("Unexpected message type")
+		}
+		return nil, fmt.Errorf("remote peer refused handshake: %s", goAway.Message)
+	}
 	if data.Subprotocol() != StatusRequest {
 		// TODO: parse message and return
 		// h.logger.Info().Str(LogPeerID, peerID.Pretty()).Str("expected", StatusRequest.String()).Str("actual", SubProtocol(data.Header.GetSubprotocol()).String()).Msg("Unexpected handshake response")
@@ -89,6 +97,11 @@ func (h *V030Handshaker) doForOutbound() (*types.Status, error) {
 	}
 
 	// check status message
+	if err = checkSameChain(statusMsg, statusResp); err != nil {
+		h.logger.Info().Str(LogPeerID, peerID.Pretty()).Err(err).Msg("Remote peer is on other chain")
+		h.sendGoAway(err.Error())
+		return nil, err
+	}
 	return statusResp, nil
 }
 
@@ -124,6 +137,11 @@ func (h *V030Handshaker) doForInbound() (*types.Status, error) {
 		h.logger.Warn().Err(err).Msg("failed to create status message")
 		return nil, err
 	}
+	if err = checkSameChain(statusResp, statusMsg); err != nil {
+		h.logger.Info().Str(LogPeerID, peerID.Pretty()).Err(err).Msg("Remote peer is on other chain")
+		h.sendGoAway(err.Error())
+		return nil, err
+	}
 	moFactory := &v030MOFactory{}
 	container := moFactory.newHandshakeMessage(StatusRequest, statusResp)
 	if container == nil {
@@ -138,3 +156,12 @@ func (h *V030Handshaker) doForInbound() (*types.Status, error) {
 
 }
 
+// sendGoAway notices the reason of the refused handshake to the remote peer
+func (h *V030Handshaker) sendGoAway(msg string) {
+	moFactory := &v030MOFactory{}
+	container := moFactory.newHandshakeMessage(GoAway, &types.GoAwayNotice{Message: msg})
+	if container == nil {
+		return
+	}
+	h.msgRW.WriteMsg(container)
+}
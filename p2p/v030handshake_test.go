@@ -25,8 +25,13 @@ func TestV030StatusHS_handshakeOutboundPeer(t *testing.T) {
 	dummyBlock := &types.Block{Hash: dummyBlockHash, Header: &types.BlockHeader{BlockNo: dummyBlockHeight}}
 	mockActor.On("GetChainAccessor").Return(mockCA)
 	mockCA.On("GetBestBlock").Return(dummyBlock, nil)
+	dummyGenesis := types.GetTestGenesis()
+	mockCA.On("GetGenesisInfo").Return(dummyGenesis)
+	mockCA.On("GetHashByNo", types.BlockNo(0)).Return(dummyGenesis.Block().BlockHash(), nil)
 
-	dummyStatusMsg := &types.Status{}
+	dummyStatusMsg := &types.Status{ChainID: dummyGenesis.ChainID(), Genesis: dummyGenesis.Block().BlockHash()}
+	otherChainMsg := &types.Status{ChainID: []byte("other chain"), Genesis: dummyGenesis.Block().BlockHash()}
+	otherGenesisMsg := &types.Status{ChainID: dummyGenesis.ChainID(), Genesis: dummyBlockHash}
 	statusBytes, _ := marshalMessage(dummyStatusMsg)
 	tests := []struct {
 		name       string
@@ -40,6 +45,8 @@ func TestV030StatusHS_handshakeOutboundPeer(t *testing.T) {
 		{"TUnexpMsg", nil, nil, nil, nil, true},
 		{"TRFail", dummyStatusMsg, fmt.Errorf("failed"), nil, nil, true},
 		{"TWFail", dummyStatusMsg, nil, fmt.Errorf("failed"), nil, true},
+		{"TOtherChain", otherChainMsg, nil, nil, nil, true},
+		{"TOtherGenesis", otherGenesisMsg, nil, nil, nil, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -49,6 +56,7 @@ func TestV030StatusHS_handshakeOutboundPeer(t *testing.T) {
 
 			containerMsg := &V030Message{payload:statusBytes}
 			if tt.readReturn != nil {
+				containerMsg.payload, _ = marshalMessage(tt.readReturn)
 				containerMsg.subProtocol = StatusRequest
 			} else {
 				containerMsg.subProtocol = AddressesRequest
@@ -83,8 +91,13 @@ func TestV030StatusHS_handshakeInboundPeer(t *testing.T) {
 	//dummyBlkRsp := message.GetBestBlockRsp{Block: dummyBlock}
 	mockActor.On("GetChainAccessor").Return(mockCA)
 	mockCA.On("GetBestBlock").Return(dummyBlock, nil)
+	dummyGenesis := types.GetTestGenesis()
+	mockCA.On("GetGenesisInfo").Return(dummyGenesis)
+	mockCA.On("GetHashByNo", types.BlockNo(0)).Return(dummyGenesis.Block().BlockHash(), nil)
 
-	dummyStatusMsg := &types.Status{}
+	dummyStatusMsg := &types.Status{ChainID: dummyGenesis.ChainID(), Genesis: dummyGenesis.Block().BlockHash()}
+	otherChainMsg := &types.Status{ChainID: []byte("other chain"), Genesis: dummyGenesis.Block().BlockHash()}
+	otherGenesisMsg := &types.Status{ChainID: dummyGenesis.ChainID(), Genesis: dummyBlockHash}
 	statusBytes, _ := marshalMessage(dummyStatusMsg)
 	tests := []struct {
 		name       string
@@ -98,6 +111,8 @@ func TestV030StatusHS_handshakeInboundPeer(t *testing.T) {
 		{"TUnexpMsg", nil, nil, nil, nil, true},
 		{"TRFail", dummyStatusMsg, fmt.Errorf("failed"), nil, nil, true},
 		{"TWFail", dummyStatusMsg, nil, fmt.Errorf("failed"), nil, true},
+		{"TOtherChain", otherChainMsg, nil, nil, nil, true},
+		{"TOtherGenesis", otherGenesisMsg, nil, nil, nil, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -106,6 +121,7 @@ func TestV030StatusHS_handshakeInboundPeer(t *testing.T) {
 			mockRW := new(MockMsgReadWriter)
 			containerMsg := &V030Message{payload:statusBytes}
 			if tt.readReturn != nil {
+				containerMsg.payload, _ = marshalMessage(tt.readReturn)
 				containerMsg.subProtocol = StatusRequest
 			} else {
 				containerMsg.subProtocol = AddressesRequest
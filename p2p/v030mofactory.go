@@ -54,6 +54,16 @@ func (mf *v030MOFactory) newMsgBlkBroadcastOrder(noticeMsg *types.NewBlockNotice
 	return nil
 }
 
+func (mf *v030MOFactory) newMsgCompactBlkBroadcastOrder(compactBlock *types.CompactBlock) msgOrder {
+	rmo := &pbBlkNoticeOrder{}
+	msgID := uuid.Must(uuid.NewV4())
+	if newV030MsgOrder(&rmo.pbMessageOrder, msgID, uuid.Nil, NewCompactBlockNotice, compactBlock) {
+		rmo.blkHash = compactBlock.BlockHash
+		return rmo
+	}
+	return nil
+}
+
 func (mf *v030MOFactory) newMsgTxBroadcastOrder(message *types.NewTransactionsNotice) msgOrder {
 	rmo := &pbTxNoticeOrder{}
 	reqID := uuid.Must(uuid.NewV4())
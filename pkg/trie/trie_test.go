@@ -340,6 +340,102 @@ func TestTrieStageUpdates(t *testing.T) {
 	os.RemoveAll(".aergo")
 }
 
+func TestTrieWalk(t *testing.T) {
+	dbPath := path.Join(".aergo", "db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		_ = os.MkdirAll(dbPath, 0711)
+	}
+	st := db.NewDB(db.BadgerImpl, dbPath)
+
+	smt := NewTrie(nil, common.Hasher, st)
+	keys := getFreshData(10, 32)
+	values := getFreshData(10, 32)
+	smt.Update(keys, values)
+	smt.Commit()
+
+	leaves := make(map[string][]byte)
+	err := smt.Walk(smt.Root, func(node []byte) bool {
+		if len(st.Get(node)) == 0 {
+			t.Fatal("visited node not stored in db")
+		}
+		return true
+	}, func(key, value []byte) {
+		leaves[string(key)] = value
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaves) != len(keys) {
+		t.Fatal("not all leaves visited")
+	}
+	for i, key := range keys {
+		if !bytes.Equal(leaves[string(key)], values[i]) {
+			t.Fatal("wrong leaf value visited")
+		}
+	}
+
+	// skip the subtree of the root
+	smt.Walk(smt.Root, func(node []byte) bool {
+		return false
+	}, func(key, value []byte) {
+		t.Fatal("leaf of skipped subtree visited")
+	})
+	st.Close()
+	os.RemoveAll(".aergo")
+}
+
+func TestTrieIterate(t *testing.T) {
+	dbPath := path.Join(".aergo", "db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		_ = os.MkdirAll(dbPath, 0711)
+	}
+	st := db.NewDB(db.BadgerImpl, dbPath)
+
+	smt := NewTrie(nil, common.Hasher, st)
+	keys := getFreshData(10, 32)
+	values := getFreshData(10, 32)
+	smt.Update(keys, values)
+	smt.Commit()
+
+	var visited [][]byte
+	collect := func(key, value []byte) bool {
+		visited = append(visited, key)
+		return true
+	}
+	if err := smt.Iterate(smt.Root, nil, collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(visited) != len(keys) {
+		t.Fatal("not all leaves visited")
+	}
+	for i, key := range keys {
+		if !bytes.Equal(visited[i], key) {
+			t.Fatal("leaves not visited in the order of the keys")
+		}
+	}
+
+	// start from the middle
+	visited = nil
+	if err := smt.Iterate(smt.Root, keys[4], collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(visited) != len(keys)-4 || !bytes.Equal(visited[0], keys[4]) {
+		t.Fatal("leaves before start visited")
+	}
+
+	// stop after 3 leaves
+	visited = nil
+	smt.Iterate(smt.Root, nil, func(key, value []byte) bool {
+		visited = append(visited, key)
+		return len(visited) < 3
+	})
+	if len(visited) != 3 {
+		t.Fatal("iteration not stopped")
+	}
+	st.Close()
+	os.RemoveAll(".aergo")
+}
+
 func TestTrieRevert(t *testing.T) {
 	dbPath := path.Join(".aergo", "db")
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
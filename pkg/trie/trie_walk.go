@@ -0,0 +1,86 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package trie
+
+import (
+	"bytes"
+)
+
+// Walk visits all the nodes of the trie given a root.
+// visitNode is called with the db key of every batch node, and if it returns
+// false, the subtree of the node is not visited. It is useful to visit the
+// nodes shared by several tries only once.
+// visitLeaf is called with the key and the value of every leaf.
+func (s *Trie) Walk(root []byte, visitNode func(node []byte) bool, visitLeaf func(key, value []byte)) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.atomicUpdate = false
+	return s.walk(root, nil, 0, s.TrieHeight, visitNode, visitLeaf)
+}
+
+// walk visits the subtree of root in depth first order
+func (s *Trie) walk(root []byte, batch [][]byte, iBatch, height int,
+	visitNode func([]byte) bool, visitLeaf func(key, value []byte)) error {
+	if len(root) == 0 {
+		return nil
+	}
+	if height%4 == 0 && !visitNode(root[:HashLength]) {
+		return nil
+	}
+	batch, iBatch, lnode, rnode, isShortcut, err := s.loadChildren(root, height, iBatch, batch)
+	if err != nil {
+		return err
+	}
+	if isShortcut {
+		visitLeaf(lnode[:HashLength], rnode[:HashLength])
+		return nil
+	}
+	if err := s.walk(lnode, batch, 2*iBatch+1, height-1, visitNode, visitLeaf); err != nil {
+		return err
+	}
+	return s.walk(rnode, batch, 2*iBatch+2, height-1, visitNode, visitLeaf)
+}
+
+// Iterate visits the leaves of the trie given a root in the order of the keys,
+// starting from the first key equal to or greater than start. It stops when
+// visitLeaf returns false.
+func (s *Trie) Iterate(root, start []byte, visitLeaf func(key, value []byte) bool) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.atomicUpdate = false
+	_, err := s.iterate(root, nil, 0, s.TrieHeight, start, visitLeaf)
+	return err
+}
+
+// iterate visits the leaves of the subtree of root in the order of the keys.
+// start is nil if all the keys of the subtree are greater than the start key.
+// It returns false if the iteration is stopped by visitLeaf.
+func (s *Trie) iterate(root []byte, batch [][]byte, iBatch, height int,
+	start []byte, visitLeaf func(key, value []byte) bool) (bool, error) {
+	if len(root) == 0 {
+		return true, nil
+	}
+	batch, iBatch, lnode, rnode, isShortcut, err := s.loadChildren(root, height, iBatch, batch)
+	if err != nil {
+		return false, err
+	}
+	if isShortcut {
+		key := lnode[:HashLength]
+		if start != nil && bytes.Compare(key, start) < 0 {
+			return true, nil
+		}
+		return visitLeaf(key, rnode[:HashLength]), nil
+	}
+	var rstart []byte
+	if start != nil && bitIsSet(start, s.TrieHeight-height) {
+		// all the keys of the left subtree are less than start
+		lnode, rstart = nil, start
+	}
+	if ok, err := s.iterate(lnode, batch, 2*iBatch+1, height-1, start, visitLeaf); !ok || err != nil {
+		return ok, err
+	}
+	return s.iterate(rnode, batch, 2*iBatch+2, height-1, rstart, visitLeaf)
+}
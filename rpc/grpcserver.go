@@ -23,6 +23,7 @@ import (
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/types"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/libp2p/go-libp2p-peer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -39,6 +40,10 @@ type AergoRPCService struct {
 
 	streamLock  sync.RWMutex
 	blockstream []types.AergoRPCService_ListBlockStreamServer
+
+	subLock   sync.RWMutex
+	blockSubs map[chan struct{}]struct{}
+	txSubs    map[*txSubscriber]struct{}
 }
 
 // FIXME remove redundant constants
@@ -60,6 +65,8 @@ func (rpc *AergoRPCService) Metric(ctx context.Context, req *types.MetricsReques
 		switch mt {
 		case types.MetricType_P2P_NETWORK:
 			rpc.fillPeerMetrics(result)
+		case types.MetricType_MEMPOOL:
+			rpc.fillMempoolMetrics(result)
 		default:
 			// TODO log itB
 		}
@@ -86,6 +93,19 @@ func (rpc *AergoRPCService) fillPeerMetrics(result *types.Metrics) {
 	result.Peers = mets
 }
 
+func (rpc *AergoRPCService) fillMempoolMetrics(result *types.Metrics) {
+	mresult, err := rpc.actorHelper.CallRequestDefaultTimeout(message.MemPoolSvc,
+		&message.MemPoolMetric{})
+	if err != nil {
+		return
+	}
+	rsp, ok := mresult.(*message.MemPoolMetricRsp)
+	if !ok {
+		return
+	}
+	result.Mempool = rsp.Metric
+}
+
 // Blockchain handle rpc request blockchain. It has no additional input parameter
 func (rpc *AergoRPCService) Blockchain(ctx context.Context, in *types.Empty) (*types.BlockchainStatus, error) {
 	//last, _ := rpc.ChainService.GetBestBlock()
@@ -111,9 +131,20 @@ func (rpc *AergoRPCService) Blockchain(ctx context.Context, in *types.Empty) (*t
 	return &types.BlockchainStatus{
 		BestBlockHash: last.BlockHash(),
 		BestHeight:    last.GetHeader().GetBlockNo(),
+		ChainIdHash:   rpc.chainIdHash(),
 	}, nil
 }
 
+// chainIdHash returns the hash of the chain ID, which the txs of the chain must
+// have
+func (rpc *AergoRPCService) chainIdHash() []byte {
+	genesis := rpc.actorHelper.GetChainAccessor().GetGenesisInfo()
+	if genesis == nil {
+		return nil
+	}
+	return genesis.ChainIdHash()
+}
+
 // ListBlockHeaders handle rpc request listblocks
 func (rpc *AergoRPCService) ListBlockHeaders(ctx context.Context, in *types.ListParams) (*types.BlockHeaderList, error) {
 	var maxFetchSize uint32
@@ -332,6 +363,9 @@ func (rpc *AergoRPCService) SendTX(ctx context.Context, tx *types.Tx) (*types.Co
 		return nil, status.Errorf(codes.Internal, "internal error : %s", getStateRsp.Err.Error())
 	}
 	tx.Body.Nonce = getStateRsp.State.GetNonce() + 1
+	if len(tx.Body.ChainIdHash) == 0 {
+		tx.Body.ChainIdHash = rpc.chainIdHash()
+	}
 
 	signTxResult, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
 		&message.SignTx{Tx: tx}, defaultActorTimeout, "rpc.(*AergoRPCService).SendTX")
@@ -577,6 +611,9 @@ func (rpc *AergoRPCService) ExportAccount(ctx context.Context, in *types.Persona
 
 // SignTX handle rpc request signtx
 func (rpc *AergoRPCService) SignTX(ctx context.Context, in *types.Tx) (*types.Tx, error) {
+	if in.GetBody() != nil && len(in.Body.ChainIdHash) == 0 {
+		in.Body.ChainIdHash = rpc.chainIdHash()
+	}
 	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
 		&message.SignTx{Tx: in}, defaultActorTimeout, "rpc.(*AergoRPCService).SignTX")
 	if err != nil {
@@ -633,12 +670,63 @@ func (rpc *AergoRPCService) GetPeers(ctx context.Context, in *types.Empty) (*typ
 	ret := &types.PeerList{Peers: []*types.Peer{}}
 	for i, state := range rsp.States {
 		peer := &types.Peer{Address: rsp.Peers[i], State: int32(state), Bestblock: rsp.LastBlks[i]}
+		if i < len(rsp.Scores) {
+			peer.Score = rsp.Scores[i]
+		}
 		ret.Peers = append(ret.Peers, peer)
 	}
+	ret.Peers = append(ret.Peers, rsp.Banned...)
 
 	return ret, nil
 }
 
+// GetAddrBook handle rpc request getaddrbook
+func (rpc *AergoRPCService) GetAddrBook(ctx context.Context, in *types.Empty) (*types.AddrBook, error) {
+	result, err := rpc.hub.RequestFuture(message.P2PSvc,
+		&message.GetAddrBook{}, defaultActorTimeout, "rpc.(*AergoRPCService).GetAddrBook").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.GetAddrBookRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return &types.AddrBook{Entries: rsp.Entries}, nil
+}
+
+// PruneAddrBook handle rpc request pruneaddrbook, and returns the removed entries
+func (rpc *AergoRPCService) PruneAddrBook(ctx context.Context, in *types.AddrBookPruneRequest) (*types.AddrBook, error) {
+	if len(in.PeerID) == 0 && in.MaxAge <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "peer id or max age is required")
+	}
+	result, err := rpc.hub.RequestFuture(message.P2PSvc,
+		&message.PruneAddrBook{PeerID: peer.ID(in.PeerID), MaxAge: time.Duration(in.MaxAge) * time.Second},
+		defaultActorTimeout, "rpc.(*AergoRPCService).PruneAddrBook").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.PruneAddrBookRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return &types.AddrBook{Entries: rsp.Pruned}, nil
+}
+
+// GetPeerAllowlist handle rpc request getpeerallowlist, and returns the
+// effective peer allowlist of the permissioned network
+func (rpc *AergoRPCService) GetPeerAllowlist(ctx context.Context, in *types.Empty) (*types.PeerAllowlist, error) {
+	result, err := rpc.hub.RequestFuture(message.P2PSvc,
+		&message.GetAllowedPeers{}, defaultActorTimeout, "rpc.(*AergoRPCService).GetPeerAllowlist").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*types.PeerAllowlist)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp, nil
+}
+
 // NodeState handle rpc request nodestate
 func (rpc *AergoRPCService) NodeState(ctx context.Context, in *types.SingleBytes) (*types.SingleBytes, error) {
 	timeout := int64(binary.LittleEndian.Uint64(in.Value))
@@ -723,6 +811,49 @@ func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.SingleBytes) (
 	return rsp.ABI, rsp.Err
 }
 
+// ListEvents handle rpc request listevents
+func (rpc *AergoRPCService) ListEvents(ctx context.Context, in *types.FilterInfo) (*types.EventList, error) {
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.ListEvents{Filter: in}, defaultActorTimeout, "rpc.(*AergoRPCService).ListEvents").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.ListEventsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return &types.EventList{Events: rsp.Events}, rsp.Err
+}
+
+// TraceTx re-executes a tx of the chain and returns the trace of the contract call
+func (rpc *AergoRPCService) TraceTx(ctx context.Context, in *types.SingleBytes) (*types.TxTrace, error) {
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.TraceTx{TxHash: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).TraceTx").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.TraceTxRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Trace, rsp.Err
+}
+
+// SimulateTx executes a tx, which may be unsigned, on the best state without
+// committing it, and returns its receipt, fee and changes of the state
+func (rpc *AergoRPCService) SimulateTx(ctx context.Context, in *types.Tx) (*types.TxSimulation, error) {
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.SimulateTx{Tx: in}, defaultActorTimeout, "rpc.(*AergoRPCService).SimulateTx").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.SimulateTxRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Simulation, rsp.Err
+}
+
 func (rpc *AergoRPCService) QueryContract(ctx context.Context, in *types.Query) (*types.SingleBytes, error) {
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
 		&message.GetQuery{Contract: in.ContractAddress, Queryinfo: in.Queryinfo}, defaultActorTimeout, "rpc.(*AergoRPCService).QueryContract").Result()
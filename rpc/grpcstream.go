@@ -0,0 +1,389 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// txStreamBufferSize is the number of mempool txs buffered for a subscriber.
+// A subscriber lagging behind more than that is disconnected, and it is
+// supposed to reconnect with the resume option.
+const txStreamBufferSize = 1024
+
+// maxInclusionHashes is the maximum number of tx hashes watched by a stream
+const maxInclusionHashes = 1000
+
+// maxStreamReorgDepth is the number of the recent blocks remembered by a
+// stream to find the fork point of a reorganization.
+const maxStreamReorgDepth = 1000
+
+// maxStreamBlockRange is the maximum number of the past blocks sent first by
+// a stream, like the block range of ListEvents.
+const maxStreamBlockRange = 10000
+
+type txSubscriber struct {
+	account []byte
+	txs     chan *types.Tx
+	lagging chan struct{}
+}
+
+func (sub *txSubscriber) match(tx *types.Tx) bool {
+	body := tx.GetBody()
+	return bytes.Equal(body.GetAccount(), sub.account) || bytes.Equal(body.GetRecipient(), sub.account)
+}
+
+// NotifyNewBlock wakes up the subscribers following the main chain.
+func (rpc *AergoRPCService) NotifyNewBlock() {
+	rpc.subLock.RLock()
+	defer rpc.subLock.RUnlock()
+	for wakeup := range rpc.blockSubs {
+		select {
+		case wakeup <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// BroadcastToTxStream sends the tx accepted by mempool to the subscribers
+// of its sender or recipient.
+func (rpc *AergoRPCService) BroadcastToTxStream(tx *types.Tx) {
+	rpc.subLock.RLock()
+	defer rpc.subLock.RUnlock()
+	for sub := range rpc.txSubs {
+		if !sub.match(tx) {
+			continue
+		}
+		select {
+		case sub.txs <- tx:
+		default:
+			select {
+			case sub.lagging <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// checkStreamRange returns an error if the past blocks from the block of
+// number from to the best block are too many to be sent by a stream.
+func checkStreamRange(from, bestNo types.BlockNo) error {
+	if from <= bestNo && bestNo-from >= maxStreamBlockRange {
+		return status.Errorf(codes.InvalidArgument, "too large block range (max %d blocks)", maxStreamBlockRange)
+	}
+	return nil
+}
+
+// followBlocks calls handle for each block of the main chain, starting from
+// the block of number from, and waits for new blocks until ctx is done. If
+// fromBest is set, it starts from the next block of the current best block
+// instead. When the main chain is reorganized, rollback, if not nil, is called
+// with the headers of the blocks handled after the fork point, from the
+// latest, and the blocks are sent again from the fork point.
+func (rpc *AergoRPCService) followBlocks(ctx context.Context, from types.BlockNo, fromBest bool,
+	handle func(*types.Block) error, rollback func(reverted []*types.Block) error) error {
+	// subscribe first not to miss a block connected while reading the best
+	wakeup := make(chan struct{}, 1)
+	rpc.subLock.Lock()
+	rpc.blockSubs[wakeup] = struct{}{}
+	rpc.subLock.Unlock()
+	defer func() {
+		rpc.subLock.Lock()
+		delete(rpc.blockSubs, wakeup)
+		rpc.subLock.Unlock()
+	}()
+
+	// the headers of the blocks sent recently, to detect a reorganization
+	sent := make(map[types.BlockNo]*types.Block)
+	next := from
+	started := false
+	for {
+		best, err := rpc.actorHelper.GetChainAccessor().GetBestBlock()
+		if err != nil {
+			return err
+		}
+		bestNo := best.GetHeader().GetBlockNo()
+		if !started {
+			if fromBest {
+				sent[bestNo] = headerOf(best)
+				next = bestNo + 1
+			} else if err = checkStreamRange(from, bestNo); err != nil {
+				return err
+			}
+			from, started = next, true
+		}
+		for next <= bestNo {
+			block, err := rpc.getBlockByNo(next)
+			if err != nil {
+				return err
+			}
+			if prev, ok := sent[next-1]; ok && !bytes.Equal(prev.Hash, block.GetHeader().GetPrevBlockHash()) {
+				var reverted []*types.Block
+				if next, reverted, err = rpc.findForkPoint(sent, next-1); err != nil {
+					return err
+				}
+				// the best block remembered at the start was not handled
+				for len(reverted) > 0 && reverted[len(reverted)-1].GetHeader().GetBlockNo() < from {
+					reverted = reverted[:len(reverted)-1]
+				}
+				if rollback != nil && len(reverted) > 0 {
+					if err = rollback(reverted); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err = handle(block); err != nil {
+				return err
+			}
+			sent[next] = headerOf(block)
+			if next >= maxStreamReorgDepth {
+				delete(sent, next-maxStreamReorgDepth)
+			}
+			next++
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wakeup:
+		}
+	}
+}
+
+// findForkPoint returns the number of the first block to send again, which
+// is the next of the last block sent still in the main chain, and the headers
+// of the blocks sent after it, from the latest. They are forgotten.
+func (rpc *AergoRPCService) findForkPoint(sent map[types.BlockNo]*types.Block, last types.BlockNo) (types.BlockNo, []*types.Block, error) {
+	var reverted []*types.Block
+	no := last
+	for ; ; no-- {
+		header, ok := sent[no]
+		if !ok {
+			// deeper than the blocks remembered; send them all again
+			break
+		}
+		block, err := rpc.getBlockByNo(no)
+		if err != nil {
+			if status.Code(err) != codes.NotFound {
+				return 0, nil, err
+			}
+		} else if bytes.Equal(block.BlockHash(), header.Hash) {
+			break
+		}
+		reverted = append(reverted, header)
+		delete(sent, no)
+		if no == 0 {
+			return 0, reverted, nil
+		}
+	}
+	return no + 1, reverted, nil
+}
+
+// headerOf returns the block without its body
+func headerOf(block *types.Block) *types.Block {
+	return &types.Block{Hash: block.BlockHash(), Header: block.GetHeader()}
+}
+
+func (rpc *AergoRPCService) getBlockByNo(blockNo types.BlockNo) (*types.Block, error) {
+	result, err := rpc.hub.RequestFuture(message.ChainSvc, &message.GetBlockByNo{BlockNo: blockNo},
+		defaultActorTimeout, "rpc.(*AergoRPCService).getBlockByNo").Result()
+	if err != nil {
+		return nil, err
+	}
+	block, err := rpc.msgHelper.ExtractBlockFromResponse(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if block == nil {
+		return nil, status.Errorf(codes.NotFound, "block %d not found", blockNo)
+	}
+	return block, nil
+}
+
+// ListBlockHeaderStream streams the headers of the blocks since FromBlockNo,
+// or of the new blocks if FromBest is set. When the main chain is
+// reorganized, the headers of the blocks reverted are sent again with Removed
+// set, from the latest, before those of the new main chain.
+func (rpc *AergoRPCService) ListBlockHeaderStream(in *types.BlockStreamRequest, stream types.AergoRPCService_ListBlockHeaderStreamServer) error {
+	return rpc.followBlocks(stream.Context(), in.FromBlockNo, in.FromBest, func(block *types.Block) error {
+		return stream.Send(&types.BlockHeaderNotice{Hash: block.BlockHash(), Header: block.GetHeader()})
+	}, func(reverted []*types.Block) error {
+		for _, block := range reverted {
+			if err := stream.Send(&types.BlockHeaderNotice{Hash: block.Hash, Header: block.Header, Removed: true}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListTxStream streams the txs of an account (sender or recipient) accepted
+// by mempool. Unless FromBest is set, the txs of the account included in the
+// blocks since FromBlockNo are sent first.
+func (rpc *AergoRPCService) ListTxStream(in *types.TxStreamRequest, stream types.AergoRPCService_ListTxStreamServer) error {
+	if len(in.Account) == 0 {
+		return status.Errorf(codes.InvalidArgument, "account is required")
+	}
+	sub := &txSubscriber{
+		account: in.Account,
+		txs:     make(chan *types.Tx, txStreamBufferSize),
+		lagging: make(chan struct{}, 1),
+	}
+	rpc.subLock.Lock()
+	rpc.txSubs[sub] = struct{}{}
+	rpc.subLock.Unlock()
+	defer func() {
+		rpc.subLock.Lock()
+		delete(rpc.txSubs, sub)
+		rpc.subLock.Unlock()
+	}()
+
+	if !in.FromBest {
+		best, err := rpc.actorHelper.GetChainAccessor().GetBestBlock()
+		if err != nil {
+			return err
+		}
+		bestNo := best.GetHeader().GetBlockNo()
+		if err = checkStreamRange(in.FromBlockNo, bestNo); err != nil {
+			return err
+		}
+		for no := in.FromBlockNo; no <= bestNo; no++ {
+			block, err := rpc.getBlockByNo(no)
+			if err != nil {
+				return err
+			}
+			for _, tx := range block.GetBody().GetTxs() {
+				if !sub.match(tx) {
+					continue
+				}
+				if err = stream.Send(tx); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-sub.lagging:
+			return status.Errorf(codes.ResourceExhausted, "tx stream is lagging behind")
+		case tx := <-sub.txs:
+			if err := stream.Send(tx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ListTxInclusionStream notifies when the txs of given hashes are included in
+// the main chain. The stream ends when all of them are notified. The blocks
+// since FromBlockNo are searched first unless FromBest is set. A tx whose
+// block is reverted by a reorganization is waited again.
+func (rpc *AergoRPCService) ListTxInclusionStream(in *types.TxInclusionRequest, stream types.AergoRPCService_ListTxInclusionStreamServer) error {
+	if len(in.Hashes) == 0 || len(in.Hashes) > maxInclusionHashes {
+		return status.Errorf(codes.InvalidArgument, "number of hashes should be 1 to %d", maxInclusionHashes)
+	}
+	waiting := make(map[types.TxID]bool, len(in.Hashes))
+	for _, hash := range in.Hashes {
+		waiting[types.ToTxID(hash)] = true
+	}
+	// the block numbers of the txs notified
+	included := make(map[types.TxID]types.BlockNo)
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	return rpc.followBlocks(ctx, in.FromBlockNo, in.FromBest, func(block *types.Block) error {
+		for idx, tx := range block.GetBody().GetTxs() {
+			id := types.ToTxID(tx.GetHash())
+			if !waiting[id] {
+				continue
+			}
+			delete(waiting, id)
+			included[id] = block.GetHeader().GetBlockNo()
+			err := stream.Send(&types.TxInBlock{
+				TxIdx: &types.TxIdx{BlockHash: block.BlockHash(), Idx: int32(idx)},
+				Tx:    tx,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(waiting) == 0 {
+			cancel()
+		}
+		return nil
+	}, func(reverted []*types.Block) error {
+		from := reverted[len(reverted)-1].GetHeader().GetBlockNo()
+		for id, no := range included {
+			if no >= from {
+				delete(included, id)
+				waiting[id] = true
+			}
+		}
+		return nil
+	})
+}
+
+// ListEventStream streams the contract events matching the filter. Blockto of
+// the filter is ignored, and unless FromBest is set, the events since
+// Blockfrom are sent first. When the main chain is reorganized, the events of
+// the blocks reverted are sent again with Removed set, from the latest.
+func (rpc *AergoRPCService) ListEventStream(in *types.FilterInfo, stream types.AergoRPCService_ListEventStreamServer) error {
+	if len(in.ContractAddress) == 0 {
+		return status.Errorf(codes.InvalidArgument, "contract address is required")
+	}
+	// the events sent recently by block number, to revert them
+	sent := make(map[types.BlockNo][]*types.Event)
+	return rpc.followBlocks(stream.Context(), in.Blockfrom, in.FromBest, func(block *types.Block) error {
+		blockNo := block.GetHeader().GetBlockNo()
+		if blockNo >= maxStreamReorgDepth {
+			delete(sent, blockNo-maxStreamReorgDepth)
+		}
+		if !types.BloomMatchEvent(block.GetHeader().GetEventsBloom(), in.ContractAddress, in.EventName) {
+			return nil
+		}
+		filter := &types.FilterInfo{
+			ContractAddress: in.ContractAddress,
+			EventName:       in.EventName,
+			Blockfrom:       blockNo,
+			Blockto:         blockNo,
+		}
+		events, err := rpc.ListEvents(stream.Context(), filter)
+		if err != nil {
+			return err
+		}
+		for _, ev := range events.GetEvents() {
+			if err = stream.Send(ev); err != nil {
+				return err
+			}
+		}
+		if len(events.GetEvents()) > 0 {
+			sent[blockNo] = events.GetEvents()
+		}
+		return nil
+	}, func(reverted []*types.Block) error {
+		for _, block := range reverted {
+			blockNo := block.GetHeader().GetBlockNo()
+			events := sent[blockNo]
+			for i := len(events) - 1; i >= 0; i-- {
+				events[i].Removed = true
+				if err := stream.Send(events[i]); err != nil {
+					return err
+				}
+			}
+			delete(sent, blockNo)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package rpc
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAergoRPCService_BroadcastToTxStream(t *testing.T) {
+	rpc := &AergoRPCService{
+		blockSubs: make(map[chan struct{}]struct{}),
+		txSubs:    make(map[*txSubscriber]struct{}),
+	}
+	sub := &txSubscriber{
+		account: dummyWalletAddress,
+		txs:     make(chan *types.Tx, 1),
+		lagging: make(chan struct{}, 1),
+	}
+	rpc.txSubs[sub] = struct{}{}
+
+	sent := &types.Tx{Hash: dummyTxHash, Body: &types.TxBody{Account: dummyWalletAddress, Recipient: dummyWalletAddress2}}
+	received := &types.Tx{Hash: dummyTxHash, Body: &types.TxBody{Account: dummyWalletAddress2, Recipient: dummyWalletAddress}}
+	other := &types.Tx{Hash: dummyTxHash, Body: &types.TxBody{Account: dummyWalletAddress2, Recipient: dummyWalletAddress2}}
+
+	rpc.BroadcastToTxStream(other)
+	assert.Equal(t, 0, len(sub.txs))
+	rpc.BroadcastToTxStream(sent)
+	assert.Equal(t, sent, <-sub.txs)
+	rpc.BroadcastToTxStream(received)
+	assert.Equal(t, 0, len(sub.lagging))
+
+	// the buffer is full
+	rpc.BroadcastToTxStream(sent)
+	assert.Equal(t, 1, len(sub.lagging))
+	assert.Equal(t, received, <-sub.txs)
+}
+
+func TestAergoRPCService_NotifyNewBlock(t *testing.T) {
+	rpc := &AergoRPCService{
+		blockSubs: make(map[chan struct{}]struct{}),
+		txSubs:    make(map[*txSubscriber]struct{}),
+	}
+	wakeup := make(chan struct{}, 1)
+	rpc.blockSubs[wakeup] = struct{}{}
+
+	// notifications are coalesced without blocking
+	rpc.NotifyNewBlock()
+	rpc.NotifyNewBlock()
+	assert.Equal(t, 1, len(wakeup))
+}
+
+func TestCheckStreamRange(t *testing.T) {
+	assert.Nil(t, checkStreamRange(0, maxStreamBlockRange-1))
+	assert.NotNil(t, checkStreamRange(0, maxStreamBlockRange))
+	assert.Nil(t, checkStreamRange(100, 100+maxStreamBlockRange-1))
+	// starting from a future block
+	assert.Nil(t, checkStreamRange(100, 10))
+}
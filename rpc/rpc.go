@@ -48,6 +48,8 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor) *RPC {
 	actualServer := &AergoRPCService{
 		msgHelper:   message.GetHelper(),
 		blockstream: []types.AergoRPCService_ListBlockStreamServer{},
+		blockSubs:   make(map[chan struct{}]struct{}),
+		txSubs:      make(map[*txSubscriber]struct{}),
 	}
 
 	tracer := opentracing.GlobalTracer()
@@ -119,6 +121,9 @@ func (ns *RPC) Receive(context actor.Context) {
 	case *types.Block:
 		server := ns.actualServer
 		server.BroadcastToListBlockStream(msg)
+		server.NotifyNewBlock()
+	case *types.Tx:
+		ns.actualServer.BroadcastToTxStream(msg)
 	case *actor.Started:
 	case *actor.Stopping:
 	case *actor.Stopped:
@@ -251,6 +256,8 @@ func convertError(err error) types.CommitStatus {
 		return types.CommitStatus_TX_INSUFFICIENT_BALANCE
 	case types.ErrSameNonceAlreadyInMempool:
 		return types.CommitStatus_TX_HAS_SAME_NONCE
+	case types.ErrTxPoolFull, types.ErrTxAccountLimit, types.ErrTxOrphanLimit:
+		return types.CommitStatus_TX_POOL_FULL
 	default:
 		//logger.Info().Str("hash", err.Error()).Msg("RPC encountered unconvertable error")
 		return types.CommitStatus_TX_INTERNAL_ERROR
@@ -15,6 +15,7 @@ type BlockState struct {
 	StateDB
 	BpReward uint64 //final bp reward, increment when tx executes
 	receipts types.Receipts
+	events   []*types.Event // events of the tx being executed
 }
 
 // NewBlockInfo create new blockInfo contains blockNo, blockHash and blockHash of previous block
@@ -40,7 +41,20 @@ func NewBlockState(states *StateDB) *BlockState {
 	}
 }
 
+// AddEvents appends the events emitted by the tx being executed. They are
+// moved to the receipt of the tx by AddReceipt.
+func (bs *BlockState) AddEvents(events []*types.Event) {
+	bs.events = append(bs.events, events...)
+}
+
 func (bs *BlockState) AddReceipt(r *types.Receipt) {
+	if len(bs.events) != 0 {
+		for i, ev := range bs.events {
+			ev.EventIdx = int32(i)
+		}
+		r.Events = bs.events
+		bs.events = nil
+	}
 	bs.receipts = append(bs.receipts, r)
 }
 
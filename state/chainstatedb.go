@@ -128,14 +128,22 @@ func (sdb *ChainStateDB) Apply(bstate *BlockState) error {
 		return err
 	}
 
-	if err := sdb.UpdateRoot(bstate); err != nil {
+	if err := sdb.updateRoot(bstate); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// UpdateRoot sets the root of bstate committed as the latest state.
 func (sdb *ChainStateDB) UpdateRoot(bstate *BlockState) error {
+	sdb.Lock()
+	defer sdb.Unlock()
+
+	return sdb.updateRoot(bstate)
+}
+
+func (sdb *ChainStateDB) updateRoot(bstate *BlockState) error {
 	// // check state root
 	// if bstate.BlockInfo.StateRoot != types.ToHashID(bstate.GetRoot()) {
 	// 	// TODO: if validation failed, than revert statedb.
@@ -148,6 +156,9 @@ func (sdb *ChainStateDB) UpdateRoot(bstate *BlockState) error {
 	if err := sdb.states.SetRoot(bstate.GetRoot()); err != nil {
 		return err
 	}
+	if store, ok := sdb.store.(*gcStore); ok {
+		store.settle()
+	}
 
 	return nil
 }
@@ -173,6 +184,16 @@ func (sdb *ChainStateDB) IsExistState(hash []byte) bool {
 	return false
 }
 
+// HasState reports whether the state of root is in the state db. The state of
+// an old block may have been removed by garbage collection.
+func (sdb *ChainStateDB) HasState(root []byte) bool {
+	if len(common.Compactz(root)) == 0 {
+		// empty state
+		return true
+	}
+	return len(sdb.store.Get(root)) != 0
+}
+
 func (sdb *ChainStateDB) NewBlockState(root []byte) *BlockState {
 	bState := NewBlockState(sdb.OpenNewStateDB(root))
 
@@ -64,6 +64,7 @@ func (st *ContractState) SetCode(code []byte) error {
 		return err
 	}
 	st.State.CodeHash = codeHash[:]
+	st.code = code
 	return nil
 }
 func (st *ContractState) GetCode() ([]byte, error) {
@@ -118,6 +119,22 @@ func (st *ContractState) DeleteData(key []byte) error {
 	return nil
 }
 
+// Clear removes the code and the storage of the contract. The storage is
+// replaced with an empty one, which is staged instead of the old one.
+func (st *ContractState) Clear() {
+	st.storage = newBufferedStorage(nil, *st.store)
+	st.code = nil
+	st.State.CodeHash = nil
+	st.State.StorageRoot = nil
+}
+
+// Destroy clears the contract, and leaves the tombstone in its state not to
+// deploy a contract at the address again.
+func (st *ContractState) Destroy() {
+	st.Clear()
+	st.State.Destroyed = true
+}
+
 // Snapshot returns revision number of storage buffer
 func (st *ContractState) Snapshot() Snapshot {
 	return Snapshot(st.storage.buffer.snapshot())
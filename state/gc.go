@@ -0,0 +1,278 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package state
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+)
+
+// gcDeleteBatch is the number of garbage keys deleted in a db transaction
+const gcDeleteBatch = 10000
+
+var (
+	errGCDisabled = errors.New("garbage collection of the state db is not enabled")
+	errGCRunning  = errors.New("garbage collection of the state db is already running")
+)
+
+// gcStore is a db.DB which tracks the keys written while the garbage of the
+// state db is collected. The keys are content hashes, so a key deleted as a
+// garbage may be written again by a block being executed. Such keys are
+// excluded from the garbage.
+type gcStore struct {
+	db.DB
+	lock sync.Mutex
+	// written contains the keys written since the last commit of the state
+	// root, and all the keys written during garbage collection.
+	written    map[types.HashID]bool
+	collecting bool
+	running    int32
+	// readers is held by the readers of an old state, which is not swept
+	// until they release it
+	readers sync.RWMutex
+}
+
+func newGCStore(store db.DB) *gcStore {
+	return &gcStore{
+		DB:      store,
+		written: make(map[types.HashID]bool),
+	}
+}
+
+// Set implements db.DB
+func (s *gcStore) Set(key, value []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.written[types.ToHashID(key)] = true
+	s.DB.Set(key, value)
+}
+
+// NewTx implements db.DB
+func (s *gcStore) NewTx() db.Transaction {
+	return &gcTx{Transaction: s.DB.NewTx(), store: s}
+}
+
+// settle forgets the keys written before a state root is committed, since they
+// are reachable from the new root. It is kept during garbage collection.
+func (s *gcStore) settle() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.collecting {
+		s.written = make(map[types.HashID]bool)
+	}
+}
+
+func (s *gcStore) setCollecting(collecting bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.collecting = collecting
+}
+
+// delete removes the keys which are not written again after they're found
+// as garbage. It returns the number of the keys removed.
+func (s *gcStore) delete(keys [][]byte) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	count := 0
+	tx := s.DB.NewTx()
+	for _, key := range keys {
+		if s.written[types.ToHashID(key)] {
+			continue
+		}
+		tx.Delete(key)
+		count++
+	}
+	tx.Commit()
+	return count
+}
+
+type gcTx struct {
+	db.Transaction
+	store *gcStore
+	keys  []types.HashID
+}
+
+// Set implements db.Transaction
+func (tx *gcTx) Set(key, value []byte) {
+	tx.keys = append(tx.keys, types.ToHashID(key))
+	tx.Transaction.Set(key, value)
+}
+
+// Commit implements db.Transaction
+func (tx *gcTx) Commit() {
+	tx.store.lock.Lock()
+	defer tx.store.lock.Unlock()
+	for _, key := range tx.keys {
+		tx.store.written[key] = true
+	}
+	tx.Transaction.Commit()
+}
+
+// EnableGC enables garbage collection of the state db by CollectGarbage. It
+// must be called before any state is written.
+func (sdb *ChainStateDB) EnableGC() {
+	sdb.Lock()
+	defer sdb.Unlock()
+
+	if _, ok := sdb.store.(*gcStore); ok {
+		return
+	}
+	sdb.store = newGCStore(sdb.store)
+	sdb.states = NewStateDB(&sdb.store, sdb.states.GetRoot(), sdb.testmode)
+}
+
+// AcquireState keeps the state of root from being removed by garbage
+// collection until release is called. ok is false if the state is not in the
+// state db.
+func (sdb *ChainStateDB) AcquireState(root []byte) (release func(), ok bool) {
+	release = func() {}
+	if store, isGC := sdb.store.(*gcStore); isGC {
+		store.readers.RLock()
+		release = store.readers.RUnlock
+	}
+	if !sdb.HasState(root) {
+		release()
+		return nil, false
+	}
+	return release, true
+}
+
+// CollectGarbage deletes the trie nodes and the data of the state db, which
+// are not reachable from any of the given state roots. The states of other
+// roots are not available any more. It returns the number of keys deleted.
+func (sdb *ChainStateDB) CollectGarbage(roots [][]byte) (int, error) {
+	collect, err := sdb.BeginGC(func() ([][]byte, error) {
+		return roots, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return collect()
+}
+
+// BeginGC starts a garbage collection of the state db, which retains the
+// states of the roots returned by retained. They are read under the same lock
+// as the latest state root is updated, and the keys written after that are not
+// deleted as garbage. The garbage is deleted by collect, which may run in the
+// background, as CollectGarbage does.
+func (sdb *ChainStateDB) BeginGC(retained func() ([][]byte, error)) (collect func() (int, error), err error) {
+	store, ok := sdb.store.(*gcStore)
+	if !ok {
+		return nil, errGCDisabled
+	}
+	if !atomic.CompareAndSwapInt32(&store.running, 0, 1) {
+		return nil, errGCRunning
+	}
+
+	sdb.Lock()
+	roots, err := retained()
+	if err == nil {
+		store.setCollecting(true)
+	}
+	sdb.Unlock()
+	if err != nil {
+		atomic.StoreInt32(&store.running, 0)
+		return nil, err
+	}
+
+	return func() (int, error) {
+		defer atomic.StoreInt32(&store.running, 0)
+		defer store.setCollecting(false)
+		return sdb.collectGarbage(store, roots)
+	}, nil
+}
+
+func (sdb *ChainStateDB) collectGarbage(store *gcStore, roots [][]byte) (int, error) {
+	// mark
+	live := make(map[types.HashID]bool)
+	for _, root := range roots {
+		if err := sdb.markState(live, root); err != nil {
+			return 0, err
+		}
+	}
+
+	// sweep, after the states acquired by the readers are released
+	store.readers.Lock()
+	defer store.readers.Unlock()
+	deleted := 0
+	garbage := make([][]byte, 0, gcDeleteBatch)
+	for it := store.DB.Iterator(nil, nil); it.Valid(); it.Next() {
+		key := it.Key()
+		// every key of the state db is a hash
+		if len(key) != trie.HashLength || live[types.ToHashID(key)] {
+			continue
+		}
+		garbage = append(garbage, append([]byte(nil), key...))
+		if len(garbage) == gcDeleteBatch {
+			deleted += store.delete(garbage)
+			garbage = garbage[:0]
+		}
+	}
+	if len(garbage) > 0 {
+		deleted += store.delete(garbage)
+	}
+
+	logger.Info().Int("roots", len(roots)).Int("live", len(live)).Int("deleted", deleted).
+		Msg("garbage of state db collected")
+	return deleted, nil
+}
+
+// markState marks the nodes of the account trie of root, and the states, codes
+// and storages of the accounts.
+func (sdb *ChainStateDB) markState(live map[types.HashID]bool, root []byte) error {
+	var err error
+	tr := trie.NewTrie(nil, common.Hasher, sdb.store)
+	walkErr := tr.Walk(root, markNode(live), func(key, value []byte) {
+		if err != nil || !mark(live, value) {
+			return
+		}
+		st := &types.State{}
+		if err = loadData(&sdb.store, value, st); err != nil {
+			return
+		}
+		if len(st.CodeHash) != 0 {
+			mark(live, st.CodeHash)
+		}
+		if storageRoot := common.Compactz(st.StorageRoot); storageRoot != nil {
+			err = sdb.markStorage(live, storageRoot)
+		}
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+	return err
+}
+
+// markStorage marks the nodes and the values of the storage trie of root.
+func (sdb *ChainStateDB) markStorage(live map[types.HashID]bool, root []byte) error {
+	tr := trie.NewTrie(nil, common.Hasher, sdb.store)
+	return tr.Walk(root, markNode(live), func(key, value []byte) {
+		mark(live, value)
+	})
+}
+
+func markNode(live map[types.HashID]bool) func([]byte) bool {
+	return func(node []byte) bool {
+		return mark(live, node)
+	}
+}
+
+// mark marks key as live. It returns false if key is already marked.
+func mark(live map[types.HashID]bool, key []byte) bool {
+	id := types.ToHashID(key)
+	if live[id] {
+		return false
+	}
+	live[id] = true
+	return true
+}
@@ -0,0 +1,142 @@
+package state
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	sdb := NewChainStateDB()
+	_ = sdb.Init(string(db.BadgerImpl), "test", nil, false)
+	defer func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll("test")
+	}()
+
+	_, err := sdb.CollectGarbage(nil)
+	assert.Equal(t, errGCDisabled, err)
+
+	sdb.EnableGC()
+	assert.NoError(t, sdb.SetGenesis(types.GetTestGenesis()), "failed init")
+
+	testContract := types.ToAccountID([]byte("test_contract"))
+	putStates := func(st *types.State, value []byte) []byte {
+		bs := sdb.NewBlockState(sdb.GetRoot())
+		assert.NoError(t, bs.PutState(testAccount, st))
+		cs, err := bs.OpenContractStateAccount(testContract)
+		assert.NoError(t, err)
+		assert.NoError(t, cs.SetData([]byte("key"), value))
+		assert.NoError(t, bs.StageContractState(cs))
+		assert.NoError(t, sdb.Apply(bs))
+		return sdb.GetRoot()
+	}
+	oldRoot := putStates(&testStates[0], []byte("old"))
+	root := putStates(&testStates[1], []byte("new"))
+	assert.True(t, sdb.HasState(oldRoot))
+
+	deleted, err := sdb.CollectGarbage([][]byte{root})
+	assert.NoError(t, err)
+	assert.NotZero(t, deleted)
+
+	// the states of the retained root are available
+	states := sdb.OpenNewStateDB(root)
+	st, err := states.GetState(testAccount)
+	assert.NoError(t, err)
+	assert.True(t, stateEquals(&testStates[1], st))
+	cs, err := states.OpenContractStateAccount(testContract)
+	assert.NoError(t, err)
+	value, err := cs.GetData([]byte("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("new"), value)
+
+	// the states of the old root are deleted
+	_, err = sdb.OpenNewStateDB(oldRoot).GetState(testAccount)
+	assert.Error(t, err)
+	assert.False(t, sdb.HasState(oldRoot))
+	assert.True(t, sdb.HasState(root))
+
+	// nothing to collect any more
+	deleted, err = sdb.CollectGarbage([][]byte{root})
+	assert.NoError(t, err)
+	assert.Zero(t, deleted)
+}
+
+func TestAcquireState(t *testing.T) {
+	sdb := NewChainStateDB()
+	_ = sdb.Init(string(db.BadgerImpl), "test", nil, false)
+	defer func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll("test")
+	}()
+	sdb.EnableGC()
+	assert.NoError(t, sdb.SetGenesis(types.GetTestGenesis()), "failed init")
+
+	putState := func(st *types.State) []byte {
+		bs := sdb.NewBlockState(sdb.GetRoot())
+		assert.NoError(t, bs.PutState(testAccount, st))
+		assert.NoError(t, sdb.Apply(bs))
+		return sdb.GetRoot()
+	}
+	oldRoot := putState(&testStates[0])
+	root := putState(&testStates[1])
+
+	release, ok := sdb.AcquireState(oldRoot)
+	assert.True(t, ok)
+
+	// the acquired state is not swept until it is released
+	done := make(chan struct{})
+	go func() {
+		_, err := sdb.CollectGarbage([][]byte{root})
+		assert.NoError(t, err)
+		close(done)
+	}()
+	st, err := sdb.OpenNewStateDB(oldRoot).GetState(testAccount)
+	assert.NoError(t, err)
+	assert.True(t, stateEquals(&testStates[0], st))
+	release()
+	<-done
+
+	_, ok = sdb.AcquireState(oldRoot)
+	assert.False(t, ok)
+	release, ok = sdb.AcquireState(root)
+	assert.True(t, ok)
+	release()
+}
+
+func TestBeginGC(t *testing.T) {
+	sdb := NewChainStateDB()
+	_ = sdb.Init(string(db.BadgerImpl), "test", nil, false)
+	defer func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll("test")
+	}()
+	sdb.EnableGC()
+	assert.NoError(t, sdb.SetGenesis(types.GetTestGenesis()), "failed init")
+
+	putState := func(st *types.State) []byte {
+		bs := sdb.NewBlockState(sdb.GetRoot())
+		assert.NoError(t, bs.PutState(testAccount, st))
+		assert.NoError(t, sdb.Apply(bs))
+		return sdb.GetRoot()
+	}
+	root := putState(&testStates[0])
+
+	collect, err := sdb.BeginGC(func() ([][]byte, error) {
+		return [][]byte{root}, nil
+	})
+	assert.NoError(t, err)
+	_, err = sdb.CollectGarbage([][]byte{root})
+	assert.Equal(t, errGCRunning, err)
+
+	// the state committed before the garbage is collected is not deleted
+	newRoot := putState(&testStates[1])
+	_, err = collect()
+	assert.NoError(t, err)
+	st, err := sdb.OpenNewStateDB(newRoot).GetState(testAccount)
+	assert.NoError(t, err)
+	assert.True(t, stateEquals(&testStates[1], st))
+}
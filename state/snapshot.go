@@ -0,0 +1,191 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package state
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/pkg/trie"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	errStateChunkData  = errors.New("state chunk: data of leaf not found")
+	errStateChunkOrder = errors.New("state chunk: leaves not in the order of keys")
+	errStateChunkProof = errors.New("state chunk: invalid merkle proof")
+	errStateChunkCode  = errors.New("state chunk: code not matched with code hash")
+)
+
+// GetStateChunk returns the leaves of the trie of root from the first key equal
+// to or greater than start, with the data of their values and their merkle
+// proofs. If accounts is true, the codes of the contracts are also included.
+// At most maxEntries leaves are returned, and the leaves are not added after
+// their size reaches maxSize. It also returns the key to continue from, which
+// is nil if there is no more leaf.
+func (sdb *ChainStateDB) GetStateChunk(root, start []byte, accounts bool, maxEntries, maxSize int) ([]*types.StateChunkEntry, []byte, error) {
+	var (
+		entries []*types.StateChunkEntry
+		next    []byte
+		size    int
+		err     error
+	)
+	tr := trie.NewTrie(nil, common.Hasher, sdb.store)
+	proofs := trie.NewTrie(root, common.Hasher, sdb.store)
+	iterErr := tr.Iterate(root, start, func(key, value []byte) bool {
+		if len(entries) >= maxEntries || (len(entries) > 0 && size >= maxSize) {
+			next = append([]byte(nil), key...)
+			return false
+		}
+		var entry *types.StateChunkEntry
+		if entry, err = sdb.newStateChunkEntry(proofs, root, key, value, accounts); err != nil {
+			return false
+		}
+		entries = append(entries, entry)
+		size += proto.Size(entry)
+		return true
+	})
+	if iterErr != nil {
+		return nil, nil, iterErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return entries, next, nil
+}
+
+func (sdb *ChainStateDB) newStateChunkEntry(proofs *trie.Trie, root, key, value []byte, accounts bool) (*types.StateChunkEntry, error) {
+	bitmap, ap, height, included, _, _, err := proofs.MerkleProofCompressedCustomized(key, root)
+	if err != nil {
+		return nil, err
+	}
+	if !included {
+		return nil, errStateChunkProof
+	}
+	var data []byte
+	if err := loadData(&sdb.store, value, &data); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errStateChunkData
+	}
+	entry := &types.StateChunkEntry{
+		Key:       append([]byte(nil), key...),
+		Value:     data,
+		Bitmap:    bitmap,
+		Height:    uint32(height),
+		AuditPath: ap,
+	}
+	if accounts {
+		st := &types.State{}
+		if err := proto.Unmarshal(data, st); err != nil {
+			return nil, err
+		}
+		if len(st.CodeHash) != 0 {
+			if err := loadData(&sdb.store, st.CodeHash, &entry.Code); err != nil {
+				return nil, err
+			}
+			if len(entry.Code) == 0 {
+				return nil, errStateChunkData
+			}
+		}
+	}
+	return entry, nil
+}
+
+// VerifyStateChunk checks that the leaves of a state chunk got from start are
+// in the order of the keys, and included in the trie of root. If accounts is
+// true, it also checks the codes of the contracts.
+//
+// It doesn't check that no leaf is omitted between the chunks. That is checked
+// by the root of the trie built from all the chunks.
+func VerifyStateChunk(root, start []byte, accounts bool, entries []*types.StateChunkEntry) error {
+	tr := trie.NewTrie(root, common.Hasher, nil)
+	for i, entry := range entries {
+		if len(entry.Key) != trie.HashLength {
+			return errStateChunkOrder
+		}
+		if (i == 0 && bytes.Compare(entry.Key, start) < 0) ||
+			(i > 0 && bytes.Compare(entry.Key, entries[i-1].Key) <= 0) {
+			return errStateChunkOrder
+		}
+		if !validProofShape(entry) ||
+			!tr.VerifyInclusionC(entry.Bitmap, entry.Key, common.Hasher(entry.Value), entry.AuditPath, int(entry.Height)) {
+			return errStateChunkProof
+		}
+		if !accounts {
+			continue
+		}
+		st := &types.State{}
+		if err := proto.Unmarshal(entry.Value, st); err != nil {
+			return err
+		}
+		if len(st.CodeHash) == 0 {
+			if len(entry.Code) != 0 {
+				return errStateChunkCode
+			}
+		} else if !bytes.Equal(common.Hasher(entry.Code), st.CodeHash) {
+			return errStateChunkCode
+		}
+	}
+	return nil
+}
+
+// validProofShape reports whether the compressed merkle proof of entry can be
+// verified without running out of its bitmap and audit path.
+func validProofShape(entry *types.StateChunkEntry) bool {
+	height := int(entry.Height)
+	if height > trie.HashLength*8 || len(entry.Bitmap)*8 < height {
+		return false
+	}
+	nodes := 0
+	for i := 0; i < height; i++ {
+		if entry.Bitmap[i/8]&(1<<uint(7-i%8)) != 0 {
+			nodes++
+		}
+	}
+	return nodes == len(entry.AuditPath)
+}
+
+// ImportStateChunk adds the leaves of a verified state chunk to the trie of
+// root, and stores the data of the values and the codes. It returns the root
+// of the trie updated. The trie of a snapshot is built by importing all its
+// chunks from the empty root.
+func (sdb *ChainStateDB) ImportStateChunk(root []byte, entries []*types.StateChunkEntry) ([]byte, error) {
+	if len(entries) == 0 {
+		return root, nil
+	}
+	keys := make([][]byte, len(entries))
+	values := make([][]byte, len(entries))
+	dbtx := sdb.store.NewTx()
+	for i, entry := range entries {
+		keys[i] = entry.Key
+		values[i] = common.Hasher(entry.Value)
+		dbtx.Set(values[i], entry.Value)
+		if len(entry.Code) != 0 {
+			dbtx.Set(common.Hasher(entry.Code), entry.Code)
+		}
+	}
+	tr := trie.NewTrie(root, common.Hasher, sdb.store)
+	newRoot, err := tr.Update(keys, values)
+	if err != nil {
+		dbtx.Discard()
+		return nil, err
+	}
+	tr.StageUpdates(&dbtx)
+	dbtx.Commit()
+	return newRoot, nil
+}
+
+// SetSnapshotRoot sets the root of an imported snapshot as the latest state.
+func (sdb *ChainStateDB) SetSnapshotRoot(root []byte) error {
+	sdb.Lock()
+	defer sdb.Unlock()
+
+	return sdb.states.SetRoot(root)
+}
@@ -0,0 +1,86 @@
+package state
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateSnapshot(t *testing.T) {
+	sdb := NewChainStateDB()
+	_ = sdb.Init(string(db.BadgerImpl), "test", nil, false)
+	dst := NewChainStateDB()
+	_ = dst.Init(string(db.BadgerImpl), "test_snapshot", nil, false)
+	defer func() {
+		_ = sdb.Close()
+		_ = dst.Close()
+		_ = os.RemoveAll("test")
+		_ = os.RemoveAll("test_snapshot")
+	}()
+	assert.NoError(t, sdb.SetGenesis(types.GetTestGenesis()), "failed init")
+
+	testContract := types.ToAccountID([]byte("test_contract"))
+	bs := sdb.NewBlockState(sdb.GetRoot())
+	for i := 0; i < 10; i++ {
+		st := &types.State{Nonce: uint64(i), Balance: 100}
+		assert.NoError(t, bs.PutState(types.ToAccountID([]byte{byte(i)}), st))
+	}
+	cs, err := bs.OpenContractStateAccount(testContract)
+	assert.NoError(t, err)
+	assert.NoError(t, cs.SetCode([]byte("code")))
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, cs.SetData([]byte{byte(i)}, []byte("value")))
+	}
+	assert.NoError(t, bs.StageContractState(cs))
+	assert.NoError(t, sdb.Apply(bs))
+	root := sdb.GetRoot()
+
+	copyTrie := func(root []byte, accounts bool) {
+		var built, start []byte
+		for {
+			entries, next, err := sdb.GetStateChunk(root, start, accounts, 3, 1024)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, entries)
+			assert.NoError(t, VerifyStateChunk(root, start, accounts, entries))
+			built, err = dst.ImportStateChunk(built, entries)
+			assert.NoError(t, err)
+			if next == nil {
+				break
+			}
+			start = next
+		}
+		assert.Equal(t, root, built)
+	}
+	copyTrie(root, true)
+
+	states := dst.OpenNewStateDB(root)
+	st, err := states.GetState(types.ToAccountID([]byte{byte(3)}))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), st.GetNonce())
+
+	dcs, err := states.OpenContractStateAccount(testContract)
+	assert.NoError(t, err)
+	code, err := dcs.GetCode()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("code"), code)
+
+	copyTrie(common.Compactz(dcs.State.StorageRoot), false)
+	value, err := dcs.GetData([]byte{byte(5)})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// tampered chunks are rejected
+	entries, _, err := sdb.GetStateChunk(root, nil, true, 3, 1024)
+	assert.NoError(t, err)
+	entries[0].Value = []byte("tampered")
+	assert.Equal(t, errStateChunkProof, VerifyStateChunk(root, nil, true, entries))
+
+	entries, _, err = sdb.GetStateChunk(root, nil, true, 3, 1024)
+	assert.NoError(t, err)
+	entries[0], entries[1] = entries[1], entries[0]
+	assert.Equal(t, errStateChunkOrder, VerifyStateChunk(root, nil, true, entries))
+}
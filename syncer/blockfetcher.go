@@ -359,6 +359,7 @@ func (bf *BlockFetcher) processFailedTask(task *FetchTask, isErr bool) error {
 
 	failPeer := task.syncPeer
 	bf.peers.processPeerFail(failPeer, isErr)
+	bf.hub.Tell(message.P2PSvc, &message.PeerMisbehavior{PeerID: failPeer.ID, Kind: message.MisbehaveFetchFail})
 
 	task.retry++
 	task.syncPeer = nil
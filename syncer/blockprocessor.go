@@ -179,6 +179,9 @@ func (bproc *BlockProcessor) GetBlockChunkRspError(msg *message.GetBlockChunksRs
 func (bproc *BlockProcessor) AddBlockResponse(msg *message.AddBlockRsp) error {
 	if err := bproc.isValidResponse(msg); err != nil {
 		logger.Info().Err(err).Uint64("no", msg.BlockNo).Str("hash", enc.ToString(msg.BlockHash)).Msg("block connect failed")
+		if kind, ok := chain.BlockMisbehavior(msg.Err); ok && bproc.curConnRequest != nil {
+			bproc.hub.Tell(message.P2PSvc, &message.PeerMisbehavior{PeerID: bproc.curConnRequest.FromPeer, Kind: kind})
+		}
 		return err
 	}
 
@@ -0,0 +1,522 @@
+package syncer
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// SnapshotFetcher downloads the states of a recent block from the remote peer
+// instead of executing all the blocks from the genesis block. The headers below
+// the block are fetched and linked to the genesis block. The account trie and
+// the storage tries are fetched in chunks, which are verified by merkle proofs
+// and by the roots of the tries rebuilt. Then the sql databases of the
+// contracts are fetched, and the block is installed as the best block with the
+// headers of the following blocks, which must confirm it is irreversible.
+type SnapshotFetcher struct {
+	hub   component.ICompRequester //for communicate with other service
+	chain types.ChainAccessor
+
+	ctx *types.SyncContext
+
+	responseCh chan interface{} //p2p response channel (<- Syncer)
+	quitCh     chan interface{}
+
+	distance uint64
+	timeout  time.Duration
+	name     string
+
+	isRunning bool
+	waitGroup *sync.WaitGroup
+
+	accounts uint64
+	storages uint64
+
+	// account IDs of the contracts using the sql database in the snapshot
+	sqlAccounts map[string]bool
+}
+
+var (
+	DfltSnapshotDistance = uint64(512)
+	// the number of the block headers requested at once
+	DfltSnapshotHeaderChunk = uint64(1000)
+)
+
+var (
+	ErrQuitSnapshotFetcher     = errors.New("SnapshotFetcher quit")
+	ErrSnapshotFetcherTimeout  = errors.New("SnapshotFetcher response timeout")
+	ErrInvalidSnapshotResponse = errors.New("invalid snapshot response")
+	ErrInvalidSnapshotBlock    = errors.New("invalid block of snapshot")
+	ErrSnapshotRootMismatch    = errors.New("root of snapshot trie not matched")
+	ErrInvalidSnapshotHeader   = errors.New("invalid block header below snapshot")
+	ErrSnapshotSQLMissing      = errors.New("sql database of snapshot not fetched")
+)
+
+func newSnapshotFetcher(ctx *types.SyncContext, hub component.ICompRequester, chain types.ChainAccessor, cfg *SyncerConfig) *SnapshotFetcher {
+	sf := &SnapshotFetcher{ctx: ctx, hub: hub, chain: chain, name: NameSnapshotFetcher}
+	sf.sqlAccounts = make(map[string]bool)
+
+	sf.responseCh = make(chan interface{}, 1)
+	sf.quitCh = make(chan interface{})
+
+	sf.distance = cfg.snapshotDistance
+	sf.timeout = cfg.fetchTimeOut
+
+	return sf
+}
+
+func (sf *SnapshotFetcher) Start() {
+	sf.waitGroup = &sync.WaitGroup{}
+	sf.waitGroup.Add(1)
+
+	sf.isRunning = true
+
+	run := func() {
+		defer sf.waitGroup.Done()
+
+		logger.Debug().Msg("start snapshot fetcher")
+
+		block, err := sf.fetch()
+		if err != nil {
+			logger.Error().Err(err).Msg("error! fetch snapshot, SnapshotFetcher exited")
+			if err != ErrQuitSnapshotFetcher {
+				stopSyncer(sf.hub, sf.name, err)
+			}
+			return
+		}
+
+		sf.hub.Tell(message.SyncerSvc, &message.SnapshotResult{Block: block})
+		logger.Debug().Msg("stopped snapshot fetcher successfully")
+	}
+
+	go run()
+}
+
+func (sf *SnapshotFetcher) stop() {
+	if sf == nil {
+		return
+	}
+
+	if sf.isRunning {
+		logger.Debug().Msg("snapshot fetcher closed quitChannel")
+
+		close(sf.quitCh)
+		sf.isRunning = false
+	}
+
+	sf.waitGroup.Wait()
+}
+
+// handleResponse passes the response of the remote peer. The requests are sent
+// one by one, so an unexpected response is dropped.
+func (sf *SnapshotFetcher) handleResponse(msg interface{}) {
+	select {
+	case sf.responseCh <- msg:
+	default:
+		logger.Debug().Msg("snapshot fetcher dropped unexpected response")
+	}
+}
+
+func (sf *SnapshotFetcher) fetch() (*types.Block, error) {
+	result, err := sf.hub.RequestFutureResult(message.ChainSvc, &message.GetSnapshotNo{BlockNo: sf.ctx.TargetNo - sf.distance},
+		sf.timeout, "SnapshotFetcher/snapshotNo")
+	if err != nil {
+		return nil, err
+	}
+	block, err := sf.fetchBlock(result.(message.GetSnapshotNoRsp).BlockNo)
+	if err != nil {
+		return nil, err
+	}
+	if err := sf.fetchAncestors(block); err != nil {
+		return nil, err
+	}
+	root := block.GetHeader().GetBlocksRootHash()
+
+	logger.Info().Uint64("no", block.BlockNo()).Str("stateroot", enc.ToString(root)).Msg("start to fetch snapshot")
+
+	if err := sf.fetchTrie(root, true); err != nil {
+		return nil, err
+	}
+	if err := sf.fetchSQL(root); err != nil {
+		return nil, err
+	}
+	if len(sf.sqlAccounts) != 0 {
+		return nil, ErrSnapshotSQLMissing
+	}
+	descendants, err := sf.fetchDescendants(block)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = sf.hub.RequestFutureResult(message.ChainSvc, &message.InstallSnapshot{Block: block, Descendants: descendants},
+		sf.timeout, "SnapshotFetcher/install")
+	if err != nil {
+		return nil, err
+	}
+	if err := result.(message.InstallSnapshotRsp).Err; err != nil {
+		return nil, err
+	}
+
+	logger.Info().Uint64("no", block.BlockNo()).Uint64("accounts", sf.accounts).
+		Uint64("storages", sf.storages).Msg("snapshot fetched")
+	return block, nil
+}
+
+// request sends msg to the remote peer, and waits the response.
+func (sf *SnapshotFetcher) request(msg interface{}) (interface{}, error) {
+	sf.hub.Tell(message.P2PSvc, msg)
+
+	timer := time.NewTimer(sf.timeout)
+	defer timer.Stop()
+
+	select {
+	case rsp := <-sf.responseCh:
+		return rsp, nil
+	case <-timer.C:
+		return nil, ErrSnapshotFetcherTimeout
+	case <-sf.quitCh:
+		return nil, ErrQuitSnapshotFetcher
+	}
+}
+
+// fetchBlock gets the block of no from the remote peer, and checks its hash and
+// its signature.
+func (sf *SnapshotFetcher) fetchBlock(no types.BlockNo) (*types.Block, error) {
+	rsp, err := sf.request(&message.GetHashByNo{ToWhom: sf.ctx.PeerID, BlockNo: no})
+	if err != nil {
+		return nil, err
+	}
+	hashRsp, ok := rsp.(*message.GetHashByNoRsp)
+	if !ok {
+		return nil, ErrInvalidSnapshotResponse
+	}
+	if hashRsp.Err != nil {
+		return nil, hashRsp.Err
+	}
+
+	rsp, err = sf.request(&message.GetBlockChunks{
+		GetBlockInfos: message.GetBlockInfos{ToWhom: sf.ctx.PeerID, Hashes: []message.BlockHash{hashRsp.BlockHash}},
+		TTL:           sf.timeout})
+	if err != nil {
+		return nil, err
+	}
+	blockRsp, ok := rsp.(*message.GetBlockChunksRsp)
+	if !ok || len(blockRsp.Blocks) != 1 {
+		return nil, ErrInvalidSnapshotResponse
+	}
+	if blockRsp.Err != nil {
+		return nil, blockRsp.Err
+	}
+
+	block := blockRsp.Blocks[0]
+	// recalculate the hash of the block
+	block.Hash = nil
+	if block.BlockNo() != no || !bytes.Equal(block.BlockHash(), hashRsp.BlockHash) {
+		return nil, ErrInvalidSnapshotBlock
+	}
+	if valid, err := block.VerifySign(); !valid || err != nil {
+		return nil, ErrInvalidSnapshotBlock
+	}
+	return block, nil
+}
+
+// fetchHeaders gets at most size headers from height in the descending order,
+// and checks that the hash of each header is the one sent.
+func (sf *SnapshotFetcher) fetchHeaders(height uint64, size uint64) ([]*types.BlockHeader, error) {
+	rsp, err := sf.request(&message.GetSyncHeaders{ToWhom: sf.ctx.PeerID, Height: height, MaxSize: uint32(size)})
+	if err != nil {
+		return nil, err
+	}
+	headersRsp, ok := rsp.(*message.GetSyncHeadersRsp)
+	if !ok {
+		return nil, ErrInvalidSnapshotResponse
+	}
+	if headersRsp.Err != nil {
+		return nil, headersRsp.Err
+	}
+	if len(headersRsp.Headers) == 0 || uint64(len(headersRsp.Headers)) > size {
+		return nil, ErrInvalidSnapshotResponse
+	}
+	for i, header := range headersRsp.Headers {
+		b := &types.Block{Header: header}
+		if header.GetBlockNo() != height-uint64(i) || !bytes.Equal(b.BlockHash(), headersRsp.Hashes[i]) {
+			return nil, ErrInvalidSnapshotHeader
+		}
+	}
+	return headersRsp.Headers, nil
+}
+
+// fetchAncestors gets the headers from the genesis block up to the block of
+// the snapshot, and verifies them by the consensus in the ascending order. The
+// BPs of each epoch are elected from the vote result of the previous epoch
+// boundary block verified, which is fetched with its merkle proofs, so the
+// headers are anchored at the BPs of the genesis block.
+func (sf *SnapshotFetcher) fetchAncestors(block *types.Block) error {
+	if block.BlockNo() == 0 {
+		return ErrInvalidSnapshotBlock
+	}
+	result, err := sf.hub.RequestFutureResult(message.ChainSvc, &message.GetAncestorVerifier{},
+		sf.timeout, "SnapshotFetcher/ancestorVerifier")
+	if err != nil {
+		return err
+	}
+	rsp := result.(message.GetAncestorVerifierRsp)
+	if rsp.Err != nil {
+		return rsp.Err
+	}
+	verifier := rsp.Verifier
+
+	verify := func(header *types.BlockHeader) error {
+		var votes *types.VoteList
+		if verifier.Elects(header) {
+			var err error
+			if votes, err = sf.fetchVoteResult(header.GetBlocksRootHash()); err != nil {
+				return err
+			}
+		}
+		if err := verifier.Verify(header, votes); err != nil {
+			logger.Warn().Err(err).Uint64("no", header.GetBlockNo()).Msg("invalid block header below snapshot")
+			return ErrInvalidSnapshotHeader
+		}
+		return nil
+	}
+	for height := uint64(1); height < block.BlockNo(); {
+		size := DfltSnapshotHeaderChunk
+		if rest := block.BlockNo() - height; rest < size {
+			size = rest
+		}
+		headers, err := sf.fetchHeaders(height+size-1, size)
+		if err != nil {
+			return err
+		}
+		if uint64(len(headers)) != size {
+			return ErrInvalidSnapshotResponse
+		}
+		for i := len(headers) - 1; i >= 0; i-- {
+			if err := verify(headers[i]); err != nil {
+				return err
+			}
+		}
+		height += size
+	}
+	if err := verify(block.GetHeader()); err != nil {
+		return err
+	}
+	logger.Info().Uint64("no", block.BlockNo()).Msg("block headers below snapshot verified")
+	return nil
+}
+
+// fetchVoteResult gets the vote result of the system contract in the state of
+// root. The account of the contract and the result are verified by their
+// merkle proofs.
+func (sf *SnapshotFetcher) fetchVoteResult(root []byte) (*types.VoteList, error) {
+	aid := types.ToAccountID([]byte(types.AergoSystem))
+	entry, err := sf.fetchStateEntry(root, aid[:], true)
+	if err != nil {
+		return nil, err
+	}
+	st := &types.State{}
+	if err := proto.Unmarshal(entry.Value, st); err != nil {
+		return nil, err
+	}
+	storageRoot := common.Compactz(st.StorageRoot)
+	if storageRoot == nil {
+		return nil, ErrInvalidSnapshotResponse
+	}
+	if entry, err = sf.fetchStateEntry(storageRoot, system.VoteResultKey(), false); err != nil {
+		return nil, err
+	}
+	return system.DecodeVoteResult(entry.Value)
+}
+
+// fetchStateEntry gets the leaf of key in the trie of root with its merkle
+// proof. The vote result is written at the genesis block, so the leaf must be
+// found; an absence can't be proved by a single leaf after key.
+func (sf *SnapshotFetcher) fetchStateEntry(root, key []byte, accounts bool) (*types.StateChunkEntry, error) {
+	rsp, err := sf.request(&message.GetStateChunk{ToWhom: sf.ctx.PeerID, Root: root, Start: key,
+		Accounts: accounts, MaxEntries: 1})
+	if err != nil {
+		return nil, err
+	}
+	chunk, ok := rsp.(*message.GetStateChunkRsp)
+	if !ok {
+		return nil, ErrInvalidSnapshotResponse
+	}
+	if chunk.Err != nil {
+		return nil, chunk.Err
+	}
+	if len(chunk.Entries) > 1 {
+		return nil, ErrInvalidSnapshotResponse
+	}
+	if err := state.VerifyStateChunk(root, key, accounts, chunk.Entries); err != nil {
+		return nil, err
+	}
+	if len(chunk.Entries) == 0 || !bytes.Equal(chunk.Entries[0].Key, key) {
+		return nil, ErrInvalidSnapshotResponse
+	}
+	return chunk.Entries[0], nil
+}
+
+// fetchDescendants gets the headers of the blocks following the block of the
+// snapshot in the ascending order. They are checked by the consensus to make
+// sure that the block is irreversible.
+func (sf *SnapshotFetcher) fetchDescendants(block *types.Block) ([]*types.BlockHeader, error) {
+	size := sf.distance
+	if size > DfltSnapshotHeaderChunk {
+		size = DfltSnapshotHeaderChunk
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	headers, err := sf.fetchHeaders(block.BlockNo()+size, size)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(headers)-1; i < j; i, j = i+1, j-1 {
+		headers[i], headers[j] = headers[j], headers[i]
+	}
+	return headers, nil
+}
+
+// fetchTrie gets all the leaves of the trie of root in chunks, and rebuilds the
+// trie. For the account trie, the storage tries of the contracts are also
+// fetched.
+func (sf *SnapshotFetcher) fetchTrie(root []byte, accounts bool) error {
+	var built, start []byte
+	for {
+		rsp, err := sf.request(&message.GetStateChunk{ToWhom: sf.ctx.PeerID, Root: root, Start: start, Accounts: accounts})
+		if err != nil {
+			return err
+		}
+		chunk, ok := rsp.(*message.GetStateChunkRsp)
+		if !ok {
+			return ErrInvalidSnapshotResponse
+		}
+		if chunk.Err != nil {
+			return chunk.Err
+		}
+		if err := state.VerifyStateChunk(root, start, accounts, chunk.Entries); err != nil {
+			return err
+		}
+		if len(chunk.Next) != 0 && (len(chunk.Entries) == 0 ||
+			bytes.Compare(chunk.Next, chunk.Entries[len(chunk.Entries)-1].Key) <= 0) {
+			return ErrInvalidSnapshotResponse
+		}
+
+		result, err := sf.hub.RequestFutureResult(message.ChainSvc, &message.ImportStateChunk{Root: built, Entries: chunk.Entries},
+			sf.timeout, "SnapshotFetcher/importStateChunk")
+		if err != nil {
+			return err
+		}
+		imported := result.(message.ImportStateChunkRsp)
+		if imported.Err != nil {
+			return imported.Err
+		}
+		built = imported.Root
+
+		if accounts {
+			sf.accounts += uint64(len(chunk.Entries))
+			if err := sf.fetchStorages(chunk.Entries); err != nil {
+				return err
+			}
+		}
+
+		if len(chunk.Next) == 0 {
+			break
+		}
+		start = chunk.Next
+	}
+
+	if !bytes.Equal(built, root) {
+		return ErrSnapshotRootMismatch
+	}
+	return nil
+}
+
+func (sf *SnapshotFetcher) fetchStorages(entries []*types.StateChunkEntry) error {
+	for _, entry := range entries {
+		st := &types.State{}
+		if err := proto.Unmarshal(entry.Value, st); err != nil {
+			return err
+		}
+		if st.GetSqlRecoveryPoint() != 0 {
+			sf.sqlAccounts[string(entry.Key)] = true
+		}
+		if storageRoot := common.Compactz(st.StorageRoot); storageRoot != nil {
+			if err := sf.fetchTrie(storageRoot, false); err != nil {
+				return err
+			}
+			sf.storages++
+		}
+	}
+	return nil
+}
+
+// fetchSQL gets the sql databases of the contracts in the state of root. The
+// databases of the contracts created after the snapshot are skipped.
+func (sf *SnapshotFetcher) fetchSQL(root []byte) error {
+	var name string
+	var offset uint64
+	for {
+		rsp, err := sf.request(&message.GetSQLChunk{ToWhom: sf.ctx.PeerID, Name: name, Offset: offset})
+		if err != nil {
+			return err
+		}
+		chunk, ok := rsp.(*message.GetSQLChunkRsp)
+		if !ok {
+			return ErrInvalidSnapshotResponse
+		}
+		if chunk.Err != nil {
+			return chunk.Err
+		}
+		if chunk.Name == "" {
+			// no sql database
+			return nil
+		}
+		if name != "" && chunk.Name != name {
+			return ErrInvalidSnapshotResponse
+		}
+
+		result, err := sf.hub.RequestFutureResult(message.ChainSvc,
+			&message.ImportSQLChunk{StateRoot: root, Name: chunk.Name, Offset: offset, Data: chunk.Data},
+			sf.timeout, "SnapshotFetcher/importSQLChunk")
+		if err != nil {
+			return err
+		}
+		switch err := result.(message.ImportSQLChunkRsp).Err; err {
+		case nil:
+			if addr, err := types.DecodeAddress(chunk.Name); err == nil {
+				id := types.ToAccountID(addr)
+				delete(sf.sqlAccounts, string(id[:]))
+			}
+			offset += uint64(len(chunk.Data))
+			if offset < chunk.Size {
+				if len(chunk.Data) == 0 {
+					return ErrInvalidSnapshotResponse
+				}
+				name = chunk.Name
+				continue
+			}
+		case chain.ErrSnapshotSQL:
+			logger.Debug().Str("name", chunk.Name).Msg("skip sql database not in snapshot")
+		default:
+			return err
+		}
+
+		if chunk.Next == "" {
+			return nil
+		}
+		name, offset = chunk.Next, 0
+	}
+}
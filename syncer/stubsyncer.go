@@ -99,6 +99,8 @@ func isOtherActorRequest(msg interface{}) bool {
 		return true
 	case *message.AddBlock:
 		return true
+	case *message.PeerMisbehavior:
+		return true
 	}
 
 	return false
@@ -186,6 +188,9 @@ func (stubSyncer *StubSyncer) handleActorMsg(inmsg interface{}) {
 
 	case *message.AddBlock:
 		stubSyncer.AddBlock(msg, nil)
+
+	case *message.PeerMisbehavior:
+		// the scores of the stub peers aren't tracked
 	default:
 		str := fmt.Sprintf("Missed message. (%v) %s", reflect.TypeOf(msg), msg)
 		stubSyncer.t.Fatal(str)
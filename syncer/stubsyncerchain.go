@@ -125,6 +125,10 @@ func (tchain *StubBlockChain) GetBestBlock() (*types.Block, error) {
 	return tchain.bestBlock, nil
 }
 
+func (tchain *StubBlockChain) GetGenesisInfo() *types.Genesis {
+	return nil
+}
+
 func (tchain *StubBlockChain) GetBlock(blockHash []byte) (*types.Block, error) {
 	for _, block := range tchain.blocks {
 		if bytes.Equal(block.GetHash(), blockHash) {
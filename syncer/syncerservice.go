@@ -25,9 +25,10 @@ type Syncer struct {
 	isstartning bool
 	ctx         *types.SyncContext
 
-	finder       *Finder
-	hashFetcher  *HashFetcher
-	blockFetcher *BlockFetcher
+	finder          *Finder
+	hashFetcher     *HashFetcher
+	blockFetcher    *BlockFetcher
+	snapshotFetcher *SnapshotFetcher
 
 	testHub component.ICompRequester //for test
 }
@@ -42,6 +43,9 @@ type SyncerConfig struct {
 
 	useFullScanOnly bool
 
+	useSnapshot      bool
+	snapshotDistance uint64
+
 	debugContext *SyncerDebug
 }
 type SyncerDebug struct {
@@ -60,18 +64,20 @@ type SyncerDebug struct {
 }
 
 var (
-	logger             = log.NewLogger("syncer")
-	NameFinder         = "Finder"
-	NameHashFetcher    = "HashFetcher"
-	NameBlockFetcher   = "BlockFetcher"
-	NameBlockProcessor = "BlockProcessor"
-	SyncerCfg          = &SyncerConfig{
+	logger              = log.NewLogger("syncer")
+	NameFinder          = "Finder"
+	NameHashFetcher     = "HashFetcher"
+	NameBlockFetcher    = "BlockFetcher"
+	NameBlockProcessor  = "BlockProcessor"
+	NameSnapshotFetcher = "SnapshotFetcher"
+	SyncerCfg           = &SyncerConfig{
 		maxHashReqSize:   DfltHashReqSize,
 		maxBlockReqSize:  DfltBlockFetchSize,
 		maxPendingConn:   MaxBlockPendingTasks,
 		maxBlockReqTasks: DfltBlockFetchTasks,
 		fetchTimeOut:     DfltFetchTimeOut,
-		useFullScanOnly:  false}
+		useFullScanOnly:  false,
+		snapshotDistance: DfltSnapshotDistance}
 )
 
 var (
@@ -90,6 +96,14 @@ func (ec *ErrSyncMsg) Error() string {
 func NewSyncer(cfg *cfg.Config, chain types.ChainAccessor, syncerCfg *SyncerConfig) *Syncer {
 	if syncerCfg == nil {
 		syncerCfg = SyncerCfg
+		if cfg != nil && cfg.Blockchain != nil && cfg.Blockchain.SnapshotSync {
+			snapshotCfg := *SyncerCfg
+			snapshotCfg.useSnapshot = true
+			if cfg.Blockchain.SnapshotDistance > 0 {
+				snapshotCfg.snapshotDistance = cfg.Blockchain.SnapshotDistance
+			}
+			syncerCfg = &snapshotCfg
+		}
 	}
 
 	syncer := &Syncer{cfg: cfg, syncerCfg: syncerCfg}
@@ -126,10 +140,12 @@ func (syncer *Syncer) Reset() {
 		syncer.finder.stop()
 		syncer.hashFetcher.stop()
 		syncer.blockFetcher.stop()
+		syncer.snapshotFetcher.stop()
 
 		syncer.finder = nil
 		syncer.hashFetcher = nil
 		syncer.blockFetcher = nil
+		syncer.snapshotFetcher = nil
 		syncer.isstartning = false
 		syncer.ctx = nil
 	}
@@ -166,6 +182,14 @@ func (syncer *Syncer) Receive(context actor.Context) {
 			return
 		case *message.AddBlockRsp:
 			return
+		case *message.GetStateChunkRsp:
+			return
+		case *message.GetSQLChunkRsp:
+			return
+		case *message.GetSyncHeadersRsp:
+			return
+		case *message.SnapshotResult:
+			return
 		case *message.SyncStop:
 			return
 		}
@@ -184,7 +208,11 @@ func (syncer *Syncer) handleMessage(inmsg interface{}) {
 	case *message.GetSyncAncestorRsp:
 		syncer.handleAncestorRsp(msg)
 	case *message.GetHashByNoRsp:
-		syncer.handleGetHashByNoRsp(msg)
+		if syncer.snapshotFetcher != nil {
+			syncer.snapshotFetcher.handleResponse(msg)
+		} else {
+			syncer.handleGetHashByNoRsp(msg)
+		}
 	case *message.FinderResult:
 		err := syncer.handleFinderResult(msg)
 		if err != nil {
@@ -195,6 +223,10 @@ func (syncer *Syncer) handleMessage(inmsg interface{}) {
 		syncer.hashFetcher.GetHahsesRsp(msg)
 
 	case *message.GetBlockChunksRsp:
+		if syncer.snapshotFetcher != nil {
+			syncer.snapshotFetcher.handleResponse(msg)
+			break
+		}
 		err := syncer.blockFetcher.handleBlockRsp(msg)
 		if err != nil {
 			syncer.Reset()
@@ -206,6 +238,16 @@ func (syncer *Syncer) handleMessage(inmsg interface{}) {
 			syncer.Reset()
 			logger.Error().Err(err).Msg("AddBlockRsp failed")
 		}
+	case *message.GetStateChunkRsp, *message.GetSQLChunkRsp, *message.GetSyncHeadersRsp:
+		if syncer.snapshotFetcher != nil {
+			syncer.snapshotFetcher.handleResponse(msg)
+		}
+	case *message.SnapshotResult:
+		err := syncer.handleSnapshotResult(msg)
+		if err != nil {
+			syncer.Reset()
+			logger.Error().Err(err).Msg("SnapshotResult failed")
+		}
 	case *message.SyncStop:
 		if msg.Err == nil {
 			logger.Info().Str("from", msg.FromWho).Err(msg.Err).Msg("Syncer succeed")
@@ -265,12 +307,44 @@ func (syncer *Syncer) handleSyncStart(msg *message.SyncStart) error {
 	syncer.ctx = types.NewSyncCtx(msg.PeerID, msg.TargetNo, bestBlockNo)
 	syncer.isstartning = true
 
+	if syncer.needSnapshot(bestBlockNo, msg.TargetNo) {
+		syncer.snapshotFetcher = newSnapshotFetcher(syncer.ctx, syncer.getHub(), syncer.chain, syncer.syncerCfg)
+		syncer.snapshotFetcher.Start()
+		return nil
+	}
+
 	syncer.finder = newFinder(syncer.ctx, syncer.getHub(), syncer.chain, syncer.syncerCfg)
 	syncer.finder.start()
 
 	return err
 }
 
+// needSnapshot reports whether the states of a recent block are fetched from
+// the remote peer before the blocks. It is used only for the empty chain.
+func (syncer *Syncer) needSnapshot(bestBlockNo types.BlockNo, targetNo types.BlockNo) bool {
+	return syncer.syncerCfg.useSnapshot && bestBlockNo == 0 && targetNo > syncer.syncerCfg.snapshotDistance
+}
+
+func (syncer *Syncer) handleSnapshotResult(msg *message.SnapshotResult) error {
+	logger.Debug().Msg("syncer received snapshot result message")
+
+	syncer.snapshotFetcher.stop()
+	syncer.snapshotFetcher = nil
+
+	if msg.Err != nil {
+		logger.Error().Err(msg.Err).Msg("fetch snapshot failed")
+		return msg.Err
+	}
+
+	//continue to sync the blocks after the snapshot
+	syncer.ctx = types.NewSyncCtx(syncer.ctx.PeerID, syncer.ctx.TargetNo, msg.Block.BlockNo())
+
+	syncer.finder = newFinder(syncer.ctx, syncer.getHub(), syncer.chain, syncer.syncerCfg)
+	syncer.finder.start()
+
+	return nil
+}
+
 func (syncer *Syncer) handleAncestorRsp(msg *message.GetSyncAncestorRsp) {
 	logger.Debug().Msg("syncer received ancestor response")
 
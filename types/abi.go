@@ -0,0 +1,184 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// The types of the arguments and the return values annotated by abi.register
+const (
+	AbiString  = "string"
+	AbiInteger = "integer"
+	AbiBignum  = "bignum"
+	AbiAddress = "address"
+	AbiBytes   = "bytes"
+	AbiMap     = "map"
+	AbiArray   = "array"
+	AbiBool    = "bool"
+)
+
+// BignumJSONKey is the key of a bignum in JSON, {"_bignum":"<decimal>"}
+const BignumJSONKey = "_bignum"
+
+var bignumPattern = regexp.MustCompile(`^-?[0-9]+$`)
+
+// maxExactFloat is the largest magnitude below which every integer is exact in
+// float64
+const maxExactFloat = 1 << 53
+
+// AbiFlagsVersion is the version of the ABIs generated with the view and the
+// payable flags of every function. The flags of an older ABI are not checked,
+// since its contract was compiled before they were declared.
+const AbiFlagsVersion = "0.3"
+
+// HasFlags reports whether the functions of the ABI declare the view and the
+// payable flags.
+func (abi *ABI) HasFlags() bool {
+	return abi.GetVersion() == AbiFlagsVersion
+}
+
+// FindFunction returns the function of name, or nil if it is not in the ABI
+func (abi *ABI) FindFunction(name string) *Function {
+	for _, fn := range abi.GetFunctions() {
+		if fn.GetName() == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// IsTyped reports whether the arguments or the return values of the function
+// are annotated with their types.
+func (fn *Function) IsTyped() bool {
+	if len(fn.GetReturns()) > 0 {
+		return true
+	}
+	for _, arg := range fn.GetArguments() {
+		if arg.GetType() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckArgs checks the JSON decoded arguments of a call against the types of
+// the arguments. The untyped arguments accept any value.
+func (fn *Function) CheckArgs(args []interface{}) error {
+	if !fn.IsTyped() {
+		return nil
+	}
+	if len(args) > len(fn.Arguments) {
+		return fmt.Errorf("%s: too many arguments (expected %d, got %d)", fn.Name, len(fn.Arguments), len(args))
+	}
+	for i, arg := range fn.Arguments {
+		if arg.Type == "" {
+			continue
+		}
+		if i >= len(args) {
+			return fmt.Errorf("%s: missing argument %d (%s %s)", fn.Name, i+1, arg.Type, arg.Name)
+		}
+		if !CheckAbiValue(arg.Type, args[i]) {
+			return fmt.Errorf("%s: argument %d (%s) must be %s, got %s", fn.Name, i+1, arg.Name, arg.Type, jsonTypeName(args[i]))
+		}
+	}
+	return nil
+}
+
+// CheckReturns checks the JSON decoded return values of a call against the
+// types of the return values.
+func (fn *Function) CheckReturns(rets []interface{}) error {
+	if len(fn.Returns) == 0 {
+		return nil
+	}
+	if len(rets) != len(fn.Returns) {
+		return fmt.Errorf("%s: expected %d return values, got %d", fn.Name, len(fn.Returns), len(rets))
+	}
+	for i, typ := range fn.Returns {
+		if !CheckAbiValue(typ, rets[i]) {
+			return fmt.Errorf("%s: return value %d must be %s, got %s", fn.Name, i+1, typ, jsonTypeName(rets[i]))
+		}
+	}
+	return nil
+}
+
+// IsAbiType reports whether typ is one of the ABI types
+func IsAbiType(typ string) bool {
+	switch typ {
+	case AbiString, AbiInteger, AbiBignum, AbiAddress, AbiBytes, AbiMap, AbiArray, AbiBool:
+		return true
+	}
+	return false
+}
+
+// CheckAbiValue reports whether the JSON decoded value v is of the ABI type typ.
+// A bignum is a decimal string, an integral number or a bignum in JSON, and an
+// address is a base58check encoded string. A number of a bignum must be exact
+// in float64, so it is at most 2^53 in magnitude; a larger one is rounded by
+// the JSON decoding and must be given in a string.
+func CheckAbiValue(typ string, v interface{}) bool {
+	switch typ {
+	case AbiString, AbiBytes:
+		_, ok := v.(string)
+		return ok
+	case AbiInteger:
+		n, ok := v.(float64)
+		return ok && isIntegral(n)
+	case AbiBignum:
+		switch n := v.(type) {
+		case string:
+			return bignumPattern.MatchString(n)
+		case float64:
+			return isIntegral(n) && math.Abs(n) <= maxExactFloat
+		case map[string]interface{}:
+			s, ok := n[BignumJSONKey].(string)
+			return ok && len(n) == 1 && bignumPattern.MatchString(s)
+		}
+		return false
+	case AbiAddress:
+		s, ok := v.(string)
+		if !ok || len(s) == 0 {
+			return false
+		}
+		_, err := DecodeAddress(s)
+		return err == nil
+	case AbiMap:
+		_, ok := v.(map[string]interface{})
+		return ok
+	case AbiArray:
+		switch a := v.(type) {
+		case []interface{}:
+			return true
+		case map[string]interface{}:
+			// an empty table of lua is encoded as an object
+			return len(a) == 0
+		}
+		return false
+	case AbiBool:
+		_, ok := v.(bool)
+		return ok
+	}
+	return false
+}
+
+func isIntegral(n float64) bool {
+	return n == math.Trunc(n) && n >= math.MinInt64 && n <= math.MaxInt64
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", v)
+}
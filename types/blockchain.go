@@ -9,7 +9,6 @@ import (
 	"bytes"
 	"encoding/binary"
 	"io"
-	"reflect"
 
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/internal/merkle"
@@ -21,33 +20,24 @@ import (
 const (
 	// DefaultMaxBlockSize is the maximum block size (currently 1MiB)
 	DefaultMaxBlockSize = 1 << 20
-	DefaultCoinbaseFee  = 1
-	lastFieldOfBH       = "Sign"
-	MaxAER              = 5000000000000000000 //500000000 AERGO
+	// DefaultCoinbaseFee is the fee of a NORMAL tx before gas is metered
+	DefaultCoinbaseFee = 1
+	// DefaultTxGasLimit is the gas limit of a tx whose Limit is not set
+	DefaultTxGasLimit = 100000000
+	// DefaultMaxInstructionCount is the maximum number of the lua instructions
+	// executed by a tx
+	DefaultMaxInstructionCount = 500000
+	// DefaultMaxCallMemory is the maximum memory allocated by the contract
+	// calls of a tx (currently 32MiB)
+	DefaultMaxCallMemory = 32 << 20
+	// DefaultMaxCallDepth is the maximum depth of the nested contract calls of
+	// a tx
+	DefaultMaxCallDepth = 64
+	// TxBaseGas is the gas charged for every NORMAL tx before it is executed
+	TxBaseGas = 1000
+	MaxAER    = 5000000000000000000 //500000000 AERGO
 )
 
-var lastIndexOfBH int
-
-func init() {
-	lastIndexOfBH = getLastIndexOfBH()
-}
-
-func getLastIndexOfBH() (lastIndex int) {
-	v := reflect.ValueOf(BlockHeader{})
-
-	nField := v.NumField()
-	var i int
-	for i = 0; i < nField; i++ {
-		name := v.Type().Field(i).Name
-		if name == lastFieldOfBH {
-			lastIndex = i
-			break
-		}
-	}
-
-	return i
-}
-
 // ChainAccessor is an interface for a another actor module to get info of chain
 type ChainAccessor interface {
 	GetBestBlock() (*Block, error)
@@ -55,6 +45,17 @@ type ChainAccessor interface {
 	GetBlock(blockHash []byte) (*Block, error)
 	// GetHashByNo returns hash of block. It return nil and error if not found block of that number or there is a problem in db store
 	GetHashByNo(blockNo BlockNo) ([]byte, error)
+	// GetGenesisInfo returns the genesis info of chain, which identifies the chain
+	GetGenesisInfo() *Genesis
+}
+
+// AncestorVerifier verifies the block headers following the genesis block in
+// the ascending order, without executing their blocks. If Elects reports true
+// for a header, the vote result in its state must be given to Verify with it,
+// since it elects the block producers of the following headers.
+type AncestorVerifier interface {
+	Elects(header *BlockHeader) bool
+	Verify(header *BlockHeader, votes *VoteList) error
 }
 
 type SyncContext struct {
@@ -141,6 +142,7 @@ func NewBlock(prevBlock *Block, blockRoot []byte, receipts Receipts, txs []*Tx,
 
 	block.Header.TxsRootHash = CalculateTxsRootHash(body.Txs)
 	block.Header.ReceiptsRootHash = receipts.MerkleRoot()
+	block.Header.EventsBloom = receipts.Bloom()
 
 	return &block
 }
@@ -153,12 +155,27 @@ func (block *Block) calculateBlockHash() []byte {
 	return digest.Sum(nil)
 }
 
-func serializeStruct(w io.Writer, s interface{}, stopIndex int) error {
-	v := reflect.Indirect(reflect.ValueOf(s))
+// digestFieldsOfBH returns the fields of bh signed by the block producer. They
+// are listed explicitly, so the block hash and the signature don't depend on
+// the order of the fields in the generated code. EventsBloom is empty in the
+// blocks without events, which are hashed as before it was added.
+func digestFieldsOfBH(bh *BlockHeader) []interface{} {
+	return []interface{}{
+		bh.PrevBlockHash,
+		bh.BlockNo,
+		bh.Timestamp,
+		bh.BlocksRootHash,
+		bh.TxsRootHash,
+		bh.ReceiptsRootHash,
+		bh.EventsBloom,
+		bh.Confirms,
+		bh.PubKey,
+	}
+}
 
-	var i int
-	for i = 0; i <= stopIndex; i++ {
-		if err := binary.Write(w, binary.LittleEndian, v.Field(i).Interface()); err != nil {
+func serializeFields(w io.Writer, fields []interface{}) error {
+	for _, f := range fields {
+		if err := binary.Write(w, binary.LittleEndian, f); err != nil {
 			return err
 		}
 	}
@@ -167,11 +184,11 @@ func serializeStruct(w io.Writer, s interface{}, stopIndex int) error {
 }
 
 func serializeBH(w io.Writer, bh *BlockHeader) error {
-	return serializeStruct(w, bh, lastIndexOfBH)
+	return serializeFields(w, append(digestFieldsOfBH(bh), bh.Sign))
 }
 
 func serializeBhForDigest(w io.Writer, bh *BlockHeader) error {
-	return serializeStruct(w, bh, lastIndexOfBH-1)
+	return serializeFields(w, digestFieldsOfBH(bh))
 }
 
 func writeBlockHeaderOld(w io.Writer, bh *BlockHeader) error {
@@ -372,10 +389,25 @@ func (tx *Tx) CalculateTxHash() []byte {
 	binary.Write(digest, binary.LittleEndian, txBody.Limit)
 	binary.Write(digest, binary.LittleEndian, txBody.Price)
 	binary.Write(digest, binary.LittleEndian, txBody.Type)
+	// the chain ID hash is prefixed with its length to be separated from the
+	// sign. A tx without it has the same hash as before.
+	if len(txBody.ChainIdHash) > 0 {
+		binary.Write(digest, binary.LittleEndian, uint32(len(txBody.ChainIdHash)))
+		digest.Write(txBody.ChainIdHash)
+	}
 	digest.Write(txBody.Sign)
 	return digest.Sum(nil)
 }
 
+// ValidateChainIdHash returns an error if tx is not bound to the chain of
+// chainIdHash, so that a tx signed for another chain cannot be replayed.
+func (tx *Tx) ValidateChainIdHash(chainIdHash []byte) error {
+	if !bytes.Equal(tx.GetBody().GetChainIdHash(), chainIdHash) {
+		return ErrTxInvalidChainIdHash
+	}
+	return nil
+}
+
 func (tx *Tx) Validate() error {
 	account := tx.GetBody().GetAccount()
 	if account == nil {
@@ -404,6 +436,16 @@ func (tx *Tx) Validate() error {
 			//contract deploy
 			return ErrTxInvalidRecipient
 		}
+	case TxType_REDEPLOY:
+		if tx.GetBody().GetRecipient() == nil {
+			return ErrTxInvalidRecipient
+		}
+		if tx.GetBody().GetAmount() > 0 {
+			return ErrTxInvalidAmount
+		}
+		if len(tx.GetBody().GetPayload()) == 0 {
+			return ErrTxFormatInvalid
+		}
 	case TxType_GOVERNANCE:
 		if len(tx.Body.Payload) <= 0 {
 			return ErrTxFormatInvalid
@@ -418,13 +460,22 @@ func (tx *Tx) Validate() error {
 	return nil
 }
 
-func (tx *Tx) ValidateWithSenderState(senderState *State) error {
+// ValidateWithSenderState checks tx against the state of its sender. The fee of
+// a NORMAL tx is DefaultCoinbaseFee unless gasMetered is set.
+func (tx *Tx) ValidateWithSenderState(senderState *State, gasMetered bool) error {
 	if (senderState.GetNonce() + 1) > tx.GetBody().GetNonce() {
 		return ErrTxNonceTooLow
 	}
 	switch tx.GetBody().GetType() {
-	case TxType_NORMAL:
-		if tx.GetBody().GetAmount()+DefaultCoinbaseFee > senderState.GetBalance() {
+	case TxType_NORMAL, TxType_REDEPLOY:
+		fee := uint64(DefaultCoinbaseFee)
+		if gasMetered {
+			if price := tx.GetBody().GetPrice(); price != 0 && tx.GasLimit() > MaxAER/price {
+				return ErrTxGasLimitOverflow
+			}
+			fee = tx.MaxFee()
+		}
+		if tx.GetBody().GetAmount()+fee > senderState.GetBalance() {
 			return ErrInsufficientBalance
 		}
 	case TxType_GOVERNANCE:
@@ -445,6 +496,28 @@ func (tx *Tx) ValidateWithSenderState(senderState *State) error {
 	return nil
 }
 
+// ValidateGasPrice checks whether the gas price of tx is at least minPrice.
+func (tx *Tx) ValidateGasPrice(minPrice uint64) error {
+	if tx.GetBody().GetPrice() < minPrice {
+		return ErrTxGasPriceTooLow
+	}
+	return nil
+}
+
+// GasLimit returns the maximum gas the tx can consume. A tx without Limit gets
+// DefaultTxGasLimit.
+func (tx *Tx) GasLimit() uint64 {
+	if limit := tx.GetBody().GetLimit(); limit != 0 {
+		return limit
+	}
+	return DefaultTxGasLimit
+}
+
+// MaxFee returns the fee charged when the tx uses up its gas limit.
+func (tx *Tx) MaxFee() uint64 {
+	return tx.GasLimit() * tx.GetBody().GetPrice()
+}
+
 //TODO : refoctor after ContractState move to types
 func (tx *Tx) ValidateWithContractState(contractState *State) error {
 	//in system.ValidateSystemTx
@@ -459,15 +532,16 @@ func (tx *Tx) Clone() *Tx {
 		return &Tx{}
 	}
 	body := &TxBody{
-		Nonce:     tx.Body.Nonce,
-		Account:   Clone(tx.Body.Account).([]byte),
-		Recipient: Clone(tx.Body.Recipient).([]byte),
-		Amount:    tx.Body.Amount,
-		Payload:   Clone(tx.Body.Payload).([]byte),
-		Limit:     tx.Body.Limit,
-		Price:     tx.Body.Price,
-		Sign:      Clone(tx.Body.Sign).([]byte),
-		Type:      tx.Body.Type,
+		Nonce:       tx.Body.Nonce,
+		Account:     Clone(tx.Body.Account).([]byte),
+		Recipient:   Clone(tx.Body.Recipient).([]byte),
+		Amount:      tx.Body.Amount,
+		Payload:     Clone(tx.Body.Payload).([]byte),
+		Limit:       tx.Body.Limit,
+		Price:       tx.Body.Price,
+		Sign:        Clone(tx.Body.Sign).([]byte),
+		Type:        tx.Body.Type,
+		ChainIdHash: Clone(tx.Body.ChainIdHash).([]byte),
 	}
 	res := &Tx{
 		Body: body,
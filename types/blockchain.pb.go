@@ -25,16 +25,19 @@ type TxType int32
 const (
 	TxType_NORMAL     TxType = 0
 	TxType_GOVERNANCE TxType = 1
+	TxType_REDEPLOY   TxType = 2
 )
 
 var TxType_name = map[int32]string{
 	0: "NORMAL",
 	1: "GOVERNANCE",
+	2: "REDEPLOY",
 }
 
 var TxType_value = map[string]int32{
 	"NORMAL":     0,
 	"GOVERNANCE": 1,
+	"REDEPLOY":   2,
 }
 
 func (x TxType) String() string {
@@ -111,6 +114,7 @@ type BlockHeader struct {
 	PubKey               []byte   `protobuf:"bytes,8,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
 	Sign                 []byte   `protobuf:"bytes,9,opt,name=sign,proto3" json:"sign,omitempty"`
 	CoinbaseAccount      []byte   `protobuf:"bytes,10,opt,name=coinbaseAccount,proto3" json:"coinbaseAccount,omitempty"`
+	EventsBloom          []byte   `protobuf:"bytes,11,opt,name=eventsBloom,proto3" json:"eventsBloom,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -211,6 +215,13 @@ func (m *BlockHeader) GetCoinbaseAccount() []byte {
 	return nil
 }
 
+func (m *BlockHeader) GetEventsBloom() []byte {
+	if m != nil {
+		return m.EventsBloom
+	}
+	return nil
+}
+
 type BlockBody struct {
 	Txs                  []*Tx    `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -346,6 +357,7 @@ type TxBody struct {
 	Price                uint64   `protobuf:"varint,7,opt,name=price,proto3" json:"price,omitempty"`
 	Type                 TxType   `protobuf:"varint,8,opt,name=type,proto3,enum=types.TxType" json:"type,omitempty"`
 	Sign                 []byte   `protobuf:"bytes,9,opt,name=sign,proto3" json:"sign,omitempty"`
+	ChainIdHash          []byte   `protobuf:"bytes,10,opt,name=chainIdHash,proto3" json:"chainIdHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -439,6 +451,13 @@ func (m *TxBody) GetSign() []byte {
 	return nil
 }
 
+func (m *TxBody) GetChainIdHash() []byte {
+	if m != nil {
+		return m.ChainIdHash
+	}
+	return nil
+}
+
 type TxIdx struct {
 	BlockHash            []byte   `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
 	Idx                  int32    `protobuf:"varint,2,opt,name=idx,proto3" json:"idx,omitempty"`
@@ -539,6 +558,7 @@ type State struct {
 	CodeHash             []byte   `protobuf:"bytes,3,opt,name=codeHash,proto3" json:"codeHash,omitempty"`
 	StorageRoot          []byte   `protobuf:"bytes,4,opt,name=storageRoot,proto3" json:"storageRoot,omitempty"`
 	SqlRecoveryPoint     uint64   `protobuf:"varint,5,opt,name=sqlRecoveryPoint,proto3" json:"sqlRecoveryPoint,omitempty"`
+	Destroyed            bool     `protobuf:"varint,6,opt,name=destroyed,proto3" json:"destroyed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -604,6 +624,13 @@ func (m *State) GetSqlRecoveryPoint() uint64 {
 	return 0
 }
 
+func (m *State) GetDestroyed() bool {
+	if m != nil {
+		return m.Destroyed
+	}
+	return false
+}
+
 type StateProof struct {
 	State                *State   `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
 	Inclusion            bool     `protobuf:"varint,2,opt,name=inclusion,proto3" json:"inclusion,omitempty"`
@@ -829,6 +856,7 @@ type Receipt struct {
 	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
 	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	Ret                  string   `protobuf:"bytes,3,opt,name=ret,proto3" json:"ret,omitempty"`
+	Events               []*Event `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -880,8 +908,16 @@ func (m *Receipt) GetRet() string {
 	return ""
 }
 
+func (m *Receipt) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 type FnArgument struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -919,9 +955,20 @@ func (m *FnArgument) GetName() string {
 	return ""
 }
 
+func (m *FnArgument) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
 type Function struct {
 	Name                 string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Arguments            []*FnArgument `protobuf:"bytes,2,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	Returns              []string      `protobuf:"bytes,3,rep,name=returns,proto3" json:"returns,omitempty"`
+	View                 bool          `protobuf:"varint,4,opt,name=view,proto3" json:"view,omitempty"`
+	Payable              bool          `protobuf:"varint,5,opt,name=payable,proto3" json:"payable,omitempty"`
+	Reentrant            bool          `protobuf:"varint,6,opt,name=reentrant,proto3" json:"reentrant,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -966,6 +1013,34 @@ func (m *Function) GetArguments() []*FnArgument {
 	return nil
 }
 
+func (m *Function) GetReturns() []string {
+	if m != nil {
+		return m.Returns
+	}
+	return nil
+}
+
+func (m *Function) GetView() bool {
+	if m != nil {
+		return m.View
+	}
+	return false
+}
+
+func (m *Function) GetPayable() bool {
+	if m != nil {
+		return m.Payable
+	}
+	return false
+}
+
+func (m *Function) GetReentrant() bool {
+	if m != nil {
+		return m.Reentrant
+	}
+	return false
+}
+
 type StateVar struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
@@ -1194,6 +1269,778 @@ func (m *StateQuery) GetCompressed() bool {
 	return false
 }
 
+type Event struct {
+	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	EventName            string   `protobuf:"bytes,2,opt,name=eventName,proto3" json:"eventName,omitempty"`
+	JsonArgs             string   `protobuf:"bytes,3,opt,name=jsonArgs,proto3" json:"jsonArgs,omitempty"`
+	EventIdx             int32    `protobuf:"varint,4,opt,name=eventIdx,proto3" json:"eventIdx,omitempty"`
+	TxHash               []byte   `protobuf:"bytes,5,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,6,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	BlockNo              uint64   `protobuf:"varint,7,opt,name=blockNo,proto3" json:"blockNo,omitempty"`
+	TxIndex              int32    `protobuf:"varint,8,opt,name=txIndex,proto3" json:"txIndex,omitempty"`
+	Removed              bool     `protobuf:"varint,9,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{19}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetContractAddress() []byte {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return nil
+}
+
+func (m *Event) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *Event) GetJsonArgs() string {
+	if m != nil {
+		return m.JsonArgs
+	}
+	return ""
+}
+
+func (m *Event) GetEventIdx() int32 {
+	if m != nil {
+		return m.EventIdx
+	}
+	return 0
+}
+
+func (m *Event) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *Event) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *Event) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *Event) GetTxIndex() int32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *Event) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+type FilterInfo struct {
+	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	EventName            string   `protobuf:"bytes,2,opt,name=eventName,proto3" json:"eventName,omitempty"`
+	Blockfrom            uint64   `protobuf:"varint,3,opt,name=blockfrom,proto3" json:"blockfrom,omitempty"`
+	Blockto              uint64   `protobuf:"varint,4,opt,name=blockto,proto3" json:"blockto,omitempty"`
+	FromBest             bool     `protobuf:"varint,5,opt,name=fromBest,proto3" json:"fromBest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FilterInfo) Reset()         { *m = FilterInfo{} }
+func (m *FilterInfo) String() string { return proto.CompactTextString(m) }
+func (*FilterInfo) ProtoMessage()    {}
+func (*FilterInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{20}
+}
+
+func (m *FilterInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilterInfo.Unmarshal(m, b)
+}
+func (m *FilterInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilterInfo.Marshal(b, m, deterministic)
+}
+func (m *FilterInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilterInfo.Merge(m, src)
+}
+func (m *FilterInfo) XXX_Size() int {
+	return xxx_messageInfo_FilterInfo.Size(m)
+}
+func (m *FilterInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilterInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilterInfo proto.InternalMessageInfo
+
+func (m *FilterInfo) GetContractAddress() []byte {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return nil
+}
+
+func (m *FilterInfo) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *FilterInfo) GetBlockfrom() uint64 {
+	if m != nil {
+		return m.Blockfrom
+	}
+	return 0
+}
+
+func (m *FilterInfo) GetBlockto() uint64 {
+	if m != nil {
+		return m.Blockto
+	}
+	return 0
+}
+
+func (m *FilterInfo) GetFromBest() bool {
+	if m != nil {
+		return m.FromBest
+	}
+	return false
+}
+
+type EventList struct {
+	Events               []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventList) Reset()         { *m = EventList{} }
+func (m *EventList) String() string { return proto.CompactTextString(m) }
+func (*EventList) ProtoMessage()    {}
+func (*EventList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{21}
+}
+
+func (m *EventList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventList.Unmarshal(m, b)
+}
+func (m *EventList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventList.Marshal(b, m, deterministic)
+}
+func (m *EventList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventList.Merge(m, src)
+}
+func (m *EventList) XXX_Size() int {
+	return xxx_messageInfo_EventList.Size(m)
+}
+func (m *EventList) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventList proto.InternalMessageInfo
+
+func (m *EventList) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ChainArchiveHeader struct {
+	Version              uint32   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	GenesisHash          []byte   `protobuf:"bytes,2,opt,name=genesisHash,proto3" json:"genesisHash,omitempty"`
+	StartNo              uint64   `protobuf:"varint,3,opt,name=startNo,proto3" json:"startNo,omitempty"`
+	EndNo                uint64   `protobuf:"varint,4,opt,name=endNo,proto3" json:"endNo,omitempty"`
+	Receipts             bool     `protobuf:"varint,5,opt,name=receipts,proto3" json:"receipts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChainArchiveHeader) Reset()         { *m = ChainArchiveHeader{} }
+func (m *ChainArchiveHeader) String() string { return proto.CompactTextString(m) }
+func (*ChainArchiveHeader) ProtoMessage()    {}
+func (*ChainArchiveHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{22}
+}
+
+func (m *ChainArchiveHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainArchiveHeader.Unmarshal(m, b)
+}
+func (m *ChainArchiveHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainArchiveHeader.Marshal(b, m, deterministic)
+}
+func (m *ChainArchiveHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainArchiveHeader.Merge(m, src)
+}
+func (m *ChainArchiveHeader) XXX_Size() int {
+	return xxx_messageInfo_ChainArchiveHeader.Size(m)
+}
+func (m *ChainArchiveHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainArchiveHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainArchiveHeader proto.InternalMessageInfo
+
+func (m *ChainArchiveHeader) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ChainArchiveHeader) GetGenesisHash() []byte {
+	if m != nil {
+		return m.GenesisHash
+	}
+	return nil
+}
+
+func (m *ChainArchiveHeader) GetStartNo() uint64 {
+	if m != nil {
+		return m.StartNo
+	}
+	return 0
+}
+
+func (m *ChainArchiveHeader) GetEndNo() uint64 {
+	if m != nil {
+		return m.EndNo
+	}
+	return 0
+}
+
+func (m *ChainArchiveHeader) GetReceipts() bool {
+	if m != nil {
+		return m.Receipts
+	}
+	return false
+}
+
+type ChainArchiveBlock struct {
+	Block                *Block     `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Receipts             []*Receipt `protobuf:"bytes,2,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ChainArchiveBlock) Reset()         { *m = ChainArchiveBlock{} }
+func (m *ChainArchiveBlock) String() string { return proto.CompactTextString(m) }
+func (*ChainArchiveBlock) ProtoMessage()    {}
+func (*ChainArchiveBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{23}
+}
+
+func (m *ChainArchiveBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainArchiveBlock.Unmarshal(m, b)
+}
+func (m *ChainArchiveBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainArchiveBlock.Marshal(b, m, deterministic)
+}
+func (m *ChainArchiveBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainArchiveBlock.Merge(m, src)
+}
+func (m *ChainArchiveBlock) XXX_Size() int {
+	return xxx_messageInfo_ChainArchiveBlock.Size(m)
+}
+func (m *ChainArchiveBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainArchiveBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainArchiveBlock proto.InternalMessageInfo
+
+func (m *ChainArchiveBlock) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *ChainArchiveBlock) GetReceipts() []*Receipt {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
+type CallFrame struct {
+	Kind                 string       `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Caller               string       `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`
+	Callee               string       `protobuf:"bytes,3,opt,name=callee,proto3" json:"callee,omitempty"`
+	Function             string       `protobuf:"bytes,4,opt,name=function,proto3" json:"function,omitempty"`
+	Args                 string       `protobuf:"bytes,5,opt,name=args,proto3" json:"args,omitempty"`
+	Amount               uint64       `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	GasUsed              uint64       `protobuf:"varint,7,opt,name=gasUsed,proto3" json:"gasUsed,omitempty"`
+	Result               string       `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	Error                string       `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	Steps                []*TraceStep `protobuf:"bytes,10,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CallFrame) Reset()         { *m = CallFrame{} }
+func (m *CallFrame) String() string { return proto.CompactTextString(m) }
+func (*CallFrame) ProtoMessage()    {}
+func (*CallFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{24}
+}
+
+func (m *CallFrame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallFrame.Unmarshal(m, b)
+}
+func (m *CallFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallFrame.Marshal(b, m, deterministic)
+}
+func (m *CallFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallFrame.Merge(m, src)
+}
+func (m *CallFrame) XXX_Size() int {
+	return xxx_messageInfo_CallFrame.Size(m)
+}
+func (m *CallFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallFrame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallFrame proto.InternalMessageInfo
+
+func (m *CallFrame) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *CallFrame) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+func (m *CallFrame) GetCallee() string {
+	if m != nil {
+		return m.Callee
+	}
+	return ""
+}
+
+func (m *CallFrame) GetFunction() string {
+	if m != nil {
+		return m.Function
+	}
+	return ""
+}
+
+func (m *CallFrame) GetArgs() string {
+	if m != nil {
+		return m.Args
+	}
+	return ""
+}
+
+func (m *CallFrame) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *CallFrame) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *CallFrame) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *CallFrame) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CallFrame) GetSteps() []*TraceStep {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type TraceStep struct {
+	Op                   string     `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Key                  string     `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string     `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Call                 *CallFrame `protobuf:"bytes,4,opt,name=call,proto3" json:"call,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *TraceStep) Reset()         { *m = TraceStep{} }
+func (m *TraceStep) String() string { return proto.CompactTextString(m) }
+func (*TraceStep) ProtoMessage()    {}
+func (*TraceStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{25}
+}
+
+func (m *TraceStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceStep.Unmarshal(m, b)
+}
+func (m *TraceStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceStep.Marshal(b, m, deterministic)
+}
+func (m *TraceStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceStep.Merge(m, src)
+}
+func (m *TraceStep) XXX_Size() int {
+	return xxx_messageInfo_TraceStep.Size(m)
+}
+func (m *TraceStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceStep proto.InternalMessageInfo
+
+func (m *TraceStep) GetOp() string {
+	if m != nil {
+		return m.Op
+	}
+	return ""
+}
+
+func (m *TraceStep) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *TraceStep) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *TraceStep) GetCall() *CallFrame {
+	if m != nil {
+		return m.Call
+	}
+	return nil
+}
+
+type TxTrace struct {
+	TxHash               []byte     `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	BlockNo              uint64     `protobuf:"varint,2,opt,name=blockNo,proto3" json:"blockNo,omitempty"`
+	Status               string     `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Call                 *CallFrame `protobuf:"bytes,4,opt,name=call,proto3" json:"call,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *TxTrace) Reset()         { *m = TxTrace{} }
+func (m *TxTrace) String() string { return proto.CompactTextString(m) }
+func (*TxTrace) ProtoMessage()    {}
+func (*TxTrace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{26}
+}
+
+func (m *TxTrace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxTrace.Unmarshal(m, b)
+}
+func (m *TxTrace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxTrace.Marshal(b, m, deterministic)
+}
+func (m *TxTrace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxTrace.Merge(m, src)
+}
+func (m *TxTrace) XXX_Size() int {
+	return xxx_messageInfo_TxTrace.Size(m)
+}
+func (m *TxTrace) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxTrace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxTrace proto.InternalMessageInfo
+
+func (m *TxTrace) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *TxTrace) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+func (m *TxTrace) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *TxTrace) GetCall() *CallFrame {
+	if m != nil {
+		return m.Call
+	}
+	return nil
+}
+
+type TxSimulation struct {
+	TxHash               []byte         `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Receipt              *Receipt       `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	GasUsed              uint64         `protobuf:"varint,3,opt,name=gasUsed,proto3" json:"gasUsed,omitempty"`
+	Fee                  uint64         `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	Accounts             []*AccountDiff `protobuf:"bytes,5,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Storage              []*StorageDiff `protobuf:"bytes,6,rep,name=storage,proto3" json:"storage,omitempty"`
+	Call                 *CallFrame     `protobuf:"bytes,7,opt,name=call,proto3" json:"call,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TxSimulation) Reset()         { *m = TxSimulation{} }
+func (m *TxSimulation) String() string { return proto.CompactTextString(m) }
+func (*TxSimulation) ProtoMessage()    {}
+func (*TxSimulation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{27}
+}
+
+func (m *TxSimulation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxSimulation.Unmarshal(m, b)
+}
+func (m *TxSimulation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxSimulation.Marshal(b, m, deterministic)
+}
+func (m *TxSimulation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxSimulation.Merge(m, src)
+}
+func (m *TxSimulation) XXX_Size() int {
+	return xxx_messageInfo_TxSimulation.Size(m)
+}
+func (m *TxSimulation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxSimulation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxSimulation proto.InternalMessageInfo
+
+func (m *TxSimulation) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *TxSimulation) GetReceipt() *Receipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *TxSimulation) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *TxSimulation) GetFee() uint64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *TxSimulation) GetAccounts() []*AccountDiff {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+func (m *TxSimulation) GetStorage() []*StorageDiff {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+func (m *TxSimulation) GetCall() *CallFrame {
+	if m != nil {
+		return m.Call
+	}
+	return nil
+}
+
+type AccountDiff struct {
+	Address              []byte   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Before               *State   `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After                *State   `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountDiff) Reset()         { *m = AccountDiff{} }
+func (m *AccountDiff) String() string { return proto.CompactTextString(m) }
+func (*AccountDiff) ProtoMessage()    {}
+func (*AccountDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{28}
+}
+
+func (m *AccountDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountDiff.Unmarshal(m, b)
+}
+func (m *AccountDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountDiff.Marshal(b, m, deterministic)
+}
+func (m *AccountDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountDiff.Merge(m, src)
+}
+func (m *AccountDiff) XXX_Size() int {
+	return xxx_messageInfo_AccountDiff.Size(m)
+}
+func (m *AccountDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountDiff proto.InternalMessageInfo
+
+func (m *AccountDiff) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *AccountDiff) GetBefore() *State {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *AccountDiff) GetAfter() *State {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+type StorageDiff struct {
+	Address              []byte   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Before               string   `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
+	After                string   `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageDiff) Reset()         { *m = StorageDiff{} }
+func (m *StorageDiff) String() string { return proto.CompactTextString(m) }
+func (*StorageDiff) ProtoMessage()    {}
+func (*StorageDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{29}
+}
+
+func (m *StorageDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageDiff.Unmarshal(m, b)
+}
+func (m *StorageDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageDiff.Marshal(b, m, deterministic)
+}
+func (m *StorageDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageDiff.Merge(m, src)
+}
+func (m *StorageDiff) XXX_Size() int {
+	return xxx_messageInfo_StorageDiff.Size(m)
+}
+func (m *StorageDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageDiff proto.InternalMessageInfo
+
+func (m *StorageDiff) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *StorageDiff) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StorageDiff) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *StorageDiff) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
 func init() {
 	proto.RegisterEnum("types.TxType", TxType_name, TxType_value)
 	proto.RegisterType((*Block)(nil), "types.Block")
@@ -1215,75 +2062,127 @@ func init() {
 	proto.RegisterType((*ABI)(nil), "types.ABI")
 	proto.RegisterType((*Query)(nil), "types.Query")
 	proto.RegisterType((*StateQuery)(nil), "types.StateQuery")
+	proto.RegisterType((*Event)(nil), "types.Event")
+	proto.RegisterType((*FilterInfo)(nil), "types.FilterInfo")
+	proto.RegisterType((*EventList)(nil), "types.EventList")
+	proto.RegisterType((*ChainArchiveHeader)(nil), "types.ChainArchiveHeader")
+	proto.RegisterType((*ChainArchiveBlock)(nil), "types.ChainArchiveBlock")
+	proto.RegisterType((*CallFrame)(nil), "types.CallFrame")
+	proto.RegisterType((*TraceStep)(nil), "types.TraceStep")
+	proto.RegisterType((*TxTrace)(nil), "types.TxTrace")
+	proto.RegisterType((*TxSimulation)(nil), "types.TxSimulation")
+	proto.RegisterType((*AccountDiff)(nil), "types.AccountDiff")
+	proto.RegisterType((*StorageDiff)(nil), "types.StorageDiff")
 }
 
 func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_e9ac6287ce250c9a) }
 
 var fileDescriptor_e9ac6287ce250c9a = []byte{
-	// 1026 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xcd, 0x6e, 0x23, 0x45,
-	0x10, 0xc6, 0xbf, 0xf1, 0x54, 0xfe, 0x4c, 0x6b, 0x05, 0x03, 0xac, 0x56, 0x66, 0x14, 0x50, 0x14,
-	0x89, 0x44, 0xca, 0x1e, 0x16, 0x89, 0x93, 0xb3, 0xda, 0x85, 0xc0, 0x92, 0x84, 0xc6, 0xca, 0x01,
-	0x09, 0xa1, 0xf6, 0x4c, 0xc7, 0x6e, 0xe1, 0x99, 0x9e, 0xed, 0xe9, 0xb1, 0xc6, 0x27, 0x5e, 0x05,
-	0x24, 0x1e, 0x87, 0x1b, 0xbc, 0x05, 0x2f, 0x81, 0xaa, 0xba, 0x3d, 0xe3, 0x78, 0x03, 0xd2, 0x1e,
-	0x39, 0xb9, 0xbf, 0xaf, 0xab, 0xaa, 0xab, 0xea, 0xab, 0xee, 0x31, 0x0c, 0xa7, 0x0b, 0x1d, 0xff,
-	0x1c, 0xcf, 0x85, 0xca, 0x4e, 0x73, 0xa3, 0xad, 0x66, 0x3d, 0xbb, 0xca, 0x65, 0x11, 0xa5, 0xd0,
-	0xbb, 0xc0, 0x2d, 0xc6, 0xa0, 0x3b, 0x17, 0xc5, 0x3c, 0x6c, 0x8d, 0x5a, 0xc7, 0x7b, 0x9c, 0xd6,
-	0xec, 0x04, 0xfa, 0x73, 0x29, 0x12, 0x69, 0xc2, 0xf6, 0xa8, 0x75, 0xbc, 0x7b, 0xce, 0x4e, 0xc9,
-	0xe9, 0x94, 0x3c, 0xbe, 0xa2, 0x1d, 0xee, 0x2d, 0xd8, 0x11, 0x74, 0xa7, 0x3a, 0x59, 0x85, 0x1d,
-	0xb2, 0x1c, 0x6e, 0x5a, 0x5e, 0xe8, 0x64, 0xc5, 0x69, 0x37, 0xfa, 0xb3, 0x0d, 0xbb, 0x1b, 0xde,
-	0xec, 0x08, 0xf6, 0x73, 0x23, 0x97, 0x8e, 0x6a, 0x8e, 0xbf, 0x4f, 0xb2, 0x10, 0x76, 0x28, 0xff,
-	0x2b, 0x4d, 0x89, 0x74, 0xf9, 0x1a, 0xb2, 0xc7, 0x10, 0x58, 0x95, 0xca, 0xc2, 0x8a, 0x34, 0xa7,
-	0xa3, 0x3b, 0xbc, 0x21, 0xd8, 0xa7, 0x70, 0x40, 0x86, 0x05, 0xd7, 0xda, 0x52, 0xf8, 0x2e, 0x85,
-	0xdf, 0x62, 0xd9, 0x08, 0x76, 0x6d, 0xd5, 0x18, 0xf5, 0xc8, 0x68, 0x93, 0x62, 0x27, 0x30, 0x34,
-	0x32, 0x96, 0x2a, 0xb7, 0x8d, 0x59, 0x9f, 0xcc, 0xde, 0xe0, 0xd9, 0x87, 0x30, 0x88, 0x75, 0x76,
-	0xa7, 0x4c, 0x5a, 0x84, 0x3b, 0x94, 0x6e, 0x8d, 0xd9, 0x7b, 0xd0, 0xcf, 0xcb, 0xe9, 0x37, 0x72,
-	0x15, 0x0e, 0xc8, 0xdb, 0x23, 0xec, 0x7e, 0xa1, 0x66, 0x59, 0x18, 0xb8, 0xee, 0xe3, 0x9a, 0x1d,
-	0xc3, 0x61, 0xac, 0x55, 0x36, 0x15, 0x85, 0x1c, 0xc7, 0xb1, 0x2e, 0x33, 0x1b, 0x02, 0x6d, 0x6f,
-	0xd3, 0xd1, 0x31, 0x04, 0x75, 0xa3, 0xd9, 0x47, 0xd0, 0xb1, 0x55, 0x11, 0xb6, 0x46, 0x9d, 0xe3,
-	0xdd, 0xf3, 0xc0, 0xeb, 0x30, 0xa9, 0x38, 0xb2, 0xd1, 0x27, 0xd0, 0x9f, 0x54, 0xaf, 0x54, 0x61,
-	0xff, 0xdb, 0xec, 0x0b, 0x68, 0x4f, 0xaa, 0x07, 0x47, 0xe2, 0x63, 0x2f, 0xb3, 0x1b, 0x88, 0xfd,
-	0xda, 0x6f, 0x43, 0xe3, 0xbf, 0x5b, 0x78, 0x08, 0xe5, 0xf2, 0x08, 0x7a, 0x99, 0xce, 0x62, 0x49,
-	0x21, 0xba, 0xdc, 0x01, 0x94, 0x53, 0xf8, 0x82, 0xda, 0x14, 0x7a, 0x0d, 0x51, 0x4e, 0x23, 0x63,
-	0x95, 0x2b, 0x99, 0x59, 0x92, 0x73, 0x8f, 0x37, 0x04, 0x36, 0x4f, 0xa4, 0xe4, 0xd6, 0xa5, 0x70,
-	0x1e, 0x61, 0xbc, 0x5c, 0xac, 0x16, 0x5a, 0x24, 0x5e, 0xba, 0x35, 0xc4, 0xf3, 0x17, 0x2a, 0x55,
-	0x96, 0xb4, 0xea, 0x72, 0x07, 0x90, 0xcd, 0x8d, 0x8a, 0xa5, 0x57, 0xc7, 0x01, 0xac, 0x0c, 0x8b,
-	0x21, 0x61, 0x0e, 0x36, 0x2a, 0x9b, 0xac, 0x72, 0xc9, 0x69, 0xeb, 0x21, 0x95, 0xa2, 0x67, 0xd0,
-	0x9b, 0x54, 0x97, 0x49, 0x85, 0xb9, 0x4f, 0xb7, 0xc6, 0xb8, 0x21, 0xd8, 0x10, 0x3a, 0x2a, 0xa9,
-	0xa8, 0xde, 0x1e, 0xc7, 0x65, 0xf4, 0x35, 0x04, 0x93, 0xea, 0x32, 0x73, 0xb7, 0x2f, 0x82, 0x9e,
-	0xc5, 0x28, 0xe4, 0xb8, 0x7b, 0xbe, 0x57, 0x9f, 0x7e, 0x99, 0x54, 0xdc, 0x6d, 0xb1, 0x0f, 0xa0,
-	0x6d, 0x2b, 0xdf, 0xf8, 0x0d, 0xc1, 0xda, 0xb6, 0x8a, 0x7e, 0x6b, 0x41, 0xef, 0x7b, 0x2b, 0xac,
-	0xfc, 0xf7, 0x8e, 0x4f, 0xc5, 0x42, 0x20, 0xbf, 0xbe, 0x40, 0x0e, 0xba, 0x61, 0x4d, 0x24, 0x25,
-	0xed, 0x1a, 0x5e, 0x63, 0xbc, 0x16, 0x85, 0xd5, 0x46, 0xcc, 0x24, 0xce, 0xb6, 0xbf, 0x3b, 0x9b,
-	0x14, 0x5e, 0x8b, 0xe2, 0xf5, 0x82, 0xcb, 0x58, 0x2f, 0xa5, 0x59, 0xdd, 0x68, 0x95, 0x59, 0x92,
-	0xa0, 0xcb, 0xdf, 0xe0, 0xa3, 0xbf, 0x5a, 0x00, 0x94, 0xe3, 0x8d, 0xd1, 0xfa, 0x0e, 0x2b, 0x2e,
-	0x10, 0x6d, 0x55, 0x4c, 0x16, 0xdc, 0x6d, 0x61, 0x4b, 0x55, 0x16, 0x2f, 0xca, 0x42, 0xe9, 0x8c,
-	0x12, 0x1f, 0xf0, 0x86, 0xc0, 0xd4, 0x73, 0x0c, 0x85, 0xb7, 0xc9, 0xa7, 0xbe, 0xc6, 0xf5, 0xde,
-	0xad, 0x58, 0xf8, 0xbc, 0x6b, 0x8c, 0x63, 0x34, 0x55, 0x36, 0x15, 0xb9, 0x9f, 0x16, 0x8f, 0x90,
-	0x9f, 0x4b, 0x35, 0x9b, 0xbb, 0x69, 0xd9, 0xe7, 0x1e, 0x61, 0x16, 0xa2, 0x4c, 0x94, 0xbd, 0x11,
-	0x76, 0x1e, 0xee, 0x8c, 0x3a, 0x28, 0x6c, 0x4d, 0x44, 0x7f, 0xb4, 0x60, 0xf8, 0x5c, 0x67, 0xd6,
-	0x88, 0xd8, 0xde, 0x0a, 0xe3, 0x8a, 0x7b, 0x04, 0xbd, 0xa5, 0x58, 0x94, 0xd2, 0xcf, 0x81, 0x03,
-	0xff, 0x8b, 0x72, 0x7e, 0x81, 0x43, 0x92, 0xe0, 0xbb, 0x12, 0x85, 0xa3, 0x62, 0x9e, 0xc1, 0x7e,
-	0xec, 0x0b, 0x24, 0xc2, 0x2b, 0xf6, 0xee, 0xa6, 0x62, 0xb4, 0xc1, 0xef, 0xdb, 0xb1, 0xa7, 0x30,
-	0x58, 0xfa, 0x8e, 0xf8, 0xb1, 0x7d, 0xdf, 0xfb, 0x6c, 0x37, 0x8c, 0xd7, 0x86, 0xd1, 0x8f, 0xb0,
-	0xc3, 0xdd, 0x8b, 0xea, 0x1e, 0x40, 0x67, 0x38, 0x4e, 0x12, 0x23, 0x8b, 0xc2, 0xf7, 0x73, 0x9b,
-	0xc6, 0x5a, 0x71, 0x62, 0xca, 0x82, 0xce, 0x09, 0xb8, 0x47, 0x78, 0xeb, 0x8c, 0x74, 0x2f, 0x49,
-	0xc0, 0x71, 0x19, 0x8d, 0x00, 0x5e, 0x66, 0x63, 0x33, 0x2b, 0x53, 0x7c, 0x51, 0x18, 0x74, 0x33,
-	0x91, 0x3a, 0x99, 0x02, 0x4e, 0xeb, 0xe8, 0x1a, 0x06, 0x2f, 0xcb, 0x2c, 0xb6, 0xa8, 0xc9, 0x03,
-	0xfb, 0xec, 0x0c, 0x02, 0xe1, 0xfd, 0xf1, 0xb8, 0xce, 0x46, 0x2b, 0x9a, 0xc8, 0xbc, 0xb1, 0x89,
-	0xce, 0x61, 0x40, 0x3d, 0xba, 0x15, 0xe6, 0xc1, 0x80, 0xcc, 0x3f, 0x3c, 0x2e, 0x75, 0x5a, 0x47,
-	0xbf, 0xb7, 0xa0, 0x33, 0xbe, 0xb8, 0xc4, 0x8b, 0xbb, 0x94, 0x86, 0x06, 0xc6, 0xb9, 0xac, 0x21,
-	0x8e, 0xc4, 0x42, 0x64, 0xb3, 0x52, 0xcc, 0xd6, 0x9e, 0x35, 0x66, 0x9f, 0x41, 0x70, 0xe7, 0x4b,
-	0x28, 0xc2, 0x0e, 0xa5, 0x78, 0xb8, 0x4e, 0xd1, 0xf3, 0xbc, 0xb1, 0x60, 0x9f, 0xc3, 0x21, 0xdd,
-	0xb7, 0x9f, 0x96, 0xc2, 0x28, 0x31, 0x5d, 0xc8, 0x22, 0xec, 0xde, 0x73, 0x5a, 0xa7, 0xcf, 0x0f,
-	0x0a, 0xbf, 0x72, 0x66, 0xd1, 0x35, 0xf4, 0x68, 0x50, 0xde, 0x42, 0xaa, 0xc7, 0x10, 0xbc, 0x46,
-	0x17, 0x95, 0xdd, 0x69, 0xff, 0xfc, 0x37, 0x44, 0xf4, 0xeb, 0xfa, 0x91, 0x78, 0xdb, 0xb0, 0xd8,
-	0x28, 0x61, 0xae, 0xb0, 0xb7, 0x6d, 0xdf, 0x28, 0x07, 0xb1, 0x51, 0x4b, 0x61, 0x2e, 0xb3, 0x44,
-	0x56, 0x7e, 0x10, 0x6a, 0x8c, 0xad, 0x37, 0xcd, 0xd3, 0x46, 0x6b, 0xf6, 0x04, 0x20, 0xd6, 0x69,
-	0x8e, 0x51, 0xa5, 0xfb, 0xa0, 0x0c, 0xf8, 0x06, 0x73, 0x72, 0x84, 0x5f, 0x37, 0xfc, 0x28, 0x30,
-	0x80, 0xfe, 0xd5, 0x35, 0xff, 0x76, 0xfc, 0x6a, 0xf8, 0x0e, 0x3b, 0x00, 0xf8, 0xf2, 0xfa, 0xf6,
-	0x05, 0xbf, 0x1a, 0x5f, 0x3d, 0x7f, 0x31, 0x6c, 0x5d, 0x8c, 0x7e, 0x78, 0x32, 0x53, 0x76, 0x5e,
-	0x4e, 0x4f, 0x63, 0x9d, 0x9e, 0x09, 0x69, 0x66, 0x5a, 0x69, 0xf7, 0x7b, 0x46, 0x4d, 0x9d, 0xf6,
-	0xe9, 0x7f, 0xd8, 0xd3, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x1b, 0x47, 0x6b, 0x40, 0x9b, 0x09,
-	0x00, 0x00,
+	// 1692 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0xcd, 0x8e, 0x23, 0x49,
+	0x11, 0xc6, 0x2e, 0xff, 0x55, 0xb8, 0x7f, 0xbc, 0xa9, 0xd1, 0x52, 0xc0, 0x6a, 0x65, 0x4a, 0xc3,
+	0xaa, 0x35, 0x82, 0x1e, 0xd1, 0x8b, 0xb4, 0x48, 0x9c, 0xba, 0x67, 0x7b, 0xa0, 0x61, 0xe8, 0x19,
+	0x72, 0x4c, 0x4b, 0x70, 0x41, 0xe9, 0xaa, 0xb4, 0x5d, 0x4c, 0xb9, 0xb2, 0x26, 0x2b, 0x6d, 0xca,
+	0x27, 0x90, 0x38, 0x70, 0x40, 0xbc, 0x01, 0x07, 0x0e, 0x1c, 0x78, 0x01, 0xce, 0xbc, 0x00, 0x47,
+	0x5e, 0x85, 0x3b, 0x8a, 0xc8, 0xac, 0x1f, 0x7b, 0x66, 0x5a, 0x33, 0x12, 0x97, 0x3d, 0x39, 0xbf,
+	0x88, 0xc8, 0x9f, 0x88, 0xf8, 0x22, 0x32, 0xcb, 0x30, 0x99, 0xa7, 0x2a, 0x7a, 0x15, 0xad, 0x44,
+	0x92, 0x9d, 0xe7, 0x5a, 0x19, 0xc5, 0xfa, 0x66, 0x97, 0xcb, 0x22, 0x5c, 0x43, 0xff, 0x0a, 0x55,
+	0x8c, 0x41, 0x6f, 0x25, 0x8a, 0x55, 0xd0, 0x99, 0x76, 0xce, 0x8e, 0x38, 0x8d, 0xd9, 0x23, 0x18,
+	0xac, 0xa4, 0x88, 0xa5, 0x0e, 0xba, 0xd3, 0xce, 0xd9, 0xf8, 0x82, 0x9d, 0xd3, 0xa4, 0x73, 0x9a,
+	0xf1, 0x13, 0xd2, 0x70, 0x67, 0xc1, 0x1e, 0x42, 0x6f, 0xae, 0xe2, 0x5d, 0xe0, 0x91, 0xe5, 0xa4,
+	0x6d, 0x79, 0xa5, 0xe2, 0x1d, 0x27, 0x6d, 0xf8, 0xdf, 0x2e, 0x8c, 0x5b, 0xb3, 0xd9, 0x43, 0x38,
+	0xce, 0xb5, 0xdc, 0x5a, 0x51, 0xb3, 0xfd, 0xbe, 0x90, 0x05, 0x30, 0xa4, 0xf3, 0xdf, 0x2a, 0x3a,
+	0x48, 0x8f, 0x57, 0x90, 0x7d, 0x02, 0xbe, 0x49, 0xd6, 0xb2, 0x30, 0x62, 0x9d, 0xd3, 0xd6, 0x1e,
+	0x6f, 0x04, 0xec, 0x33, 0x38, 0x21, 0xc3, 0x82, 0x2b, 0x65, 0x68, 0xf9, 0x1e, 0x2d, 0x7f, 0x20,
+	0x65, 0x53, 0x18, 0x9b, 0xb2, 0x31, 0xea, 0x93, 0x51, 0x5b, 0xc4, 0x1e, 0xc1, 0x44, 0xcb, 0x48,
+	0x26, 0xb9, 0x69, 0xcc, 0x06, 0x64, 0xf6, 0x86, 0x9c, 0x7d, 0x13, 0x46, 0x91, 0xca, 0x16, 0x89,
+	0x5e, 0x17, 0xc1, 0x90, 0x8e, 0x5b, 0x63, 0xf6, 0x31, 0x0c, 0xf2, 0xcd, 0xfc, 0x67, 0x72, 0x17,
+	0x8c, 0x68, 0xb6, 0x43, 0x18, 0xfd, 0x22, 0x59, 0x66, 0x81, 0x6f, 0xa3, 0x8f, 0x63, 0x76, 0x06,
+	0xa7, 0x91, 0x4a, 0xb2, 0xb9, 0x28, 0xe4, 0x65, 0x14, 0xa9, 0x4d, 0x66, 0x02, 0x20, 0xf5, 0xa1,
+	0x18, 0xcf, 0x2f, 0xb7, 0x32, 0x33, 0xc5, 0x55, 0xaa, 0xd4, 0x3a, 0x18, 0xdb, 0xf3, 0xb7, 0x44,
+	0xe1, 0x19, 0xf8, 0x75, 0x2a, 0xd8, 0xb7, 0xc0, 0x33, 0x65, 0x11, 0x74, 0xa6, 0xde, 0xd9, 0xf8,
+	0xc2, 0x77, 0x99, 0x9a, 0x95, 0x1c, 0xa5, 0xe1, 0x77, 0x60, 0x30, 0x2b, 0x9f, 0x25, 0x85, 0xb9,
+	0xdf, 0xec, 0x47, 0xd0, 0x9d, 0x95, 0x6f, 0x25, 0xcd, 0xb7, 0x1d, 0x11, 0x2c, 0x65, 0x8e, 0xeb,
+	0x79, 0x2d, 0x16, 0xfc, 0xa5, 0x0b, 0x03, 0x2b, 0x60, 0x0f, 0xa0, 0x9f, 0xa9, 0x2c, 0x92, 0xb4,
+	0x44, 0x8f, 0x5b, 0x80, 0x09, 0x17, 0xce, 0xe5, 0x2e, 0x2d, 0x5d, 0x41, 0x4c, 0xb8, 0x96, 0x51,
+	0x92, 0x27, 0x32, 0x33, 0x94, 0xf0, 0x23, 0xde, 0x08, 0x30, 0xbc, 0x62, 0x4d, 0xd3, 0x7a, 0xb4,
+	0x9c, 0x43, 0xb8, 0x5e, 0x2e, 0x76, 0xa9, 0x12, 0xb1, 0x4b, 0x6e, 0x05, 0x71, 0xff, 0x34, 0x59,
+	0x27, 0x86, 0xb2, 0xd9, 0xe3, 0x16, 0xa0, 0x34, 0xd7, 0x49, 0x24, 0x5d, 0xfe, 0x2c, 0x40, 0xcf,
+	0xd0, 0x19, 0x4a, 0xdd, 0x49, 0xcb, 0xb3, 0xd9, 0x2e, 0x97, 0x9c, 0x54, 0x6f, 0xcd, 0xe3, 0x14,
+	0xc6, 0x54, 0x78, 0x37, 0x31, 0xd1, 0xc6, 0xe6, 0xb0, 0x2d, 0x0a, 0xbf, 0x80, 0xfe, 0xac, 0xbc,
+	0x89, 0x4b, 0xf4, 0x6e, 0x7e, 0x50, 0x0a, 0x8d, 0x80, 0x4d, 0xc0, 0x4b, 0xe2, 0x92, 0x22, 0xd2,
+	0xe7, 0x38, 0x0c, 0x7f, 0x0a, 0xfe, 0xac, 0xbc, 0xc9, 0x6c, 0x05, 0x87, 0xd0, 0x37, 0xb8, 0x0a,
+	0x4d, 0x1c, 0x5f, 0x1c, 0xd5, 0xe7, 0xbb, 0x89, 0x4b, 0x6e, 0x55, 0xec, 0x1b, 0xd0, 0x35, 0xa5,
+	0x4b, 0x4d, 0x2b, 0xa5, 0x5d, 0x53, 0x86, 0xff, 0xea, 0x40, 0xff, 0xa5, 0x11, 0x46, 0xbe, 0x3b,
+	0x27, 0x73, 0x91, 0x0a, 0x94, 0x57, 0x45, 0x68, 0xa1, 0x25, 0x7c, 0x2c, 0xe9, 0xd0, 0x36, 0x25,
+	0x35, 0x46, 0xe7, 0x0b, 0xa3, 0xb4, 0x58, 0x4a, 0xac, 0x0f, 0x57, 0x7f, 0x6d, 0x11, 0x96, 0x56,
+	0xf1, 0x3a, 0xe5, 0x32, 0x52, 0x5b, 0xa9, 0x77, 0x2f, 0x54, 0x92, 0x19, 0x4a, 0x52, 0x8f, 0xbf,
+	0x21, 0xc7, 0xf8, 0xc4, 0xb2, 0x30, 0x5a, 0xed, 0x64, 0x4c, 0x19, 0x1b, 0xf1, 0x46, 0x10, 0xfe,
+	0xa7, 0x03, 0x40, 0x1e, 0xbc, 0xd0, 0x4a, 0x2d, 0x30, 0x1e, 0x05, 0xa2, 0x83, 0x78, 0x90, 0x05,
+	0xb7, 0x2a, 0x5c, 0x30, 0xc9, 0xa2, 0x74, 0x53, 0x24, 0x2a, 0x23, 0xb7, 0x46, 0xbc, 0x11, 0xa0,
+	0x63, 0x39, 0x2e, 0x85, 0xf5, 0xea, 0x1c, 0xab, 0x70, 0xad, 0xbb, 0x13, 0xa9, 0xf3, 0xaa, 0xc6,
+	0x48, 0xc3, 0x79, 0x62, 0xd6, 0x22, 0x77, 0x6c, 0x73, 0x08, 0xe5, 0x2b, 0x99, 0x2c, 0x57, 0x96,
+	0x6d, 0xc7, 0xdc, 0x21, 0x3c, 0x85, 0xd8, 0xc4, 0x89, 0x79, 0x21, 0xcc, 0x2a, 0x18, 0x4e, 0x3d,
+	0x4c, 0x7b, 0x2d, 0x08, 0xff, 0xdd, 0x81, 0xc9, 0x13, 0x95, 0x19, 0x2d, 0x22, 0x73, 0x27, 0xb4,
+	0x75, 0xee, 0x01, 0xf4, 0xb7, 0x22, 0xdd, 0x48, 0xc7, 0x12, 0x0b, 0xbe, 0x12, 0xee, 0xfc, 0x1e,
+	0x4e, 0x29, 0x05, 0xbf, 0xd8, 0x60, 0x5a, 0xc9, 0x99, 0x2f, 0xe0, 0x38, 0x72, 0x0e, 0x92, 0xc0,
+	0x65, 0xec, 0xa3, 0x76, 0xc6, 0x48, 0xc1, 0xf7, 0xed, 0xd8, 0xe7, 0x30, 0xda, 0xba, 0x88, 0x38,
+	0x52, 0x7f, 0xdd, 0xcd, 0x39, 0x0c, 0x18, 0xaf, 0x0d, 0xc3, 0x3f, 0x76, 0x60, 0xc8, 0x6d, 0xd3,
+	0xb6, 0x3d, 0xd6, 0x5a, 0x5e, 0xc6, 0xb1, 0x96, 0x45, 0xe1, 0x02, 0x7a, 0x28, 0x46, 0x67, 0x91,
+	0x32, 0x9b, 0x82, 0x36, 0xf2, 0xb9, 0x43, 0x58, 0x94, 0x5a, 0xda, 0x56, 0xe4, 0x73, 0x1c, 0xb2,
+	0x87, 0x30, 0xb0, 0xad, 0x37, 0xe8, 0x4d, 0xbd, 0x16, 0xf1, 0xae, 0x51, 0xc8, 0x9d, 0x2e, 0xfc,
+	0x01, 0xc0, 0xd3, 0xec, 0x52, 0x2f, 0x37, 0x6b, 0x6c, 0x5c, 0x0c, 0x7a, 0x99, 0x58, 0xdb, 0x6c,
+	0xfa, 0x9c, 0xc6, 0x28, 0xc3, 0x89, 0x6e, 0x3f, 0x1a, 0x87, 0xff, 0xec, 0xc0, 0xe8, 0xe9, 0x26,
+	0x8b, 0x0c, 0xe6, 0xf3, 0x6d, 0x93, 0x1e, 0x83, 0x2f, 0xdc, 0xa2, 0x78, 0x52, 0xaf, 0x15, 0xc6,
+	0x66, 0x3b, 0xde, 0xd8, 0x60, 0x59, 0x6b, 0x69, 0x36, 0x3a, 0x2b, 0x02, 0x6f, 0xea, 0x9d, 0xf9,
+	0xbc, 0x82, 0xb8, 0xfc, 0x36, 0x91, 0xbf, 0x23, 0x3a, 0x8c, 0x38, 0x8d, 0x5d, 0x23, 0x15, 0xf3,
+	0x54, 0x12, 0x17, 0x46, 0xbc, 0x82, 0xb6, 0x31, 0x4b, 0x8c, 0x59, 0x66, 0xaa, 0xd2, 0xac, 0x05,
+	0xe1, 0x05, 0x8c, 0x28, 0x8b, 0x77, 0x42, 0xbf, 0xb7, 0xaf, 0x7f, 0xef, 0x80, 0x77, 0x79, 0x75,
+	0x83, 0x7b, 0x6e, 0xa5, 0x26, 0x4a, 0xdb, 0x29, 0x15, 0x44, 0xd2, 0xa6, 0x22, 0x5b, 0x6e, 0xc4,
+	0xb2, 0x9a, 0x59, 0x63, 0xf6, 0x3d, 0xf0, 0x17, 0x2e, 0x50, 0xd6, 0xb3, 0xf1, 0xc5, 0x69, 0x15,
+	0x08, 0x27, 0xe7, 0x8d, 0x05, 0xfb, 0x21, 0x9c, 0x52, 0x47, 0xf8, 0xcd, 0x56, 0xe8, 0x04, 0x1d,
+	0xaa, 0xb2, 0x77, 0xda, 0x26, 0xe1, 0x9d, 0xd0, 0xfc, 0xa4, 0x70, 0x23, 0x6b, 0x16, 0x3e, 0x87,
+	0x3e, 0x51, 0xf9, 0x03, 0xb8, 0xf4, 0x09, 0xf8, 0xaf, 0x71, 0x4a, 0x92, 0x2d, 0x94, 0xbb, 0xe0,
+	0x1a, 0x41, 0xf8, 0xb7, 0xaa, 0x8d, 0x7d, 0xe8, 0xb2, 0x18, 0x28, 0xa1, 0x6f, 0x31, 0xb6, 0x5d,
+	0x17, 0x28, 0x0b, 0x31, 0x50, 0x5b, 0xa1, 0x6f, 0xb2, 0x58, 0x96, 0x8e, 0xa9, 0x35, 0xc6, 0xd0,
+	0xeb, 0xa6, 0x35, 0xd3, 0x98, 0x7d, 0x0a, 0x10, 0xa9, 0x75, 0x8e, 0xab, 0xca, 0xd8, 0x65, 0xba,
+	0x25, 0x09, 0xff, 0xdc, 0x85, 0x3e, 0xd1, 0xf9, 0xc3, 0x9c, 0x26, 0xea, 0xb7, 0xce, 0xd7, 0x08,
+	0xf0, 0x84, 0xbf, 0x2d, 0x14, 0x32, 0xb4, 0xa8, 0x4e, 0x58, 0x61, 0xd4, 0x91, 0x21, 0xde, 0x6d,
+	0x3d, 0xba, 0xfc, 0x6a, 0x8c, 0x65, 0x69, 0xca, 0xd6, 0xab, 0xcd, 0xa1, 0xfd, 0x9b, 0x74, 0x70,
+	0x78, 0x93, 0xb6, 0x1e, 0x94, 0xc3, 0xfd, 0x07, 0x65, 0x00, 0x43, 0x53, 0xda, 0x40, 0x8d, 0x68,
+	0xab, 0x0a, 0xda, 0x42, 0x59, 0xab, 0xad, 0x8c, 0xe9, 0x76, 0x1f, 0xf1, 0x0a, 0x86, 0xff, 0xe8,
+	0x00, 0x3c, 0x4d, 0x52, 0x23, 0xf5, 0x4d, 0xb6, 0x50, 0xff, 0xb7, 0x90, 0x54, 0x2e, 0x2c, 0xb4,
+	0x5a, 0x53, 0x4c, 0x7a, 0xbc, 0x11, 0xd4, 0x2e, 0x18, 0xe5, 0xde, 0x3a, 0x15, 0xc4, 0x70, 0xa1,
+	0xc5, 0x95, 0x2c, 0x8c, 0x4b, 0x5d, 0x8d, 0xc3, 0xef, 0x83, 0x4f, 0x79, 0xa3, 0x07, 0x5e, 0xd3,
+	0xa8, 0x3a, 0xf7, 0x34, 0xaa, 0xbf, 0x76, 0x80, 0x3d, 0xc1, 0xc7, 0xca, 0xa5, 0x8e, 0x56, 0xc9,
+	0x56, 0xba, 0x97, 0xfb, 0x41, 0x55, 0x1e, 0x37, 0x55, 0x39, 0x85, 0xf1, 0x52, 0x66, 0xb2, 0x48,
+	0x0a, 0x0a, 0xbe, 0xe5, 0x77, 0x5b, 0x84, 0x73, 0x0b, 0x23, 0xb4, 0xb9, 0x55, 0xce, 0xaf, 0x0a,
+	0xe2, 0xb5, 0x26, 0xb3, 0xf8, 0xb6, 0xf2, 0xc9, 0x02, 0xf4, 0xa8, 0x7a, 0x65, 0x57, 0x1e, 0x55,
+	0x38, 0x8c, 0xe0, 0xa3, 0xf6, 0xe9, 0xea, 0xa7, 0x10, 0x45, 0xe3, 0xe0, 0xea, 0x27, 0x25, 0xb7,
+	0x2a, 0xf6, 0xa8, 0xb5, 0xa8, 0x6d, 0x94, 0x27, 0xce, 0xcc, 0x5d, 0x0e, 0xad, 0x4d, 0xfe, 0xd4,
+	0x05, 0xff, 0x89, 0x48, 0xd3, 0xa7, 0xda, 0x35, 0xab, 0x57, 0x49, 0x16, 0x57, 0x0d, 0x0c, 0xc7,
+	0xc8, 0xc3, 0x48, 0xa4, 0xa9, 0xfb, 0x54, 0xf2, 0xb9, 0x43, 0xb5, 0x5c, 0x3a, 0x56, 0x3b, 0x44,
+	0x49, 0x72, 0xcd, 0x87, 0x7c, 0xf5, 0x79, 0x8d, 0x71, 0x7d, 0xa1, 0x97, 0xd6, 0x55, 0x9f, 0xd3,
+	0xb8, 0xf5, 0xb2, 0x1d, 0x1c, 0xbe, 0x6c, 0x97, 0xa2, 0xf8, 0x25, 0x96, 0xa9, 0x63, 0xb2, 0x83,
+	0x38, 0x43, 0xcb, 0x62, 0x93, 0x1a, 0x22, 0xb2, 0xcf, 0x1d, 0xa2, 0x10, 0x6b, 0xad, 0x34, 0xb1,
+	0xd8, 0xe7, 0x16, 0xb0, 0xcf, 0xf0, 0xb1, 0x24, 0xf3, 0x22, 0x80, 0xa9, 0xd7, 0xfa, 0x7e, 0x9b,
+	0x69, 0x11, 0xc9, 0x97, 0x46, 0xe6, 0xdc, 0xaa, 0xc3, 0x04, 0xfc, 0x5a, 0xc6, 0x4e, 0xa0, 0xab,
+	0x72, 0x17, 0x86, 0xae, 0xca, 0xf1, 0x2e, 0x7c, 0x25, 0x77, 0x2e, 0x02, 0x38, 0x6c, 0x9e, 0x29,
+	0xd6, 0x7b, 0x0b, 0xf0, 0x5b, 0x11, 0xc3, 0x40, 0x8e, 0x37, 0x7b, 0xd5, 0x01, 0xe6, 0xa4, 0x0d,
+	0x77, 0x30, 0x9c, 0x95, 0xb4, 0x59, 0xab, 0xca, 0x3b, 0x7b, 0x55, 0xfe, 0xee, 0x0f, 0xc3, 0xe6,
+	0xba, 0xf6, 0xf6, 0xae, 0xeb, 0xf7, 0xdb, 0xfa, 0x0f, 0x5d, 0x38, 0x9a, 0x95, 0x2f, 0x93, 0xf5,
+	0x26, 0x15, 0x94, 0x92, 0x77, 0x1d, 0xe0, 0x0c, 0x86, 0x8e, 0x24, 0xee, 0xfd, 0x71, 0xc8, 0xa1,
+	0x4a, 0xdd, 0x4e, 0x94, 0xb7, 0x9f, 0xa8, 0x09, 0x78, 0x0b, 0x29, 0x1d, 0xe3, 0x71, 0xc8, 0xce,
+	0x61, 0xe4, 0xbe, 0x77, 0x90, 0x04, 0x5e, 0xeb, 0xcb, 0xdb, 0x7d, 0xf1, 0x7d, 0x99, 0x2c, 0x16,
+	0xbc, 0xb6, 0x61, 0xdf, 0x85, 0xa1, 0x7b, 0x51, 0x07, 0x83, 0x3d, 0xf3, 0x97, 0x56, 0x4a, 0xe6,
+	0x95, 0x49, 0x1d, 0x82, 0xe1, 0xbd, 0x21, 0x78, 0x0d, 0xe3, 0xd6, 0x66, 0xf4, 0x45, 0xb6, 0xd7,
+	0xcc, 0x2a, 0x88, 0x5d, 0x64, 0x2e, 0x17, 0x4a, 0xcb, 0xa0, 0xbb, 0x57, 0x6c, 0xf6, 0x9d, 0xed,
+	0x74, 0x58, 0x91, 0x62, 0x61, 0xa4, 0x0e, 0xbc, 0xb7, 0x18, 0x59, 0x55, 0xb8, 0x84, 0x71, 0xeb,
+	0xc0, 0xf7, 0x6c, 0xf9, 0x26, 0xcf, 0x3e, 0xae, 0x0f, 0xe1, 0xd2, 0xed, 0xb6, 0x7d, 0x50, 0x6d,
+	0x6b, 0x6b, 0xcc, 0x82, 0x47, 0x17, 0x30, 0xb0, 0x5f, 0x6d, 0x0c, 0x60, 0x70, 0xfb, 0x9c, 0xff,
+	0xfc, 0xf2, 0xd9, 0xe4, 0x6b, 0xec, 0x04, 0xe0, 0xc7, 0xcf, 0xef, 0xae, 0xf9, 0xed, 0xe5, 0xed,
+	0x93, 0xeb, 0x49, 0x87, 0x1d, 0xc1, 0x88, 0x5f, 0x7f, 0x79, 0xfd, 0xe2, 0xd9, 0xf3, 0x5f, 0x4d,
+	0xba, 0x57, 0xd3, 0x5f, 0x7f, 0xba, 0x4c, 0xcc, 0x6a, 0x33, 0x3f, 0x8f, 0xd4, 0xfa, 0xb1, 0x90,
+	0x7a, 0xa9, 0x12, 0x65, 0x7f, 0x1f, 0x93, 0x2f, 0xf3, 0x01, 0xfd, 0xb1, 0xf2, 0xf9, 0xff, 0x02,
+	0x00, 0x00, 0xff, 0xff, 0x22, 0x64, 0xe2, 0x3f, 0x6c, 0x11, 0x00, 0x00,
 }
@@ -26,6 +26,21 @@ func TestBlockHash(t *testing.T) {
 	assert.Equal(t, h1, h2)
 }
 
+func TestBlockHashEventsBloom(t *testing.T) {
+	block := NewBlock(nil, nil, make(Receipts, 0), make([]*Tx, 0), nil, 0)
+	h1 := block.calculateBlockHash()
+	d1, err := block.Header.bytesForDigest()
+	assert.Nil(t, err)
+
+	// the bloom is signed and hashed with the header
+	block.Header.EventsBloom = make([]byte, BloomByteLength)
+	block.Header.EventsBloom[0] = 1
+	d2, err := block.Header.bytesForDigest()
+	assert.Nil(t, err)
+	assert.NotEqual(t, d1, d2)
+	assert.NotEqual(t, h1, block.calculateBlockHash())
+}
+
 func genKeyPair(assert *assert.Assertions) (crypto.PrivKey, crypto.PubKey) {
 	privKey, pubKey, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
 	assert.Nil(err)
@@ -91,3 +106,20 @@ func TestBlockSign(t *testing.T) {
 	signAssert.Nil(err)
 	signAssert.True(valid)
 }
+
+func TestTxHashChainIdHash(t *testing.T) {
+	tx := &Tx{Body: &TxBody{Nonce: 1, Amount: []byte{1}, Sign: []byte{1, 2, 3, 4}}}
+	legacy := tx.CalculateTxHash()
+
+	tx.Body.ChainIdHash = []byte{}
+	assert.Equal(t, legacy, tx.CalculateTxHash())
+
+	tx.Body.ChainIdHash = []byte{1, 2}
+	tx.Body.Sign = []byte{3, 4}
+	moved := tx.CalculateTxHash()
+	assert.NotEqual(t, legacy, moved)
+
+	tx.Body.ChainIdHash = []byte{1, 2, 3}
+	tx.Body.Sign = []byte{4}
+	assert.NotEqual(t, moved, tx.CalculateTxHash())
+}
@@ -0,0 +1,64 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/minio/sha256-simd"
+)
+
+const (
+	// BloomByteLength is the size of the events bloom filter in a block header
+	BloomByteLength = 256
+	bloomBitLength  = BloomByteLength * 8
+	bloomHashCount  = 3
+)
+
+func bloomIndexes(data []byte) [bloomHashCount]uint32 {
+	var idx [bloomHashCount]uint32
+	h := sha256.Sum256(data)
+	for i := 0; i < bloomHashCount; i++ {
+		idx[i] = uint32(binary.BigEndian.Uint16(h[2*i:])) % bloomBitLength
+	}
+	return idx
+}
+
+// BloomAdd sets the bits of data to bloom.
+func BloomAdd(bloom []byte, data []byte) {
+	for _, i := range bloomIndexes(data) {
+		bloom[i/8] |= 1 << (i % 8)
+	}
+}
+
+// BloomContains reports whether data may be in bloom. A false positive is
+// possible, but a false negative is not.
+func BloomContains(bloom []byte, data []byte) bool {
+	if len(bloom) != BloomByteLength {
+		return false
+	}
+	for _, i := range bloomIndexes(data) {
+		if bloom[i/8]&(1<<(i%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BloomMatchEvent reports whether events of the contract, optionally with the
+// event name, may be in bloom.
+func BloomMatchEvent(bloom []byte, contractAddress []byte, eventName string) bool {
+	if len(eventName) == 0 {
+		return BloomContains(bloom, contractAddress)
+	}
+	return BloomContains(bloom, eventBloomKey(contractAddress, eventName))
+}
+
+func eventBloomKey(contractAddress []byte, eventName string) []byte {
+	key := make([]byte, 0, len(contractAddress)+len(eventName))
+	key = append(key, contractAddress...)
+	return append(key, eventName...)
+}
@@ -9,18 +9,36 @@ var (
 	//ErrTxHasInvalidHash is returned by MemPool Service if transaction does have invalid hash
 	ErrTxHasInvalidHash = errors.New("tx has invalid hash")
 
+	//ErrTxInvalidChainIdHash is returned by MemPool Service if transaction is not bound to the chain of the node
+	ErrTxInvalidChainIdHash = errors.New("tx has invalid chain id hash")
+
 	//ErrTxAlreadyInMempool is returned by MemPool Service if exact same transaction is already exists
 	ErrTxAlreadyInMempool = errors.New("tx is already in mempool")
 
 	//ErrSameNonceInMempool is returned by MemPool Service if transaction which has same nonce is already exists
 	ErrSameNonceAlreadyInMempool = errors.New("tx with same nonce is already in mempool")
 
+	//ErrTxPoolFull is returned by MemPool Service if mempool is full and the price of transaction is too low to evict others
+	ErrTxPoolFull = errors.New("mempool is full")
+
+	//ErrTxAccountLimit is returned by MemPool Service if the account has too many transactions in mempool
+	ErrTxAccountLimit = errors.New("too many txs of the account in mempool")
+
+	//ErrTxOrphanLimit is returned by MemPool Service if the account has too many orphan transactions in mempool
+	ErrTxOrphanLimit = errors.New("too many orphan txs of the account in mempool")
+
 	//ErrTxFormatInvalid is returned by MemPool Service if transaction does not exists ErrTxFormatInvalid = errors.New("tx invalid format")
 	ErrTxFormatInvalid = errors.New("tx invalid format")
 
 	//ErrInsufficientBalance is returned by MemPool Service if account has not enough balance
 	ErrInsufficientBalance = errors.New("not enough balance")
 
+	//ErrTxGasLimitOverflow is returned by MemPool Service if the maximum fee of transaction overflows
+	ErrTxGasLimitOverflow = errors.New("tx gas limit overflows the maximum fee")
+
+	//ErrTxGasPriceTooLow is returned by MemPool Service if the gas price of transaction is below the minimum of the chain
+	ErrTxGasPriceTooLow = errors.New("gas price is too low")
+
 	//ErrTxNonceTooLow is returned by MemPool Service if transaction's nonce is already existed in block
 	ErrTxNonceTooLow = errors.New("nonce is too low")
 
@@ -32,6 +50,9 @@ var (
 	//ErrInvalidRecipient
 	ErrTxInvalidRecipient = errors.New("tx invalid recipient")
 
+	//ErrTxInvalidAmount is returned if the amount is not allowed for the type of tx
+	ErrTxInvalidAmount = errors.New("tx invalid amount")
+
 	ErrSignNotMatch = errors.New("signature not matched")
 
 	ErrCouldNotRecoverPubKey = errors.New("could not recover pubkey from sign")
@@ -43,6 +64,12 @@ var (
 	//ErrStakeBeforeVote
 	ErrMustStakeBeforeVote = errors.New("must stake before vote")
 
+	//ErrNotAllowlistAdmin
+	ErrNotAllowlistAdmin = errors.New("only the admins can change peer allowlist")
+
+	//ErrAllowlistAlreadyApproved
+	ErrAllowlistAlreadyApproved = errors.New("peer allowlist change is already approved by the admin")
+
 	//ErrLessTimeHasPassed
 	ErrLessTimeHasPassed = errors.New("less time has passed")
 
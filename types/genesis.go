@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"math"
 
 	"github.com/aergoio/aergo/internal/common"
 )
@@ -9,6 +10,8 @@ import (
 const (
 	// DefaultSeed is temporary const to create same genesis block with no configuration
 	DefaultSeed = 1530838800
+	// DefaultMinGasPrice is the minimum gas price of a tx in aer
+	DefaultMinGasPrice = 1
 )
 
 var (
@@ -39,12 +42,127 @@ func (cid *ChainID) Equals(rhs *ChainID) bool {
 	return bytes.Compare(cid.Bytes(), rhs.Bytes()) == 0
 }
 
+// ChainParams represents the consensus parameters of the chain. A rule added
+// after the chain started takes effect from its activation height, so the
+// blocks below the height are executed as before.
+type ChainParams struct {
+	MinGasPrice         uint64   `json:"min_gas_price"`
+	GasHeight           uint64   `json:"gas_height"`
+	ElectionHeight      uint64   `json:"election_height"`
+	SandboxHeight       uint64   `json:"sandbox_height"`
+	ViewPayableHeight   uint64   `json:"view_payable_height"`
+	MaxInstructionCount uint64   `json:"max_instruction_count"`
+	MaxCallMemory       uint64   `json:"max_call_memory"`
+	VMLimitsHeight      uint64   `json:"vm_limits_height"`
+	MaxCallDepth        uint64   `json:"max_call_depth"`
+	AllowReentrancy     bool     `json:"allow_reentrancy"`
+	CallLimitsHeight    uint64   `json:"call_limits_height"`
+	ChainIdHeight       uint64   `json:"chain_id_height"`
+	AllowlistAdmins     []string `json:"allowlist_admins"`
+	AllowlistQuorum     uint64   `json:"allowlist_quorum"`
+	AllowlistHeight     uint64   `json:"allowlist_height"`
+}
+
+// DefaultChainParams returns the parameters of a new chain. Every rule is
+// active from the genesis block.
+func DefaultChainParams() *ChainParams {
+	return &ChainParams{
+		MinGasPrice:         DefaultMinGasPrice,
+		MaxInstructionCount: DefaultMaxInstructionCount,
+		MaxCallMemory:       DefaultMaxCallMemory,
+		MaxCallDepth:        DefaultMaxCallDepth,
+	}
+}
+
+// LegacyChainParams returns the parameters of a chain whose genesis has none.
+// No rule is activated.
+func LegacyChainParams() *ChainParams {
+	return &ChainParams{
+		GasHeight:         math.MaxUint64,
+		ElectionHeight:    math.MaxUint64,
+		SandboxHeight:     math.MaxUint64,
+		ViewPayableHeight: math.MaxUint64,
+		VMLimitsHeight:    math.MaxUint64,
+		CallLimitsHeight:  math.MaxUint64,
+		ChainIdHeight:     math.MaxUint64,
+		AllowlistHeight:   math.MaxUint64,
+	}
+}
+
+// GasAt reports whether the txs in the block of blockNo are charged for the
+// gas used by their execution. Before, every NORMAL tx is charged
+// DefaultCoinbaseFee.
+func (p *ChainParams) GasAt(blockNo uint64) bool {
+	return blockNo >= p.GasHeight
+}
+
+// MinGasPriceAt returns the minimum gas price of the txs in the block of
+// blockNo.
+func (p *ChainParams) MinGasPriceAt(blockNo uint64) uint64 {
+	if !p.GasAt(blockNo) {
+		return 0
+	}
+	return p.MinGasPrice
+}
+
+// ElectionAt reports whether the block of blockNo is produced by the BPs
+// elected from the vote result at its epoch boundary block, and whether the
+// candidates of the same amount are ordered by their IDs in the vote result of
+// the block. Before, the blocks are produced by the genesis BPs.
+func (p *ChainParams) ElectionAt(blockNo uint64) bool {
+	return blockNo >= p.ElectionHeight
+}
+
+// SandboxAt reports whether the contracts called in the block of blockNo run
+// in the deterministic Lua environment, without the globals of the file system
+// and the process, and without the iteration over the keys of reference types.
+// The queries run at the block height of zero.
+func (p *ChainParams) SandboxAt(blockNo uint64) bool {
+	return blockNo >= p.SandboxHeight
+}
+
+// ViewPayableAt reports whether, in the block of blockNo, only the functions
+// registered as views can be queried and only the payable functions accept an
+// amount, for the contracts whose ABIs declare the flags (AbiFlagsVersion).
+func (p *ChainParams) ViewPayableAt(blockNo uint64) bool {
+	return blockNo >= p.ViewPayableHeight
+}
+
+// VMLimitsAt reports whether the txs in the block of blockNo are limited by
+// MaxInstructionCount and MaxCallMemory, which are shared by the nested calls
+// of a tx. Zero means no limit.
+func (p *ChainParams) VMLimitsAt(blockNo uint64) bool {
+	return blockNo >= p.VMLimitsHeight
+}
+
+// CallLimitsAt reports whether the nested contract calls of a tx in the block
+// of blockNo are limited to MaxCallDepth, where zero means no limit, and
+// whether a contract already on the call stack can be called again only by
+// its functions registered by abi.reentrant, unless AllowReentrancy is set.
+func (p *ChainParams) CallLimitsAt(blockNo uint64) bool {
+	return blockNo >= p.CallLimitsHeight
+}
+
+// ChainIdAt reports whether every tx in the block of blockNo must be bound to
+// the chain by its chain ID hash.
+func (p *ChainParams) ChainIdAt(blockNo uint64) bool {
+	return blockNo >= p.ChainIdHeight
+}
+
+// AllowlistAt reports whether the system txs in the block of blockNo change
+// the peer allowlist. A change is applied once it is sent by AllowlistQuorum
+// of the AllowlistAdmins, or by the majority of them if zero.
+func (p *ChainParams) AllowlistAt(blockNo uint64) bool {
+	return blockNo >= p.AllowlistHeight
+}
+
 // Genesis represents genesis block
 type Genesis struct {
 	ID        ChainID           `json:"chain_id,omitempty"`
 	Timestamp int64             `json:"timestamp,omitempty"`
 	Balance   map[string]*State `json:"alloc"`
 	BPs       []string          `json:"bps"`
+	Params    *ChainParams      `json:"params,omitempty"`
 
 	// followings are for internal use only
 	block     *Block
@@ -59,9 +177,42 @@ func (g *Genesis) Block() *Block {
 	return g.block
 }
 
-// ChainID returns the binary representation of g.ID.
+// ChainID returns the binary representation of the identity of the chain of
+// g. It includes the hash of the parameters of g if any, so the nodes running
+// the chain under different parameters don't take each other as on the same
+// chain.
 func (g *Genesis) ChainID() []byte {
-	return g.ID.Bytes()
+	b := g.ID.Bytes()
+	if h := g.ParamsHash(); h != nil {
+		b = append(b, h...)
+	}
+	return b
+}
+
+// ParamsHash returns the hash of the parameters of g, or nil if g has none.
+func (g *Genesis) ParamsHash() []byte {
+	if g.Params == nil {
+		return nil
+	}
+	b, err := common.GobEncode(g.Params)
+	if err != nil {
+		return nil
+	}
+	return common.Hasher(b)
+}
+
+// ChainIdHash returns the hash of the chain ID of g, which binds the txs to the
+// chain.
+func (g *Genesis) ChainIdHash() []byte {
+	return common.Hasher(g.ChainID())
+}
+
+// ChainParams returns the consensus parameters of g.
+func (g *Genesis) ChainParams() *ChainParams {
+	if g.Params == nil {
+		return LegacyChainParams()
+	}
+	return g.Params
 }
 
 // Bytes returns byte-encoded BPs from g.
@@ -89,6 +240,7 @@ func GetDefaultGenesis() *Genesis {
 	return &Genesis{
 		ID:        defaultChainID,
 		Timestamp: DefaultSeed,
+		Params:    DefaultChainParams(),
 		block:     nil,
 	} //TODO embed MAINNET genesis block
 }
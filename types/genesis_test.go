@@ -31,6 +31,13 @@ func TestGenesisChainID(t *testing.T) {
 	a.True(g.ID.Equals(&defaultChainID))
 	fmt.Println("len:", len(chainID))
 	fmt.Println("chain_id: ", enc.ToString(chainID))
+
+	// the parameters are a part of the identity of the chain
+	other := GetDefaultGenesis()
+	other.Params.MinGasPrice = 2
+	a.NotEqual(chainID, other.ChainID())
+	other.Params = nil
+	a.Equal(g.ID.Bytes(), other.ChainID())
 }
 
 func TestGenesisBytes(t *testing.T) {
@@ -45,3 +52,37 @@ func TestGenesisBytes(t *testing.T) {
 	fmt.Println(spew.Sdump(g2))
 	a.Nil(g2.Balance)
 }
+
+func TestGenesisChainParams(t *testing.T) {
+	a := assert.New(t)
+	g1 := GetDefaultGenesis()
+	g1.Params.GasHeight = 10
+
+	g2 := GetGenesisFromBytes(g1.Bytes())
+	a.Equal(g1.Params, g2.Params)
+	a.False(g2.ChainParams().GasAt(9))
+	a.True(g2.ChainParams().GasAt(10))
+	a.Equal(uint64(0), g2.ChainParams().MinGasPriceAt(9))
+	a.Equal(uint64(DefaultMinGasPrice), g2.ChainParams().MinGasPriceAt(10))
+	a.True(g2.ChainParams().ElectionAt(0))
+	a.True(g2.ChainParams().SandboxAt(0))
+	a.True(g2.ChainParams().ViewPayableAt(0))
+	a.True(g2.ChainParams().VMLimitsAt(0))
+	a.True(g2.ChainParams().CallLimitsAt(0))
+	a.True(g2.ChainParams().ChainIdAt(0))
+	a.True(g2.ChainParams().AllowlistAt(0))
+	a.False(g2.ChainParams().AllowReentrancy)
+	a.Equal(uint64(DefaultMaxInstructionCount), g2.ChainParams().MaxInstructionCount)
+
+	// a genesis without parameters activates no rule
+	g2.Params = nil
+	a.False(g2.ChainParams().GasAt(10))
+	a.Equal(uint64(0), g2.ChainParams().MinGasPriceAt(10))
+	a.False(g2.ChainParams().ElectionAt(10))
+	a.False(g2.ChainParams().SandboxAt(10))
+	a.False(g2.ChainParams().ViewPayableAt(10))
+	a.False(g2.ChainParams().VMLimitsAt(10))
+	a.False(g2.ChainParams().CallLimitsAt(10))
+	a.False(g2.ChainParams().ChainIdAt(10))
+	a.False(g2.ChainParams().AllowlistAt(10))
+}
@@ -25,15 +25,19 @@ const (
 	MetricType_NOTHING MetricType = 0
 	// Metric for p2p network transfer
 	MetricType_P2P_NETWORK MetricType = 1
+	// Metric for mempool
+	MetricType_MEMPOOL MetricType = 2
 )
 
 var MetricType_name = map[int32]string{
 	0: "NOTHING",
 	1: "P2P_NETWORK",
+	2: "MEMPOOL",
 }
 var MetricType_value = map[string]int32{
 	"NOTHING":     0,
 	"P2P_NETWORK": 1,
+	"MEMPOOL":     2,
 }
 
 func (x MetricType) String() string {
@@ -82,10 +86,11 @@ func (m *MetricsRequest) GetTypes() []MetricType {
 }
 
 type Metrics struct {
-	Peers                []*PeerMetric `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Peers                []*PeerMetric  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	Mempool              *MempoolMetric `protobuf:"bytes,2,opt,name=mempool,proto3" json:"mempool,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *Metrics) Reset()         { *m = Metrics{} }
@@ -119,6 +124,13 @@ func (m *Metrics) GetPeers() []*PeerMetric {
 	return nil
 }
 
+func (m *Metrics) GetMempool() *MempoolMetric {
+	if m != nil {
+		return m.Mempool
+	}
+	return nil
+}
+
 type PeerMetric struct {
 	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
 	SumIn                int64    `protobuf:"varint,2,opt,name=sumIn,proto3" json:"sumIn,omitempty"`
@@ -189,31 +201,118 @@ func (m *PeerMetric) GetAvrOut() int64 {
 	return 0
 }
 
+type MempoolMetric struct {
+	Txs                  int64    `protobuf:"varint,1,opt,name=txs,proto3" json:"txs,omitempty"`
+	Orphans              int64    `protobuf:"varint,2,opt,name=orphans,proto3" json:"orphans,omitempty"`
+	Accounts             int64    `protobuf:"varint,3,opt,name=accounts,proto3" json:"accounts,omitempty"`
+	Bytes                int64    `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Evicted              int64    `protobuf:"varint,5,opt,name=evicted,proto3" json:"evicted,omitempty"`
+	Expired              int64    `protobuf:"varint,6,opt,name=expired,proto3" json:"expired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MempoolMetric) Reset()         { *m = MempoolMetric{} }
+func (m *MempoolMetric) String() string { return proto.CompactTextString(m) }
+func (*MempoolMetric) ProtoMessage()    {}
+func (*MempoolMetric) Descriptor() ([]byte, []int) {
+	return fileDescriptor_metric_00420d7062d0a7ff, []int{3}
+}
+
+func (m *MempoolMetric) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MempoolMetric.Unmarshal(m, b)
+}
+func (m *MempoolMetric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MempoolMetric.Marshal(b, m, deterministic)
+}
+func (dst *MempoolMetric) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolMetric.Merge(dst, src)
+}
+func (m *MempoolMetric) XXX_Size() int {
+	return xxx_messageInfo_MempoolMetric.Size(m)
+}
+func (m *MempoolMetric) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolMetric.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolMetric proto.InternalMessageInfo
+
+func (m *MempoolMetric) GetTxs() int64 {
+	if m != nil {
+		return m.Txs
+	}
+	return 0
+}
+
+func (m *MempoolMetric) GetOrphans() int64 {
+	if m != nil {
+		return m.Orphans
+	}
+	return 0
+}
+
+func (m *MempoolMetric) GetAccounts() int64 {
+	if m != nil {
+		return m.Accounts
+	}
+	return 0
+}
+
+func (m *MempoolMetric) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *MempoolMetric) GetEvicted() int64 {
+	if m != nil {
+		return m.Evicted
+	}
+	return 0
+}
+
+func (m *MempoolMetric) GetExpired() int64 {
+	if m != nil {
+		return m.Expired
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*MetricsRequest)(nil), "types.MetricsRequest")
 	proto.RegisterType((*Metrics)(nil), "types.Metrics")
 	proto.RegisterType((*PeerMetric)(nil), "types.PeerMetric")
+	proto.RegisterType((*MempoolMetric)(nil), "types.MempoolMetric")
 	proto.RegisterEnum("types.MetricType", MetricType_name, MetricType_value)
 }
 
 func init() { proto.RegisterFile("metric.proto", fileDescriptor_metric_00420d7062d0a7ff) }
 
 var fileDescriptor_metric_00420d7062d0a7ff = []byte{
-	// 251 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x90, 0x4d, 0x4b, 0xc3, 0x40,
-	0x10, 0x40, 0x5d, 0x63, 0x5a, 0x98, 0x94, 0x5a, 0x17, 0x91, 0x9c, 0x24, 0xf4, 0x62, 0xe8, 0x21,
-	0x85, 0x78, 0xf2, 0x2a, 0x8a, 0x06, 0x31, 0x09, 0x4b, 0x40, 0xf0, 0x22, 0x69, 0x19, 0x6a, 0x0e,
-	0xe9, 0xc6, 0xfd, 0x28, 0xf4, 0xe6, 0x4f, 0x97, 0xdd, 0x09, 0xf6, 0x14, 0xde, 0x9b, 0x79, 0x81,
-	0x59, 0x98, 0xf5, 0x68, 0x54, 0xb7, 0xcd, 0x06, 0x25, 0x8d, 0xe4, 0xa1, 0x39, 0x0e, 0xa8, 0x97,
-	0x0f, 0x30, 0x7f, 0xf7, 0x5a, 0x0b, 0xfc, 0xb1, 0xa8, 0x0d, 0xbf, 0x03, 0x1a, 0xc5, 0x2c, 0x09,
-	0xd2, 0x79, 0x7e, 0x95, 0x79, 0xca, 0x68, 0xab, 0x39, 0x0e, 0x28, 0xc6, 0x34, 0x87, 0xe9, 0x98,
-	0xba, 0x66, 0x40, 0x54, 0xd4, 0x44, 0xff, 0x4d, 0x8d, 0xa8, 0x68, 0x45, 0xd0, 0x7c, 0xf9, 0xcb,
-	0x00, 0x4e, 0x96, 0xdf, 0xc0, 0xc4, 0xf9, 0xe2, 0x29, 0x66, 0x09, 0x4b, 0x67, 0x62, 0x24, 0x7e,
-	0x0d, 0xa1, 0xb6, 0x7d, 0xb1, 0x8f, 0xcf, 0x13, 0x96, 0x06, 0x82, 0xc0, 0xd9, 0xf6, 0xa0, 0x8a,
-	0x7d, 0x1c, 0x90, 0xf5, 0xe0, 0xfe, 0xa1, 0x6d, 0x5f, 0x59, 0x13, 0x5f, 0x78, 0x3d, 0x92, 0xf3,
-	0xed, 0x41, 0x39, 0x1f, 0x92, 0x27, 0x5a, 0xad, 0x00, 0x4e, 0xb7, 0xf0, 0x08, 0xa6, 0x65, 0xd5,
-	0xbc, 0x16, 0xe5, 0xcb, 0xe2, 0x8c, 0x5f, 0x42, 0x54, 0xe7, 0xf5, 0x57, 0xf9, 0xdc, 0x7c, 0x54,
-	0xe2, 0x6d, 0xc1, 0x1e, 0x93, 0xcf, 0xdb, 0x5d, 0x67, 0xbe, 0xed, 0x26, 0xdb, 0xca, 0x7e, 0xdd,
-	0xa2, 0xda, 0xc9, 0x4e, 0xd2, 0x77, 0xed, 0x4f, 0xdc, 0x4c, 0xfc, 0x6b, 0xde, 0xff, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0xb5, 0xeb, 0x41, 0xa0, 0x5d, 0x01, 0x00, 0x00,
+	// 359 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0x4f, 0x6b, 0xea, 0x40,
+	0x14, 0xc5, 0x5f, 0xcc, 0xd3, 0x3c, 0x6e, 0x7c, 0xbe, 0xbc, 0x41, 0x4a, 0xe8, 0xa2, 0x04, 0x37,
+	0x95, 0x2e, 0x22, 0xd8, 0x45, 0xe9, 0xb6, 0x54, 0xda, 0xd0, 0x6a, 0x42, 0x10, 0x0a, 0xdd, 0x94,
+	0x24, 0x5e, 0x34, 0xd0, 0x64, 0xd2, 0x99, 0x89, 0xe8, 0xae, 0x5f, 0xa5, 0xdf, 0xb4, 0xcc, 0x1f,
+	0x95, 0xae, 0xf4, 0x77, 0xce, 0x3d, 0x87, 0x03, 0x13, 0xe8, 0x57, 0x28, 0x58, 0x59, 0x84, 0x0d,
+	0xa3, 0x82, 0x92, 0xae, 0xd8, 0x37, 0xc8, 0x47, 0xb7, 0x30, 0x98, 0x2b, 0x99, 0xa7, 0xf8, 0xd1,
+	0x22, 0x17, 0xe4, 0x12, 0xb4, 0xe5, 0x5b, 0x81, 0x3d, 0x1e, 0x4c, 0xff, 0x87, 0x8a, 0x42, 0x7d,
+	0xb5, 0xdc, 0x37, 0x98, 0x9a, 0x68, 0x0e, 0x8e, 0x89, 0xca, 0x4c, 0x83, 0xc8, 0x74, 0xc6, 0x3d,
+	0x66, 0x12, 0x44, 0xa6, 0x4f, 0x52, 0xed, 0x93, 0x10, 0x9c, 0x0a, 0xab, 0x86, 0xd2, 0x77, 0xbf,
+	0x13, 0x58, 0x63, 0x77, 0x3a, 0x3c, 0xd6, 0x2b, 0xd5, 0x5c, 0x1f, 0x8e, 0x46, 0x9f, 0x16, 0xc0,
+	0xa9, 0x85, 0x9c, 0x41, 0x4f, 0xf6, 0x44, 0xf7, 0xbe, 0x15, 0x58, 0xe3, 0x7e, 0x6a, 0x88, 0x0c,
+	0xa1, 0xcb, 0xdb, 0x2a, 0xaa, 0x55, 0xa9, 0x9d, 0x6a, 0x90, 0x6a, 0xb6, 0x65, 0x51, 0xed, 0xdb,
+	0x5a, 0x55, 0x20, 0x3b, 0x78, 0x5b, 0xc5, 0xad, 0xf0, 0x7f, 0x2b, 0xd9, 0x90, 0xd4, 0xb3, 0x2d,
+	0x93, 0x7a, 0x57, 0xeb, 0x9a, 0x46, 0x5f, 0x16, 0xfc, 0xfd, 0xb1, 0x8e, 0x78, 0x60, 0x8b, 0x1d,
+	0x57, 0x13, 0xec, 0x54, 0xfe, 0x25, 0x3e, 0x38, 0x94, 0x35, 0x9b, 0xac, 0xe6, 0x66, 0xc1, 0x01,
+	0xc9, 0x39, 0xfc, 0xc9, 0x8a, 0x82, 0xb6, 0xb5, 0xe0, 0x66, 0xc6, 0x91, 0xe5, 0xbe, 0x7c, 0x2f,
+	0x90, 0x9b, 0x21, 0x1a, 0x64, 0x17, 0x6e, 0xcb, 0x42, 0xe0, 0xca, 0x0c, 0x39, 0xa0, 0x72, 0x76,
+	0x4d, 0xc9, 0x70, 0xe5, 0xf7, 0x8c, 0xa3, 0xf1, 0xea, 0x06, 0xe0, 0xf4, 0x3e, 0xc4, 0x05, 0x67,
+	0x11, 0x2f, 0x1f, 0xa3, 0xc5, 0x83, 0xf7, 0x8b, 0xfc, 0x03, 0x37, 0x99, 0x26, 0x6f, 0x8b, 0xd9,
+	0xf2, 0x25, 0x4e, 0x9f, 0x3c, 0x4b, 0xba, 0xf3, 0xd9, 0x3c, 0x89, 0xe3, 0x67, 0xaf, 0x73, 0x17,
+	0xbc, 0x5e, 0xac, 0x4b, 0xb1, 0x69, 0xf3, 0xb0, 0xa0, 0xd5, 0x24, 0x43, 0xb6, 0xa6, 0x25, 0xd5,
+	0xbf, 0x13, 0xf5, 0x30, 0x79, 0x4f, 0x7d, 0x2e, 0xd7, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xa9,
+	0x34, 0x90, 0x33, 0x3e, 0x02, 0x00, 0x00,
 }
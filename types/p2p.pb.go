@@ -379,6 +379,9 @@ type Status struct {
 	Sender               *PeerAddress `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
 	BestBlockHash        []byte       `protobuf:"bytes,2,opt,name=bestBlockHash,proto3" json:"bestBlockHash,omitempty"`
 	BestHeight           uint64       `protobuf:"varint,3,opt,name=bestHeight,proto3" json:"bestHeight,omitempty"`
+	ChainID              []byte       `protobuf:"bytes,4,opt,name=chainID,proto3" json:"chainID,omitempty"`
+	Genesis              []byte       `protobuf:"bytes,5,opt,name=genesis,proto3" json:"genesis,omitempty"`
+	CompactBlock         bool         `protobuf:"varint,6,opt,name=compactBlock,proto3" json:"compactBlock,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -430,6 +433,27 @@ func (m *Status) GetBestHeight() uint64 {
 	return 0
 }
 
+func (m *Status) GetChainID() []byte {
+	if m != nil {
+		return m.ChainID
+	}
+	return nil
+}
+
+func (m *Status) GetGenesis() []byte {
+	if m != nil {
+		return m.Genesis
+	}
+	return nil
+}
+
+func (m *Status) GetCompactBlock() bool {
+	if m != nil {
+		return m.CompactBlock
+	}
+	return false
+}
+
 type GoAwayNotice struct {
 	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -884,8 +908,11 @@ func (m *NewTransactionsNotice) GetTxHashes() [][]byte {
 	return nil
 }
 
+// GetTransactionsRequest asks the txs of hashes. If blockHash is set, the txs
+// are looked up in the block, and hashes can be the short ids of the txs.
 type GetTransactionsRequest struct {
 	Hashes               [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -923,6 +950,13 @@ func (m *GetTransactionsRequest) GetHashes() [][]byte {
 	return nil
 }
 
+func (m *GetTransactionsRequest) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
 type GetTransactionsResponse struct {
 	Status               ResultStatus `protobuf:"varint,1,opt,name=status,proto3,enum=types.ResultStatus" json:"status,omitempty"`
 	Hashes               [][]byte     `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
@@ -1332,6 +1366,388 @@ func (m *GetHashesResponse) GetHasNext() bool {
 	return false
 }
 
+// GetStateChunkRequest asks the leaves of the state trie of root, from the key start.
+// accounts is set for the account trie, to get the contract codes together.
+type GetStateChunkRequest struct {
+	Root                 []byte   `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Start                []byte   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Accounts             bool     `protobuf:"varint,3,opt,name=accounts,proto3" json:"accounts,omitempty"`
+	MaxEntries           uint32   `protobuf:"varint,4,opt,name=maxEntries,proto3" json:"maxEntries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateChunkRequest) Reset()         { *m = GetStateChunkRequest{} }
+func (m *GetStateChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateChunkRequest) ProtoMessage()    {}
+func (*GetStateChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7fdddb109e6467a, []int{23}
+}
+
+func (m *GetStateChunkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateChunkRequest.Unmarshal(m, b)
+}
+func (m *GetStateChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateChunkRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStateChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateChunkRequest.Merge(m, src)
+}
+func (m *GetStateChunkRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateChunkRequest.Size(m)
+}
+func (m *GetStateChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateChunkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateChunkRequest proto.InternalMessageInfo
+
+func (m *GetStateChunkRequest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *GetStateChunkRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *GetStateChunkRequest) GetAccounts() bool {
+	if m != nil {
+		return m.Accounts
+	}
+	return false
+}
+
+func (m *GetStateChunkRequest) GetMaxEntries() uint32 {
+	if m != nil {
+		return m.MaxEntries
+	}
+	return 0
+}
+
+// StateChunkEntry is a leaf of a state trie with the merkle proof of its inclusion.
+// value is the data of which hash is the leaf value.
+type StateChunkEntry struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Code                 []byte   `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Bitmap               []byte   `protobuf:"bytes,4,opt,name=bitmap,proto3" json:"bitmap,omitempty"`
+	Height               uint32   `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	AuditPath            [][]byte `protobuf:"bytes,6,rep,name=auditPath,proto3" json:"auditPath,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateChunkEntry) Reset()         { *m = StateChunkEntry{} }
+func (m *StateChunkEntry) String() string { return proto.CompactTextString(m) }
+func (*StateChunkEntry) ProtoMessage()    {}
+func (*StateChunkEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7fdddb109e6467a, []int{24}
+}
+
+func (m *StateChunkEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateChunkEntry.Unmarshal(m, b)
+}
+func (m *StateChunkEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateChunkEntry.Marshal(b, m, deterministic)
+}
+func (m *StateChunkEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateChunkEntry.Merge(m, src)
+}
+func (m *StateChunkEntry) XXX_Size() int {
+	return xxx_messageInfo_StateChunkEntry.Size(m)
+}
+func (m *StateChunkEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateChunkEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateChunkEntry proto.InternalMessageInfo
+
+func (m *StateChunkEntry) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StateChunkEntry) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateChunkEntry) GetCode() []byte {
+	if m != nil {
+		return m.Code
+	}
+	return nil
+}
+
+func (m *StateChunkEntry) GetBitmap() []byte {
+	if m != nil {
+		return m.Bitmap
+	}
+	return nil
+}
+
+func (m *StateChunkEntry) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateChunkEntry) GetAuditPath() [][]byte {
+	if m != nil {
+		return m.AuditPath
+	}
+	return nil
+}
+
+// GetStateChunkResponse contains the leaves of a state trie in the order of the keys.
+// next is the key to continue from, and it is empty if there is no more leaf.
+type GetStateChunkResponse struct {
+	Status               ResultStatus       `protobuf:"varint,1,opt,name=status,proto3,enum=types.ResultStatus" json:"status,omitempty"`
+	Entries              []*StateChunkEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Next                 []byte             `protobuf:"bytes,3,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetStateChunkResponse) Reset()         { *m = GetStateChunkResponse{} }
+func (m *GetStateChunkResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateChunkResponse) ProtoMessage()    {}
+func (*GetStateChunkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7fdddb109e6467a, []int{25}
+}
+
+func (m *GetStateChunkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateChunkResponse.Unmarshal(m, b)
+}
+func (m *GetStateChunkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateChunkResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStateChunkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateChunkResponse.Merge(m, src)
+}
+func (m *GetStateChunkResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateChunkResponse.Size(m)
+}
+func (m *GetStateChunkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateChunkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateChunkResponse proto.InternalMessageInfo
+
+func (m *GetStateChunkResponse) GetStatus() ResultStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResultStatus_OK
+}
+
+func (m *GetStateChunkResponse) GetEntries() []*StateChunkEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *GetStateChunkResponse) GetNext() []byte {
+	if m != nil {
+		return m.Next
+	}
+	return nil
+}
+
+// GetSQLChunkRequest asks a part of the sql database file of a contract.
+// The first database is returned if name is empty.
+type GetSQLChunkRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Offset               uint64   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSQLChunkRequest) Reset()         { *m = GetSQLChunkRequest{} }
+func (m *GetSQLChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSQLChunkRequest) ProtoMessage()    {}
+func (*GetSQLChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7fdddb109e6467a, []int{26}
+}
+
+func (m *GetSQLChunkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSQLChunkRequest.Unmarshal(m, b)
+}
+func (m *GetSQLChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSQLChunkRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSQLChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSQLChunkRequest.Merge(m, src)
+}
+func (m *GetSQLChunkRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSQLChunkRequest.Size(m)
+}
+func (m *GetSQLChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSQLChunkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSQLChunkRequest proto.InternalMessageInfo
+
+func (m *GetSQLChunkRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetSQLChunkRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// GetSQLChunkResponse contains a part of the sql database file, the file size and
+// the name of the next database, which is empty for the last one.
+type GetSQLChunkResponse struct {
+	Status               ResultStatus `protobuf:"varint,1,opt,name=status,proto3,enum=types.ResultStatus" json:"status,omitempty"`
+	Name                 string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Data                 []byte       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Size                 uint64       `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Next                 string       `protobuf:"bytes,5,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetSQLChunkResponse) Reset()         { *m = GetSQLChunkResponse{} }
+func (m *GetSQLChunkResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSQLChunkResponse) ProtoMessage()    {}
+func (*GetSQLChunkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7fdddb109e6467a, []int{27}
+}
+
+func (m *GetSQLChunkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSQLChunkResponse.Unmarshal(m, b)
+}
+func (m *GetSQLChunkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSQLChunkResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSQLChunkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSQLChunkResponse.Merge(m, src)
+}
+func (m *GetSQLChunkResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSQLChunkResponse.Size(m)
+}
+func (m *GetSQLChunkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSQLChunkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSQLChunkResponse proto.InternalMessageInfo
+
+func (m *GetSQLChunkResponse) GetStatus() ResultStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResultStatus_OK
+}
+
+func (m *GetSQLChunkResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetSQLChunkResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *GetSQLChunkResponse) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *GetSQLChunkResponse) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+// CompactBlock is a new block in which the txs are replaced by their short ids,
+// so that the receiver can rebuild the block from the txs in its mempool.
+type CompactBlock struct {
+	BlockHash            []byte       `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Header               *BlockHeader `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	ShortTxIDs           [][]byte     `protobuf:"bytes,3,rep,name=shortTxIDs,proto3" json:"shortTxIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CompactBlock) Reset()         { *m = CompactBlock{} }
+func (m *CompactBlock) String() string { return proto.CompactTextString(m) }
+func (*CompactBlock) ProtoMessage()    {}
+func (*CompactBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7fdddb109e6467a, []int{28}
+}
+
+func (m *CompactBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactBlock.Unmarshal(m, b)
+}
+func (m *CompactBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactBlock.Marshal(b, m, deterministic)
+}
+func (m *CompactBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlock.Merge(m, src)
+}
+func (m *CompactBlock) XXX_Size() int {
+	return xxx_messageInfo_CompactBlock.Size(m)
+}
+func (m *CompactBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlock proto.InternalMessageInfo
+
+func (m *CompactBlock) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetShortTxIDs() [][]byte {
+	if m != nil {
+		return m.ShortTxIDs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("types.ResultStatus", ResultStatus_name, ResultStatus_value)
 	proto.RegisterType((*MsgHeader)(nil), "types.MsgHeader")
@@ -1357,81 +1773,105 @@ func init() {
 	proto.RegisterType((*GetHashByNoResponse)(nil), "types.GetHashByNoResponse")
 	proto.RegisterType((*GetHashesRequest)(nil), "types.GetHashesRequest")
 	proto.RegisterType((*GetHashesResponse)(nil), "types.GetHashesResponse")
+	proto.RegisterType((*GetStateChunkRequest)(nil), "types.GetStateChunkRequest")
+	proto.RegisterType((*StateChunkEntry)(nil), "types.StateChunkEntry")
+	proto.RegisterType((*GetStateChunkResponse)(nil), "types.GetStateChunkResponse")
+	proto.RegisterType((*GetSQLChunkRequest)(nil), "types.GetSQLChunkRequest")
+	proto.RegisterType((*GetSQLChunkResponse)(nil), "types.GetSQLChunkResponse")
+	proto.RegisterType((*CompactBlock)(nil), "types.CompactBlock")
 }
 
 func init() { proto.RegisterFile("p2p.proto", fileDescriptor_e7fdddb109e6467a) }
 
 var fileDescriptor_e7fdddb109e6467a = []byte{
-	// 1126 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xef, 0x72, 0xda, 0x46,
-	0x10, 0xaf, 0x00, 0x63, 0x58, 0xc0, 0x96, 0xcf, 0x4d, 0xa2, 0x71, 0x33, 0x29, 0xa3, 0xc9, 0xb4,
-	0x34, 0xcd, 0x38, 0x1d, 0xe7, 0x09, 0x64, 0x74, 0x01, 0x35, 0x70, 0x62, 0x0e, 0x91, 0xa6, 0xfd,
-	0x42, 0x05, 0x5c, 0x90, 0x5a, 0x5b, 0xa2, 0xba, 0xa3, 0xb1, 0xf3, 0xa5, 0x33, 0xfd, 0xd0, 0x37,
-	0xe8, 0x2b, 0xf4, 0x31, 0xfa, 0x66, 0x9d, 0xe9, 0xdc, 0x49, 0x02, 0x91, 0x3f, 0xf5, 0xd4, 0x93,
-	0x4f, 0xec, 0xee, 0xed, 0xed, 0xfe, 0xf6, 0xb7, 0x7b, 0x8b, 0xa0, 0xbe, 0x3a, 0x5b, 0x9d, 0xae,
-	0x92, 0x58, 0xc4, 0x68, 0x4f, 0x5c, 0xaf, 0x18, 0x3f, 0xd1, 0x67, 0x17, 0xf1, 0xfc, 0xe7, 0x79,
-	0xe0, 0x87, 0x51, 0x7a, 0x70, 0x02, 0x51, 0xbc, 0x60, 0xa9, 0x6c, 0xfe, 0xa3, 0x41, 0x7d, 0xc8,
-	0x97, 0x7d, 0xe6, 0x2f, 0x58, 0x82, 0x1e, 0x42, 0x6b, 0x7e, 0x11, 0xb2, 0x48, 0xbc, 0x60, 0x09,
-	0x0f, 0xe3, 0xc8, 0xd0, 0xda, 0x5a, 0xa7, 0x4e, 0x77, 0x8d, 0xe8, 0x3e, 0xd4, 0x45, 0x78, 0xc9,
-	0xb8, 0xf0, 0x2f, 0x57, 0x46, 0xa9, 0xad, 0x75, 0xca, 0x74, 0x6b, 0x40, 0x07, 0x50, 0x0a, 0x17,
-	0x46, 0x59, 0x5d, 0x2c, 0x85, 0x0b, 0x74, 0x17, 0xaa, 0xcb, 0x98, 0xf3, 0x70, 0x65, 0x54, 0xda,
-	0x5a, 0xa7, 0x46, 0x33, 0x4d, 0xda, 0x57, 0x8c, 0x25, 0x8e, 0x6d, 0xec, 0xb5, 0xb5, 0x4e, 0x93,
-	0x66, 0x1a, 0x7a, 0x00, 0x0a, 0xdf, 0x68, 0x3d, 0x7b, 0xce, 0xae, 0x8d, 0xaa, 0x3a, 0x2b, 0x58,
-	0x10, 0x82, 0x0a, 0x0f, 0x97, 0x91, 0xb1, 0xaf, 0x4e, 0x94, 0x8c, 0xda, 0xd0, 0xe0, 0xeb, 0x99,
-	0xaa, 0x68, 0x1e, 0x5f, 0x18, 0xb5, 0xb6, 0xd6, 0x69, 0xd1, 0xa2, 0x49, 0x66, 0xbb, 0x60, 0xd1,
-	0x52, 0x04, 0x46, 0x5d, 0x1d, 0x66, 0x9a, 0xf9, 0x2d, 0xc0, 0xe8, 0x6c, 0x34, 0x64, 0x9c, 0xfb,
-	0x4b, 0x86, 0x3a, 0x50, 0x0d, 0x14, 0x13, 0xaa, 0xf0, 0xc6, 0x99, 0x7e, 0xaa, 0x38, 0x3c, 0xdd,
-	0x30, 0x44, 0xb3, 0x73, 0x89, 0x62, 0xe1, 0x0b, 0x5f, 0x95, 0xdf, 0xa4, 0x4a, 0x36, 0x5d, 0xa8,
-	0x8c, 0xc2, 0x68, 0x89, 0xbe, 0x80, 0xc3, 0x19, 0xe3, 0x62, 0xaa, 0x88, 0x9f, 0x06, 0x3e, 0x0f,
-	0x54, 0xb8, 0x26, 0x6d, 0x49, 0xf3, 0xb9, 0xb4, 0xf6, 0x7d, 0x1e, 0xa0, 0xcf, 0xa1, 0xa1, 0xfc,
-	0x02, 0x16, 0x2e, 0x03, 0xa1, 0x42, 0x55, 0x28, 0x48, 0x53, 0x5f, 0x59, 0xcc, 0x01, 0x54, 0x46,
-	0x71, 0xb4, 0x94, 0x6d, 0xd9, 0xb9, 0xf9, 0xfe, 0x70, 0x0f, 0xa0, 0x70, 0xf7, 0x3d, 0xd1, 0xde,
-	0x40, 0x75, 0x2c, 0x7c, 0xb1, 0xe6, 0xe8, 0x11, 0x54, 0x39, 0x8b, 0xb6, 0x65, 0xa2, 0xac, 0xcc,
-	0x11, 0x63, 0x89, 0xb5, 0x58, 0x24, 0x8c, 0x73, 0x9a, 0x79, 0xbc, 0x9b, 0xbb, 0x74, 0x73, 0xee,
-	0xf2, 0x3b, 0xb9, 0x3b, 0xd0, 0xec, 0xc5, 0xd6, 0x6b, 0xff, 0x9a, 0xc4, 0x22, 0x9c, 0x33, 0x64,
-	0xc0, 0xfe, 0x65, 0xca, 0x79, 0x36, 0x62, 0xb9, 0x6a, 0xbe, 0x04, 0x3d, 0x83, 0xc0, 0x38, 0x65,
-	0xbf, 0xac, 0x19, 0x17, 0xff, 0x0b, 0xaf, 0x8c, 0xec, 0x5f, 0x8d, 0xc3, 0x37, 0x4c, 0x21, 0x6d,
-	0xd1, 0x5c, 0x35, 0x7f, 0x82, 0xa3, 0x42, 0x64, 0xbe, 0x8a, 0x23, 0xce, 0xd0, 0xd7, 0x50, 0xe5,
-	0x8a, 0x14, 0x15, 0xfa, 0xe0, 0xec, 0x38, 0x0b, 0x4d, 0x19, 0x5f, 0x5f, 0x88, 0x94, 0x2f, 0x9a,
-	0xb9, 0xa0, 0x0e, 0xec, 0xc9, 0x21, 0xe5, 0x46, 0xa9, 0x5d, 0xfe, 0x00, 0x8c, 0xd4, 0xc1, 0xec,
-	0xc3, 0x01, 0x61, 0xaf, 0x15, 0x3f, 0x59, 0xc5, 0xf7, 0xa1, 0x3e, 0x7b, 0xab, 0x7f, 0x5b, 0x83,
-	0x44, 0x3d, 0x4b, 0x9d, 0xb3, 0xc6, 0xe5, 0xaa, 0xf9, 0xbb, 0x06, 0x77, 0x7b, 0x2c, 0xa3, 0x5a,
-	0xcd, 0xde, 0x86, 0x16, 0x04, 0x95, 0xc2, 0x70, 0x29, 0x59, 0xce, 0xf9, 0xce, 0x38, 0x65, 0x9a,
-	0xb4, 0xc7, 0xaf, 0x5e, 0x71, 0x96, 0x37, 0x27, 0xd3, 0xd2, 0xd7, 0xf4, 0x86, 0xa9, 0xb7, 0xd9,
-	0xa2, 0x4a, 0x46, 0x3a, 0x94, 0x7d, 0x3e, 0x57, 0xcf, 0xb2, 0x46, 0xa5, 0x68, 0xfe, 0xa5, 0xc1,
-	0xbd, 0x77, 0x40, 0xdc, 0x86, 0x41, 0x09, 0xcf, 0xe7, 0x01, 0x4b, 0x29, 0x6c, 0xd2, 0x4c, 0x43,
-	0x8f, 0x61, 0x3f, 0x7d, 0x58, 0xdc, 0x28, 0xef, 0x70, 0x5b, 0x48, 0x49, 0x73, 0x17, 0xc9, 0x56,
-	0xe0, 0x73, 0xc2, 0xae, 0x44, 0xb6, 0x53, 0x72, 0xd5, 0xfc, 0x0a, 0x0e, 0x73, 0x9c, 0x39, 0x4b,
-	0xdb, 0x94, 0x5a, 0x31, 0xa5, 0xf9, 0x1b, 0xe8, 0x5b, 0xd7, 0xdb, 0xd4, 0xf2, 0x10, 0xaa, 0xaa,
-	0x49, 0xf9, 0x38, 0x34, 0x8b, 0x90, 0x69, 0x76, 0x56, 0xc4, 0x5a, 0xde, 0xc5, 0xfa, 0x14, 0xee,
-	0x10, 0xf6, 0xda, 0x4b, 0xfc, 0x88, 0xfb, 0x73, 0x11, 0xc6, 0x11, 0xcf, 0x46, 0xe5, 0x04, 0x6a,
-	0xe2, 0xaa, 0x5f, 0xc4, 0xbc, 0xd1, 0xcd, 0x6f, 0xd4, 0x34, 0x14, 0x2f, 0xdd, 0x54, 0xe7, 0x9f,
-	0x69, 0xef, 0x76, 0xaf, 0x7c, 0xcc, 0xde, 0x7d, 0x06, 0x65, 0x71, 0x95, 0xf7, 0xad, 0x9e, 0x45,
-	0xf0, 0xae, 0xa8, 0xb4, 0xfe, 0x47, 0xab, 0x7a, 0x70, 0xd4, 0x63, 0x62, 0x18, 0x72, 0x1e, 0x46,
-	0xcb, 0x1b, 0x8a, 0x90, 0x94, 0x70, 0x11, 0xaf, 0x82, 0xed, 0x02, 0xda, 0xe8, 0xe6, 0x63, 0x40,
-	0x3d, 0x26, 0xac, 0x68, 0xce, 0xb8, 0x88, 0x93, 0x9b, 0xe8, 0xf8, 0x43, 0x83, 0xe3, 0x1d, 0xf7,
-	0xdb, 0x50, 0x61, 0x42, 0xd3, 0xcf, 0x02, 0x14, 0x76, 0xe2, 0x8e, 0x4d, 0xae, 0xc4, 0x5c, 0x27,
-	0x71, 0xbe, 0x12, 0xb7, 0x16, 0xf3, 0x4b, 0x68, 0xf4, 0x98, 0x90, 0xae, 0xe7, 0xd7, 0x24, 0x2e,
-	0x6e, 0x00, 0x6d, 0x77, 0x03, 0xfc, 0xa8, 0x00, 0xe7, 0x8e, 0xb7, 0x03, 0xbc, 0xb3, 0x7d, 0x4a,
-	0x6f, 0x6d, 0x1f, 0x73, 0xa6, 0x9e, 0x42, 0x3a, 0x61, 0x39, 0x7f, 0x27, 0x50, 0x5b, 0x25, 0xec,
-	0xd7, 0xc2, 0xba, 0xda, 0xe8, 0xb2, 0x34, 0x29, 0x93, 0xf5, 0xe5, 0x8c, 0x25, 0xf9, 0x3f, 0xcd,
-	0xd6, 0xb2, 0x59, 0x2a, 0x69, 0xd1, 0x4a, 0x36, 0x13, 0xd5, 0xee, 0x3c, 0xc7, 0xc7, 0x9c, 0xbf,
-	0x0f, 0xbe, 0xb0, 0x47, 0x7f, 0x97, 0xa0, 0x59, 0x0c, 0x85, 0xaa, 0x50, 0x72, 0x9f, 0xeb, 0x9f,
-	0xa0, 0x26, 0xd4, 0xba, 0x16, 0xe9, 0xe2, 0x01, 0xb6, 0x75, 0x0d, 0x35, 0x60, 0x7f, 0x42, 0x9e,
-	0x13, 0xf7, 0x3b, 0xa2, 0x97, 0xd0, 0xa7, 0xa0, 0x3b, 0xe4, 0x85, 0x35, 0x70, 0xec, 0xa9, 0x45,
-	0x7b, 0x93, 0x21, 0x26, 0x9e, 0x5e, 0x46, 0x77, 0xe0, 0xc8, 0xc6, 0x96, 0x3d, 0x70, 0x08, 0x9e,
-	0xe2, 0x97, 0x5d, 0x8c, 0x6d, 0x6c, 0xeb, 0x15, 0xd4, 0x82, 0x3a, 0x71, 0xbd, 0xe9, 0x33, 0x77,
-	0x42, 0x6c, 0x7d, 0x0f, 0x21, 0x38, 0xb0, 0x06, 0x14, 0x5b, 0xf6, 0xf7, 0x53, 0xfc, 0xd2, 0x19,
-	0x7b, 0x63, 0xbd, 0x2a, 0x6f, 0x8e, 0x30, 0x1d, 0x3a, 0xe3, 0xb1, 0xe3, 0x92, 0xa9, 0x8d, 0x89,
-	0x83, 0x6d, 0x7d, 0x1f, 0xdd, 0x05, 0x44, 0xf1, 0xd8, 0x9d, 0xd0, 0xae, 0x0c, 0xd8, 0xb7, 0x26,
-	0x63, 0x0f, 0xdb, 0x7a, 0x0d, 0xdd, 0x83, 0xe3, 0x67, 0x96, 0x33, 0xc0, 0xf6, 0x74, 0x44, 0x71,
-	0xd7, 0x25, 0xb6, 0xe3, 0x39, 0x2e, 0xd1, 0xeb, 0x12, 0xa4, 0x75, 0xee, 0x52, 0xe9, 0x05, 0x48,
-	0x87, 0xa6, 0x3b, 0xf1, 0xa6, 0xee, 0xb3, 0x29, 0xb5, 0x48, 0x0f, 0xeb, 0x0d, 0x74, 0x04, 0xad,
-	0x09, 0x71, 0x86, 0xa3, 0x01, 0x96, 0x88, 0xb1, 0xad, 0x37, 0x65, 0x91, 0x0e, 0xf1, 0x30, 0x25,
-	0xd6, 0x40, 0x6f, 0xa1, 0x43, 0x68, 0x4c, 0x88, 0xf5, 0xc2, 0x72, 0x06, 0xd6, 0xf9, 0x00, 0xeb,
-	0x07, 0x12, 0xbb, 0x6d, 0x79, 0xd6, 0x74, 0xe0, 0x8e, 0xc7, 0xfa, 0x21, 0x3a, 0x86, 0xc3, 0x09,
-	0xb1, 0x26, 0x5e, 0x1f, 0x13, 0xcf, 0xe9, 0x5a, 0x32, 0x84, 0x7e, 0xde, 0xfe, 0xe1, 0xc1, 0x32,
-	0x14, 0xc1, 0x7a, 0x76, 0x3a, 0x8f, 0x2f, 0x9f, 0xf8, 0x2c, 0x59, 0xc6, 0x61, 0x9c, 0xfe, 0x3e,
-	0x51, 0x9d, 0x9a, 0x55, 0xd5, 0x17, 0xd6, 0xd3, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x10, 0xcd,
-	0xed, 0x5c, 0x78, 0x0a, 0x00, 0x00,
+	// 1422 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
+	0x16, 0xbe, 0x94, 0x6c, 0x59, 0x3a, 0xa6, 0x6c, 0x7a, 0x9c, 0x38, 0x82, 0x6f, 0x90, 0x2b, 0x10,
+	0xc1, 0xbd, 0xba, 0x69, 0xe0, 0x14, 0xce, 0x0b, 0x94, 0x16, 0x19, 0x89, 0x8d, 0x3c, 0x52, 0x47,
+	0x52, 0x9a, 0x76, 0xa3, 0x52, 0xd4, 0x44, 0x64, 0x63, 0x91, 0x2a, 0x67, 0x94, 0xc8, 0x41, 0x81,
+	0x02, 0x5d, 0x74, 0xd1, 0x65, 0x81, 0xae, 0xba, 0xef, 0x63, 0xf4, 0x21, 0xfa, 0x3e, 0x05, 0x8a,
+	0x19, 0x0e, 0x25, 0xca, 0xf9, 0x31, 0x6a, 0x64, 0xa5, 0x39, 0x67, 0x0e, 0xcf, 0xcf, 0x77, 0xbe,
+	0x39, 0x33, 0x82, 0xca, 0xfc, 0x74, 0x7e, 0x32, 0x4f, 0x62, 0x1e, 0xa3, 0x6d, 0x7e, 0x39, 0xa7,
+	0xec, 0xd8, 0x18, 0x5f, 0xc4, 0xfe, 0x4b, 0x3f, 0xf0, 0xc2, 0x28, 0xdd, 0x38, 0x86, 0x28, 0x9e,
+	0xd0, 0x74, 0x6d, 0xfe, 0xa5, 0x41, 0xe5, 0x9c, 0x4d, 0xdb, 0xd4, 0x9b, 0xd0, 0x04, 0xdd, 0x87,
+	0xaa, 0x7f, 0x11, 0xd2, 0x88, 0x3f, 0xa3, 0x09, 0x0b, 0xe3, 0xa8, 0xa6, 0xd5, 0xb5, 0x46, 0x85,
+	0x6c, 0x2a, 0xd1, 0x5d, 0xa8, 0xf0, 0x70, 0x46, 0x19, 0xf7, 0x66, 0xf3, 0x5a, 0xa1, 0xae, 0x35,
+	0x8a, 0x64, 0xad, 0x40, 0x7b, 0x50, 0x08, 0x27, 0xb5, 0xa2, 0xfc, 0xb0, 0x10, 0x4e, 0xd0, 0x11,
+	0x94, 0xa6, 0x31, 0x63, 0xe1, 0xbc, 0xb6, 0x55, 0xd7, 0x1a, 0x65, 0xa2, 0x24, 0xa1, 0x9f, 0x53,
+	0x9a, 0xb8, 0x76, 0x6d, 0xbb, 0xae, 0x35, 0x74, 0xa2, 0x24, 0x74, 0x0f, 0x64, 0x7e, 0xbd, 0xc5,
+	0xf8, 0x29, 0xbd, 0xac, 0x95, 0xe4, 0x5e, 0x4e, 0x83, 0x10, 0x6c, 0xb1, 0x70, 0x1a, 0xd5, 0x76,
+	0xe4, 0x8e, 0x5c, 0xa3, 0x3a, 0xec, 0xb2, 0xc5, 0x58, 0x56, 0xe4, 0xc7, 0x17, 0xb5, 0x72, 0x5d,
+	0x6b, 0x54, 0x49, 0x5e, 0x25, 0xa2, 0x5d, 0xd0, 0x68, 0xca, 0x83, 0x5a, 0x45, 0x6e, 0x2a, 0xc9,
+	0xfc, 0x1c, 0xa0, 0x77, 0xda, 0x3b, 0xa7, 0x8c, 0x79, 0x53, 0x8a, 0x1a, 0x50, 0x0a, 0x24, 0x12,
+	0xb2, 0xf0, 0xdd, 0x53, 0xe3, 0x44, 0x62, 0x78, 0xb2, 0x42, 0x88, 0xa8, 0x7d, 0x91, 0xc5, 0xc4,
+	0xe3, 0x9e, 0x2c, 0x5f, 0x27, 0x72, 0x6d, 0x76, 0x61, 0xab, 0x17, 0x46, 0x53, 0xf4, 0x5f, 0xd8,
+	0x1f, 0x53, 0xc6, 0x47, 0x12, 0xf8, 0x51, 0xe0, 0xb1, 0x40, 0xba, 0xd3, 0x49, 0x55, 0xa8, 0xcf,
+	0x84, 0xb6, 0xed, 0xb1, 0x00, 0xfd, 0x07, 0x76, 0xa5, 0x5d, 0x40, 0xc3, 0x69, 0xc0, 0xa5, 0xab,
+	0x2d, 0x02, 0x42, 0xd5, 0x96, 0x1a, 0xb3, 0x03, 0x5b, 0xbd, 0x38, 0x9a, 0x8a, 0xb6, 0x6c, 0x7c,
+	0xf9, 0x6e, 0x77, 0xf7, 0x20, 0xf7, 0xed, 0x3b, 0xbc, 0xfd, 0xa9, 0x41, 0xa9, 0xcf, 0x3d, 0xbe,
+	0x60, 0xe8, 0x01, 0x94, 0x18, 0x8d, 0xd6, 0x75, 0x22, 0x55, 0x67, 0x8f, 0xd2, 0xc4, 0x9a, 0x4c,
+	0x12, 0xca, 0x18, 0x51, 0x16, 0x6f, 0x07, 0x2f, 0x5c, 0x1f, 0xbc, 0x78, 0x35, 0x38, 0xaa, 0xc1,
+	0x8e, 0xa4, 0xa0, 0x6b, 0x4b, 0x1a, 0xe8, 0x24, 0x13, 0xc5, 0xce, 0x94, 0x46, 0x94, 0x85, 0x4c,
+	0x11, 0x21, 0x13, 0x91, 0x09, 0xba, 0x1f, 0xcf, 0xe6, 0x9e, 0x9f, 0xc6, 0x91, 0x5c, 0x28, 0x93,
+	0x0d, 0x9d, 0xd9, 0x00, 0xbd, 0x15, 0x5b, 0xaf, 0xbd, 0x4b, 0x1c, 0xf3, 0xd0, 0xa7, 0xc2, 0xdb,
+	0x2c, 0x6d, 0xa6, 0xe2, 0x6e, 0x26, 0x9a, 0xcf, 0xc1, 0x50, 0xa5, 0x51, 0x46, 0xe8, 0x77, 0x0b,
+	0xca, 0xf8, 0x3f, 0xc2, 0x41, 0x78, 0xf6, 0x96, 0xfd, 0xf0, 0x0d, 0x95, 0x08, 0x54, 0x49, 0x26,
+	0x9a, 0xdf, 0xc2, 0x41, 0xce, 0x33, 0x9b, 0xc7, 0x11, 0xa3, 0xe8, 0x13, 0x28, 0x31, 0x09, 0xb6,
+	0x74, 0xbd, 0x77, 0x7a, 0xa8, 0x5c, 0x13, 0xca, 0x16, 0x17, 0x3c, 0xed, 0x03, 0x51, 0x26, 0xa8,
+	0x01, 0xdb, 0x82, 0xfd, 0xac, 0x56, 0xa8, 0x17, 0xdf, 0x93, 0x46, 0x6a, 0x60, 0xb6, 0x61, 0x0f,
+	0xd3, 0xd7, 0xb2, 0x76, 0x55, 0xf1, 0x5d, 0xa8, 0x8c, 0xaf, 0x10, 0x63, 0xad, 0x10, 0x59, 0x8f,
+	0x53, 0x63, 0xc5, 0x88, 0x4c, 0x34, 0x7f, 0xd4, 0xe0, 0xa8, 0x45, 0x55, 0x0b, 0x25, 0xa9, 0x57,
+	0xb0, 0x20, 0xd8, 0xca, 0xb1, 0x56, 0xae, 0xc5, 0x01, 0xda, 0xe0, 0xa9, 0x92, 0x84, 0x3e, 0x7e,
+	0xf1, 0x82, 0xd1, 0xac, 0xe9, 0x4a, 0x4a, 0x8f, 0xe9, 0x1b, 0x2a, 0xbb, 0x5d, 0x25, 0x72, 0x8d,
+	0x0c, 0x28, 0x7a, 0xcc, 0x97, 0x6d, 0x2e, 0x13, 0xb1, 0x34, 0x7f, 0xd7, 0xe0, 0xce, 0x5b, 0x49,
+	0xdc, 0x04, 0x41, 0x91, 0x9e, 0xc7, 0x02, 0x9a, 0x42, 0xa8, 0x13, 0x25, 0xa1, 0x87, 0xb0, 0x93,
+	0x9e, 0x58, 0x56, 0x2b, 0x6e, 0x60, 0x9b, 0x0b, 0x49, 0x32, 0x13, 0x81, 0x56, 0xe0, 0x31, 0x4c,
+	0x97, 0x5c, 0x0d, 0xab, 0x4c, 0x34, 0xff, 0x0f, 0xfb, 0x59, 0x9e, 0x19, 0x4a, 0xeb, 0x90, 0x5a,
+	0x3e, 0xa4, 0xf9, 0x03, 0x18, 0x6b, 0xd3, 0x9b, 0xd4, 0x72, 0x1f, 0x4a, 0xb2, 0x49, 0x19, 0x1d,
+	0xf4, 0x7c, 0xca, 0x44, 0xed, 0xe5, 0x73, 0x2d, 0x6e, 0xe6, 0xfa, 0x18, 0x6e, 0x63, 0xfa, 0x7a,
+	0x90, 0x78, 0x11, 0xf3, 0x7c, 0x1e, 0xc6, 0x11, 0x53, 0x54, 0x39, 0x86, 0x32, 0x5f, 0xb6, 0xf3,
+	0x39, 0xaf, 0x64, 0x13, 0x4b, 0x36, 0xe4, 0x3f, 0xba, 0xa6, 0xce, 0x4d, 0xe2, 0x15, 0xae, 0x10,
+	0xcf, 0xfc, 0x35, 0xed, 0xec, 0xa6, 0xc3, 0x8f, 0xd9, 0xd9, 0x7f, 0x43, 0x91, 0x2f, 0xb3, 0xae,
+	0x56, 0x94, 0x87, 0xc1, 0x92, 0x08, 0xed, 0x07, 0x1a, 0xd9, 0x82, 0x83, 0x16, 0xe5, 0xe7, 0x21,
+	0x63, 0x61, 0x34, 0xbd, 0xae, 0xc4, 0x63, 0x28, 0x33, 0x1e, 0xcf, 0x83, 0x75, 0x85, 0x2b, 0xd9,
+	0x7c, 0x08, 0xa8, 0x45, 0xb9, 0x15, 0xf9, 0x94, 0xf1, 0x38, 0xb9, 0x8e, 0x14, 0x3f, 0x69, 0x70,
+	0xb8, 0x61, 0x7e, 0x13, 0x28, 0x4c, 0xd0, 0x3d, 0xe5, 0x20, 0x07, 0xfa, 0x86, 0x4e, 0x0c, 0xe2,
+	0x4c, 0xc6, 0x71, 0x36, 0x88, 0xd7, 0x1a, 0xf3, 0x7f, 0xb0, 0xdb, 0xa2, 0x5c, 0x98, 0x9e, 0x5d,
+	0xe2, 0x38, 0x3f, 0x1f, 0xb4, 0xcd, 0xf9, 0xf0, 0x0d, 0x1c, 0xe6, 0x0c, 0x6f, 0x96, 0xf0, 0x87,
+	0x29, 0x32, 0x96, 0x07, 0x25, 0xe5, 0x5f, 0x86, 0xdf, 0x31, 0x94, 0xe7, 0x09, 0x7d, 0x95, 0x1b,
+	0x66, 0x2b, 0x59, 0x94, 0x26, 0xd6, 0x78, 0x31, 0x1b, 0xd3, 0x24, 0xbb, 0xe0, 0xd6, 0x9a, 0xd5,
+	0xc8, 0x49, 0x8b, 0x96, 0x6b, 0x33, 0x91, 0xed, 0xce, 0x62, 0x7c, 0x4c, 0xfe, 0xbd, 0xff, 0xfc,
+	0x7d, 0x0f, 0xb7, 0x5a, 0x54, 0xba, 0xa1, 0xcd, 0x60, 0x11, 0xbd, 0xcc, 0x8d, 0xd5, 0x24, 0x8e,
+	0x79, 0x36, 0x56, 0xc5, 0x1a, 0xdd, 0x82, 0x6d, 0xc6, 0xbd, 0x84, 0x2b, 0x74, 0x52, 0x41, 0xa0,
+	0xe0, 0xf9, 0x7e, 0xbc, 0x88, 0x38, 0x53, 0xce, 0x57, 0xb2, 0x40, 0x61, 0xe6, 0x2d, 0x9d, 0x88,
+	0x27, 0x21, 0x65, 0x6a, 0xbc, 0xe6, 0x34, 0xe6, 0x6f, 0x1a, 0xec, 0xaf, 0x63, 0x0b, 0xed, 0xa5,
+	0x18, 0xbc, 0x2f, 0xe9, 0xa5, 0x0a, 0x2c, 0x96, 0x22, 0xee, 0x2b, 0xef, 0x62, 0x41, 0xb3, 0xb8,
+	0x52, 0x10, 0x19, 0xfa, 0xf1, 0x24, 0x45, 0x50, 0x27, 0x72, 0x2d, 0xea, 0x1f, 0x87, 0x7c, 0xe6,
+	0xcd, 0xd5, 0xc5, 0xad, 0xa4, 0xdc, 0x85, 0xb0, 0x9d, 0xbe, 0xa8, 0x52, 0x49, 0xf4, 0xdc, 0x5b,
+	0x4c, 0x42, 0xde, 0xf3, 0x78, 0x50, 0x2b, 0x49, 0xc8, 0xd6, 0x0a, 0xf3, 0x67, 0x0d, 0x6e, 0x5f,
+	0x01, 0xe7, 0x26, 0x4d, 0xf9, 0x14, 0x76, 0xa8, 0x42, 0x20, 0x9d, 0x91, 0x47, 0xca, 0xfa, 0x4a,
+	0xe5, 0x24, 0x33, 0x13, 0xa5, 0x45, 0x59, 0xaf, 0x74, 0x22, 0xd7, 0xe6, 0x67, 0xf2, 0x08, 0xf7,
+	0xbf, 0xe8, 0x5c, 0x6d, 0x53, 0xe4, 0xcd, 0xb2, 0xf7, 0x83, 0x5c, 0xe7, 0x6e, 0xb9, 0x42, 0xfe,
+	0x96, 0x33, 0x7f, 0x49, 0x8f, 0xf5, 0xda, 0xc5, 0x4d, 0x8a, 0xc9, 0x02, 0x16, 0x72, 0x01, 0xb3,
+	0xf7, 0x65, 0x71, 0xfd, 0xbe, 0xdc, 0xb8, 0x52, 0x15, 0xbf, 0x57, 0x65, 0x6d, 0xab, 0x6f, 0x45,
+	0x59, 0x4b, 0xd0, 0x9b, 0xb9, 0x37, 0xd2, 0x35, 0x2f, 0x84, 0x07, 0xab, 0x37, 0x6f, 0x61, 0xe3,
+	0x0d, 0x94, 0xbf, 0x20, 0x95, 0x85, 0xe0, 0x1e, 0x0b, 0xe2, 0x84, 0x0f, 0x96, 0xae, 0x9d, 0x8e,
+	0x5e, 0x9d, 0xe4, 0x34, 0x0f, 0xfe, 0x28, 0x80, 0x9e, 0x2f, 0x11, 0x95, 0xa0, 0xd0, 0x7d, 0x6a,
+	0xfc, 0x0b, 0xe9, 0x50, 0x6e, 0x5a, 0xb8, 0xe9, 0x74, 0x1c, 0xdb, 0xd0, 0xd0, 0x2e, 0xec, 0x0c,
+	0xf1, 0x53, 0xdc, 0xfd, 0x12, 0x1b, 0x05, 0x74, 0x0b, 0x0c, 0x17, 0x3f, 0xb3, 0x3a, 0xae, 0x3d,
+	0xb2, 0x48, 0x6b, 0x78, 0xee, 0xe0, 0x81, 0x51, 0x44, 0xb7, 0xe1, 0xc0, 0x76, 0x2c, 0xbb, 0xe3,
+	0x62, 0x67, 0xe4, 0x3c, 0x6f, 0x3a, 0x8e, 0xed, 0xd8, 0xc6, 0x16, 0xaa, 0x42, 0x05, 0x77, 0x07,
+	0xa3, 0x27, 0xdd, 0x21, 0xb6, 0x8d, 0x6d, 0x84, 0x60, 0xcf, 0xea, 0x10, 0xc7, 0xb2, 0xbf, 0x1a,
+	0x39, 0xcf, 0xdd, 0xfe, 0xa0, 0x6f, 0x94, 0xc4, 0x97, 0x3d, 0x87, 0x9c, 0xbb, 0xfd, 0xbe, 0xdb,
+	0xc5, 0x23, 0xdb, 0xc1, 0xae, 0x63, 0x1b, 0x3b, 0xe8, 0x08, 0x10, 0x71, 0xfa, 0xdd, 0x21, 0x69,
+	0x0a, 0x87, 0x6d, 0x6b, 0xd8, 0x1f, 0x38, 0xb6, 0x51, 0x46, 0x77, 0xe0, 0xf0, 0x89, 0xe5, 0x76,
+	0x1c, 0x7b, 0xd4, 0x23, 0x4e, 0xb3, 0x8b, 0x6d, 0x77, 0xe0, 0x76, 0xb1, 0x51, 0x11, 0x49, 0x5a,
+	0x67, 0x5d, 0x22, 0xac, 0x00, 0x19, 0xa0, 0x77, 0x87, 0x83, 0x51, 0xf7, 0xc9, 0x88, 0x58, 0xb8,
+	0xe5, 0x18, 0xbb, 0xe8, 0x00, 0xaa, 0x43, 0xec, 0x9e, 0xf7, 0x3a, 0x8e, 0xc8, 0xd8, 0xb1, 0x0d,
+	0x5d, 0x14, 0xe9, 0xe2, 0x81, 0x43, 0xb0, 0xd5, 0x31, 0xaa, 0x68, 0x1f, 0x76, 0x87, 0xd8, 0x7a,
+	0x66, 0xb9, 0x1d, 0xeb, 0xac, 0xe3, 0x18, 0x7b, 0x22, 0x77, 0xdb, 0x1a, 0x58, 0xa3, 0x4e, 0xb7,
+	0xdf, 0x37, 0xf6, 0xd1, 0x21, 0xec, 0x0f, 0xb1, 0x35, 0x1c, 0xb4, 0x1d, 0x3c, 0x70, 0x9b, 0x96,
+	0x70, 0x61, 0x9c, 0xd5, 0xbf, 0xbe, 0x37, 0x0d, 0x79, 0xb0, 0x18, 0x9f, 0xf8, 0xf1, 0xec, 0x91,
+	0x47, 0x93, 0x69, 0x1c, 0xc6, 0xe9, 0xef, 0x23, 0xd9, 0x96, 0x71, 0x49, 0xfe, 0xa5, 0x79, 0xfc,
+	0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8a, 0x98, 0x33, 0x18, 0xe9, 0x0d, 0x00, 0x00,
 }
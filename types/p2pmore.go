@@ -6,12 +6,31 @@
 package types
 
 import (
+	"encoding/binary"
 	"net"
 	"strconv"
 
+	"github.com/aergoio/aergo/internal/common"
 	"github.com/libp2p/go-libp2p-peer"
 )
 
+// ShortTxIDLength is the length of the short id of a tx in compact block
+const ShortTxIDLength = 8
+
+// ShortTxID returns the short id of the tx of txHash in the compact block of
+// blockHash. It is the SipHash of the tx hash keyed by the block hash, so that
+// the txs whose short ids collide can't be made before the block is made.
+func ShortTxID(blockHash, txHash []byte) []byte {
+	var key [16]byte
+	copy(key[:], blockHash)
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:])
+
+	id := make([]byte, ShortTxIDLength)
+	binary.LittleEndian.PutUint64(id, common.SipHash24(k0, k1, txHash))
+	return id
+}
+
 // AddressesToStringMap make map of string for logging or json encoding
 func AddressesToStringMap(addrs []*PeerAddress) []map[string]string {
 	arr := make([]map[string]string, len(addrs))
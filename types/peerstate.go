@@ -17,6 +17,8 @@ const (
 	DOWN
 	// STOPPED is totally finished peer, and maybe local server is shutting down.
 	STOPPED
+	// BANNED means remote peer is refused to connect for a while because of its misbehaviors
+	BANNED
 )
 
 // Get returns current state with concurrent manner
@@ -4,9 +4,9 @@ package types
 
 import "strconv"
 
-const _PeerState_name = "STARTINGHANDSHAKINGRUNNINGDOWNSTOPPED"
+const _PeerState_name = "STARTINGHANDSHAKINGRUNNINGDOWNSTOPPEDBANNED"
 
-var _PeerState_index = [...]uint8{0, 8, 19, 26, 30, 37}
+var _PeerState_index = [...]uint8{0, 8, 19, 26, 30, 37, 43}
 
 func (i PeerState) String() string {
 	if i < 0 || i >= PeerState(len(_PeerState_index)-1) {
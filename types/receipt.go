@@ -4,13 +4,25 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"strconv"
 	"strings"
 
+	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/internal/merkle"
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/minio/sha256-simd"
 )
 
+// The statuses of the receipts of the txs aborted by the limits of the VM and
+// of the contract calls
+const (
+	ReceiptInstructionLimit = "INSTRUCTION_LIMIT"
+	ReceiptMemoryLimit      = "MEMORY_LIMIT"
+	ReceiptCallDepth        = "CALL_DEPTH"
+	ReceiptReentrancy       = "REENTRANCY"
+)
+
 func NewReceipt(contractAddress []byte, status string, jsonRet string) *Receipt {
 	return &Receipt{
 		ContractAddress: contractAddress[:33],
@@ -19,7 +31,62 @@ func NewReceipt(contractAddress []byte, status string, jsonRet string) *Receipt
 	}
 }
 
+// receiptVersion1 is the first byte of the binary encoding of a receipt with
+// events. A receipt without events is encoded as before, so its hash and the
+// receipts root of the blocks made before are not changed, and is decoded by
+// unmarshalLegacyBinary. The version cannot be confused with the first byte of
+// an address.
+const receiptVersion1 = 0x01
+
+var errReceiptFormat = errors.New("invalid receipt format")
+
 func (r Receipt) MarshalBinary() ([]byte, error) {
+	if len(r.Events) == 0 {
+		return r.marshalLegacyBinary(), nil
+	}
+	var b bytes.Buffer
+	l := make([]byte, 4)
+	b.WriteByte(receiptVersion1)
+	b.Write(r.ContractAddress)
+	binary.LittleEndian.PutUint16(l[:2], uint16(len(r.Status)))
+	b.Write(l[:2])
+	b.WriteString(r.Status)
+	binary.LittleEndian.PutUint32(l, uint32(len(r.Ret)))
+	b.Write(l)
+	b.WriteString(r.Ret)
+	binary.LittleEndian.PutUint32(l, uint32(len(r.Events)))
+	b.Write(l)
+	for _, ev := range r.Events {
+		ev.marshalBinary(&b)
+	}
+	return b.Bytes(), nil
+}
+
+func (r *Receipt) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errReceiptFormat
+	}
+	if data[0] != receiptVersion1 {
+		return r.unmarshalLegacyBinary(data)
+	}
+	d := binaryDecoder{data: data, pos: 1}
+	r.ContractAddress = d.bytes(33)
+	r.Status = string(d.bytes(uint32(d.uint16())))
+	r.Ret = string(d.bytes(d.uint32()))
+	n := d.uint32()
+	r.Events = nil
+	for i := uint32(0); i < n && d.err == nil; i++ {
+		ev := &Event{EventIdx: int32(i)}
+		ev.unmarshalBinary(&d)
+		r.Events = append(r.Events, ev)
+	}
+	if d.err == nil && d.pos != uint32(len(data)) {
+		d.err = errReceiptFormat
+	}
+	return d.err
+}
+
+func (r Receipt) marshalLegacyBinary() []byte {
 	var b bytes.Buffer
 	l := make([]byte, 2)
 	b.Write(r.ContractAddress)
@@ -27,15 +94,72 @@ func (r Receipt) MarshalBinary() ([]byte, error) {
 	b.Write(l)
 	b.WriteString(r.Status)
 	b.WriteString(r.Ret)
-	return b.Bytes(), nil
+	return b.Bytes()
 }
 
-func (r *Receipt) UnmarshalBinary(data []byte) error {
-	r.ContractAddress = data[:33]
-	l := binary.LittleEndian.Uint16(data[33:])
-	r.Status = string(data[35 : 35+l])
-	r.Ret = string(data[35+l:])
-	return nil
+// unmarshalLegacyBinary decodes a receipt without events, which has no version
+// byte.
+func (r *Receipt) unmarshalLegacyBinary(data []byte) error {
+	d := binaryDecoder{data: data}
+	r.ContractAddress = d.bytes(33)
+	r.Status = string(d.bytes(uint32(d.uint16())))
+	r.Ret = string(d.bytes(uint32(len(data)) - d.pos))
+	r.Events = nil
+	return d.err
+}
+
+// marshalBinary writes the fields of the event which are set by a contract.
+// The location of the event (tx, block) is derived from the receipt.
+func (ev *Event) marshalBinary(b *bytes.Buffer) {
+	l := make([]byte, 4)
+	b.Write(ev.ContractAddress)
+	binary.LittleEndian.PutUint16(l[:2], uint16(len(ev.EventName)))
+	b.Write(l[:2])
+	b.WriteString(ev.EventName)
+	binary.LittleEndian.PutUint32(l, uint32(len(ev.JsonArgs)))
+	b.Write(l)
+	b.WriteString(ev.JsonArgs)
+}
+
+func (ev *Event) unmarshalBinary(d *binaryDecoder) {
+	ev.ContractAddress = d.bytes(33)
+	ev.EventName = string(d.bytes(uint32(d.uint16())))
+	ev.JsonArgs = string(d.bytes(d.uint32()))
+}
+
+// binaryDecoder reads the fields of a binary encoding. Once the data is
+// exhausted, err is set and the following reads return zero values.
+type binaryDecoder struct {
+	data []byte
+	pos  uint32
+	err  error
+}
+
+func (d *binaryDecoder) bytes(n uint32) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if uint64(d.pos)+uint64(n) > uint64(len(d.data)) {
+		d.err = errReceiptFormat
+		return nil
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *binaryDecoder) uint16() uint16 {
+	if b := d.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *binaryDecoder) uint32() uint32 {
+	if b := d.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
 }
 
 func (r Receipt) MarshalJSONPB(*jsonpb.Marshaler) ([]byte, error) {
@@ -49,12 +173,54 @@ func (r Receipt) MarshalJSON() ([]byte, error) {
 	b.WriteString(`","status":"`)
 	b.WriteString(strings.Replace(r.Status, "\"", "'", -1))
 	if len(r.Ret) == 0 {
-		b.WriteString(`","ret": {}}`)
+		b.WriteString(`","ret": {}`)
 	} else {
 		b.WriteString(`","ret": `)
 		b.WriteString(r.Ret)
-		b.WriteString(`}`)
 	}
+	if len(r.Events) != 0 {
+		b.WriteString(`,"events":[`)
+		for i, ev := range r.Events {
+			if i > 0 {
+				b.WriteString(`,`)
+			}
+			evJSON, _ := ev.MarshalJSON()
+			b.Write(evJSON)
+		}
+		b.WriteString(`]`)
+	}
+	b.WriteString(`}`)
+	return b.Bytes(), nil
+}
+
+func (ev *Event) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(`{"contractAddress":"`)
+	b.WriteString(EncodeAddress(ev.ContractAddress))
+	b.WriteString(`","eventName":"`)
+	b.WriteString(strings.Replace(ev.EventName, "\"", "'", -1))
+	b.WriteString(`","args":`)
+	if len(ev.JsonArgs) == 0 {
+		b.WriteString(`[]`)
+	} else {
+		b.WriteString(ev.JsonArgs)
+	}
+	b.WriteString(`,"eventIdx":`)
+	b.WriteString(strconv.Itoa(int(ev.EventIdx)))
+	if len(ev.TxHash) != 0 {
+		b.WriteString(`,"txHash":"`)
+		b.WriteString(enc.ToString(ev.TxHash))
+		b.WriteString(`","blockHash":"`)
+		b.WriteString(enc.ToString(ev.BlockHash))
+		b.WriteString(`","blockNo":`)
+		b.WriteString(strconv.FormatUint(ev.BlockNo, 10))
+		b.WriteString(`,"txIndex":`)
+		b.WriteString(strconv.Itoa(int(ev.TxIndex)))
+	}
+	if ev.Removed {
+		b.WriteString(`,"removed":true`)
+	}
+	b.WriteString(`}`)
 	return b.Bytes(), nil
 }
 
@@ -65,8 +231,33 @@ func (r Receipt) GetHash() []byte {
 	return h.Sum(nil)
 }
 
+// MatchFilter reports whether the event is of the contract and the event name
+// of filter. An empty event name matches any event.
+func (ev *Event) MatchFilter(filter *FilterInfo) bool {
+	if !bytes.Equal(ev.ContractAddress, filter.ContractAddress) {
+		return false
+	}
+	return len(filter.EventName) == 0 || ev.EventName == filter.EventName
+}
+
 type Receipts []*Receipt
 
+// Bloom returns the bloom filter of the events in the receipts. It is nil when
+// no event is emitted.
+func (rs Receipts) Bloom() []byte {
+	var bloom []byte
+	for _, r := range rs {
+		for _, ev := range r.Events {
+			if bloom == nil {
+				bloom = make([]byte, BloomByteLength)
+			}
+			BloomAdd(bloom, ev.ContractAddress)
+			BloomAdd(bloom, eventBloomKey(ev.ContractAddress, ev.EventName))
+		}
+	}
+	return bloom
+}
+
 func (rs Receipts) MerkleRoot() []byte {
 	mes := make([]merkle.MerkleEntry, len(rs))
 	for i, r := range rs {
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiptBinary(t *testing.T) {
+	contract := make([]byte, 33)
+	contract[0] = 0x0C
+	r := NewReceipt(contract, "SUCCESS", `{"ok":true}`)
+	r.Events = []*Event{
+		{ContractAddress: contract, EventName: "transfer", JsonArgs: `["a","b",10]`},
+		{ContractAddress: contract, EventName: "approve", EventIdx: 1},
+	}
+
+	b, err := r.MarshalBinary()
+	assert.Nil(t, err)
+
+	var decoded Receipt
+	assert.Nil(t, decoded.UnmarshalBinary(b))
+	assert.Equal(t, r.ContractAddress, decoded.ContractAddress)
+	assert.Equal(t, r.Status, decoded.Status)
+	assert.Equal(t, r.Ret, decoded.Ret)
+	assert.Equal(t, 2, len(decoded.Events))
+	for i, ev := range decoded.Events {
+		assert.Equal(t, int32(i), ev.EventIdx)
+		assert.Equal(t, r.Events[i].EventName, ev.EventName)
+		assert.Equal(t, r.Events[i].JsonArgs, ev.JsonArgs)
+	}
+	assert.Equal(t, r.GetHash(), decoded.GetHash())
+}
+
+func TestReceiptsBloom(t *testing.T) {
+	c1 := append([]byte{0x0C}, make([]byte, 32)...)
+	c2 := append([]byte{0x0D}, make([]byte, 32)...)
+
+	rs := Receipts{NewReceipt(c1, "SUCCESS", "")}
+	assert.Nil(t, rs.Bloom())
+
+	rs[0].Events = []*Event{{ContractAddress: c1, EventName: "transfer"}}
+	bloom := rs.Bloom()
+	assert.Equal(t, BloomByteLength, len(bloom))
+	assert.True(t, BloomMatchEvent(bloom, c1, ""))
+	assert.True(t, BloomMatchEvent(bloom, c1, "transfer"))
+	assert.False(t, BloomMatchEvent(bloom, c2, ""))
+	assert.False(t, BloomMatchEvent(nil, c1, ""))
+}
+
+func TestReceiptBinaryLegacy(t *testing.T) {
+	contract := make([]byte, 33)
+	contract[0] = 0x0C
+	legacy := append(append(contract, 7, 0), []byte(`SUCCESS{"ok":true}`)...)
+
+	var decoded Receipt
+	assert.Nil(t, decoded.UnmarshalBinary(legacy))
+	assert.Equal(t, contract, decoded.ContractAddress)
+	assert.Equal(t, "SUCCESS", decoded.Status)
+	assert.Equal(t, `{"ok":true}`, decoded.Ret)
+	assert.Nil(t, decoded.Events)
+}
+
+func TestReceiptsRootLegacy(t *testing.T) {
+	contract := make([]byte, 33)
+	contract[0] = 0x0C
+	account := make([]byte, 33)
+	account[0] = 0x02
+	rs := Receipts{
+		NewReceipt(contract, "CREATED", `{}`),
+		NewReceipt(contract, "SUCCESS", `{"ok":true}`),
+		NewReceipt(account, "SUCCESS", ""),
+	}
+	// the root of the receipts without events is the same as that of the
+	// blocks made before the events were added
+	assert.Equal(t, "7efc1c09ef25e23bc8ef950191c460494375eb496e0a491228448c568d32f4c9",
+		hex.EncodeToString(rs.MerkleRoot()))
+
+	b, err := rs[1].MarshalBinary()
+	assert.Nil(t, err)
+	var decoded Receipt
+	assert.Nil(t, decoded.UnmarshalBinary(b))
+	assert.Equal(t, rs[1].Ret, decoded.Ret)
+}
+
+func TestReceiptBinaryTruncated(t *testing.T) {
+	contract := make([]byte, 33)
+	contract[0] = 0x0C
+	r := NewReceipt(contract, "SUCCESS", `{"ok":true}`)
+	r.Events = []*Event{{ContractAddress: contract, EventName: "transfer", JsonArgs: `[1]`}}
+	b, err := r.MarshalBinary()
+	assert.Nil(t, err)
+
+	var decoded Receipt
+	for i := 0; i < len(b); i++ {
+		assert.NotNil(t, decoded.UnmarshalBinary(b[:i]), "length %d", i)
+	}
+	assert.NotNil(t, decoded.UnmarshalBinary(append(b, 0)))
+	// a huge event count must not be trusted
+	b[len(b)-len(contract)-2-len("transfer")-4-len("[1]")-4] = 0xff
+	assert.NotNil(t, decoded.UnmarshalBinary(b))
+}
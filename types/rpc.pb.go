@@ -35,18 +35,20 @@ const (
 	CommitStatus_TX_INSUFFICIENT_BALANCE CommitStatus = 6
 	CommitStatus_TX_HAS_SAME_NONCE       CommitStatus = 7
 	CommitStatus_TX_INTERNAL_ERROR       CommitStatus = 9
+	CommitStatus_TX_POOL_FULL            CommitStatus = 10
 )
 
 var CommitStatus_name = map[int32]string{
-	0: "TX_OK",
-	1: "TX_NONCE_TOO_LOW",
-	2: "TX_ALREADY_EXISTS",
-	3: "TX_INVALID_HASH",
-	4: "TX_INVALID_SIGN",
-	5: "TX_INVALID_FORMAT",
-	6: "TX_INSUFFICIENT_BALANCE",
-	7: "TX_HAS_SAME_NONCE",
-	9: "TX_INTERNAL_ERROR",
+	0:  "TX_OK",
+	1:  "TX_NONCE_TOO_LOW",
+	2:  "TX_ALREADY_EXISTS",
+	3:  "TX_INVALID_HASH",
+	4:  "TX_INVALID_SIGN",
+	5:  "TX_INVALID_FORMAT",
+	6:  "TX_INSUFFICIENT_BALANCE",
+	7:  "TX_HAS_SAME_NONCE",
+	9:  "TX_INTERNAL_ERROR",
+	10: "TX_POOL_FULL",
 }
 var CommitStatus_value = map[string]int32{
 	"TX_OK":                   0,
@@ -58,6 +60,7 @@ var CommitStatus_value = map[string]int32{
 	"TX_INSUFFICIENT_BALANCE": 6,
 	"TX_HAS_SAME_NONCE":       7,
 	"TX_INTERNAL_ERROR":       9,
+	"TX_POOL_FULL":            10,
 }
 
 func (x CommitStatus) String() string {
@@ -97,6 +100,7 @@ func (VerifyStatus) EnumDescriptor() ([]byte, []int) {
 type BlockchainStatus struct {
 	BestBlockHash        []byte   `protobuf:"bytes,1,opt,name=best_block_hash,json=bestBlockHash,proto3" json:"best_block_hash,omitempty"`
 	BestHeight           uint64   `protobuf:"varint,2,opt,name=best_height,json=bestHeight,proto3" json:"best_height,omitempty"`
+	ChainIdHash          []byte   `protobuf:"bytes,3,opt,name=chain_id_hash,json=chainIdHash,proto3" json:"chain_id_hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -140,6 +144,13 @@ func (m *BlockchainStatus) GetBestHeight() uint64 {
 	return 0
 }
 
+func (m *BlockchainStatus) GetChainIdHash() []byte {
+	if m != nil {
+		return m.ChainIdHash
+	}
+	return nil
+}
+
 type Input struct {
 	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Address              [][]byte `protobuf:"bytes,2,rep,name=address,proto3" json:"address,omitempty"`
@@ -390,6 +401,8 @@ type Peer struct {
 	Address              *PeerAddress    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Bestblock            *NewBlockNotice `protobuf:"bytes,2,opt,name=bestblock,proto3" json:"bestblock,omitempty"`
 	State                int32           `protobuf:"varint,3,opt,name=state,proto3" json:"state,omitempty"`
+	Score                int32           `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	BannedUntil          int64           `protobuf:"varint,5,opt,name=bannedUntil,proto3" json:"bannedUntil,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -440,6 +453,20 @@ func (m *Peer) GetState() int32 {
 	return 0
 }
 
+func (m *Peer) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Peer) GetBannedUntil() int64 {
+	if m != nil {
+		return m.BannedUntil
+	}
+	return 0
+}
+
 type PeerList struct {
 	Peers                []*Peer  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -954,6 +981,431 @@ func (m *VoteList) GetVotes() []*Vote {
 	return nil
 }
 
+type BlockStreamRequest struct {
+	FromBlockNo          uint64   `protobuf:"varint,1,opt,name=fromBlockNo,proto3" json:"fromBlockNo,omitempty"`
+	FromBest             bool     `protobuf:"varint,2,opt,name=fromBest,proto3" json:"fromBest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockStreamRequest) Reset()         { *m = BlockStreamRequest{} }
+func (m *BlockStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockStreamRequest) ProtoMessage()    {}
+func (*BlockStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_f4581f16e5715b6f, []int{18}
+}
+
+func (m *BlockStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockStreamRequest.Unmarshal(m, b)
+}
+func (m *BlockStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockStreamRequest.Marshal(b, m, deterministic)
+}
+func (dst *BlockStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockStreamRequest.Merge(dst, src)
+}
+func (m *BlockStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_BlockStreamRequest.Size(m)
+}
+func (m *BlockStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockStreamRequest proto.InternalMessageInfo
+
+func (m *BlockStreamRequest) GetFromBlockNo() uint64 {
+	if m != nil {
+		return m.FromBlockNo
+	}
+	return 0
+}
+
+func (m *BlockStreamRequest) GetFromBest() bool {
+	if m != nil {
+		return m.FromBest
+	}
+	return false
+}
+
+type TxStreamRequest struct {
+	Account              []byte   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	FromBlockNo          uint64   `protobuf:"varint,2,opt,name=fromBlockNo,proto3" json:"fromBlockNo,omitempty"`
+	FromBest             bool     `protobuf:"varint,3,opt,name=fromBest,proto3" json:"fromBest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxStreamRequest) Reset()         { *m = TxStreamRequest{} }
+func (m *TxStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*TxStreamRequest) ProtoMessage()    {}
+func (*TxStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_f4581f16e5715b6f, []int{19}
+}
+
+func (m *TxStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxStreamRequest.Unmarshal(m, b)
+}
+func (m *TxStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxStreamRequest.Marshal(b, m, deterministic)
+}
+func (dst *TxStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxStreamRequest.Merge(dst, src)
+}
+func (m *TxStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_TxStreamRequest.Size(m)
+}
+func (m *TxStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxStreamRequest proto.InternalMessageInfo
+
+func (m *TxStreamRequest) GetAccount() []byte {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *TxStreamRequest) GetFromBlockNo() uint64 {
+	if m != nil {
+		return m.FromBlockNo
+	}
+	return 0
+}
+
+func (m *TxStreamRequest) GetFromBest() bool {
+	if m != nil {
+		return m.FromBest
+	}
+	return false
+}
+
+type TxInclusionRequest struct {
+	Hashes               [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	FromBlockNo          uint64   `protobuf:"varint,2,opt,name=fromBlockNo,proto3" json:"fromBlockNo,omitempty"`
+	FromBest             bool     `protobuf:"varint,3,opt,name=fromBest,proto3" json:"fromBest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxInclusionRequest) Reset()         { *m = TxInclusionRequest{} }
+func (m *TxInclusionRequest) String() string { return proto.CompactTextString(m) }
+func (*TxInclusionRequest) ProtoMessage()    {}
+func (*TxInclusionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_f4581f16e5715b6f, []int{20}
+}
+
+func (m *TxInclusionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxInclusionRequest.Unmarshal(m, b)
+}
+func (m *TxInclusionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxInclusionRequest.Marshal(b, m, deterministic)
+}
+func (dst *TxInclusionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxInclusionRequest.Merge(dst, src)
+}
+func (m *TxInclusionRequest) XXX_Size() int {
+	return xxx_messageInfo_TxInclusionRequest.Size(m)
+}
+func (m *TxInclusionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxInclusionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxInclusionRequest proto.InternalMessageInfo
+
+func (m *TxInclusionRequest) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func (m *TxInclusionRequest) GetFromBlockNo() uint64 {
+	if m != nil {
+		return m.FromBlockNo
+	}
+	return 0
+}
+
+type BlockHeaderNotice struct {
+	Hash                 []byte       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Header               *BlockHeader `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	Removed              bool         `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *BlockHeaderNotice) Reset()         { *m = BlockHeaderNotice{} }
+func (m *BlockHeaderNotice) String() string { return proto.CompactTextString(m) }
+func (*BlockHeaderNotice) ProtoMessage()    {}
+func (*BlockHeaderNotice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_f4581f16e5715b6f, []int{21}
+}
+
+func (m *BlockHeaderNotice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeaderNotice.Unmarshal(m, b)
+}
+func (m *BlockHeaderNotice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeaderNotice.Marshal(b, m, deterministic)
+}
+func (dst *BlockHeaderNotice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeaderNotice.Merge(dst, src)
+}
+func (m *BlockHeaderNotice) XXX_Size() int {
+	return xxx_messageInfo_BlockHeaderNotice.Size(m)
+}
+func (m *BlockHeaderNotice) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeaderNotice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeaderNotice proto.InternalMessageInfo
+
+func (m *BlockHeaderNotice) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *BlockHeaderNotice) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *BlockHeaderNotice) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+type AddrBookEntry struct {
+	Address              *PeerAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Source               string       `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	LastSeen             int64        `protobuf:"varint,3,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	LastAttempt          int64        `protobuf:"varint,4,opt,name=lastAttempt,proto3" json:"lastAttempt,omitempty"`
+	Successes            uint32       `protobuf:"varint,5,opt,name=successes,proto3" json:"successes,omitempty"`
+	Failures             uint32       `protobuf:"varint,6,opt,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *AddrBookEntry) Reset()         { *m = AddrBookEntry{} }
+func (m *AddrBookEntry) String() string { return proto.CompactTextString(m) }
+func (*AddrBookEntry) ProtoMessage()    {}
+func (*AddrBookEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_f4581f16e5715b6f, []int{22}
+}
+
+func (m *AddrBookEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddrBookEntry.Unmarshal(m, b)
+}
+func (m *AddrBookEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddrBookEntry.Marshal(b, m, deterministic)
+}
+func (dst *AddrBookEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddrBookEntry.Merge(dst, src)
+}
+func (m *AddrBookEntry) XXX_Size() int {
+	return xxx_messageInfo_AddrBookEntry.Size(m)
+}
+func (m *AddrBookEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddrBookEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddrBookEntry proto.InternalMessageInfo
+
+func (m *AddrBookEntry) GetAddress() *PeerAddress {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *AddrBookEntry) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *AddrBookEntry) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *AddrBookEntry) GetLastAttempt() int64 {
+	if m != nil {
+		return m.LastAttempt
+	}
+	return 0
+}
+
+func (m *AddrBookEntry) GetSuccesses() uint32 {
+	if m != nil {
+		return m.Successes
+	}
+	return 0
+}
+
+func (m *AddrBookEntry) GetFailures() uint32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+type AddrBook struct {
+	Entries              []*AddrBookEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *AddrBook) Reset()         { *m = AddrBook{} }
+func (m *AddrBook) String() string { return proto.CompactTextString(m) }
+func (*AddrBook) ProtoMessage()    {}
+func (*AddrBook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_f4581f16e5715b6f, []int{23}
+}
+
+func (m *AddrBook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddrBook.Unmarshal(m, b)
+}
+func (m *AddrBook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddrBook.Marshal(b, m, deterministic)
+}
+func (dst *AddrBook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddrBook.Merge(dst, src)
+}
+func (m *AddrBook) XXX_Size() int {
+	return xxx_messageInfo_AddrBook.Size(m)
+}
+func (m *AddrBook) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddrBook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddrBook proto.InternalMessageInfo
+
+func (m *AddrBook) GetEntries() []*AddrBookEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type AddrBookPruneRequest struct {
+	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	MaxAge               int64    `protobuf:"varint,2,opt,name=maxAge,proto3" json:"maxAge,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddrBookPruneRequest) Reset()         { *m = AddrBookPruneRequest{} }
+func (m *AddrBookPruneRequest) String() string { return proto.CompactTextString(m) }
+func (*AddrBookPruneRequest) ProtoMessage()    {}
+func (*AddrBookPruneRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_f4581f16e5715b6f, []int{24}
+}
+
+func (m *AddrBookPruneRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddrBookPruneRequest.Unmarshal(m, b)
+}
+func (m *AddrBookPruneRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddrBookPruneRequest.Marshal(b, m, deterministic)
+}
+func (dst *AddrBookPruneRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddrBookPruneRequest.Merge(dst, src)
+}
+func (m *AddrBookPruneRequest) XXX_Size() int {
+	return xxx_messageInfo_AddrBookPruneRequest.Size(m)
+}
+func (m *AddrBookPruneRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddrBookPruneRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddrBookPruneRequest proto.InternalMessageInfo
+
+func (m *AddrBookPruneRequest) GetPeerID() []byte {
+	if m != nil {
+		return m.PeerID
+	}
+	return nil
+}
+
+func (m *AddrBookPruneRequest) GetMaxAge() int64 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+type PeerAllowlist struct {
+	Permissioned         bool     `protobuf:"varint,1,opt,name=permissioned,proto3" json:"permissioned,omitempty"`
+	ConfigPeers          [][]byte `protobuf:"bytes,2,rep,name=configPeers,proto3" json:"configPeers,omitempty"`
+	ChainPeers           [][]byte `protobuf:"bytes,3,rep,name=chainPeers,proto3" json:"chainPeers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerAllowlist) Reset()         { *m = PeerAllowlist{} }
+func (m *PeerAllowlist) String() string { return proto.CompactTextString(m) }
+func (*PeerAllowlist) ProtoMessage()    {}
+func (*PeerAllowlist) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_f4581f16e5715b6f, []int{25}
+}
+
+func (m *PeerAllowlist) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerAllowlist.Unmarshal(m, b)
+}
+func (m *PeerAllowlist) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerAllowlist.Marshal(b, m, deterministic)
+}
+func (dst *PeerAllowlist) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerAllowlist.Merge(dst, src)
+}
+func (m *PeerAllowlist) XXX_Size() int {
+	return xxx_messageInfo_PeerAllowlist.Size(m)
+}
+func (m *PeerAllowlist) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerAllowlist.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerAllowlist proto.InternalMessageInfo
+
+func (m *PeerAllowlist) GetPermissioned() bool {
+	if m != nil {
+		return m.Permissioned
+	}
+	return false
+}
+
+func (m *PeerAllowlist) GetConfigPeers() [][]byte {
+	if m != nil {
+		return m.ConfigPeers
+	}
+	return nil
+}
+
+func (m *PeerAllowlist) GetChainPeers() [][]byte {
+	if m != nil {
+		return m.ChainPeers
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockchainStatus)(nil), "types.BlockchainStatus")
 	proto.RegisterType((*Input)(nil), "types.Input")
@@ -973,6 +1425,14 @@ func init() {
 	proto.RegisterType((*Staking)(nil), "types.Staking")
 	proto.RegisterType((*Vote)(nil), "types.Vote")
 	proto.RegisterType((*VoteList)(nil), "types.VoteList")
+	proto.RegisterType((*BlockStreamRequest)(nil), "types.BlockStreamRequest")
+	proto.RegisterType((*TxStreamRequest)(nil), "types.TxStreamRequest")
+	proto.RegisterType((*TxInclusionRequest)(nil), "types.TxInclusionRequest")
+	proto.RegisterType((*BlockHeaderNotice)(nil), "types.BlockHeaderNotice")
+	proto.RegisterType((*AddrBookEntry)(nil), "types.AddrBookEntry")
+	proto.RegisterType((*AddrBook)(nil), "types.AddrBook")
+	proto.RegisterType((*AddrBookPruneRequest)(nil), "types.AddrBookPruneRequest")
+	proto.RegisterType((*PeerAllowlist)(nil), "types.PeerAllowlist")
 	proto.RegisterEnum("types.CommitStatus", CommitStatus_name, CommitStatus_value)
 	proto.RegisterEnum("types.VerifyStatus", VerifyStatus_name, VerifyStatus_value)
 }
@@ -1014,8 +1474,18 @@ type AergoRPCServiceClient interface {
 	QueryContract(ctx context.Context, in *Query, opts ...grpc.CallOption) (*SingleBytes, error)
 	QueryContractState(ctx context.Context, in *StateQuery, opts ...grpc.CallOption) (*StateQueryProof, error)
 	GetPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerList, error)
+	GetAddrBook(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AddrBook, error)
+	PruneAddrBook(ctx context.Context, in *AddrBookPruneRequest, opts ...grpc.CallOption) (*AddrBook, error)
+	GetPeerAllowlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerAllowlist, error)
 	GetVotes(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*VoteList, error)
 	GetStaking(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*Staking, error)
+	ListEvents(ctx context.Context, in *FilterInfo, opts ...grpc.CallOption) (*EventList, error)
+	TraceTx(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*TxTrace, error)
+	SimulateTx(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*TxSimulation, error)
+	ListBlockHeaderStream(ctx context.Context, in *BlockStreamRequest, opts ...grpc.CallOption) (AergoRPCService_ListBlockHeaderStreamClient, error)
+	ListTxStream(ctx context.Context, in *TxStreamRequest, opts ...grpc.CallOption) (AergoRPCService_ListTxStreamClient, error)
+	ListTxInclusionStream(ctx context.Context, in *TxInclusionRequest, opts ...grpc.CallOption) (AergoRPCService_ListTxInclusionStreamClient, error)
+	ListEventStream(ctx context.Context, in *FilterInfo, opts ...grpc.CallOption) (AergoRPCService_ListEventStreamClient, error)
 }
 
 type aergoRPCServiceClient struct {
@@ -1274,6 +1744,33 @@ func (c *aergoRPCServiceClient) GetPeers(ctx context.Context, in *Empty, opts ..
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) GetAddrBook(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AddrBook, error) {
+	out := new(AddrBook)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetAddrBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) PruneAddrBook(ctx context.Context, in *AddrBookPruneRequest, opts ...grpc.CallOption) (*AddrBook, error) {
+	out := new(AddrBook)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/PruneAddrBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetPeerAllowlist(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerAllowlist, error) {
+	out := new(PeerAllowlist)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetPeerAllowlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aergoRPCServiceClient) GetVotes(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*VoteList, error) {
 	out := new(VoteList)
 	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetVotes", in, out, opts...)
@@ -1292,6 +1789,161 @@ func (c *aergoRPCServiceClient) GetStaking(ctx context.Context, in *SingleBytes,
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) ListEvents(ctx context.Context, in *FilterInfo, opts ...grpc.CallOption) (*EventList, error) {
+	out := new(EventList)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/ListEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) TraceTx(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*TxTrace, error) {
+	out := new(TxTrace)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/TraceTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) SimulateTx(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*TxSimulation, error) {
+	out := new(TxSimulation)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/SimulateTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) ListBlockHeaderStream(ctx context.Context, in *BlockStreamRequest, opts ...grpc.CallOption) (AergoRPCService_ListBlockHeaderStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AergoRPCService_serviceDesc.Streams[1], "/types.AergoRPCService/ListBlockHeaderStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aergoRPCServiceListBlockHeaderStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AergoRPCService_ListBlockHeaderStreamClient interface {
+	Recv() (*BlockHeaderNotice, error)
+	grpc.ClientStream
+}
+
+type aergoRPCServiceListBlockHeaderStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aergoRPCServiceListBlockHeaderStreamClient) Recv() (*BlockHeaderNotice, error) {
+	m := new(BlockHeaderNotice)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aergoRPCServiceClient) ListTxStream(ctx context.Context, in *TxStreamRequest, opts ...grpc.CallOption) (AergoRPCService_ListTxStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AergoRPCService_serviceDesc.Streams[2], "/types.AergoRPCService/ListTxStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aergoRPCServiceListTxStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AergoRPCService_ListTxStreamClient interface {
+	Recv() (*Tx, error)
+	grpc.ClientStream
+}
+
+type aergoRPCServiceListTxStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aergoRPCServiceListTxStreamClient) Recv() (*Tx, error) {
+	m := new(Tx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aergoRPCServiceClient) ListTxInclusionStream(ctx context.Context, in *TxInclusionRequest, opts ...grpc.CallOption) (AergoRPCService_ListTxInclusionStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AergoRPCService_serviceDesc.Streams[3], "/types.AergoRPCService/ListTxInclusionStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aergoRPCServiceListTxInclusionStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AergoRPCService_ListTxInclusionStreamClient interface {
+	Recv() (*TxInBlock, error)
+	grpc.ClientStream
+}
+
+type aergoRPCServiceListTxInclusionStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aergoRPCServiceListTxInclusionStreamClient) Recv() (*TxInBlock, error) {
+	m := new(TxInBlock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aergoRPCServiceClient) ListEventStream(ctx context.Context, in *FilterInfo, opts ...grpc.CallOption) (AergoRPCService_ListEventStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AergoRPCService_serviceDesc.Streams[4], "/types.AergoRPCService/ListEventStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aergoRPCServiceListEventStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AergoRPCService_ListEventStreamClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type aergoRPCServiceListEventStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aergoRPCServiceListEventStreamClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AergoRPCServiceServer is the server API for AergoRPCService service.
 type AergoRPCServiceServer interface {
 	NodeState(context.Context, *SingleBytes) (*SingleBytes, error)
@@ -1319,8 +1971,18 @@ type AergoRPCServiceServer interface {
 	QueryContract(context.Context, *Query) (*SingleBytes, error)
 	QueryContractState(context.Context, *StateQuery) (*StateQueryProof, error)
 	GetPeers(context.Context, *Empty) (*PeerList, error)
+	GetAddrBook(context.Context, *Empty) (*AddrBook, error)
+	PruneAddrBook(context.Context, *AddrBookPruneRequest) (*AddrBook, error)
+	GetPeerAllowlist(context.Context, *Empty) (*PeerAllowlist, error)
 	GetVotes(context.Context, *SingleBytes) (*VoteList, error)
 	GetStaking(context.Context, *SingleBytes) (*Staking, error)
+	ListEvents(context.Context, *FilterInfo) (*EventList, error)
+	TraceTx(context.Context, *SingleBytes) (*TxTrace, error)
+	SimulateTx(context.Context, *Tx) (*TxSimulation, error)
+	ListBlockHeaderStream(*BlockStreamRequest, AergoRPCService_ListBlockHeaderStreamServer) error
+	ListTxStream(*TxStreamRequest, AergoRPCService_ListTxStreamServer) error
+	ListTxInclusionStream(*TxInclusionRequest, AergoRPCService_ListTxInclusionStreamServer) error
+	ListEventStream(*FilterInfo, AergoRPCService_ListEventStreamServer) error
 }
 
 func RegisterAergoRPCServiceServer(s *grpc.Server, srv AergoRPCServiceServer) {
@@ -1780,6 +2442,60 @@ func _AergoRPCService_GetPeers_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_GetAddrBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetAddrBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetAddrBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetAddrBook(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_PruneAddrBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddrBookPruneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).PruneAddrBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/PruneAddrBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).PruneAddrBook(ctx, req.(*AddrBookPruneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetPeerAllowlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetPeerAllowlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetPeerAllowlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetPeerAllowlist(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AergoRPCService_GetVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SingleBytes)
 	if err := dec(in); err != nil {
@@ -1816,6 +2532,144 @@ func _AergoRPCService_GetStaking_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ListEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/ListEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ListEvents(ctx, req.(*FilterInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_TraceTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SingleBytes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).TraceTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/TraceTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).TraceTx(ctx, req.(*SingleBytes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_SimulateTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tx)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).SimulateTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/SimulateTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).SimulateTx(ctx, req.(*Tx))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_ListBlockHeaderStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AergoRPCServiceServer).ListBlockHeaderStream(m, &aergoRPCServiceListBlockHeaderStreamServer{stream})
+}
+
+type AergoRPCService_ListBlockHeaderStreamServer interface {
+	Send(*BlockHeaderNotice) error
+	grpc.ServerStream
+}
+
+type aergoRPCServiceListBlockHeaderStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aergoRPCServiceListBlockHeaderStreamServer) Send(m *BlockHeaderNotice) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AergoRPCService_ListTxStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TxStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AergoRPCServiceServer).ListTxStream(m, &aergoRPCServiceListTxStreamServer{stream})
+}
+
+type AergoRPCService_ListTxStreamServer interface {
+	Send(*Tx) error
+	grpc.ServerStream
+}
+
+type aergoRPCServiceListTxStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aergoRPCServiceListTxStreamServer) Send(m *Tx) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AergoRPCService_ListTxInclusionStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TxInclusionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AergoRPCServiceServer).ListTxInclusionStream(m, &aergoRPCServiceListTxInclusionStreamServer{stream})
+}
+
+type AergoRPCService_ListTxInclusionStreamServer interface {
+	Send(*TxInBlock) error
+	grpc.ServerStream
+}
+
+type aergoRPCServiceListTxInclusionStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aergoRPCServiceListTxInclusionStreamServer) Send(m *TxInBlock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AergoRPCService_ListEventStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FilterInfo)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AergoRPCServiceServer).ListEventStream(m, &aergoRPCServiceListEventStreamServer{stream})
+}
+
+type AergoRPCService_ListEventStreamServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type aergoRPCServiceListEventStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aergoRPCServiceListEventStreamServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "types.AergoRPCService",
 	HandlerType: (*AergoRPCServiceServer)(nil),
@@ -1916,6 +2770,18 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetPeers",
 			Handler:    _AergoRPCService_GetPeers_Handler,
 		},
+		{
+			MethodName: "GetAddrBook",
+			Handler:    _AergoRPCService_GetAddrBook_Handler,
+		},
+		{
+			MethodName: "PruneAddrBook",
+			Handler:    _AergoRPCService_PruneAddrBook_Handler,
+		},
+		{
+			MethodName: "GetPeerAllowlist",
+			Handler:    _AergoRPCService_GetPeerAllowlist_Handler,
+		},
 		{
 			MethodName: "GetVotes",
 			Handler:    _AergoRPCService_GetVotes_Handler,
@@ -1924,6 +2790,18 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStaking",
 			Handler:    _AergoRPCService_GetStaking_Handler,
 		},
+		{
+			MethodName: "ListEvents",
+			Handler:    _AergoRPCService_ListEvents_Handler,
+		},
+		{
+			MethodName: "TraceTx",
+			Handler:    _AergoRPCService_TraceTx_Handler,
+		},
+		{
+			MethodName: "SimulateTx",
+			Handler:    _AergoRPCService_SimulateTx_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1931,6 +2809,26 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _AergoRPCService_ListBlockStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListBlockHeaderStream",
+			Handler:       _AergoRPCService_ListBlockHeaderStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTxStream",
+			Handler:       _AergoRPCService_ListTxStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTxInclusionStream",
+			Handler:       _AergoRPCService_ListTxInclusionStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListEventStream",
+			Handler:       _AergoRPCService_ListEventStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
@@ -1938,90 +2836,123 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor_rpc_f4581f16e5715b6f) }
 
 var fileDescriptor_rpc_f4581f16e5715b6f = []byte{
-	// 1349 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x6d, 0x73, 0xda, 0x46,
-	0x10, 0x06, 0x0c, 0xd8, 0x2c, 0x60, 0x94, 0x8b, 0xe3, 0x50, 0x9a, 0x49, 0x5d, 0xb5, 0xd3, 0x71,
-	0xd3, 0xc4, 0x49, 0x49, 0xd3, 0x7e, 0xe9, 0xb4, 0x23, 0x13, 0x6c, 0x33, 0xc5, 0xe0, 0x9e, 0x14,
-	0x97, 0xb4, 0x33, 0xd5, 0xc8, 0xd2, 0x61, 0x34, 0x01, 0x9d, 0x2a, 0x1d, 0x7e, 0xe9, 0x97, 0x4e,
-	0xff, 0x62, 0x7f, 0x51, 0xe7, 0x5e, 0x04, 0x12, 0xc1, 0x9d, 0x49, 0x3f, 0x71, 0xbb, 0xf7, 0xec,
-	0xcb, 0xed, 0x3e, 0xb7, 0x27, 0xa0, 0x12, 0x85, 0xee, 0x41, 0x18, 0x51, 0x46, 0x51, 0x89, 0xdd,
-	0x86, 0x24, 0x6e, 0x69, 0x17, 0x53, 0xea, 0xbe, 0x73, 0x27, 0x8e, 0x1f, 0xc8, 0x8d, 0x56, 0xdd,
-	0x71, 0x5d, 0x3a, 0x0f, 0x98, 0x12, 0x21, 0xa0, 0x1e, 0x51, 0xeb, 0x4a, 0xd8, 0x0e, 0xd5, 0xb2,
-	0x36, 0x23, 0x2c, 0xf2, 0x95, 0x33, 0xfd, 0x37, 0xd0, 0x0e, 0x17, 0x7e, 0x4c, 0xe6, 0xb0, 0x79,
-	0x8c, 0xbe, 0x80, 0xc6, 0x05, 0x89, 0x99, 0x2d, 0x02, 0xd8, 0x13, 0x27, 0x9e, 0x34, 0xf3, 0x7b,
-	0xf9, 0xfd, 0x1a, 0xae, 0x73, 0xb5, 0x80, 0x9f, 0x38, 0xf1, 0x04, 0x7d, 0x02, 0x55, 0x81, 0x9b,
-	0x10, 0xff, 0x72, 0xc2, 0x9a, 0x85, 0xbd, 0xfc, 0x7e, 0x11, 0x03, 0x57, 0x9d, 0x08, 0x8d, 0xee,
-	0x42, 0xa9, 0x17, 0x84, 0x73, 0x86, 0x10, 0x14, 0x53, 0x6e, 0xc4, 0x1a, 0x35, 0x61, 0xd3, 0xf1,
-	0xbc, 0x88, 0xc4, 0x71, 0xb3, 0xb0, 0xb7, 0xb1, 0x5f, 0xc3, 0x89, 0x88, 0x76, 0xa0, 0x74, 0xe5,
-	0x4c, 0xe7, 0xa4, 0xb9, 0x21, 0xe0, 0x52, 0x40, 0xbb, 0x50, 0x8e, 0xdd, 0xc8, 0x0f, 0x59, 0xb3,
-	0x28, 0xd4, 0x4a, 0xd2, 0xc7, 0x50, 0x1e, 0xce, 0x19, 0x8f, 0xb2, 0x03, 0x25, 0x3f, 0xf0, 0xc8,
-	0x8d, 0x08, 0x53, 0xc7, 0x52, 0xc8, 0xc6, 0xc9, 0xff, 0xff, 0x38, 0x9b, 0x50, 0xea, 0xce, 0x42,
-	0x76, 0xab, 0x7f, 0x06, 0x55, 0xd3, 0x0f, 0x2e, 0xa7, 0xe4, 0xf0, 0x96, 0x91, 0x94, 0x97, 0x7c,
-	0xca, 0x8b, 0xfe, 0x3b, 0x6c, 0x1b, 0xb2, 0x1b, 0x46, 0xe0, 0x61, 0x4a, 0x19, 0xcf, 0x43, 0x69,
-	0x14, 0x32, 0x11, 0x79, 0x75, 0x38, 0x42, 0xa5, 0x27, 0xd6, 0xe8, 0x31, 0x40, 0x87, 0xce, 0x42,
-	0x9e, 0x27, 0xf1, 0x44, 0x82, 0x5b, 0x38, 0xa5, 0xd1, 0xff, 0x82, 0xe2, 0x19, 0x21, 0x11, 0x7a,
-	0xba, 0x3c, 0x1d, 0xf7, 0x5a, 0x6d, 0xa3, 0x03, 0x41, 0x8f, 0x03, 0xbe, 0x6b, 0xc8, 0x9d, 0xe5,
-	0x89, 0x5f, 0x42, 0x85, 0xb7, 0x47, 0x34, 0x56, 0x84, 0xab, 0xb6, 0x1f, 0x28, 0xfc, 0x80, 0x5c,
-	0x8b, 0xce, 0x0e, 0x28, 0xf3, 0x5d, 0x82, 0x97, 0x38, 0x7e, 0xc0, 0x98, 0x39, 0x4c, 0x96, 0xa9,
-	0x84, 0xa5, 0xa0, 0x3f, 0x83, 0x2d, 0x1e, 0xa2, 0xef, 0xc7, 0x0c, 0x7d, 0x0a, 0xa5, 0x90, 0x90,
-	0x88, 0xa7, 0xb0, 0xb1, 0x5f, 0x6d, 0x57, 0x53, 0x29, 0x60, 0xb9, 0xa3, 0x5f, 0x01, 0x70, 0xe8,
-	0x99, 0x13, 0x39, 0xb3, 0x78, 0x2d, 0x1f, 0x76, 0xa1, 0x9c, 0x21, 0x92, 0x92, 0x38, 0x36, 0xf6,
-	0xff, 0x94, 0xd1, 0xeb, 0x58, 0xac, 0x39, 0x96, 0x8e, 0xc7, 0x31, 0x91, 0x3d, 0xaa, 0x63, 0x25,
-	0x21, 0x0d, 0x36, 0x9c, 0xd8, 0x6d, 0x96, 0x44, 0xb9, 0xf8, 0x52, 0xff, 0x0e, 0x1a, 0x92, 0xb0,
-	0xc4, 0xf1, 0x54, 0xb6, 0x9f, 0x43, 0x59, 0x1c, 0x2c, 0x49, 0xb7, 0xa6, 0xd2, 0x15, 0x38, 0xac,
-	0xf6, 0x74, 0x02, 0xb5, 0x0e, 0x9d, 0xcd, 0x7c, 0x86, 0x49, 0x3c, 0x9f, 0xae, 0xa7, 0xf0, 0x97,
-	0x50, 0x22, 0x51, 0x44, 0x23, 0x91, 0xf1, 0x76, 0xfb, 0xbe, 0x72, 0x24, 0xed, 0xe4, 0x65, 0xc2,
-	0x12, 0xc1, 0x33, 0xf6, 0x08, 0x73, 0xfc, 0xa9, 0x38, 0x47, 0x05, 0x2b, 0x49, 0x37, 0x40, 0x4b,
-	0x87, 0x11, 0x09, 0x3e, 0x83, 0xcd, 0x48, 0x48, 0x49, 0x86, 0x59, 0xc7, 0x12, 0x89, 0x13, 0x8c,
-	0x6e, 0x41, 0xed, 0x9c, 0x44, 0xfe, 0xf8, 0x56, 0x65, 0xfa, 0x11, 0x14, 0xd8, 0x8d, 0x62, 0x43,
-	0x45, 0x59, 0x5a, 0x37, 0xb8, 0xc0, 0x6e, 0xee, 0x4a, 0x58, 0x9a, 0x67, 0x12, 0xd6, 0x2d, 0xde,
-	0xdf, 0x28, 0xa6, 0x81, 0x33, 0xe5, 0x64, 0x0c, 0x9d, 0x38, 0x0e, 0x27, 0x91, 0x13, 0x4b, 0x9e,
-	0x57, 0x70, 0x4a, 0x83, 0xf6, 0x61, 0x53, 0x8d, 0x1e, 0x45, 0xaa, 0x6d, 0xe5, 0x58, 0x31, 0x1c,
-	0x27, 0xdb, 0xfa, 0x04, 0x6a, 0xbd, 0x59, 0x48, 0x23, 0x76, 0x44, 0xa3, 0x99, 0xc3, 0x7b, 0xb1,
-	0x71, 0xed, 0x8f, 0x57, 0xa8, 0x9b, 0xba, 0x5d, 0x98, 0x6f, 0xf3, 0xab, 0x43, 0xa7, 0x1e, 0x0f,
-	0x28, 0xfc, 0x57, 0x70, 0x22, 0xf2, 0x9d, 0x80, 0x5c, 0x8b, 0x1d, 0x59, 0xd7, 0x44, 0xd4, 0x5f,
-	0xc1, 0xa6, 0xc9, 0x9c, 0x77, 0x7e, 0x70, 0xc9, 0x6b, 0xef, 0xcc, 0x16, 0x17, 0xaf, 0x88, 0x95,
-	0xc4, 0x5b, 0x7a, 0x3d, 0x21, 0x81, 0xe2, 0x9b, 0x58, 0xeb, 0xdf, 0x43, 0xf1, 0x9c, 0x32, 0x82,
-	0x1e, 0x41, 0xc5, 0x75, 0x02, 0xcf, 0xf7, 0x38, 0xf1, 0x65, 0xcf, 0x97, 0x8a, 0x94, 0xc7, 0x42,
-	0xda, 0x23, 0xbf, 0x14, 0xdc, 0x3a, 0xb9, 0x14, 0x57, 0x94, 0x91, 0xd5, 0x4b, 0xc1, 0xf7, 0xb1,
-	0xdc, 0x79, 0xf2, 0x4f, 0x3e, 0x21, 0x99, 0x9a, 0xbc, 0x15, 0x28, 0x59, 0x23, 0x7b, 0xf8, 0x93,
-	0x96, 0x43, 0x3b, 0xa0, 0x59, 0x23, 0x7b, 0x30, 0x1c, 0x74, 0xba, 0xb6, 0x35, 0x1c, 0xda, 0xfd,
-	0xe1, 0x2f, 0x5a, 0x1e, 0x3d, 0x80, 0x7b, 0xd6, 0xc8, 0x36, 0xfa, 0xb8, 0x6b, 0xbc, 0x7e, 0x6b,
-	0x77, 0x47, 0x3d, 0xd3, 0x32, 0xb5, 0x02, 0xba, 0x0f, 0x0d, 0x6b, 0x64, 0xf7, 0x06, 0xe7, 0x46,
-	0xbf, 0xf7, 0xda, 0x3e, 0x31, 0xcc, 0x13, 0x6d, 0x63, 0x45, 0x69, 0xf6, 0x8e, 0x07, 0x5a, 0x51,
-	0x39, 0x48, 0x94, 0x47, 0x43, 0x7c, 0x6a, 0x58, 0x5a, 0x09, 0x7d, 0x0c, 0x0f, 0x85, 0xda, 0x7c,
-	0x73, 0x74, 0xd4, 0xeb, 0xf4, 0xba, 0x03, 0xcb, 0x3e, 0x34, 0xfa, 0xc6, 0xa0, 0xd3, 0xd5, 0xca,
-	0xca, 0xe6, 0xc4, 0x30, 0x6d, 0xd3, 0x38, 0xed, 0xca, 0x9c, 0xb4, 0xcd, 0x85, 0x2b, 0xab, 0x8b,
-	0x07, 0x46, 0xdf, 0xee, 0x62, 0x3c, 0xc4, 0x5a, 0xe5, 0xc9, 0x38, 0xa1, 0xa3, 0x3a, 0xd3, 0x0e,
-	0x68, 0xe7, 0x5d, 0xdc, 0x3b, 0x7a, 0x6b, 0x9b, 0x96, 0x61, 0xbd, 0x31, 0xe5, 0xf1, 0xf6, 0xe0,
-	0x51, 0x56, 0xcb, 0xf3, 0xb3, 0x07, 0x43, 0xcb, 0x3e, 0x35, 0xac, 0xce, 0x89, 0x96, 0x47, 0x8f,
-	0xa1, 0x95, 0x45, 0x64, 0x8e, 0x57, 0x68, 0xff, 0x5d, 0x85, 0x86, 0x41, 0xa2, 0x4b, 0x8a, 0xcf,
-	0x3a, 0x26, 0x89, 0xae, 0x7c, 0x97, 0xa0, 0x57, 0x50, 0x19, 0x50, 0x8f, 0xf0, 0xc8, 0x04, 0xad,
-	0xa1, 0x53, 0x6b, 0x8d, 0x4e, 0xcf, 0xa1, 0xaf, 0xa1, 0x7c, 0x2a, 0x1e, 0x45, 0x94, 0x4c, 0x43,
-	0x29, 0xc6, 0x98, 0xfc, 0x31, 0x27, 0x31, 0x6b, 0x6d, 0x67, 0xd5, 0x7a, 0x0e, 0xbd, 0x02, 0x58,
-	0xbe, 0x9b, 0x28, 0x19, 0x21, 0xe2, 0x81, 0x68, 0x3d, 0x4c, 0x0f, 0x94, 0xd4, 0xc3, 0xaa, 0xe7,
-	0xd0, 0x8f, 0xa0, 0x71, 0x72, 0xa4, 0x46, 0x52, 0x8c, 0xee, 0x29, 0xf8, 0x72, 0x3e, 0xb6, 0x76,
-	0xd3, 0x1e, 0x96, 0xa3, 0x4b, 0xa4, 0xda, 0x58, 0x38, 0x30, 0x59, 0x44, 0x9c, 0xd9, 0x4a, 0xf0,
-	0xcc, 0x34, 0xd3, 0x73, 0x2f, 0xf2, 0xe8, 0x00, 0xb6, 0x8e, 0x89, 0xb4, 0x58, 0x5b, 0x93, 0x15,
-	0x0b, 0xb4, 0x0f, 0xa5, 0x63, 0xc2, 0xac, 0xd1, 0x5a, 0xf0, 0x72, 0xa0, 0xe8, 0x39, 0xf4, 0x0d,
-	0x40, 0xe2, 0xf9, 0x0e, 0xb8, 0xb6, 0x80, 0xf7, 0x82, 0xc4, 0x7f, 0x5b, 0x58, 0x61, 0xe2, 0x12,
-	0x3f, 0x64, 0x6b, 0xad, 0x92, 0x72, 0x2b, 0x8c, 0x9e, 0x43, 0x4f, 0xa0, 0x7c, 0x4c, 0x98, 0x71,
-	0xd8, 0x5b, 0x8b, 0x87, 0x64, 0xdc, 0x1c, 0xf6, 0x24, 0xd6, 0x24, 0x81, 0x67, 0x8d, 0xd0, 0x32,
-	0xd9, 0xd6, 0xba, 0x11, 0x2a, 0x4e, 0xb0, 0x25, 0x35, 0xd6, 0x08, 0xd5, 0x17, 0x68, 0x5e, 0xe1,
-	0x45, 0x17, 0x57, 0xc7, 0xb3, 0x9e, 0x53, 0x15, 0xbd, 0x9b, 0x65, 0x49, 0x45, 0x05, 0x42, 0xcf,
-	0xa1, 0x1f, 0x40, 0x4b, 0xf0, 0x46, 0xe0, 0x9d, 0x45, 0x94, 0x8e, 0x17, 0x4c, 0xcb, 0x7e, 0x25,
-	0xb4, 0xee, 0xa5, 0x4d, 0x05, 0x52, 0x54, 0xac, 0xde, 0x89, 0x08, 0xb7, 0x56, 0x5f, 0x0c, 0x8d,
-	0xc5, 0x0b, 0x2b, 0x27, 0x74, 0x6b, 0x65, 0xe0, 0x0a, 0xa2, 0x54, 0x79, 0xc5, 0xa4, 0x1c, 0xaf,
-	0x90, 0x04, 0x65, 0xe1, 0xea, 0x58, 0x2f, 0xa0, 0xda, 0xa7, 0xee, 0xbb, 0x0f, 0x08, 0xd2, 0x86,
-	0xfa, 0x9b, 0x60, 0xfa, 0x61, 0x36, 0xdf, 0x42, 0x5d, 0x3e, 0x01, 0x89, 0x4d, 0xd2, 0x9a, 0xf4,
-	0xc3, 0xb0, 0xde, 0xae, 0x7b, 0x93, 0xb6, 0x7b, 0x2f, 0xd6, 0xfa, 0xcb, 0xbd, 0x07, 0x65, 0xd3,
-	0xbf, 0x0c, 0xb2, 0x74, 0xc8, 0xd0, 0xf8, 0x29, 0x6c, 0xc9, 0x89, 0xb5, 0x9e, 0x32, 0xe9, 0xc7,
-	0x55, 0xcf, 0xa1, 0x97, 0x50, 0xff, 0x79, 0x4e, 0xa2, 0xdb, 0x0e, 0x0d, 0x58, 0xe4, 0xb8, 0x6c,
-	0x51, 0x5a, 0xa1, 0xbd, 0x23, 0x09, 0x03, 0x50, 0xc6, 0x48, 0x72, 0x27, 0xd3, 0x6c, 0x69, 0xbe,
-	0xfb, 0x9e, 0x2a, 0x21, 0xc1, 0x57, 0x82, 0x74, 0xfc, 0x9b, 0x6a, 0xb5, 0x9b, 0x8d, 0xd4, 0xf7,
-	0xd6, 0x62, 0x4c, 0x70, 0x30, 0x7f, 0x6b, 0xe2, 0xb5, 0x0c, 0x6d, 0xa4, 0x5e, 0x23, 0x65, 0x22,
-	0xaf, 0x65, 0xf2, 0x66, 0xfe, 0xd7, 0xb5, 0x54, 0x18, 0x3d, 0x77, 0xb8, 0xf7, 0xeb, 0xe3, 0x4b,
-	0x9f, 0x4d, 0xe6, 0x17, 0x07, 0x2e, 0x9d, 0x3d, 0x77, 0xf8, 0x34, 0xf6, 0xa9, 0xfc, 0x7d, 0x2e,
-	0xb0, 0x17, 0x65, 0xf1, 0x37, 0xe3, 0xe5, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xc5, 0x16, 0x0a,
-	0x6c, 0xc0, 0x0c, 0x00, 0x00,
+	// 1879 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x6f, 0x73, 0xda, 0xc8,
+	0x19, 0x07, 0x63, 0x6c, 0x78, 0x80, 0xa0, 0xec, 0x39, 0x3e, 0x8e, 0xbb, 0x49, 0x5d, 0xb5, 0xd3,
+	0x71, 0xd3, 0x3b, 0x27, 0x75, 0x9a, 0x6b, 0xa7, 0xd3, 0x69, 0x47, 0x26, 0x38, 0x66, 0x4a, 0xc0,
+	0x5d, 0xc9, 0x29, 0xd7, 0x17, 0xd5, 0xc8, 0xd2, 0x62, 0xd4, 0x20, 0x2d, 0x5d, 0x2d, 0x36, 0xee,
+	0x9b, 0x7e, 0xac, 0x7e, 0x8a, 0x7c, 0x8b, 0x7e, 0x90, 0xce, 0xfe, 0x11, 0x48, 0x58, 0xc9, 0x4c,
+	0x7a, 0xaf, 0xac, 0xe7, 0xd9, 0xdf, 0xf3, 0x67, 0x9f, 0x7f, 0xfb, 0x18, 0xa8, 0xb3, 0x85, 0x7f,
+	0xb2, 0x60, 0x94, 0x53, 0x54, 0xe5, 0xf7, 0x0b, 0x92, 0x74, 0x8d, 0xeb, 0x39, 0xf5, 0xdf, 0xfb,
+	0x33, 0x2f, 0x8c, 0xd5, 0x41, 0xb7, 0xe5, 0xf9, 0x3e, 0x5d, 0xc6, 0x5c, 0x93, 0x10, 0xd3, 0x80,
+	0xe8, 0xef, 0xfa, 0xe2, 0x74, 0xa1, 0x3f, 0x9b, 0x11, 0xe1, 0x2c, 0xd4, 0xca, 0xcc, 0x7f, 0x83,
+	0x71, 0xb6, 0xd6, 0x63, 0x73, 0x8f, 0x2f, 0x13, 0xf4, 0x0b, 0x68, 0x5f, 0x93, 0x84, 0xbb, 0xd2,
+	0x80, 0x3b, 0xf3, 0x92, 0x59, 0xa7, 0x7c, 0x54, 0x3e, 0x6e, 0xe2, 0x96, 0x60, 0x4b, 0xf8, 0x85,
+	0x97, 0xcc, 0xd0, 0x4f, 0xa0, 0x21, 0x71, 0x33, 0x12, 0xde, 0xcc, 0x78, 0x67, 0xe7, 0xa8, 0x7c,
+	0xbc, 0x8b, 0x41, 0xb0, 0x2e, 0x24, 0x07, 0x99, 0xd0, 0x92, 0x7a, 0xdd, 0x30, 0x50, 0x6a, 0x2a,
+	0x52, 0x4d, 0x43, 0x32, 0x07, 0x81, 0x50, 0x62, 0xfa, 0x50, 0x1d, 0xc4, 0x8b, 0x25, 0x47, 0x08,
+	0x76, 0x33, 0xa6, 0xe4, 0x37, 0xea, 0xc0, 0xbe, 0x17, 0x04, 0x8c, 0x24, 0x49, 0x67, 0xe7, 0xa8,
+	0x72, 0xdc, 0xc4, 0x29, 0x89, 0x0e, 0xa0, 0x7a, 0xeb, 0xcd, 0x97, 0x44, 0xab, 0x54, 0x04, 0x3a,
+	0x84, 0xbd, 0xc4, 0x67, 0xe1, 0x82, 0x77, 0x76, 0x25, 0x5b, 0x53, 0xe6, 0x14, 0xf6, 0xc6, 0x4b,
+	0x2e, 0xac, 0x1c, 0x40, 0x35, 0x8c, 0x03, 0xb2, 0x92, 0x66, 0x5a, 0x58, 0x11, 0x79, 0x3b, 0xe5,
+	0xff, 0xdf, 0xce, 0x3e, 0x54, 0xfb, 0xd1, 0x82, 0xdf, 0x9b, 0x3f, 0x83, 0x86, 0x1d, 0xc6, 0x37,
+	0x73, 0x72, 0x76, 0xcf, 0x49, 0x46, 0x4b, 0x39, 0xa3, 0xc5, 0xfc, 0x3b, 0x3c, 0xb2, 0x54, 0xc6,
+	0xac, 0x38, 0xc0, 0x94, 0x72, 0xe1, 0x87, 0xe6, 0x68, 0x64, 0x4a, 0x8a, 0xe8, 0x08, 0x84, 0x76,
+	0x4f, 0x7e, 0xa3, 0xa7, 0x00, 0x3d, 0x1a, 0x2d, 0x84, 0x9f, 0x24, 0x90, 0x0e, 0xd6, 0x70, 0x86,
+	0x63, 0xfe, 0xa7, 0x0c, 0xbb, 0x97, 0x84, 0x30, 0xf4, 0xed, 0xe6, 0x7a, 0x42, 0x6d, 0xe3, 0x14,
+	0x9d, 0xc8, 0x1a, 0x3a, 0x11, 0xa7, 0x96, 0x3a, 0xd9, 0x5c, 0xf9, 0x25, 0xd4, 0x45, 0x0e, 0x65,
+	0xf6, 0xa5, 0xbd, 0xc6, 0xe9, 0x13, 0x8d, 0x1f, 0x91, 0x3b, 0x99, 0xfe, 0x11, 0xe5, 0xa1, 0x4f,
+	0xf0, 0x06, 0x27, 0x6e, 0x98, 0x70, 0x8f, 0xab, 0x38, 0x55, 0xb1, 0x22, 0x24, 0xd7, 0xa7, 0x8c,
+	0x74, 0x76, 0x35, 0x57, 0x10, 0xe8, 0x08, 0x1a, 0xd7, 0x5e, 0x1c, 0x93, 0xe0, 0x2a, 0xe6, 0xe1,
+	0xbc, 0x53, 0x3d, 0x2a, 0x1f, 0x57, 0x70, 0x96, 0x65, 0x7e, 0x07, 0x35, 0xe1, 0xda, 0x30, 0x4c,
+	0x38, 0xfa, 0x29, 0x54, 0x17, 0x84, 0x30, 0xe1, 0x7a, 0xe5, 0xb8, 0x71, 0xda, 0xc8, 0xb8, 0x8e,
+	0xd5, 0x89, 0x79, 0x0b, 0x20, 0xa0, 0x97, 0x1e, 0xf3, 0xa2, 0xa4, 0xb0, 0x90, 0x0e, 0x61, 0x2f,
+	0x57, 0xa5, 0x9a, 0x12, 0xd8, 0x24, 0xfc, 0x97, 0xf2, 0xba, 0x85, 0xe5, 0xb7, 0xc0, 0xd2, 0xe9,
+	0x34, 0x21, 0x2a, 0xb9, 0x2d, 0xac, 0x29, 0x64, 0x40, 0xc5, 0x4b, 0x7c, 0xe9, 0x6e, 0x0d, 0x8b,
+	0x4f, 0xf3, 0xb7, 0xd0, 0x56, 0xdd, 0x40, 0xbc, 0x40, 0x7b, 0xfb, 0x73, 0xd8, 0x93, 0x01, 0x49,
+	0xdd, 0x6d, 0x6a, 0x77, 0x25, 0x0e, 0xeb, 0x33, 0x93, 0x40, 0xb3, 0x47, 0xa3, 0x28, 0xe4, 0x98,
+	0x24, 0xcb, 0x79, 0x71, 0xed, 0xff, 0x12, 0xaa, 0x84, 0x31, 0xca, 0xa4, 0xc7, 0x8f, 0x4e, 0xbf,
+	0xd0, 0x8a, 0x94, 0x9c, 0xea, 0x54, 0xac, 0x10, 0xc2, 0xe3, 0x80, 0x70, 0x2f, 0x9c, 0xcb, 0x7b,
+	0xd4, 0xb1, 0xa6, 0x4c, 0x0b, 0x8c, 0xac, 0x19, 0xe9, 0xe0, 0x77, 0xb0, 0xcf, 0x24, 0x95, 0x7a,
+	0x98, 0x57, 0xac, 0x90, 0x38, 0xc5, 0x98, 0x0e, 0x34, 0xdf, 0x11, 0x16, 0x4e, 0xef, 0xb5, 0xa7,
+	0x5f, 0xc1, 0x0e, 0x5f, 0xe9, 0x2a, 0xaa, 0x6b, 0x49, 0x67, 0x85, 0x77, 0xf8, 0xea, 0x63, 0x0e,
+	0x2b, 0xf1, 0x9c, 0xc3, 0xa6, 0x23, 0xf2, 0xcb, 0x12, 0x1a, 0x7b, 0x73, 0x51, 0xc5, 0x0b, 0x2f,
+	0x49, 0x16, 0x33, 0xe6, 0x25, 0xaa, 0x41, 0xea, 0x38, 0xc3, 0x41, 0xc7, 0xb0, 0xaf, 0xe7, 0x9a,
+	0x2e, 0xc6, 0x47, 0x5a, 0xb1, 0x6e, 0x0d, 0x9c, 0x1e, 0x9b, 0x33, 0x68, 0x0e, 0xa2, 0x05, 0x65,
+	0xfc, 0x9c, 0xb2, 0xc8, 0x13, 0xb9, 0xa8, 0xdc, 0x85, 0xd3, 0xad, 0x92, 0xcf, 0xb4, 0x25, 0x16,
+	0xc7, 0xa2, 0xe7, 0xe8, 0x3c, 0x10, 0x06, 0xa5, 0xfe, 0x3a, 0x4e, 0x49, 0x71, 0x12, 0x93, 0x3b,
+	0x79, 0xa2, 0xe2, 0x9a, 0x92, 0xe6, 0x2b, 0xd8, 0xb7, 0xb9, 0xf7, 0x3e, 0x8c, 0x6f, 0x44, 0xec,
+	0xbd, 0x68, 0xdd, 0xb1, 0xbb, 0x58, 0x53, 0x22, 0xa5, 0x77, 0x33, 0x12, 0xeb, 0x7a, 0x93, 0xdf,
+	0xe6, 0x1f, 0x60, 0xf7, 0x1d, 0xe5, 0x04, 0x7d, 0x03, 0x75, 0xdf, 0x8b, 0x83, 0x30, 0x10, 0x0d,
+	0xa3, 0x72, 0xbe, 0x61, 0x64, 0x34, 0xee, 0x64, 0x35, 0x8a, 0xa6, 0x10, 0xd2, 0x69, 0x53, 0xdc,
+	0x52, 0x4e, 0xb6, 0x9b, 0x42, 0x9c, 0x63, 0x75, 0x62, 0x62, 0x40, 0xb2, 0xe8, 0x6c, 0xce, 0x88,
+	0x17, 0x61, 0xf2, 0xcf, 0x25, 0x49, 0xb8, 0xe8, 0xbd, 0x29, 0xa3, 0x91, 0xee, 0x62, 0xed, 0x73,
+	0x96, 0x85, 0xba, 0x50, 0x93, 0x24, 0x49, 0x94, 0x03, 0x35, 0xbc, 0xa6, 0xcd, 0x10, 0xda, 0xce,
+	0x2a, 0xaf, 0xb0, 0xb3, 0x49, 0x8f, 0x1e, 0x59, 0x9a, 0xdc, 0x36, 0xb5, 0xf3, 0x69, 0x53, 0x95,
+	0x2d, 0x53, 0xff, 0x00, 0xe4, 0xac, 0x06, 0xb1, 0x3f, 0x5f, 0x26, 0x21, 0x8d, 0x53, 0x6b, 0xa2,
+	0x8f, 0xbd, 0x64, 0xa6, 0x2f, 0xde, 0xc4, 0x9a, 0xfa, 0x91, 0xb6, 0x22, 0x78, 0x9c, 0xe9, 0x63,
+	0x35, 0xdc, 0x0a, 0x7b, 0xf2, 0x99, 0x18, 0x23, 0x02, 0xd3, 0xd9, 0xc9, 0x15, 0x55, 0x46, 0x1a,
+	0x6b, 0x84, 0x08, 0x0c, 0x23, 0x11, 0xbd, 0x5d, 0x8f, 0xe6, 0x94, 0x34, 0x3f, 0x94, 0xa1, 0x25,
+	0xa6, 0xee, 0x19, 0xa5, 0xef, 0xfb, 0x31, 0x67, 0xf7, 0x9f, 0x39, 0xa0, 0xc5, 0xeb, 0x43, 0x97,
+	0xcc, 0x27, 0xba, 0x60, 0x35, 0x25, 0xae, 0x38, 0xf7, 0x12, 0x6e, 0x13, 0x12, 0x4b, 0x93, 0x15,
+	0xbc, 0xa6, 0x45, 0x80, 0xc4, 0xb7, 0xc5, 0x39, 0x89, 0xf4, 0xb3, 0x55, 0xc1, 0x59, 0x96, 0x28,
+	0xca, 0x64, 0xe9, 0xfb, 0x24, 0x49, 0x48, 0x22, 0x87, 0x5c, 0x0b, 0x6f, 0x18, 0x32, 0x7c, 0x5e,
+	0x38, 0x5f, 0x32, 0x92, 0x74, 0xf6, 0xe4, 0xe1, 0x9a, 0x36, 0x7f, 0x0f, 0xb5, 0xf4, 0x3a, 0xe8,
+	0x04, 0xf6, 0x49, 0xcc, 0x59, 0xb8, 0x2e, 0xcd, 0x83, 0xb4, 0x5b, 0xb3, 0x17, 0xc6, 0x29, 0xc8,
+	0x3c, 0x87, 0x83, 0xf4, 0xe4, 0x92, 0x2d, 0x63, 0x92, 0x49, 0xb4, 0x98, 0xed, 0x83, 0xd7, 0x3a,
+	0xfe, 0x9a, 0x12, 0xfc, 0xc8, 0x5b, 0x59, 0x37, 0xea, 0xee, 0x15, 0xac, 0x29, 0x73, 0x09, 0x2d,
+	0x19, 0xab, 0xf9, 0x9c, 0xde, 0xcd, 0x45, 0x87, 0x98, 0xd0, 0x5c, 0x10, 0x16, 0x85, 0x89, 0x28,
+	0x1f, 0x12, 0x48, 0x35, 0x35, 0x9c, 0xe3, 0x89, 0xa0, 0xf8, 0x34, 0x9e, 0x86, 0x37, 0x97, 0xf2,
+	0x81, 0x51, 0x2b, 0x46, 0x96, 0x25, 0x86, 0x93, 0x5c, 0x56, 0x14, 0xa0, 0x22, 0x01, 0x19, 0xce,
+	0xb3, 0xff, 0x96, 0xd3, 0x49, 0xae, 0x77, 0xa7, 0x3a, 0x54, 0x9d, 0x89, 0x3b, 0xfe, 0xb3, 0x51,
+	0x42, 0x07, 0x60, 0x38, 0x13, 0x77, 0x34, 0x1e, 0xf5, 0xfa, 0xae, 0x33, 0x1e, 0xbb, 0xc3, 0xf1,
+	0x5f, 0x8d, 0x32, 0x7a, 0x02, 0x8f, 0x9d, 0x89, 0x6b, 0x0d, 0x71, 0xdf, 0x7a, 0xfd, 0x83, 0xdb,
+	0x9f, 0x0c, 0x6c, 0xc7, 0x36, 0x76, 0xd0, 0x17, 0xd0, 0x76, 0x26, 0xee, 0x60, 0xf4, 0xce, 0x1a,
+	0x0e, 0x5e, 0xbb, 0x17, 0x96, 0x7d, 0x61, 0x54, 0xb6, 0x98, 0xf6, 0xe0, 0xcd, 0xc8, 0xd8, 0xd5,
+	0x0a, 0x52, 0xe6, 0xf9, 0x18, 0xbf, 0xb5, 0x1c, 0xa3, 0x8a, 0xbe, 0x86, 0x2f, 0x25, 0xdb, 0xbe,
+	0x3a, 0x3f, 0x1f, 0xf4, 0x06, 0xfd, 0x91, 0xe3, 0x9e, 0x59, 0x43, 0x6b, 0xd4, 0xeb, 0x1b, 0x7b,
+	0x5a, 0xe6, 0xc2, 0xb2, 0x5d, 0xdb, 0x7a, 0xdb, 0x57, 0x3e, 0x19, 0xfb, 0x6b, 0x55, 0x4e, 0x1f,
+	0x8f, 0xac, 0xa1, 0xdb, 0xc7, 0x78, 0x8c, 0x8d, 0x3a, 0x32, 0xa0, 0xe9, 0x4c, 0xdc, 0xcb, 0xf1,
+	0x78, 0xe8, 0x9e, 0x5f, 0x0d, 0x87, 0x06, 0x3c, 0x9b, 0xa6, 0xaf, 0x80, 0xbe, 0xe5, 0x01, 0x18,
+	0xef, 0xfa, 0x78, 0x70, 0xfe, 0x83, 0x6b, 0x3b, 0x96, 0x73, 0x65, 0xab, 0x0b, 0x1f, 0xc1, 0x37,
+	0x79, 0xae, 0xf0, 0xd8, 0x1d, 0x8d, 0x1d, 0xf7, 0xad, 0xe5, 0xf4, 0x2e, 0x8c, 0x32, 0x7a, 0x0a,
+	0xdd, 0x3c, 0x22, 0x77, 0xe1, 0x9d, 0xd3, 0x0f, 0x6d, 0x68, 0x5b, 0x84, 0xdd, 0x50, 0x7c, 0xd9,
+	0xb3, 0x09, 0xbb, 0x15, 0x7d, 0xf8, 0x0a, 0xea, 0x23, 0x1a, 0x10, 0x5b, 0x2e, 0x14, 0x05, 0x53,
+	0xbc, 0x5b, 0xc0, 0x33, 0x4b, 0xe8, 0xd7, 0xb0, 0xf7, 0x56, 0x2e, 0xba, 0x28, 0x5d, 0x5e, 0x14,
+	0x99, 0xe8, 0x0a, 0xeb, 0x3e, 0xca, 0xb3, 0xcd, 0x12, 0x7a, 0x05, 0xb0, 0xd9, 0x85, 0x51, 0xfa,
+	0x72, 0xcb, 0x85, 0xae, 0xfb, 0x65, 0xb6, 0xd3, 0x33, 0xcb, 0xb2, 0x59, 0x42, 0x7f, 0x02, 0x43,
+	0xcc, 0xe4, 0xcc, 0x0c, 0x48, 0xd0, 0x63, 0x0d, 0xdf, 0xac, 0x25, 0xdd, 0xc3, 0x87, 0xb3, 0x42,
+	0x9c, 0x4a, 0x57, 0xdb, 0x6b, 0x05, 0x6a, 0xb8, 0x6e, 0x19, 0xcf, 0x2d, 0x11, 0x66, 0xe9, 0x45,
+	0x19, 0x9d, 0x40, 0xed, 0x0d, 0x51, 0x12, 0x85, 0x31, 0xd9, 0x92, 0x40, 0xc7, 0x50, 0x7d, 0x43,
+	0xb8, 0x33, 0x29, 0x04, 0x6f, 0xde, 0x71, 0xb3, 0x84, 0x7e, 0x03, 0x90, 0x6a, 0xfe, 0x08, 0xdc,
+	0x58, 0xc3, 0x07, 0x71, 0xaa, 0xff, 0x54, 0x4a, 0x61, 0xe2, 0x93, 0x70, 0xc1, 0x0b, 0xa5, 0xd2,
+	0x70, 0x6b, 0x8c, 0x59, 0x12, 0xc3, 0xf4, 0x0d, 0xe1, 0xd6, 0xd9, 0xa0, 0x10, 0x0f, 0x9a, 0x67,
+	0x9d, 0x0d, 0x14, 0xd6, 0x26, 0x71, 0xe0, 0x4c, 0xd0, 0xc6, 0xd9, 0x6e, 0xd1, 0xe6, 0x22, 0x6f,
+	0x50, 0x53, 0x1c, 0x67, 0x82, 0x5a, 0x6b, 0xb4, 0x88, 0xf0, 0x3a, 0x8b, 0xdb, 0x5b, 0x91, 0x59,
+	0xd2, 0x11, 0xfd, 0x78, 0x95, 0xa5, 0x11, 0x95, 0x08, 0xb3, 0x84, 0xfe, 0x08, 0x46, 0x8a, 0xb7,
+	0xe2, 0xe0, 0x92, 0x51, 0x3a, 0x45, 0x4f, 0xf2, 0x9b, 0x89, 0xde, 0xea, 0xbb, 0x8f, 0xb3, 0xa2,
+	0x12, 0x29, 0x23, 0xd6, 0xea, 0x31, 0x22, 0xa4, 0x15, 0x18, 0xb5, 0xd7, 0x23, 0x5f, 0x2d, 0x46,
+	0xdd, 0xad, 0x3d, 0x47, 0x16, 0x4a, 0x43, 0x44, 0x4c, 0xd1, 0xc9, 0x56, 0x91, 0xa0, 0x3c, 0x5c,
+	0x5f, 0xeb, 0x05, 0x34, 0x86, 0xd4, 0x7f, 0xff, 0x19, 0x46, 0x4e, 0xa1, 0x75, 0x15, 0xcf, 0x3f,
+	0x4f, 0xe6, 0x7b, 0x68, 0xa9, 0xcd, 0x2b, 0x95, 0x49, 0x53, 0x93, 0xdd, 0xc7, 0x8a, 0xe5, 0xfa,
+	0xab, 0xac, 0xdc, 0x03, 0x5b, 0xc5, 0xcd, 0x7d, 0x04, 0x7b, 0x76, 0x78, 0x13, 0xe7, 0xcb, 0x21,
+	0x57, 0xc6, 0xdf, 0x42, 0x4d, 0x4d, 0xac, 0xe2, 0x92, 0xc9, 0xee, 0xb4, 0x66, 0x09, 0xbd, 0x84,
+	0xd6, 0x5f, 0x96, 0x84, 0xdd, 0xf7, 0x68, 0xcc, 0x99, 0xe7, 0xf3, 0x75, 0x68, 0x25, 0xf7, 0x23,
+	0x4e, 0x58, 0x80, 0x72, 0x42, 0xaa, 0x76, 0x72, 0xc9, 0x56, 0xe2, 0x87, 0x0f, 0x58, 0x69, 0x11,
+	0xfc, 0x4a, 0x16, 0x9d, 0x7a, 0x6a, 0xf2, 0xd9, 0x6c, 0x67, 0x16, 0x80, 0x75, 0x85, 0xca, 0xec,
+	0xa7, 0x2f, 0x6d, 0x31, 0x3e, 0x3d, 0x96, 0x73, 0xa9, 0x25, 0x9f, 0xd4, 0xb5, 0xc4, 0xd7, 0x5b,
+	0x98, 0xec, 0x83, 0x5b, 0xa4, 0xe0, 0x77, 0xb2, 0xc4, 0xf3, 0xcf, 0x6a, 0xde, 0xea, 0x41, 0x76,
+	0x4d, 0x49, 0x31, 0xb2, 0x50, 0xc5, 0xbd, 0xc4, 0x36, 0x9a, 0x14, 0x36, 0x53, 0x3b, 0xb3, 0xaf,
+	0xea, 0xdb, 0xa9, 0x09, 0x92, 0x6e, 0xd5, 0x9f, 0x9a, 0x20, 0x1a, 0x23, 0xd3, 0x26, 0xff, 0xef,
+	0xeb, 0xdf, 0x12, 0xd1, 0x0e, 0x69, 0xe4, 0xcf, 0xc3, 0x39, 0x27, 0x6c, 0x10, 0x4f, 0xe9, 0x7a,
+	0x54, 0x49, 0x84, 0x36, 0xf4, 0x1c, 0xf6, 0x1d, 0xe6, 0xf9, 0xc4, 0x59, 0x7d, 0xd2, 0x8a, 0xb3,
+	0x92, 0x28, 0x19, 0x77, 0xb0, 0xc3, 0x68, 0x39, 0xf7, 0xb8, 0x90, 0x29, 0x28, 0x26, 0x67, 0xa5,
+	0xcf, 0x43, 0x1a, 0x9b, 0x25, 0x74, 0x09, 0x4f, 0xb6, 0xde, 0x03, 0x3d, 0xd4, 0xbf, 0xca, 0x0e,
+	0xe5, 0xdc, 0x16, 0xdd, 0xed, 0x3c, 0x7c, 0x1c, 0xd4, 0x1a, 0x2a, 0xa7, 0xfd, 0x2b, 0x68, 0x0a,
+	0x8d, 0xe9, 0xea, 0x8d, 0x0e, 0x37, 0x86, 0x73, 0x5a, 0xb2, 0x1d, 0xf0, 0xa2, 0x8c, 0x2e, 0x94,
+	0x23, 0x99, 0x35, 0x7a, 0xcb, 0x91, 0x87, 0x0b, 0x76, 0xd1, 0x70, 0x7f, 0x51, 0x46, 0xdf, 0xab,
+	0x17, 0x4a, 0x86, 0x51, 0xeb, 0x28, 0x88, 0x76, 0x33, 0x1b, 0x6d, 0x21, 0x77, 0x76, 0xf4, 0xb7,
+	0xa7, 0x37, 0x21, 0x9f, 0x2d, 0xaf, 0x4f, 0x7c, 0x1a, 0x3d, 0xf7, 0xc4, 0xcb, 0x1e, 0x52, 0xf5,
+	0xf7, 0xb9, 0xc4, 0x5e, 0xef, 0xc9, 0x9f, 0xa1, 0x5e, 0xfe, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x09,
+	0x17, 0x6b, 0xef, 0xe0, 0x12, 0x00, 0x00,
 }
@@ -1,8 +1,24 @@
 package types
 
+import "bytes"
+
 const AergoSystem = "aergo.system"
 const StakingMinimum = 1000
 
 func (v VoteList) Len() int           { return len(v.Votes) }
 func (v VoteList) Less(i, j int) bool { return v.Votes[i].Amount < v.Votes[j].Amount }
 func (v VoteList) Swap(i, j int)      { v.Votes[i], v.Votes[j] = v.Votes[j], v.Votes[i] }
+
+// OrderedVoteList orders the candidates of the same amount by the reverse
+// order of their IDs so that the order of the vote result sorted in reverse is
+// deterministic.
+type OrderedVoteList struct {
+	VoteList
+}
+
+func (v OrderedVoteList) Less(i, j int) bool {
+	if v.Votes[i].Amount == v.Votes[j].Amount {
+		return bytes.Compare(v.Votes[i].Candidate, v.Votes[j].Candidate) > 0
+	}
+	return v.Votes[i].Amount < v.Votes[j].Amount
+}